@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"ports-and-adapters-architecture/internal/config"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+// commandUsage lists the maintenance commands
+const commandUsage = "usage: api [config print [--redacted] | migrate up | migrate down [-steps n] | migrate status]"
+
+// runCommand runs a maintenance command instead of the server
+//
+//	go run ./cmd/api config print --redacted
+//	go run ./cmd/api migrate up
+func runCommand(cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) < 2 {
+		return fmt.Errorf("unknown command %q, %s", strings.Join(args, " "), commandUsage)
+	}
+
+	switch args[0] + " " + args[1] {
+	case "config print":
+		return printConfig(cfg, args[2:], out)
+	case "migrate up", "migrate down", "migrate status":
+		return migrate(cfg, args[1], args[2:], out)
+	default:
+		return fmt.Errorf("unknown command %q, %s", strings.Join(args, " "), commandUsage)
+	}
+}
+
+// printConfig shows the effective configuration, after the environment
+// overrides and secret files are applied
+func printConfig(cfg *config.Config, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("config print", flag.ContinueOnError)
+	redacted := fs.Bool("redacted", false, "hide secrets")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return cfg.Print(out, *redacted)
+}
+
+// migrate applies, rolls back or lists the embedded schema migrations of the
+// database driver. Only the database settings are used, so it runs without
+// the other secrets
+func migrate(cfg *config.Config, action string, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("migrate "+action, flag.ContinueOnError)
+	steps := fs.Int("steps", 1, "number of migrations to roll back")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *steps < 1 {
+		return fmt.Errorf("-steps must be at least 1")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := openDatabase(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the database: %w", err)
+	}
+	defer db.Close()
+
+	migrator, err := newMigrator(cfg.Database.Driver, db)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %03d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(out, "no pending migrations")
+		}
+		return err
+	case "down":
+		rolledBack, err := migrator.Down(ctx, *steps)
+		for _, migration := range rolledBack {
+			fmt.Fprintf(out, "rolled back %03d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(rolledBack) == 0 {
+			fmt.Fprintln(out, "no applied migrations")
+		}
+		return err
+	default:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED")
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(tw, "%03d\t%s\t%s\n", status.Version, status.Name, applied)
+		}
+		return tw.Flush()
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"database/sql"
+	"path/filepath"
+	"ports-and-adapters-architecture/internal/adapters/persistence"
+	"ports-and-adapters-architecture/internal/adapters/persistence/sqlite"
+	"ports-and-adapters-architecture/internal/config"
+	ports "ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"ports-and-adapters-architecture/migrations"
+	sqlitemigrations "ports-and-adapters-architecture/migrations/sqlite"
+)
+
+// repositories are the persistence adapters of the database driver
+type repositories struct {
+	user            ports.UserRepository
+	wallet          ports.WalletRepository
+	transaction     ports.TransactionRepository
+	payment         ports.PaymentRepository
+	batchTransfer   ports.BatchTransferRepository
+	paymentRequest  ports.PaymentRequestRepository
+	balanceSnapshot ports.BalanceSnapshotRepository
+	riskAssessment  ports.RiskAssessmentRepository
+	merchant        ports.MerchantRepository
+	deadEvent       ports.DeadEventRepository
+}
+
+// openDatabase connects to the database selected by database.driver
+func openDatabase(cfg config.DatabaseConfig) (*sql.DB, error) {
+	if cfg.Driver == config.DriverSQLite {
+		return sqlite.NewSQLiteConnection(cfg.SQLite)
+	}
+	return persistence.NewPostgresConnection(cfg.PostgresConfig)
+}
+
+// databaseName labels the connection pool metrics
+func databaseName(cfg config.DatabaseConfig) string {
+	if cfg.Driver == config.DriverSQLite {
+		return filepath.Base(cfg.SQLite.Path)
+	}
+	return cfg.Name
+}
+
+// newMigrator returns the migrator of the schema of the database driver
+func newMigrator(driver string, db *sql.DB) (*persistence.Migrator, error) {
+	if driver == config.DriverSQLite {
+		return persistence.NewSQLiteMigrator(db, sqlitemigrations.FS)
+	}
+	return persistence.NewPostgresMigrator(db, migrations.FS)
+}
+
+// newRepositories creates the repositories of the database driver
+func newRepositories(driver string, db *sql.DB) *repositories {
+	if driver == config.DriverSQLite {
+		return &repositories{
+			user:            sqlite.NewSQLiteUserRepository(db),
+			wallet:          sqlite.NewSQLiteWalletRepository(db),
+			transaction:     sqlite.NewSQLiteTransactionRepository(db),
+			payment:         sqlite.NewSQLitePaymentRepository(db),
+			batchTransfer:   sqlite.NewSQLiteBatchTransferRepository(db),
+			paymentRequest:  sqlite.NewSQLitePaymentRequestRepository(db),
+			balanceSnapshot: sqlite.NewSQLiteBalanceSnapshotRepository(db),
+			riskAssessment:  sqlite.NewSQLiteRiskAssessmentRepository(db),
+			merchant:        sqlite.NewSQLiteMerchantRepository(db),
+			deadEvent:       sqlite.NewSQLiteDeadEventRepository(db),
+		}
+	}
+
+	return &repositories{
+		user:            persistence.NewPostgresUserRepository(db),
+		wallet:          persistence.NewPostgresWalletRepository(db),
+		transaction:     persistence.NewPostgresTransactionRepository(db),
+		payment:         persistence.NewPostgresPaymentRepository(db),
+		batchTransfer:   persistence.NewPostgresBatchTransferRepository(db),
+		paymentRequest:  persistence.NewPostgresPaymentRequestRepository(db),
+		balanceSnapshot: persistence.NewPostgresBalanceSnapshotRepository(db),
+		riskAssessment:  persistence.NewPostgresRiskAssessmentRepository(db),
+		merchant:        persistence.NewPostgresMerchantRepository(db),
+		deadEvent:       persistence.NewPostgresDeadEventRepository(db),
+	}
+}
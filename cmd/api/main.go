@@ -2,221 +2,539 @@ package main
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"ports-and-adapters-architecture/api/rest"
+	"ports-and-adapters-architecture/api/rest/openapi"
+	"ports-and-adapters-architecture/api/rpc"
+	"ports-and-adapters-architecture/internal/adapters/auth"
 	"ports-and-adapters-architecture/internal/adapters/cache"
+	"ports-and-adapters-architecture/internal/adapters/logging"
 	"ports-and-adapters-architecture/internal/adapters/messaging"
+	"ports-and-adapters-architecture/internal/adapters/metrics"
 	"ports-and-adapters-architecture/internal/adapters/payment"
-	"ports-and-adapters-architecture/internal/adapters/persistence"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/adapters/risk"
+	"ports-and-adapters-architecture/internal/adapters/statement"
+	"ports-and-adapters-architecture/internal/adapters/tracing"
+	"ports-and-adapters-architecture/internal/config"
 	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/external"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	ports "ports-and-adapters-architecture/internal/ports/secondary/persistence"
 	"ports-and-adapters-architecture/internal/usecase"
+	"slices"
 	"syscall"
 	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/spf13/viper"
+	"google.golang.org/grpc"
 )
 
 func main() {
 	// Load configuration
-	cfg, err := loadConfig()
+	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Run a command such as config print instead of the server
+	if len(os.Args) > 1 {
+		if err := runCommand(cfg, os.Args[1:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+
+	// Initialize logging
+	logger, err := logging.NewSlogLogger(cfg.Logging, os.Stdout)
+	if err != nil {
+		log.Fatalf("Failed to initialize logging: %v", err)
+	}
+
+	// Route the standard library logger through the same handler
+	slog.SetDefault(logger.Slog())
+
+	// Initialize tracing. Spans are no-ops unless it is enabled
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error(ctx, "failed to flush spans", "error", err)
+		}
+	}()
+
 	// Initialize database
-	db, err := initDatabase(cfg)
+	db, err := openDatabase(cfg.Database)
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 	defer db.Close()
 
-	// Initialize Redis cache
-	redisCache := cache.NewRedisCache(
-		cfg.GetString("redis.addr"),
-		cfg.GetString("redis.password"),
-		cfg.GetInt("redis.db"),
-	)
-	defer redisCache.Close()
+	// A SQLite file belongs to this process alone, so its schema is brought
+	// up to date on start. Postgres is migrated separately, see migrate up
+	if cfg.Database.Driver == config.DriverSQLite {
+		migrator, err := newMigrator(cfg.Database.Driver, db)
+		if err != nil {
+			log.Fatalf("Failed to load migrations: %v", err)
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+	}
 
-	// Test Redis connection
-	if err := redisCache.Ping(context.Background()); err != nil {
-		log.Printf("Warning: Redis connection failed: %v", err)
+	// Initialize the cache. Redis is shared by every instance, the memory
+	// cache only serves this process
+	var baseCache infrastructure.Cache
+	var redisCache *cache.RedisCache
+	if cfg.Cache.Driver == config.CacheDriverRedis {
+		redisCache = cache.NewRedisCache(
+			cfg.Redis.Addr,
+			cfg.Redis.Password,
+			cfg.Redis.DB,
+		)
+		defer redisCache.Close()
+		baseCache = redisCache
+	} else {
+		baseCache = memory.NewInMemoryCache()
 	}
 
 	// Initialize Kafka
 	kafkaPublisher := messaging.NewKafkaEventPublisher(
-		cfg.GetStringSlice("kafka.brokers"),
+		cfg.Kafka.Brokers,
 	)
 	defer kafkaPublisher.Close()
+	kafkaPublisher.SetLogger(logger)
+
+	// Initialize metrics. The decorators record what goes through the ports
+	var appMetrics *metrics.Metrics
+	var eventPublisher infrastructure.EventPublisher = kafkaPublisher
+	appCache := baseCache
+	if cfg.Metrics.Enabled {
+		appMetrics = metrics.NewMetrics()
+		if err := appMetrics.RegisterDB(db, databaseName(cfg.Database)); err != nil {
+			log.Fatalf("Failed to register database metrics: %v", err)
+		}
+
+		eventPublisher = metrics.NewEventPublisher(kafkaPublisher, appMetrics)
+		appCache = metrics.NewCache(baseCache, appMetrics)
+	}
 
 	// Initialize repositories
-	userRepo := persistence.NewPostgresUserRepository(db)
-	walletRepo := persistence.NewPostgresWalletRepository(db)
-	transactionRepo := persistence.NewPostgresTransactionRepository(db)
-	paymentRepo := persistence.NewPostgresPaymentRepository(db)
+	repos := newRepositories(cfg.Database.Driver, db)
+	userRepo := repos.user
+	walletRepo := repos.wallet
+	transactionRepo := repos.transaction
+	paymentRepo := repos.payment
+	batchTransferRepo := repos.batchTransfer
+	paymentRequestRepo := repos.paymentRequest
+	balanceSnapshotRepo := repos.balanceSnapshot
 
 	// Initialize payment gateways
 	midtransGateway := payment.NewMidtransGateway(
-		cfg.GetString("payment.midtrans.server_key"),
-		cfg.GetString("payment.midtrans.client_key"),
-		cfg.GetBool("payment.midtrans.is_production"),
+		cfg.Payment.Midtrans.ServerKey,
+		cfg.Payment.Midtrans.ClientKey,
+		cfg.Payment.Midtrans.IsProduction,
 	)
 
 	stripeGateway := payment.NewStripeGateway(
-		cfg.GetString("payment.stripe.api_key"),
-		cfg.GetString("payment.stripe.webhook_secret"),
-		cfg.GetBool("payment.stripe.is_test"),
+		cfg.Payment.Stripe.APIKey,
+		cfg.Payment.Stripe.WebhookSecret,
+		cfg.Payment.Stripe.IsTest,
 	)
 
 	// Initialize services
-	userService := usecase.NewUserService(userRepo, kafkaPublisher, redisCache)
+	userService := usecase.NewUserService(userRepo, eventPublisher, appCache)
 	walletService := usecase.NewWalletService(
 		walletRepo,
 		userRepo,
 		transactionRepo,
-		kafkaPublisher,
-		redisCache,
+		eventPublisher,
+		appCache,
 	)
+
+	// The REST, gRPC and dependent use cases go through the instrumented wallet service
+	var walletAPI primary.WalletService = walletService
+	if appMetrics != nil {
+		walletAPI = metrics.NewWalletService(walletService, appMetrics)
+	}
+	walletAPI = tracing.NewWalletService(walletAPI)
+
 	transactionService := usecase.NewTransactionService(
 		transactionRepo,
 		walletRepo,
-		kafkaPublisher,
-		redisCache,
+		eventPublisher,
+		appCache,
 	)
 	paymentService := usecase.NewPaymentService(
 		paymentRepo,
 		walletRepo,
 		transactionRepo,
-		kafkaPublisher,
-		redisCache,
+		eventPublisher,
+		appCache,
+	)
+
+	batchTransferService := usecase.NewBatchTransferService(
+		batchTransferRepo,
+		walletRepo,
+		transactionRepo,
+		walletAPI,
+		eventPublisher,
+		cfg.BatchTransfers,
+	)
+
+	paymentRequestService := usecase.NewPaymentRequestService(
+		paymentRequestRepo,
+		walletRepo,
+		userRepo,
+		walletAPI,
+		eventPublisher,
+		cfg.PaymentRequests,
+	)
+
+	recipientService := usecase.NewRecipientService(
+		userRepo,
+		walletRepo,
+		walletAPI,
+		appCache,
+		cfg.Recipients,
+	)
+
+	balanceSnapshotService := usecase.NewBalanceSnapshotService(
+		balanceSnapshotRepo,
+		walletRepo,
+		transactionRepo,
+		cfg.BalanceSnapshots,
+	)
+
+	walletService.SetLogger(logger)
+	paymentService.SetLogger(logger)
+	batchTransferService.SetLogger(logger)
+	paymentRequestService.SetLogger(logger)
+	balanceSnapshotService.SetLogger(logger)
+
+	statementService := usecase.NewStatementService(
+		walletRepo,
+		transactionRepo,
+		statement.NewCSVRenderer(),
+		statement.NewPDFRenderer(),
 	)
 
 	// Register payment gateways
-	paymentService.RegisterGateway(domain.PaymentProviderMidtrans, midtransGateway)
-	paymentService.RegisterGateway(domain.PaymentProviderStripe, stripeGateway)
+	var midtransAPI external.PaymentGateway = midtransGateway
+	var stripeAPI external.PaymentGateway = stripeGateway
+	if appMetrics != nil {
+		midtransAPI = metrics.NewPaymentGateway(midtransAPI, appMetrics)
+		stripeAPI = metrics.NewPaymentGateway(stripeAPI, appMetrics)
+	}
+
+	paymentService.RegisterGateway(domain.PaymentProviderMidtrans, midtransAPI)
+	paymentService.RegisterGateway(domain.PaymentProviderStripe, stripeAPI)
+
+	// Initialize risk checks
+	var riskService primary.RiskService
+	if cfg.Risk.Enabled {
+		rs, err := initRiskService(cfg.Risk.Rules, repos.riskAssessment, transactionRepo, eventPublisher)
+		if err != nil {
+			log.Fatalf("Failed to initialize risk engine: %v", err)
+		}
+
+		walletService.SetRiskService(rs)
+		paymentService.SetRiskService(rs)
+		riskService = rs
+	}
+
+	// Initialize fee schedule
+	var feeService primary.FeeService
+	if cfg.Fees.Enabled {
+		fs, err := initFeeService(cfg.Fees, walletRepo, transactionRepo, appCache)
+		if err != nil {
+			log.Fatalf("Failed to initialize fee schedule: %v", err)
+		}
+
+		walletService.SetFeeService(fs)
+		paymentService.SetFeeService(fs)
+		batchTransferService.SetFeeService(fs)
+		transactionService.SetFeeService(fs)
+		feeService = fs
+	}
+
+	// Initialize event consumers. Events whose handlers fail are kept as
+	// dead events until ewalletctl events redrive publishes them again
+	deadEventService := usecase.NewDeadEventService(repos.deadEvent, eventPublisher)
+
+	kafkaConsumer := messaging.NewKafkaEventConsumer(
+		cfg.Kafka.Brokers,
+		cfg.Kafka.ConsumerGroup,
+	)
+	kafkaConsumer.SetLogger(logger)
+
+	var eventConsumer infrastructure.EventConsumer = kafkaConsumer
+	if appMetrics != nil {
+		eventConsumer = metrics.NewEventConsumer(kafkaConsumer, appMetrics)
+	}
+	eventConsumer.SetFailureHandler(deadEventService.Record)
+
+	eventProcessor := usecase.NewEventProcessor(eventConsumer, walletService, paymentService)
+	eventProcessor.SetLogger(logger)
+
+	// Initialize authentication
+	tokenService, err := auth.NewJWTService(cfg.Auth.JWTConfig)
+	if err != nil {
+		log.Fatalf("Failed to initialize authentication: %v", err)
+	}
+
+	var tokenIssuer infrastructure.TokenIssuer
+	if cfg.Auth.DevTokens {
+		logger.Warn(context.Background(), "dev token endpoint is enabled, anyone can mint tokens for any user")
+		tokenIssuer = tokenService
+	}
+
+	// Initialize merchant API keys
+	var merchantService primary.MerchantService
+	if cfg.Merchants.Enabled {
+		ms, err := initMerchantService(cfg.Merchants, repos.merchant, userRepo, appCache)
+		if err != nil {
+			log.Fatalf("Failed to initialize merchant API keys: %v", err)
+		}
+
+		merchantService = ms
+	}
+
+	// Initialize rate limiting
+	var rateLimitService primary.RateLimitService
+	if cfg.RateLimits.Enabled {
+		rateLimitService = usecase.NewRateLimitService(appCache, cfg.RateLimits.Groups)
+	}
+
+	// Trace the use cases called by the REST and gRPC APIs
+	userAPI := tracing.NewUserService(userService)
+	transactionAPI := tracing.NewTransactionService(transactionService)
+	paymentAPI := tracing.NewPaymentService(paymentService)
+	if riskService != nil {
+		riskService = tracing.NewRiskService(riskService)
+	}
+	if feeService != nil {
+		feeService = tracing.NewFeeService(feeService)
+	}
+	if merchantService != nil {
+		merchantService = tracing.NewMerchantService(merchantService)
+	}
+
+	// Initialize health checks. Dependencies listed in health.critical take
+	// the service down when they fail, the others only degrade it
+	healthService := usecase.NewHealthService(cfg.Health.HealthConfig)
+	healthService.SetLogger(logger)
+
+	critical := cfg.Health.Critical
+	healthService.Register(cfg.Database.Driver, infrastructure.HealthCheckFunc(db.PingContext), slices.Contains(critical, cfg.Database.Driver))
+	if redisCache != nil {
+		healthService.Register("redis", infrastructure.HealthCheckFunc(redisCache.Ping), slices.Contains(critical, "redis"))
+	}
+	healthService.Register("kafka", kafkaPublisher, slices.Contains(critical, "kafka"))
+	healthService.Register("midtrans", midtransGateway, slices.Contains(critical, "midtrans"))
+	healthService.Register("stripe", stripeGateway, slices.Contains(critical, "stripe"))
+
+	// Report unreachable dependencies on startup
+	healthService.Readiness(context.Background())
 
 	// Initialize Echo
 	e := echo.New()
+	e.Server.ReadTimeout = cfg.Server.Timeout
+	e.Server.WriteTimeout = cfg.Server.Timeout
 
 	// Setup routes
-	rest.SetupRoutes(e, walletService, paymentService)
+	rest.SetupRoutes(
+		e,
+		userAPI,
+		walletAPI,
+		transactionAPI,
+		paymentAPI,
+		tracing.NewBatchTransferService(batchTransferService),
+		tracing.NewPaymentRequestService(paymentRequestService),
+		tracing.NewRecipientService(recipientService),
+		tracing.NewStatementService(statementService),
+		tracing.NewBalanceSnapshotService(balanceSnapshotService),
+		riskService,
+		feeService,
+		merchantService,
+		rateLimitService,
+		healthService,
+		tokenService,
+		tokenIssuer,
+		logger,
+	)
 
-	// Start server
+	// Start a span for every request, continuing the trace of the caller
+	e.Use(tracing.HTTPMiddleware())
+
+	// Validate requests against the OpenAPI spec
+	if cfg.OpenAPI.ValidateRequests {
+		spec, err := openapi.Load()
+		if err != nil {
+			log.Fatalf("Failed to load OpenAPI spec: %v", err)
+		}
+
+		validateRequests, err := openapi.ValidateRequests(spec)
+		if err != nil {
+			log.Fatalf("Failed to set up request validation: %v", err)
+		}
+
+		e.Use(validateRequests)
+	}
+
+	// Expose metrics
+	if appMetrics != nil {
+		e.Use(appMetrics.HTTPMiddleware())
+		e.GET("/metrics", echo.WrapHandler(appMetrics.Handler()))
+	}
+
+	// Initialize gRPC API
+	var grpcServer *grpc.Server
+	var grpcListener net.Listener
+	if cfg.GRPC.Enabled {
+		grpcConfig := rpc.Config{
+			Port:          cfg.GRPC.Port,
+			WatchInterval: cfg.GRPC.WatchInterval,
+		}
+
+		grpcListener, err = net.Listen("tcp", ":"+grpcConfig.Port)
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+
+		grpcServer = rpc.NewServer(
+			userAPI,
+			walletAPI,
+			transactionAPI,
+			paymentAPI,
+			tokenService,
+			grpcConfig,
+		)
+	}
+
+	// Start background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go paymentRequestService.RunExpiryWorker(workerCtx)
+	go batchTransferService.RunRecoveryWorker(workerCtx)
+	go balanceSnapshotService.RunSnapshotWorker(workerCtx)
 	go func() {
-		port := cfg.GetString("server.port")
-		if port == "" {
-			port = "8080"
+		if err := eventProcessor.Start(workerCtx); err != nil {
+			logger.Error(context.Background(), "failed to start event processor", "error", err)
 		}
+	}()
 
-		log.Printf("Starting server on port %s", port)
-		if err := e.Start(":" + port); err != nil && err != http.ErrServerClosed {
+	// Start server
+	go func() {
+		logger.Info(context.Background(), "starting server", "port", cfg.Server.Port)
+		if err := e.Start(":" + cfg.Server.Port); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
 		}
 	}()
 
+	if grpcServer != nil {
+		go func() {
+			logger.Info(context.Background(), "starting gRPC server", "addr", grpcListener.Addr().String())
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	logger.Info(context.Background(), "shutting down server")
+
+	// Stop background workers
+	stopWorkers()
+	if eventConsumer.IsRunning() {
+		if err := eventProcessor.Stop(); err != nil {
+			logger.Error(context.Background(), "failed to stop event processor", "error", err)
+		}
+	}
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcServer.Stop()
+		}
+	}
+
 	if err := e.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
-	log.Println("Server exited")
+	logger.Info(context.Background(), "server exited")
 }
 
-func loadConfig() (*viper.Viper, error) {
-	v := viper.New()
-
-	// Set config name and paths
-	v.SetConfigName("config")
-	v.SetConfigType("yaml")
-	v.AddConfigPath("./config")
-	v.AddConfigPath(".")
-
-	// Set defaults
-	setDefaults(v)
-
-	// Read base config
-	if err := v.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+func initRiskService(
+	rulesConfig risk.RulesConfig,
+	assessmentRepo ports.RiskAssessmentRepository,
+	transactionRepo ports.TransactionRepository,
+	eventPublisher infrastructure.EventPublisher,
+) (*usecase.RiskService, error) {
+	engine, err := risk.NewRulesEngine(rulesConfig, transactionRepo)
+	if err != nil {
+		return nil, err
 	}
 
-	// Override with environment-specific config
-	env := os.Getenv("APP_ENV")
-	if env == "" {
-		env = "local"
-	}
+	return usecase.NewRiskService(engine, assessmentRepo, eventPublisher), nil
+}
 
-	v.SetConfigName(fmt.Sprintf("config.%s", env))
-	if err := v.MergeInConfig(); err != nil {
-		log.Printf("No environment-specific config found for %s: %v", env, err)
+func initFeeService(
+	feesConfig config.FeesConfig,
+	walletRepo ports.WalletRepository,
+	transactionRepo ports.TransactionRepository,
+	appCache infrastructure.Cache,
+) (*usecase.FeeService, error) {
+	schedule, err := domain.NewFeeSchedule(feesConfig.Rules)
+	if err != nil {
+		return nil, err
 	}
 
-	// Allow environment variables to override
-	v.AutomaticEnv()
-
-	return v, nil
+	return usecase.NewFeeService(schedule, feesConfig.RevenueWallets, walletRepo, transactionRepo, appCache), nil
 }
 
-func setDefaults(v *viper.Viper) {
-	// Server defaults
-	v.SetDefault("server.port", "8080")
-	v.SetDefault("server.timeout", "30s")
-
-	// Database defaults
-	v.SetDefault("database.host", "localhost")
-	v.SetDefault("database.port", "5432")
-	v.SetDefault("database.name", "mini_ewallet")
-	v.SetDefault("database.user", "postgres")
-	v.SetDefault("database.password", "postgres")
-	v.SetDefault("database.max_open_conns", 25)
-	v.SetDefault("database.max_idle_conns", 25)
-	v.SetDefault("database.conn_max_lifetime", "5m")
-
-	// Redis defaults
-	v.SetDefault("redis.addr", "localhost:6379")
-	v.SetDefault("redis.password", "")
-	v.SetDefault("redis.db", 0)
-
-	// Kafka defaults
-	v.SetDefault("kafka.brokers", []string{"localhost:9092"})
-	v.SetDefault("kafka.consumer_group", "mini-ewallet")
-
-	// Payment gateway defaults
-	v.SetDefault("payment.midtrans.is_production", false)
-	v.SetDefault("payment.stripe.is_test", true)
-}
-
-func initDatabase(cfg *viper.Viper) (*sql.DB, error) {
-	db, err := persistence.NewPostgresConnection(
-		cfg.GetString("database.host"),
-		cfg.GetString("database.port"),
-		cfg.GetString("database.user"),
-		cfg.GetString("database.password"),
-		cfg.GetString("database.name"),
-	)
+func initMerchantService(
+	merchantsConfig config.MerchantsConfig,
+	merchantRepo ports.MerchantRepository,
+	userRepo ports.UserRepository,
+	appCache infrastructure.Cache,
+) (*usecase.MerchantService, error) {
+	secretBox, err := auth.NewAESSecretBox(merchantsConfig.EncryptionKey)
 	if err != nil {
 		return nil, err
 	}
 
-	// Configure connection pool
-	db.SetMaxOpenConns(cfg.GetInt("database.max_open_conns"))
-	db.SetMaxIdleConns(cfg.GetInt("database.max_idle_conns"))
-	db.SetConnMaxLifetime(cfg.GetDuration("database.conn_max_lifetime"))
-
-	return db, nil
+	return usecase.NewMerchantService(merchantRepo, userRepo, secretBox, appCache, merchantsConfig.MerchantConfig), nil
 }
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// principalKey is the echo context key the authenticated caller is stored under
+const principalKey = "principal"
+
+// headers API clients sign their requests with
+const (
+	HeaderAPIKey       = "X-Api-Key"
+	HeaderAPITimestamp = "X-Api-Timestamp"
+	HeaderAPISignature = "X-Api-Signature"
+)
+
+// maxSignedBodySize caps how much of a signed request is buffered to verify it
+const maxSignedBodySize = 10 << 20
+
+// Authenticate verifies the bearer token of every request, or its API key
+// signature when merchantService is set and the request carries an API key.
+// The caller is stored in both the echo context and the request context
+func Authenticate(verifier infrastructure.TokenVerifier, merchantService primary.MerchantService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var principal *domain.Principal
+			var err error
+
+			if merchantService != nil && c.Request().Header.Get(HeaderAPIKey) != "" {
+				principal, err = authenticateSignedRequest(c, merchantService)
+				if err != nil {
+					return err
+				}
+			} else {
+				scheme, token, found := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+				if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+					c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+					return handleServiceError(domain.ErrUnauthenticated)
+				}
+
+				principal, err = verifier.Verify(c.Request().Context(), strings.TrimSpace(token))
+				if err != nil {
+					c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+					return handleServiceError(err)
+				}
+			}
+
+			c.Set(principalKey, principal)
+			c.SetRequest(c.Request().WithContext(domain.ContextWithPrincipal(c.Request().Context(), principal)))
+
+			return next(c)
+		}
+	}
+}
+
+// authenticateSignedRequest verifies the HMAC signature of an API client request.
+// The body is buffered for the signature and put back for the handler
+func authenticateSignedRequest(c echo.Context, merchantService primary.MerchantService) (*domain.Principal, error) {
+	req := c.Request()
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxSignedBodySize+1))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body")
+	}
+	if len(body) > maxSignedBodySize {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Request body is too large")
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	principal, err := merchantService.AuthenticateRequest(req.Context(), primary.SignedRequest{
+		APIKey:    req.Header.Get(HeaderAPIKey),
+		Timestamp: req.Header.Get(HeaderAPITimestamp),
+		Signature: req.Header.Get(HeaderAPISignature),
+		Method:    req.Method,
+		Path:      req.URL.RequestURI(),
+		Body:      body,
+	})
+	if err != nil {
+		return nil, handleServiceError(err)
+	}
+
+	return principal, nil
+}
+
+// RequireScope limits API clients to the routes their scopes grant, user
+// tokens are not scoped
+func RequireScope(scope domain.APIScope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, err := currentPrincipal(c)
+			if err != nil {
+				return handleServiceError(err)
+			}
+
+			if !principal.HasScope(scope) {
+				return handleServiceError(domain.ErrAccessDenied)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RejectAPIClients keeps API clients off routes meant for users only
+func RejectAPIClients() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, err := currentPrincipal(c)
+			if err != nil {
+				return handleServiceError(err)
+			}
+
+			if principal.IsAPIClient() {
+				return handleServiceError(domain.ErrAccessDenied)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireRole rejects callers that were not granted a role
+func RequireRole(role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, err := currentPrincipal(c)
+			if err != nil {
+				return handleServiceError(err)
+			}
+
+			if !principal.HasRole(role) {
+				return handleServiceError(domain.ErrAccessDenied)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// currentPrincipal returns the caller stored by Authenticate
+func currentPrincipal(c echo.Context) (*domain.Principal, error) {
+	principal, ok := c.Get(principalKey).(*domain.Principal)
+	if !ok || principal == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+	return principal, nil
+}
+
+// Authorizer checks that the caller owns the wallets and users a request acts on.
+// Admins bypass every check
+type Authorizer struct {
+	walletService      primary.WalletService
+	transactionService primary.TransactionService
+}
+
+// NewAuthorizer creates a new authorizer
+func NewAuthorizer(walletService primary.WalletService, transactionService primary.TransactionService) *Authorizer {
+	return &Authorizer{
+		walletService:      walletService,
+		transactionService: transactionService,
+	}
+}
+
+// AuthorizeUser allows the request when the caller is the given user
+func (a *Authorizer) AuthorizeUser(c echo.Context, userID int) error {
+	principal, err := currentPrincipal(c)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	if !principal.CanActAsUser(userID) {
+		return handleServiceError(domain.ErrAccessDenied)
+	}
+
+	return nil
+}
+
+// AuthorizeWallet allows the request when the caller owns at least one of the wallets
+func (a *Authorizer) AuthorizeWallet(c echo.Context, walletIDs ...int) error {
+	principal, err := currentPrincipal(c)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	if principal.IsAdmin() {
+		return nil
+	}
+
+	for _, walletID := range walletIDs {
+		wallet, err := a.walletService.GetWallet(c.Request().Context(), walletID)
+		if err != nil {
+			return handleServiceError(err)
+		}
+
+		if wallet.UserID == principal.UserID {
+			return nil
+		}
+	}
+
+	return handleServiceError(domain.ErrAccessDenied)
+}
+
+// AuthorizeTransaction allows the request when the caller owns either side of a transaction
+func (a *Authorizer) AuthorizeTransaction(c echo.Context, transactionID int) error {
+	principal, err := currentPrincipal(c)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	if principal.IsAdmin() {
+		return nil
+	}
+
+	transaction, err := a.transactionService.GetTransaction(c.Request().Context(), transactionID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	walletIDs := []int{transaction.WalletID}
+	if transaction.ToWalletID != nil {
+		walletIDs = append(walletIDs, *transaction.ToWalletID)
+	}
+
+	return a.AuthorizeWallet(c, walletIDs...)
+}
+
+// WalletOwner guards routes whose path parameter names a wallet
+func (a *Authorizer) WalletOwner(param string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			walletID, err := strconv.Atoi(c.Param(param))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
+			}
+
+			if err := a.AuthorizeWallet(c, walletID); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// UserSelf guards routes whose path parameter names a user
+func (a *Authorizer) UserSelf(param string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, err := strconv.Atoi(c.Param(param))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+			}
+
+			if err := a.AuthorizeUser(c, userID); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuthHandler handles token HTTP requests
+type AuthHandler struct {
+	tokenIssuer infrastructure.TokenIssuer
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(tokenIssuer infrastructure.TokenIssuer) *AuthHandler {
+	return &AuthHandler{
+		tokenIssuer: tokenIssuer,
+	}
+}
+
+// DevTokenRequest represents the request to mint a development token
+type DevTokenRequest struct {
+	UserID     int      `json:"user_id" validate:"required,min=1"`
+	Roles      []string `json:"roles"`
+	TTLSeconds int      `json:"ttl_seconds" validate:"omitempty,min=60"`
+}
+
+// IssueDevToken handles POST /api/v1/auth/dev-token. It signs a token for any
+// user and must only be enabled in local and test environments
+func (h *AuthHandler) IssueDevToken(c echo.Context) error {
+	var req DevTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	principal := domain.Principal{UserID: req.UserID, Roles: req.Roles}
+
+	token, expiresAt, err := h.tokenIssuer.Issue(c.Request().Context(), principal, time.Duration(req.TTLSeconds)*time.Second)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"access_token": token,
+			"token_type":   "Bearer",
+			"expires_at":   expiresAt,
+		},
+	})
+}
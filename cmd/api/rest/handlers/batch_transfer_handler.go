@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// BatchTransferHandler handles batch transfer HTTP requests
+type BatchTransferHandler struct {
+	batchTransferService primary.BatchTransferService
+	authorizer           *Authorizer
+}
+
+// NewBatchTransferHandler creates a new batch transfer handler
+func NewBatchTransferHandler(batchTransferService primary.BatchTransferService, authorizer *Authorizer) *BatchTransferHandler {
+	return &BatchTransferHandler{
+		batchTransferService: batchTransferService,
+		authorizer:           authorizer,
+	}
+}
+
+// BatchTransferItem represents one recipient of a batch transfer
+type BatchTransferItem struct {
+	ToWalletID  int    `json:"to_wallet_id" validate:"required,min=1"`
+	Amount      int    `json:"amount" validate:"required,min=1"`
+	Description string `json:"description"`
+}
+
+// CreateBatchTransferRequest represents the request to pay many wallets at once
+type CreateBatchTransferRequest struct {
+	Mode        string              `json:"mode" validate:"omitempty,oneof=ALL_OR_NOTHING BEST_EFFORT"`
+	Description string              `json:"description"`
+	Items       []BatchTransferItem `json:"items" validate:"required,min=1,dive"`
+}
+
+// CreateBatchTransfer handles POST /api/v1/wallets/:id/batch-transfers
+func (h *BatchTransferHandler) CreateBatchTransfer(c echo.Context) error {
+	walletID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
+	}
+
+	var req CreateBatchTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	items := make([]primary.BatchTransferItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = primary.BatchTransferItemRequest{
+			ToWalletID:  item.ToWalletID,
+			Amount:      item.Amount,
+			Description: item.Description,
+		}
+	}
+
+	return h.createBatchTransfer(c, primary.BatchTransferRequest{
+		FromWalletID: walletID,
+		Mode:         domain.BatchTransferMode(req.Mode),
+		Description:  req.Description,
+		Items:        items,
+	})
+}
+
+// UploadBatchTransferCSV handles POST /api/v1/wallets/:id/batch-transfers/csv
+//
+// The CSV is read from the "file" field of a multipart form or from the raw
+// request body. Columns are to_wallet_id, amount and an optional description,
+// a header row is allowed. Mode and description come from the query string
+func (h *BatchTransferHandler) UploadBatchTransferCSV(c echo.Context) error {
+	walletID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
+	}
+
+	mode := strings.ToUpper(c.QueryParam("mode"))
+	if mode != "" && !domain.BatchTransferMode(mode).IsValid() {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid batch transfer mode")
+	}
+
+	var body io.Reader = c.Request().Body
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "CSV file is required")
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid CSV file")
+		}
+		defer file.Close()
+
+		body = file
+	}
+
+	items, err := parseBatchTransferCSV(body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return h.createBatchTransfer(c, primary.BatchTransferRequest{
+		FromWalletID: walletID,
+		Mode:         domain.BatchTransferMode(mode),
+		Description:  c.QueryParam("description"),
+		Items:        items,
+	})
+}
+
+func (h *BatchTransferHandler) createBatchTransfer(c echo.Context, req primary.BatchTransferRequest) error {
+	batch, err := h.batchTransferService.CreateBatchTransfer(c.Request().Context(), req)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]interface{}{
+		"status": "success",
+		"data":   batch,
+	})
+}
+
+// GetBatchTransfer handles GET /api/v1/batch-transfers/:id
+func (h *BatchTransferHandler) GetBatchTransfer(c echo.Context) error {
+	batchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid batch transfer ID")
+	}
+
+	batch, err := h.batchTransferService.GetBatchTransfer(c.Request().Context(), batchID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	if err := h.authorizer.AuthorizeWallet(c, batch.FromWalletID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   batch,
+	})
+}
+
+// GetWalletBatchTransfers handles GET /api/v1/wallets/:id/batch-transfers
+func (h *BatchTransferHandler) GetWalletBatchTransfers(c echo.Context) error {
+	walletID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
+	}
+
+	limit, offset := parsePagination(c)
+
+	batches, err := h.batchTransferService.GetBatchTransfersByWalletID(c.Request().Context(), walletID, limit, offset)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"batch_transfers": batches,
+			"limit":           limit,
+			"offset":          offset,
+		},
+	})
+}
+
+// parseBatchTransferCSV reads batch items from CSV rows of to_wallet_id, amount, description
+func parseBatchTransferCSV(r io.Reader) ([]primary.BatchTransferItemRequest, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var items []primary.BatchTransferItemRequest
+	line := 0
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV on line %d: %v", line, err)
+		}
+
+		// Skip the optional header row
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "to_wallet_id") {
+			continue
+		}
+
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected to_wallet_id and amount", line)
+		}
+
+		toWalletID, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid to_wallet_id %q", line, record[0])
+		}
+
+		amount, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %q", line, record[1])
+		}
+
+		item := primary.BatchTransferItemRequest{
+			ToWalletID: toWalletID,
+			Amount:     amount,
+		}
+
+		if len(record) > 2 {
+			item.Description = strings.TrimSpace(record[2])
+		}
+
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		return nil, errors.New("CSV has no batch transfer items")
+	}
+
+	return items, nil
+}
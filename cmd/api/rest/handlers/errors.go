@@ -9,13 +9,65 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// ServiceErrorStatus returns the HTTP status and message a service error maps
+// to, so other driving adapters answer with the same errors as the REST API
+func ServiceErrorStatus(err error) (int, string) {
+	var httpErr *echo.HTTPError
+	if !errors.As(handleServiceError(err), &httpErr) {
+		return http.StatusInternalServerError, err.Error()
+	}
+
+	if message, ok := httpErr.Message.(string); ok {
+		return httpErr.Code, message
+	}
+	return httpErr.Code, err.Error()
+}
+
 // handleServiceError converts service errors to HTTP errors
 func handleServiceError(err error) error {
 	if err == nil {
 		return nil
 	}
 
+	// Batch validation errors carry the rejected items
+	var batchErr *domain.BatchValidationError
+	if errors.As(err, &batchErr) {
+		return echo.NewHTTPError(http.StatusBadRequest, map[string]interface{}{
+			"message": "Invalid batch transfer",
+			"errors":  batchErr.Errors,
+		})
+	}
+
 	// Domain errors
+	if errors.Is(err, domain.ErrUnauthenticated) {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+	if errors.Is(err, domain.ErrInvalidToken) {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+	}
+	if errors.Is(err, domain.ErrAccessDenied) {
+		return echo.NewHTTPError(http.StatusForbidden, "Access denied")
+	}
+	if errors.Is(err, domain.ErrInvalidAPIKey) ||
+		errors.Is(err, domain.ErrAPIKeyEnvironmentMismatch) ||
+		errors.Is(err, domain.ErrInvalidSignature) ||
+		errors.Is(err, domain.ErrSignatureExpired) ||
+		errors.Is(err, domain.ErrReplayedRequest) {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+	if errors.Is(err, domain.ErrInvalidCursor) ||
+		errors.Is(err, domain.ErrInvalidTransactionType) ||
+		errors.Is(err, domain.ErrInvalidTransactionDirection) ||
+		errors.Is(err, domain.ErrInvalidDateRange) ||
+		errors.Is(err, domain.ErrInvalidAmountRange) ||
+		errors.Is(err, domain.ErrInvalidStatementFormat) ||
+		errors.Is(err, domain.ErrBalanceTimeInFuture) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, domain.ErrInvalidAPIScope) ||
+		errors.Is(err, domain.ErrInvalidMerchantEnvironment) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
 	if errors.Is(err, domain.ErrInsufficientBalance) {
 		return echo.NewHTTPError(http.StatusBadRequest, "Insufficient balance")
 	}
@@ -25,6 +77,66 @@ func handleServiceError(err error) error {
 	if errors.Is(err, domain.ErrWalletNotActive) {
 		return echo.NewHTTPError(http.StatusBadRequest, "Wallet is not active")
 	}
+	if errors.Is(err, domain.ErrInvalidTransactionStatus) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid transaction status")
+	}
+	if errors.Is(err, domain.ErrInvalidTransactionStatusTransition) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if errors.Is(err, domain.ErrTransactionStatusReasonRequired) ||
+		errors.Is(err, domain.ErrTransactionStatusActorRequired) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, domain.ErrWalletFrozen) {
+		return echo.NewHTTPError(http.StatusForbidden, "Wallet is frozen")
+	}
+	if errors.Is(err, domain.ErrWalletClosed) {
+		return echo.NewHTTPError(http.StatusGone, "Wallet is closed")
+	}
+	if errors.Is(err, domain.ErrInvalidWalletStatusTransition) ||
+		errors.Is(err, domain.ErrWalletBalanceNotEmpty) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if errors.Is(err, domain.ErrInvalidWalletStatusReason) ||
+		errors.Is(err, domain.ErrWalletStatusActorRequired) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, domain.ErrRiskReviewNotPending) {
+		return echo.NewHTTPError(http.StatusConflict, "Risk review is not pending")
+	}
+	if errors.Is(err, domain.ErrRiskReviewerRequired) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Reviewer is required")
+	}
+	if errors.Is(err, domain.ErrFeeExceedsAmount) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Fee exceeds transaction amount")
+	}
+	if errors.Is(err, domain.ErrPaymentRequestNotPending) {
+		return echo.NewHTTPError(http.StatusConflict, "Payment request is not pending")
+	}
+	if errors.Is(err, domain.ErrPaymentRequestExpired) {
+		return echo.NewHTTPError(http.StatusGone, "Payment request has expired")
+	}
+	if errors.Is(err, domain.ErrPaymentRequestNotAllowed) {
+		return echo.NewHTTPError(http.StatusForbidden, "Not allowed to act on this payment request")
+	}
+	if errors.Is(err, domain.ErrSelfPaymentRequest) ||
+		errors.Is(err, domain.ErrPayerWalletMismatch) ||
+		errors.Is(err, domain.ErrInvalidPaymentRequestTTL) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, domain.ErrInvalidHandle) ||
+		errors.Is(err, domain.ErrInvalidRecipient) ||
+		errors.Is(err, domain.ErrRecipientIsSender) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, domain.ErrHandleReserved) {
+		return echo.NewHTTPError(http.StatusConflict, "Handle is reserved")
+	}
+	if errors.Is(err, domain.ErrBatchTransferEmpty) ||
+		errors.Is(err, domain.ErrBatchTransferTooLarge) ||
+		errors.Is(err, domain.ErrInvalidBatchMode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
 
 	// Use case errors
 	if errors.Is(err, usecase.ErrWalletNotFound) {
@@ -48,12 +160,19 @@ func handleServiceError(err error) error {
 	if errors.Is(err, usecase.ErrWalletAlreadyExists) {
 		return echo.NewHTTPError(http.StatusConflict, "Wallet already exists for this user and currency")
 	}
+	if errors.Is(err, usecase.ErrSweepWalletRequired) ||
+		errors.Is(err, usecase.ErrInvalidSweepWallet) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
 	if errors.Is(err, usecase.ErrEmailAlreadyExists) {
 		return echo.NewHTTPError(http.StatusConflict, "Email already exists")
 	}
 	if errors.Is(err, usecase.ErrPhoneAlreadyExists) {
 		return echo.NewHTTPError(http.StatusConflict, "Phone already exists")
 	}
+	if errors.Is(err, usecase.ErrHandleAlreadyTaken) {
+		return echo.NewHTTPError(http.StatusConflict, "Handle already taken")
+	}
 	if errors.Is(err, usecase.ErrTransactionNotFound) {
 		return echo.NewHTTPError(http.StatusNotFound, "Transaction not found")
 	}
@@ -66,6 +185,40 @@ func handleServiceError(err error) error {
 	if errors.Is(err, usecase.ErrInvalidPaymentStatus) {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid payment status")
 	}
+	if errors.Is(err, usecase.ErrTransactionDenied) {
+		return echo.NewHTTPError(http.StatusForbidden, "Transaction denied by risk checks")
+	}
+	if errors.Is(err, usecase.ErrRiskAssessmentNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Risk assessment not found")
+	}
+	if errors.Is(err, usecase.ErrPaymentRequestNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Payment request not found")
+	}
+	if errors.Is(err, usecase.ErrBatchTransferNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Batch transfer not found")
+	}
+	if errors.Is(err, usecase.ErrRecipientNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Recipient not found")
+	}
+	if errors.Is(err, usecase.ErrRecipientWalletNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Recipient has no active wallet in this currency")
+	}
+	if errors.Is(err, usecase.ErrRecipientCurrencyMismatch) ||
+		errors.Is(err, usecase.ErrConfirmationRequired) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, usecase.ErrInvalidConfirmationToken) {
+		return echo.NewHTTPError(http.StatusGone, "Confirmation token is invalid or expired")
+	}
+	if errors.Is(err, usecase.ErrMerchantNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Merchant not found")
+	}
+	if errors.Is(err, usecase.ErrAPIKeyNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "API key not found")
+	}
+	if errors.Is(err, usecase.ErrRevenueWalletNotConfigured) {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Fee collection is not configured for this currency")
+	}
 
 	// Default error
 	return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+
+	"github.com/labstack/echo/v4"
+)
+
+// FeeHandler handles fee-related HTTP requests
+type FeeHandler struct {
+	feeService primary.FeeService
+}
+
+// NewFeeHandler creates a new fee handler
+func NewFeeHandler(feeService primary.FeeService) *FeeHandler {
+	return &FeeHandler{
+		feeService: feeService,
+	}
+}
+
+// FeeQuoteRequest represents the request to preview a fee. Either WalletID
+// or CurrencyCode must be given so the currency of the operation is known
+type FeeQuoteRequest struct {
+	WalletID        int    `json:"wallet_id" validate:"required_without=CurrencyCode,omitempty,min=1"`
+	CurrencyCode    string `json:"currency_code" validate:"required_without=WalletID,omitempty,len=3"`
+	TransactionType string `json:"transaction_type" validate:"required,oneof=DEPOSIT WITHDRAWAL TRANSFER"`
+	Amount          int    `json:"amount" validate:"required,min=1"`
+	PaymentProvider string `json:"payment_provider"`
+}
+
+// QuoteFee handles POST /api/v1/fees/quote
+func (h *FeeHandler) QuoteFee(c echo.Context) error {
+	var req FeeQuoteRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	quote, err := h.feeService.QuoteFee(c.Request().Context(), primary.FeeQuoteRequest{
+		WalletID:        req.WalletID,
+		TransactionType: domain.TransactionType(req.TransactionType),
+		Amount:          req.Amount,
+		Currency:        req.CurrencyCode,
+		PaymentProvider: domain.PaymentProvider(req.PaymentProvider),
+	})
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   quote,
+	})
+}
+
+// GetFeeSchedule handles GET /api/v1/fees/schedule
+func (h *FeeHandler) GetFeeSchedule(c echo.Context) error {
+	schedule, err := h.feeService.GetFeeSchedule(c.Request().Context())
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   schedule,
+	})
+}
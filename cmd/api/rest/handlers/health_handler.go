@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HealthHandler handles health probes. Reports are returned as they are,
+// without the API envelope, so probes only have to read the status code
+type HealthHandler struct {
+	healthService primary.HealthService
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(healthService primary.HealthService) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
+}
+
+// Live handles GET /health/live
+func (h *HealthHandler) Live(c echo.Context) error {
+	return healthResponse(c, h.healthService.Liveness(c.Request().Context()))
+}
+
+// Ready handles GET /health/ready and GET /health. Degraded dependencies
+// still answer 200 so the instance keeps receiving traffic
+func (h *HealthHandler) Ready(c echo.Context) error {
+	return healthResponse(c, h.healthService.Readiness(c.Request().Context()))
+}
+
+// healthResponse answers 503 when the service is down
+func healthResponse(c echo.Context, report *domain.HealthReport) error {
+	status := http.StatusOK
+	if !report.IsAvailable() {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.JSON(status, report)
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MerchantHandler handles merchant API client HTTP requests
+type MerchantHandler struct {
+	merchantService primary.MerchantService
+}
+
+// NewMerchantHandler creates a new merchant handler
+func NewMerchantHandler(merchantService primary.MerchantService) *MerchantHandler {
+	return &MerchantHandler{
+		merchantService: merchantService,
+	}
+}
+
+// CreateMerchantRequest represents the request to register an API client
+type CreateMerchantRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=100"`
+	UserID      int      `json:"user_id" validate:"required,min=1"`
+	Environment string   `json:"environment" validate:"required,oneof=SANDBOX LIVE"`
+	Scopes      []string `json:"scopes" validate:"required,min=1,dive,oneof=read payments transfers"`
+}
+
+// CreateMerchant handles POST /api/v1/admin/merchants
+func (h *MerchantHandler) CreateMerchant(c echo.Context) error {
+	var req CreateMerchantRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	scopes := make([]domain.APIScope, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		scopes = append(scopes, domain.APIScope(scope))
+	}
+
+	merchant, credentials, err := h.merchantService.CreateMerchant(c.Request().Context(), primary.CreateMerchantRequest{
+		Name:        req.Name,
+		UserID:      req.UserID,
+		Environment: domain.MerchantEnvironment(req.Environment),
+		Scopes:      scopes,
+	})
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"merchant":    merchant,
+			"credentials": credentials,
+		},
+	})
+}
+
+// GetMerchant handles GET /api/v1/admin/merchants/:id
+func (h *MerchantHandler) GetMerchant(c echo.Context) error {
+	merchantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid merchant ID")
+	}
+
+	merchant, err := h.merchantService.GetMerchant(c.Request().Context(), merchantID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	keys, err := h.merchantService.GetAPIKeys(c.Request().Context(), merchantID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"merchant": merchant,
+			"keys":     keys,
+		},
+	})
+}
+
+// RotateAPIKey handles POST /api/v1/admin/merchants/:id/keys
+func (h *MerchantHandler) RotateAPIKey(c echo.Context) error {
+	merchantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid merchant ID")
+	}
+
+	credentials, err := h.merchantService.RotateAPIKey(c.Request().Context(), merchantID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status": "success",
+		"data":   credentials,
+	})
+}
+
+// RevokeAPIKey handles DELETE /api/v1/admin/merchants/:id/keys/:key_id
+func (h *MerchantHandler) RevokeAPIKey(c echo.Context) error {
+	merchantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid merchant ID")
+	}
+
+	keyID, err := strconv.Atoi(c.Param("key_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid key ID")
+	}
+
+	if err := h.merchantService.RevokeAPIKey(c.Request().Context(), merchantID, keyID); err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  "success",
+		"message": "API key revoked",
+	})
+}
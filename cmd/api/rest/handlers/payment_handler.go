@@ -12,12 +12,14 @@ import (
 // PaymentHandler handles payment-related HTTP requests
 type PaymentHandler struct {
 	paymentService primary.PaymentService
+	authorizer     *Authorizer
 }
 
 // NewPaymentHandler creates a new payment handler
-func NewPaymentHandler(paymentService primary.PaymentService) *PaymentHandler {
+func NewPaymentHandler(paymentService primary.PaymentService, authorizer *Authorizer) *PaymentHandler {
 	return &PaymentHandler{
 		paymentService: paymentService,
+		authorizer:     authorizer,
 	}
 }
 
@@ -42,6 +44,10 @@ func (h *PaymentHandler) ProcessPayment(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	if err := h.authorizer.AuthorizeWallet(c, req.WalletID); err != nil {
+		return err
+	}
+
 	paymentReq := primary.PaymentRequest{
 		WalletID:        req.WalletID,
 		Amount:          req.Amount,
@@ -74,6 +80,10 @@ func (h *PaymentHandler) GetPayment(c echo.Context) error {
 		return handleServiceError(err)
 	}
 
+	if err := h.authorizer.AuthorizeTransaction(c, payment.TransactionID); err != nil {
+		return err
+	}
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status": "success",
 		"data":   payment,
@@ -87,6 +97,10 @@ func (h *PaymentHandler) VerifyPayment(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid payment ID")
 	}
 
+	if err := h.authorizePayment(c, paymentID); err != nil {
+		return err
+	}
+
 	payment, err := h.paymentService.VerifyPayment(c.Request().Context(), paymentID)
 	if err != nil {
 		return handleServiceError(err)
@@ -105,6 +119,10 @@ func (h *PaymentHandler) CancelPayment(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid payment ID")
 	}
 
+	if err := h.authorizePayment(c, paymentID); err != nil {
+		return err
+	}
+
 	err = h.paymentService.CancelPayment(c.Request().Context(), paymentID)
 	if err != nil {
 		return handleServiceError(err)
@@ -123,6 +141,10 @@ func (h *PaymentHandler) GetPaymentsByTransactionID(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid transaction ID")
 	}
 
+	if err := h.authorizer.AuthorizeTransaction(c, transactionID); err != nil {
+		return err
+	}
+
 	payments, err := h.paymentService.GetPaymentsByTransactionID(c.Request().Context(), transactionID)
 	if err != nil {
 		return handleServiceError(err)
@@ -134,6 +156,16 @@ func (h *PaymentHandler) GetPaymentsByTransactionID(c echo.Context) error {
 	})
 }
 
+// authorizePayment checks that the caller owns the wallet a payment was made for
+func (h *PaymentHandler) authorizePayment(c echo.Context, paymentID int) error {
+	payment, err := h.paymentService.GetPaymentID(c.Request().Context(), paymentID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return h.authorizer.AuthorizeTransaction(c, payment.TransactionID)
+}
+
 // PaymentCallback handles POST /api/v1/payments/callback/:provider
 func (h *PaymentHandler) PaymentCallback(c echo.Context) error {
 	provider := c.Param("provider")
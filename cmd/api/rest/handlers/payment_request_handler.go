@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PaymentRequestHandler handles payment request HTTP requests
+type PaymentRequestHandler struct {
+	paymentRequestService primary.PaymentRequestService
+	authorizer            *Authorizer
+}
+
+// NewPaymentRequestHandler creates a new payment request handler
+func NewPaymentRequestHandler(paymentRequestService primary.PaymentRequestService, authorizer *Authorizer) *PaymentRequestHandler {
+	return &PaymentRequestHandler{
+		paymentRequestService: paymentRequestService,
+		authorizer:            authorizer,
+	}
+}
+
+// CreatePaymentRequestRequest represents the request to ask another user for money
+type CreatePaymentRequestRequest struct {
+	RequesterWalletID int    `json:"requester_wallet_id" validate:"required,min=1"`
+	PayerUserID       int    `json:"payer_user_id" validate:"required,min=1"`
+	Amount            int    `json:"amount" validate:"required,min=1"`
+	Note              string `json:"note" validate:"max=255"`
+	ExpiresInSeconds  int    `json:"expires_in_seconds" validate:"omitempty,min=60"`
+}
+
+// AcceptPaymentRequestRequest represents the request to pay a payment request
+type AcceptPaymentRequestRequest struct {
+	PayerWalletID int `json:"payer_wallet_id" validate:"required,min=1"`
+}
+
+// RespondPaymentRequestRequest represents the request to decline or cancel a payment request
+type RespondPaymentRequestRequest struct {
+	UserID int `json:"user_id" validate:"required,min=1"`
+}
+
+// CreatePaymentRequest handles POST /api/v1/payment-requests
+func (h *PaymentRequestHandler) CreatePaymentRequest(c echo.Context) error {
+	var req CreatePaymentRequestRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.authorizer.AuthorizeWallet(c, req.RequesterWalletID); err != nil {
+		return err
+	}
+
+	request, err := h.paymentRequestService.CreatePaymentRequest(c.Request().Context(), primary.CreatePaymentRequest{
+		RequesterWalletID: req.RequesterWalletID,
+		PayerUserID:       req.PayerUserID,
+		Amount:            req.Amount,
+		Note:              req.Note,
+		ExpiresIn:         time.Duration(req.ExpiresInSeconds) * time.Second,
+	})
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status": "success",
+		"data":   request,
+	})
+}
+
+// GetPaymentRequest handles GET /api/v1/payment-requests/:id
+func (h *PaymentRequestHandler) GetPaymentRequest(c echo.Context) error {
+	requestID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid payment request ID")
+	}
+
+	request, err := h.paymentRequestService.GetPaymentRequest(c.Request().Context(), requestID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	// Only the two parties of a request may read it
+	if err := h.authorizer.AuthorizeUser(c, request.RequesterUserID); err != nil {
+		if err := h.authorizer.AuthorizeUser(c, request.PayerUserID); err != nil {
+			return err
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   request,
+	})
+}
+
+// GetInbox handles GET /api/v1/users/:user_id/payment-requests/inbox
+func (h *PaymentRequestHandler) GetInbox(c echo.Context) error {
+	return h.listPaymentRequests(c, h.paymentRequestService.GetInbox)
+}
+
+// GetOutbox handles GET /api/v1/users/:user_id/payment-requests/outbox
+func (h *PaymentRequestHandler) GetOutbox(c echo.Context) error {
+	return h.listPaymentRequests(c, h.paymentRequestService.GetOutbox)
+}
+
+func (h *PaymentRequestHandler) listPaymentRequests(
+	c echo.Context,
+	list func(ctx context.Context, userID int, status domain.PaymentRequestStatus, limit, offset int) ([]*domain.PaymentRequest, error),
+) error {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	status := domain.PaymentRequestStatus(strings.ToUpper(c.QueryParam("status")))
+	limit, offset := parsePagination(c)
+
+	requests, err := list(c.Request().Context(), userID, status, limit, offset)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"payment_requests": requests,
+			"limit":            limit,
+			"offset":           offset,
+		},
+	})
+}
+
+// AcceptPaymentRequest handles POST /api/v1/payment-requests/:id/accept
+func (h *PaymentRequestHandler) AcceptPaymentRequest(c echo.Context) error {
+	requestID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid payment request ID")
+	}
+
+	var req AcceptPaymentRequestRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.authorizer.AuthorizeWallet(c, req.PayerWalletID); err != nil {
+		return err
+	}
+
+	request, err := h.paymentRequestService.AcceptPaymentRequest(c.Request().Context(), requestID, req.PayerWalletID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   request,
+	})
+}
+
+// DeclinePaymentRequest handles POST /api/v1/payment-requests/:id/decline
+func (h *PaymentRequestHandler) DeclinePaymentRequest(c echo.Context) error {
+	return h.respond(c, h.paymentRequestService.DeclinePaymentRequest)
+}
+
+// CancelPaymentRequest handles POST /api/v1/payment-requests/:id/cancel
+func (h *PaymentRequestHandler) CancelPaymentRequest(c echo.Context) error {
+	return h.respond(c, h.paymentRequestService.CancelPaymentRequest)
+}
+
+func (h *PaymentRequestHandler) respond(
+	c echo.Context,
+	action func(ctx context.Context, requestID int, userID int) (*domain.PaymentRequest, error),
+) error {
+	requestID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid payment request ID")
+	}
+
+	var req RespondPaymentRequestRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.authorizer.AuthorizeUser(c, req.UserID); err != nil {
+		return err
+	}
+
+	request, err := action(c.Request().Context(), requestID, req.UserID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   request,
+	})
+}
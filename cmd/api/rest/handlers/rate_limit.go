@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// rate limit headers, following the IETF RateLimit header fields draft
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// RateLimit counts requests against the limits configured for a route group.
+// Placed after Authenticate it also limits per user or API key. When the cache
+// cannot be reached requests are let through and the failure is logged under
+// the request ID
+func RateLimit(rateLimitService primary.RateLimitService, logger infrastructure.Logger, group string) echo.MiddlewareFunc {
+	if logger == nil {
+		logger = infrastructure.NopLogger{}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			subject := domain.RateLimitSubject{IP: c.RealIP()}
+			if principal, err := currentPrincipal(c); err == nil {
+				subject.UserID = principal.UserID
+				subject.APIKeyID = principal.APIKeyID
+			}
+
+			ctx := c.Request().Context()
+			status, err := rateLimitService.Check(ctx, group, subject)
+			if err != nil {
+				logger.Warn(ctx, "rate limit check failed", "group", group, "error", err)
+				return next(c)
+			}
+			if status == nil {
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(status.Limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(status.Remaining))
+			header.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(status.Reset)))
+			header.Set(HeaderRateLimitPolicy, status.Policy())
+
+			if !status.Allowed {
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(ceilSeconds(status.RetryAfter)))
+				return echo.NewHTTPError(http.StatusTooManyRequests, "Rate limit exceeded")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// ceilSeconds rounds a duration up to whole seconds, at least one
+func ceilSeconds(d time.Duration) int {
+	return max(int(math.Ceil(d.Seconds())), 1)
+}
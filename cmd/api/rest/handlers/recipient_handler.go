@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RecipientHandler handles transfers addressed by phone, email or handle
+type RecipientHandler struct {
+	recipientService primary.RecipientService
+}
+
+// NewRecipientHandler creates a new recipient handler
+func NewRecipientHandler(recipientService primary.RecipientService) *RecipientHandler {
+	return &RecipientHandler{
+		recipientService: recipientService,
+	}
+}
+
+// ResolveRecipientRequest represents the request to look up a transfer recipient
+type ResolveRecipientRequest struct {
+	Recipient    string `json:"recipient" validate:"required,max=255"`
+	CurrencyCode string `json:"currency_code" validate:"required,len=3"`
+}
+
+// RecipientTransferRequest represents the request to transfer funds to a recipient
+type RecipientTransferRequest struct {
+	Recipient         string `json:"recipient" validate:"required_without=ConfirmationToken,max=255"`
+	ConfirmationToken string `json:"confirmation_token"`
+	Amount            int    `json:"amount" validate:"required,min=1"`
+	Description       string `json:"description"`
+}
+
+// ResolveRecipient handles POST /api/v1/recipients/resolve
+func (h *RecipientHandler) ResolveRecipient(c echo.Context) error {
+	var req ResolveRecipientRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	recipient, err := h.recipientService.ResolveRecipient(c.Request().Context(), req.Recipient, req.CurrencyCode)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   recipient,
+	})
+}
+
+// TransferToRecipient handles POST /api/v1/wallets/:id/transfer/recipient
+func (h *RecipientHandler) TransferToRecipient(c echo.Context) error {
+	fromWalletID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
+	}
+
+	var req RecipientTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	transaction, err := h.recipientService.TransferToRecipient(c.Request().Context(), primary.TransferToRecipientRequest{
+		FromWalletID:      fromWalletID,
+		Recipient:         req.Recipient,
+		ConfirmationToken: req.ConfirmationToken,
+		Amount:            req.Amount,
+		Description:       req.Description,
+	})
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   transaction,
+	})
+}
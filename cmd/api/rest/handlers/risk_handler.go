@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RiskHandler handles risk review HTTP requests
+type RiskHandler struct {
+	riskService primary.RiskService
+}
+
+// NewRiskHandler creates a new risk handler
+func NewRiskHandler(riskService primary.RiskService) *RiskHandler {
+	return &RiskHandler{
+		riskService: riskService,
+	}
+}
+
+// ResolveReviewRequest represents the request to approve or reject a flagged assessment
+type ResolveReviewRequest struct {
+	Reviewer string `json:"reviewer" validate:"required"`
+	Note     string `json:"note"`
+}
+
+// GetReviewQueue handles GET /api/v1/risk/reviews
+func (h *RiskHandler) GetReviewQueue(c echo.Context) error {
+	limit, offset := parsePagination(c)
+
+	assessments, err := h.riskService.GetReviewQueue(c.Request().Context(), limit, offset)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"assessments": assessments,
+			"limit":       limit,
+			"offset":      offset,
+		},
+	})
+}
+
+// GetAssessment handles GET /api/v1/risk/assessments/:id
+func (h *RiskHandler) GetAssessment(c echo.Context) error {
+	assessmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid assessment ID")
+	}
+
+	assessment, err := h.riskService.GetAssessment(c.Request().Context(), assessmentID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   assessment,
+	})
+}
+
+// GetWalletAssessments handles GET /api/v1/wallets/:id/risk-assessments
+func (h *RiskHandler) GetWalletAssessments(c echo.Context) error {
+	walletID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
+	}
+
+	limit, offset := parsePagination(c)
+
+	assessments, err := h.riskService.GetAssessmentsByWalletID(c.Request().Context(), walletID, limit, offset)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"assessments": assessments,
+			"limit":       limit,
+			"offset":      offset,
+		},
+	})
+}
+
+// ApproveReview handles POST /api/v1/risk/reviews/:id/approve
+func (h *RiskHandler) ApproveReview(c echo.Context) error {
+	assessmentID, req, err := h.bindResolveReview(c)
+	if err != nil {
+		return err
+	}
+
+	assessment, err := h.riskService.ApproveReview(c.Request().Context(), assessmentID, req.Reviewer, req.Note)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   assessment,
+	})
+}
+
+// RejectReview handles POST /api/v1/risk/reviews/:id/reject
+func (h *RiskHandler) RejectReview(c echo.Context) error {
+	assessmentID, req, err := h.bindResolveReview(c)
+	if err != nil {
+		return err
+	}
+
+	assessment, err := h.riskService.RejectReview(c.Request().Context(), assessmentID, req.Reviewer, req.Note)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   assessment,
+	})
+}
+
+func (h *RiskHandler) bindResolveReview(c echo.Context) (int, ResolveReviewRequest, error) {
+	var req ResolveReviewRequest
+
+	assessmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, req, echo.NewHTTPError(http.StatusBadRequest, "Invalid assessment ID")
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return 0, req, echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return 0, req, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return assessmentID, req, nil
+}
+
+// parsePagination reads limit and offset query parameters with the same
+// defaults as the transaction history endpoint
+func parsePagination(c echo.Context) (int, int) {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	return limit, offset
+}
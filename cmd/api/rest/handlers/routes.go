@@ -1,8 +1,12 @@
 package rest
 
 import (
+	"net/http"
 	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/api/rest/openapi"
+	"ports-and-adapters-architecture/internal/domain"
 	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -22,54 +26,226 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 	return nil
 }
 
-// SetupRoutes sets up all HTTP routes
+// SetupRoutes sets up all HTTP routes. Every API route requires a bearer token,
+// or a signed API key request when merchantService is set, except the payment
+// provider callbacks and, when tokenIssuer is set, the dev token endpoint.
+// Requests are logged to logger when it is set. healthService answers the
+// health probes
 func SetupRoutes(
 	e *echo.Echo,
+	userService primary.UserService,
 	walletService primary.WalletService,
+	transactionService primary.TransactionService,
 	paymentService primary.PaymentService,
+	batchTransferService primary.BatchTransferService,
+	paymentRequestService primary.PaymentRequestService,
+	recipientService primary.RecipientService,
+	statementService primary.StatementService,
+	balanceService primary.BalanceSnapshotService,
+	riskService primary.RiskService,
+	feeService primary.FeeService,
+	merchantService primary.MerchantService,
+	rateLimitService primary.RateLimitService,
+	healthService primary.HealthService,
+	tokenVerifier infrastructure.TokenVerifier,
+	tokenIssuer infrastructure.TokenIssuer,
+	logger infrastructure.Logger,
 ) {
 	// Setup validator
 	e.Validator = &CustomValidator{validator: validator.New()}
 
 	// Middleware
-	e.Use(middleware.Logger())
+	e.Use(requestID())
+	if logger != nil {
+		e.Use(requestLogger(logger))
+	}
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
-	e.Use(middleware.RequestID())
 
-	// Health check
-	e.GET("/health", func(c echo.Context) error {
-		return c.JSON(200, map[string]string{
-			"status": "healthy",
-		})
-	})
+	// Health checks
+	healthHandler := handlers.NewHealthHandler(healthService)
+	e.GET("/health", healthHandler.Ready)
+	e.GET("/health/live", healthHandler.Live)
+	e.GET("/health/ready", healthHandler.Ready)
 
-	// API v1 group
-	v1 := e.Group("/api/v1")
+	// API documentation
+	e.GET("/openapi.json", openapi.ServeSpec)
+	e.GET("/docs", openapi.ServeDocs)
 
 	// Initialize handlers
-	walletHandler := handlers.NewWalletHandler(walletService)
-	paymentHandler := handlers.NewPaymentHandler(paymentService)
+	authorizer := handlers.NewAuthorizer(walletService, transactionService)
+	userHandler := handlers.NewUserHandler(userService)
+	walletHandler := handlers.NewWalletHandler(walletService, balanceService, authorizer)
+	transactionHandler := handlers.NewTransactionHandler(transactionService, authorizer)
+	paymentHandler := handlers.NewPaymentHandler(paymentService, authorizer)
+	batchTransferHandler := handlers.NewBatchTransferHandler(batchTransferService, authorizer)
+	paymentRequestHandler := handlers.NewPaymentRequestHandler(paymentRequestService, authorizer)
+	recipientHandler := handlers.NewRecipientHandler(recipientService)
+	statementHandler := handlers.NewStatementHandler(statementService)
+
+	// Rate limits per route group (only when rate limiting is enabled)
+	rateLimit := func(group string) echo.MiddlewareFunc {
+		if rateLimitService == nil {
+			return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+		}
+		return handlers.RateLimit(rateLimitService, logger, group)
+	}
+
+	// Public routes, reachable without a token
+	public := e.Group("/api/v1", rateLimit("public"))
+	public.POST("/payments/callback/:provider", paymentHandler.PaymentCallback)
+
+	if tokenIssuer != nil {
+		authHandler := handlers.NewAuthHandler(tokenIssuer)
+		public.POST("/auth/dev-token", authHandler.IssueDevToken)
+	}
+
+	// API v1 group
+	v1 := e.Group("/api/v1", handlers.Authenticate(tokenVerifier, merchantService), rateLimit("api"))
+
+	// Access checks, admins bypass ownership
+	ownsWallet := authorizer.WalletOwner("id")
+	isUser := authorizer.UserSelf("user_id")
+	requireAdmin := handlers.RequireRole(domain.RoleAdmin)
+
+	// Scope checks for API clients, user tokens pass them
+	canRead := handlers.RequireScope(domain.APIScopeRead)
+	canPay := handlers.RequireScope(domain.APIScopePayments)
+	canTransfer := handlers.RequireScope(domain.APIScopeTransfers)
 
 	// Wallet routes
 	wallets := v1.Group("/wallets")
-	wallets.POST("", walletHandler.CreateWallet)
-	wallets.GET("/:id", walletHandler.GetWallet)
-	wallets.POST("/:id/deposit", walletHandler.Deposit)
-	wallets.POST("/:id/withdraw", walletHandler.Withdraw)
-	wallets.POST("/:id/transfer", walletHandler.Transfer)
-	wallets.GET("/:id/transactions", walletHandler.GetTransactionHistory)
-	wallets.GET("/:id/balance", walletHandler.GetBalance)
+	wallets.POST("", walletHandler.CreateWallet, canTransfer)
+	wallets.GET("/:id", walletHandler.GetWallet, canRead, ownsWallet)
+	wallets.POST("/:id/deposit", walletHandler.Deposit, canTransfer, ownsWallet)
+	wallets.POST("/:id/withdraw", walletHandler.Withdraw, canTransfer, ownsWallet)
+	wallets.POST("/:id/transfer", walletHandler.Transfer, canTransfer, ownsWallet)
+	wallets.GET("/:id/transactions", walletHandler.GetTransactionHistory, canRead, ownsWallet)
+	wallets.GET("/:id/balance", walletHandler.GetBalance, canRead, ownsWallet)
+	wallets.GET("/:id/statements", statementHandler.ExportStatement, canRead, ownsWallet)
+
+	// Recipient routes
+	wallets.POST("/:id/transfer/recipient", recipientHandler.TransferToRecipient, canTransfer, ownsWallet)
+	v1.POST("/recipients/resolve", recipientHandler.ResolveRecipient, canTransfer)
+
+	// Batch transfer routes
+	wallets.POST("/:id/batch-transfers", batchTransferHandler.CreateBatchTransfer, canTransfer, ownsWallet)
+	wallets.POST("/:id/batch-transfers/csv", batchTransferHandler.UploadBatchTransferCSV, canTransfer, ownsWallet)
+	wallets.GET("/:id/batch-transfers", batchTransferHandler.GetWalletBatchTransfers, canRead, ownsWallet)
+	v1.GET("/batch-transfers/:id", batchTransferHandler.GetBatchTransfer, canRead)
+
+	// User routes, API clients act on wallets only
+	users := v1.Group("/users", handlers.RejectAPIClients())
+	users.POST("", userHandler.CreateUser, requireAdmin)
+	users.GET("/lookup", userHandler.LookupUser, requireAdmin)
+	users.GET("/:user_id", userHandler.GetUser, isUser)
+	users.PUT("/:user_id", userHandler.UpdateUser, isUser)
+	users.POST("/:user_id/activate", userHandler.ActivateUser, requireAdmin)
+	users.POST("/:user_id/deactivate", userHandler.DeactivateUser, requireAdmin)
+	users.PUT("/:user_id/handle", userHandler.SetHandle, isUser)
 
 	// User wallet routes
-	v1.GET("/users/:user_id/wallets", walletHandler.GetWalletsByUserID)
+	v1.GET("/users/:user_id/wallets", walletHandler.GetWalletsByUserID, canRead, isUser)
+
+	// Transaction routes
+	transactions := v1.Group("/transactions")
+	transactions.GET("", transactionHandler.GetTransactions, requireAdmin)
+	transactions.GET("/:id", transactionHandler.GetTransaction, canRead)
 
 	// Payment routes
-	payments := v1.Group("/payments")
+	payments := v1.Group("/payments", canPay, rateLimit("payments"))
 	payments.POST("/process", paymentHandler.ProcessPayment)
 	payments.GET("/:id", paymentHandler.GetPayment)
 	payments.POST("/:id/verify", paymentHandler.VerifyPayment)
 	payments.POST("/:id/cancel", paymentHandler.CancelPayment)
 	payments.GET("/transaction/:transaction_id", paymentHandler.GetPaymentsByTransactionID)
-	payments.POST("/callback/:provider", paymentHandler.PaymentCallback)
+
+	// Payment request routes
+	paymentRequests := v1.Group("/payment-requests", canPay, rateLimit("payments"))
+	paymentRequests.POST("", paymentRequestHandler.CreatePaymentRequest)
+	paymentRequests.GET("/:id", paymentRequestHandler.GetPaymentRequest)
+	paymentRequests.POST("/:id/accept", paymentRequestHandler.AcceptPaymentRequest)
+	paymentRequests.POST("/:id/decline", paymentRequestHandler.DeclinePaymentRequest)
+	paymentRequests.POST("/:id/cancel", paymentRequestHandler.CancelPaymentRequest)
+	v1.GET("/users/:user_id/payment-requests/inbox", paymentRequestHandler.GetInbox, canPay, isUser)
+	v1.GET("/users/:user_id/payment-requests/outbox", paymentRequestHandler.GetOutbox, canPay, isUser)
+
+	// Admin routes, kept apart from customer routes
+	admin := v1.Group("/admin", requireAdmin, rateLimit("admin"))
+	admin.POST("/wallets/:id/freeze", walletHandler.FreezeWallet)
+	admin.POST("/wallets/:id/unfreeze", walletHandler.UnfreezeWallet)
+	admin.POST("/wallets/:id/close", walletHandler.CloseWallet)
+	admin.PUT("/transactions/:id/status", transactionHandler.UpdateTransactionStatus)
+	admin.POST("/transactions/reconcile", transactionHandler.ReconcileTransactions)
+
+	// Risk review routes (only when risk checks are enabled)
+	if riskService != nil {
+		riskHandler := handlers.NewRiskHandler(riskService)
+
+		wallets.GET("/:id/risk-assessments", riskHandler.GetWalletAssessments, requireAdmin)
+
+		risk := v1.Group("/risk", requireAdmin)
+		risk.GET("/assessments/:id", riskHandler.GetAssessment)
+		risk.GET("/reviews", riskHandler.GetReviewQueue)
+		risk.POST("/reviews/:id/approve", riskHandler.ApproveReview)
+		risk.POST("/reviews/:id/reject", riskHandler.RejectReview)
+	}
+
+	// Fee routes (only when fees are enabled)
+	if feeService != nil {
+		feeHandler := handlers.NewFeeHandler(feeService)
+
+		fees := v1.Group("/fees", canRead)
+		fees.GET("/schedule", feeHandler.GetFeeSchedule)
+		fees.POST("/quote", feeHandler.QuoteFee)
+	}
+
+	// Merchant API client routes (only when merchant API keys are enabled)
+	if merchantService != nil {
+		merchantHandler := handlers.NewMerchantHandler(merchantService)
+
+		admin.POST("/merchants", merchantHandler.CreateMerchant)
+		admin.GET("/merchants/:id", merchantHandler.GetMerchant)
+		admin.POST("/merchants/:id/keys", merchantHandler.RotateAPIKey)
+		admin.DELETE("/merchants/:id/keys/:key_id", merchantHandler.RevokeAPIKey)
+	}
+}
+
+// requestID reuses the X-Request-Id header of the caller or generates one, and
+// stores it in the request context so use cases and published events carry it
+func requestID() echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, requestID string) {
+			req := c.Request()
+			c.SetRequest(req.WithContext(domain.ContextWithRequestID(req.Context(), requestID)))
+		},
+	})
+}
+
+// requestLogger logs every request under its request ID. Server errors are
+// logged with the error returned by the handler
+func requestLogger(logger infrastructure.Logger) echo.MiddlewareFunc {
+	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		LogMethod:   true,
+		LogURI:      true,
+		LogStatus:   true,
+		LogLatency:  true,
+		LogError:    true,
+		HandleError: true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			ctx := c.Request().Context()
+			args := []any{"method", v.Method, "uri", v.URI, "status", v.Status, "latency", v.Latency}
+
+			if v.Status >= http.StatusInternalServerError {
+				if v.Error != nil {
+					args = append(args, "error", v.Error)
+				}
+				logger.Error(ctx, "request failed", args...)
+				return nil
+			}
+
+			logger.Info(ctx, "request", args...)
+			return nil
+		},
+	})
 }
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// StatementHandler handles account statement downloads
+type StatementHandler struct {
+	statementService primary.StatementService
+}
+
+// NewStatementHandler creates a new statement handler
+func NewStatementHandler(statementService primary.StatementService) *StatementHandler {
+	return &StatementHandler{
+		statementService: statementService,
+	}
+}
+
+// ExportStatement handles GET /api/v1/wallets/:id/statements. from and to are
+// required RFC 3339 times or whole days, a whole day to includes the day itself.
+// format is csv (default) or pdf
+func (h *StatementHandler) ExportStatement(c echo.Context) error {
+	walletID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
+	}
+
+	if c.QueryParam("from") == "" || c.QueryParam("to") == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "from and to are required")
+	}
+
+	from, _, err := parseTimeParam(c.QueryParam("from"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid from date")
+	}
+
+	to, wholeDay, err := parseTimeParam(c.QueryParam("to"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid to date")
+	}
+	if wholeDay {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	format := domain.StatementFormatCSV
+	if param := c.QueryParam("format"); param != "" {
+		format = domain.StatementFormat(strings.ToLower(param))
+	}
+
+	req := primary.StatementRequest{
+		WalletID: walletID,
+		From:     from,
+		To:       to,
+		Format:   format,
+	}
+
+	// Headers are only set once the statement is known to be valid, so a
+	// rejected request still gets a JSON error
+	output := func(contentType, filename string) io.Writer {
+		header := c.Response().Header()
+		header.Set(echo.HeaderContentType, contentType)
+		header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		c.Response().WriteHeader(http.StatusOK)
+		return c.Response()
+	}
+
+	// Errors after streaming started can only be logged, the default error
+	// handler skips committed responses
+	if err := h.statementService.ExportStatement(c.Request().Context(), req, output); err != nil {
+		return handleServiceError(err)
+	}
+
+	return nil
+}
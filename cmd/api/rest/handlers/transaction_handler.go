@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TransactionHandler handles transaction-related HTTP requests
+type TransactionHandler struct {
+	transactionService primary.TransactionService
+	authorizer         *Authorizer
+}
+
+// NewTransactionHandler creates a new transaction handler
+func NewTransactionHandler(transactionService primary.TransactionService, authorizer *Authorizer) *TransactionHandler {
+	return &TransactionHandler{
+		transactionService: transactionService,
+		authorizer:         authorizer,
+	}
+}
+
+// UpdateTransactionStatusRequest represents the request to override a transaction status.
+// The actor is taken from the caller's token
+type UpdateTransactionStatusRequest struct {
+	Status string `json:"status" validate:"required"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+// GetTransaction handles GET /api/v1/transactions/:id
+func (h *TransactionHandler) GetTransaction(c echo.Context) error {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid transaction ID")
+	}
+
+	if err := h.authorizer.AuthorizeTransaction(c, transactionID); err != nil {
+		return err
+	}
+
+	transaction, err := h.transactionService.GetTransaction(c.Request().Context(), transactionID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   transaction,
+	})
+}
+
+// GetTransactions handles GET /api/v1/transactions?status=
+func (h *TransactionHandler) GetTransactions(c echo.Context) error {
+	status := domain.TransactionStatus(strings.ToUpper(c.QueryParam("status")))
+	if status == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "status query parameter is required")
+	}
+
+	limit, offset := parsePagination(c)
+
+	transactions, err := h.transactionService.GetTransactionsByStatus(c.Request().Context(), status, limit, offset)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"transactions": transactions,
+			"limit":        limit,
+			"offset":       offset,
+		},
+	})
+}
+
+// UpdateTransactionStatus handles PUT /api/v1/admin/transactions/:id/status
+func (h *TransactionHandler) UpdateTransactionStatus(c echo.Context) error {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid transaction ID")
+	}
+
+	var req UpdateTransactionStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	principal, err := currentPrincipal(c)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	status := domain.TransactionStatus(strings.ToUpper(req.Status))
+	err = h.transactionService.UpdateTransactionStatus(c.Request().Context(), transactionID, status, req.Reason, principal.Actor())
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	transaction, err := h.transactionService.GetTransaction(c.Request().Context(), transactionID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   transaction,
+	})
+}
+
+// ReconcileTransactions handles POST /api/v1/admin/transactions/reconcile
+func (h *TransactionHandler) ReconcileTransactions(c echo.Context) error {
+	if err := h.transactionService.ReconcileFailedTransactions(c.Request().Context()); err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"message": "Reconciliation completed",
+		},
+	})
+}
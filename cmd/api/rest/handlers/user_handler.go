@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UserHandler handles user-related HTTP requests
+type UserHandler struct {
+	userService primary.UserService
+}
+
+// NewUserHandler creates a new user handler
+func NewUserHandler(userService primary.UserService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+	}
+}
+
+// CreateUserRequest represents the request to create a user
+type CreateUserRequest struct {
+	Fullname string `json:"fullname" validate:"required,max=255"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Phone    string `json:"phone" validate:"required,e164"`
+}
+
+// UpdateUserRequest represents the request to update a user
+type UpdateUserRequest struct {
+	Fullname string `json:"fullname" validate:"required,max=255"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Phone    string `json:"phone" validate:"required,e164"`
+}
+
+// LookupUserRequest represents the query to find a user by email or phone
+type LookupUserRequest struct {
+	Email string `query:"email" validate:"required_without=Phone,omitempty,email"`
+	Phone string `query:"phone" validate:"required_without=Email,omitempty,e164"`
+}
+
+// SetHandleRequest represents the request to claim a user handle
+type SetHandleRequest struct {
+	Handle string `json:"handle" validate:"required"`
+}
+
+// CreateUser handles POST /api/v1/users
+func (h *UserHandler) CreateUser(c echo.Context) error {
+	var req CreateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	user, err := h.userService.CreateUser(c.Request().Context(), req.Fullname, req.Email, req.Phone)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"status": "success",
+		"data":   user,
+	})
+}
+
+// GetUser handles GET /api/v1/users/:user_id
+func (h *UserHandler) GetUser(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	user, err := h.userService.GetUser(c.Request().Context(), userID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   user,
+	})
+}
+
+// LookupUser handles GET /api/v1/users/lookup?email= or ?phone=
+func (h *UserHandler) LookupUser(c echo.Context) error {
+	var req LookupUserRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	var user *domain.User
+	var err error
+	if req.Email != "" {
+		user, err = h.userService.GetUserByEmail(c.Request().Context(), req.Email)
+	} else {
+		user, err = h.userService.GetUserByPhone(c.Request().Context(), req.Phone)
+	}
+
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   user,
+	})
+}
+
+// UpdateUser handles PUT /api/v1/users/:user_id
+func (h *UserHandler) UpdateUser(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req UpdateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	user, err := h.userService.UpdateUser(c.Request().Context(), userID, req.Fullname, req.Email, req.Phone)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   user,
+	})
+}
+
+// ActivateUser handles POST /api/v1/users/:user_id/activate
+func (h *UserHandler) ActivateUser(c echo.Context) error {
+	return h.changeStatus(c, h.userService.ActivateUser)
+}
+
+// DeactivateUser handles POST /api/v1/users/:user_id/deactivate
+func (h *UserHandler) DeactivateUser(c echo.Context) error {
+	return h.changeStatus(c, h.userService.DeactiveUser)
+}
+
+func (h *UserHandler) changeStatus(c echo.Context, action func(ctx context.Context, id int) error) error {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	if err := action(c.Request().Context(), userID); err != nil {
+		return handleServiceError(err)
+	}
+
+	user, err := h.userService.GetUser(c.Request().Context(), userID)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   user,
+	})
+}
+
+// SetHandle handles PUT /api/v1/users/:user_id/handle
+func (h *UserHandler) SetHandle(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req SetHandleRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	user, err := h.userService.SetHandle(c.Request().Context(), userID, req.Handle)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   user,
+	})
+}
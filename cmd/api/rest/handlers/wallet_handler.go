@@ -1,22 +1,34 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"ports-and-adapters-architecture/internal/domain"
 	"ports-and-adapters-architecture/internal/ports/primary"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 // WalletHandler handles wallet-related HTTP requests
 type WalletHandler struct {
-	walletService primary.WalletService
+	walletService  primary.WalletService
+	balanceService primary.BalanceSnapshotService
+	authorizer     *Authorizer
 }
 
 // NewWalletHandler creates a new wallet handler
-func NewWalletHandler(walletService primary.WalletService) *WalletHandler {
+func NewWalletHandler(
+	walletService primary.WalletService,
+	balanceService primary.BalanceSnapshotService,
+	authorizer *Authorizer,
+) *WalletHandler {
 	return &WalletHandler{
-		walletService: walletService,
+		walletService:  walletService,
+		balanceService: balanceService,
+		authorizer:     authorizer,
 	}
 }
 
@@ -46,6 +58,19 @@ type TransferRequest struct {
 	Description string `json:"description"`
 }
 
+// WalletStatusRequest represents the request to freeze or unfreeze a wallet
+type WalletStatusRequest struct {
+	Reason string `json:"reason" validate:"required"`
+	Actor  string `json:"actor" validate:"required,max=255"`
+}
+
+// CloseWalletRequest represents the request to close a wallet
+type CloseWalletRequest struct {
+	Reason          string `json:"reason" validate:"required"`
+	Actor           string `json:"actor" validate:"required,max=255"`
+	SweepToWalletID int    `json:"sweep_to_wallet_id" validate:"omitempty,min=1"`
+}
+
 // CreateWallet handles POST /api/v1/wallets
 func (h *WalletHandler) CreateWallet(c echo.Context) error {
 	var req CreateWalletRequest
@@ -57,6 +82,10 @@ func (h *WalletHandler) CreateWallet(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	if err := h.authorizer.AuthorizeUser(c, req.UserID); err != nil {
+		return err
+	}
+
 	wallet, err := h.walletService.CreateWallet(c.Request().Context(), req.UserID, req.CurrencyCode, req.Description)
 	if err != nil {
 		return handleServiceError(err)
@@ -198,38 +227,132 @@ func (h *WalletHandler) GetTransactionHistory(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
 	}
 
-	// Parse pagination parameters
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	if limit <= 0 || limit > 100 {
-		limit = 20
+	filter, err := parseTransactionFilter(c)
+	if err != nil {
+		return err
 	}
 
-	offset, _ := strconv.Atoi(c.QueryParam("offset"))
-	if offset < 0 {
-		offset = 0
+	// Offset pages are kept for older clients, they also get the total by default
+	limit, offset := parsePagination(c)
+	cursor := c.QueryParam("cursor")
+
+	includeTotal := cursor == ""
+	if param := c.QueryParam("include_total"); param != "" {
+		includeTotal, err = strconv.ParseBool(param)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid include_total")
+		}
 	}
 
-	transactions, total, err := h.walletService.GetTransactionHistory(
-		c.Request().Context(),
-		walletID,
-		limit,
-		offset,
-	)
+	page, err := h.walletService.GetTransactionHistoryPage(c.Request().Context(), walletID, primary.TransactionHistoryQuery{
+		Filter:       filter,
+		Limit:        limit,
+		Offset:       offset,
+		Cursor:       cursor,
+		IncludeTotal: includeTotal,
+	})
 	if err != nil {
 		return handleServiceError(err)
 	}
 
+	data := map[string]interface{}{
+		"transactions": page.Transactions,
+		"limit":        limit,
+		"next_cursor":  nil,
+	}
+	if page.NextCursor != "" {
+		data["next_cursor"] = page.NextCursor
+	}
+	if cursor == "" {
+		data["offset"] = offset
+	}
+	if page.Total != nil {
+		data["total"] = *page.Total
+	}
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"status": "success",
-		"data": map[string]interface{}{
-			"transactions": transactions,
-			"total":        total,
-			"limit":        limit,
-			"offset":       offset,
-		},
+		"data":   data,
 	})
 }
 
+// parseTransactionFilter reads the history filter from the query string. Lists
+// are comma separated and dates are RFC 3339 times or whole days
+func parseTransactionFilter(c echo.Context) (domain.TransactionFilter, error) {
+	var filter domain.TransactionFilter
+
+	if param := c.QueryParam("from"); param != "" {
+		from, _, err := parseTimeParam(param)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid from date")
+		}
+		filter.From = &from
+	}
+
+	if param := c.QueryParam("to"); param != "" {
+		to, wholeDay, err := parseTimeParam(param)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid to date")
+		}
+		// A whole day includes the day itself
+		if wholeDay {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+
+	for _, t := range splitQueryList(c.QueryParam("type")) {
+		filter.Types = append(filter.Types, domain.TransactionType(t))
+	}
+
+	for _, s := range splitQueryList(c.QueryParam("status")) {
+		filter.Statuses = append(filter.Statuses, domain.TransactionStatus(s))
+	}
+
+	for name, target := range map[string]**int{
+		"min_amount":             &filter.MinAmount,
+		"max_amount":             &filter.MaxAmount,
+		"counterparty_wallet_id": &filter.CounterpartyWalletID,
+	} {
+		param := c.QueryParam(name)
+		if param == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(param)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid "+name)
+		}
+		*target = &value
+	}
+
+	filter.Direction = domain.TransactionDirection(strings.ToUpper(c.QueryParam("direction")))
+	filter.Description = strings.TrimSpace(c.QueryParam("q"))
+
+	return filter, nil
+}
+
+// parseTimeParam parses an RFC 3339 time or a YYYY-MM-DD day in UTC
+func parseTimeParam(param string) (time.Time, bool, error) {
+	if day, err := time.Parse(time.DateOnly, param); err == nil {
+		return day, true, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, param)
+	return t.UTC(), false, err
+}
+
+// splitQueryList splits a comma separated query parameter into upper-cased values
+func splitQueryList(param string) []string {
+	var values []string
+	for _, value := range strings.Split(param, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, strings.ToUpper(value))
+		}
+	}
+	return values
+}
+
 // GetBalance handles GET /api/v1/wallets/:id/balance
 func (h *WalletHandler) GetBalance(c echo.Context) error {
 	walletID, err := strconv.Atoi(c.Param("id"))
@@ -237,6 +360,10 @@ func (h *WalletHandler) GetBalance(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
 	}
 
+	if param := c.QueryParam("at"); param != "" {
+		return h.getBalanceAt(c, walletID, param)
+	}
+
 	balance, currency, err := h.walletService.GetBalance(c.Request().Context(), walletID)
 	if err != nil {
 		return handleServiceError(err)
@@ -251,3 +378,96 @@ func (h *WalletHandler) GetBalance(c echo.Context) error {
 		},
 	})
 }
+
+// getBalanceAt answers GET /api/v1/wallets/:id/balance?at= with the balance at
+// an RFC 3339 time or at the end of a YYYY-MM-DD day
+func (h *WalletHandler) getBalanceAt(c echo.Context, walletID int, param string) error {
+	at, wholeDay, err := parseTimeParam(param)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid at time")
+	}
+	if wholeDay {
+		at = at.AddDate(0, 0, 1)
+	}
+
+	balance, err := h.balanceService.GetBalanceAt(c.Request().Context(), walletID, at)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   balance,
+	})
+}
+
+// FreezeWallet handles POST /api/v1/admin/wallets/:id/freeze
+func (h *WalletHandler) FreezeWallet(c echo.Context) error {
+	return h.changeStatus(c, h.walletService.FreezeWallet)
+}
+
+// UnfreezeWallet handles POST /api/v1/admin/wallets/:id/unfreeze
+func (h *WalletHandler) UnfreezeWallet(c echo.Context) error {
+	return h.changeStatus(c, h.walletService.UnfreezeWallet)
+}
+
+func (h *WalletHandler) changeStatus(
+	c echo.Context,
+	action func(ctx context.Context, walletID int, reason domain.WalletStatusReason, actor string) (*domain.Wallet, error),
+) error {
+	walletID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
+	}
+
+	var req WalletStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	wallet, err := action(c.Request().Context(), walletID, domain.WalletStatusReason(strings.ToUpper(req.Reason)), req.Actor)
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   wallet,
+	})
+}
+
+// CloseWallet handles POST /api/v1/admin/wallets/:id/close
+func (h *WalletHandler) CloseWallet(c echo.Context) error {
+	walletID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid wallet ID")
+	}
+
+	var req CloseWalletRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	wallet, err := h.walletService.CloseWallet(c.Request().Context(), primary.CloseWalletRequest{
+		WalletID:        walletID,
+		SweepToWalletID: req.SweepToWalletID,
+		Reason:          domain.WalletStatusReason(strings.ToUpper(req.Reason)),
+		Actor:           req.Actor,
+	})
+	if err != nil {
+		return handleServiceError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   wallet,
+	})
+}
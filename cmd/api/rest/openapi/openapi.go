@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+// swaggerUIVersion pins the Swagger UI assets served by the docs page
+const swaggerUIVersion = "5.17.14"
+
+func init() {
+	// Batch transfers accept a raw CSV body and the docs page is HTML
+	openapi3filter.RegisterBodyDecoder("text/csv", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("text/html", openapi3filter.FileBodyDecoder)
+}
+
+// Load parses the embedded OpenAPI specification and validates it
+func Load() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+
+	doc, err := loader.LoadFromData(specYAML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+
+	return doc, nil
+}
+
+var (
+	specOnce sync.Once
+	specJSON []byte
+	specErr  error
+)
+
+// ServeSpec handles GET /openapi.json
+func ServeSpec(c echo.Context) error {
+	specOnce.Do(func() {
+		doc, err := Load()
+		if err != nil {
+			specErr = err
+			return
+		}
+		specJSON, specErr = json.Marshal(doc)
+	})
+	if specErr != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, specErr.Error())
+	}
+
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, specJSON)
+}
+
+// ServeDocs handles GET /docs with a Swagger UI page for /openapi.json
+func ServeDocs(c echo.Context) error {
+	return c.HTML(http.StatusOK, docsPage)
+}
+
+var docsPage = strings.ReplaceAll(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>E-Wallet API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@{{version}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@{{version}}/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`, "{{version}}", swaggerUIVersion)
+
+// ValidateRequests returns middleware that rejects requests whose parameters
+// or body do not match the specification. Credentials are left to the route
+// authentication, and paths the spec does not know pass through so the router
+// answers them
+func ValidateRequests(doc *openapi3.T) (echo.MiddlewareFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI router: %w", err)
+	}
+
+	options := &openapi3filter.Options{
+		AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		SkipSettingDefaults: true,
+	}
+	options.WithCustomSchemaErrorFunc(schemaErrorMessage)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			route, pathParams, err := router.FindRoute(req)
+			if err != nil {
+				return next(c)
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, requestErrorMessage(err))
+			}
+
+			return next(c)
+		}
+	}, nil
+}
+
+// requestErrorMessage names the parameter or body field a request failed on
+func requestErrorMessage(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return err.Error()
+	}
+
+	reason := requestErr.Reason
+	if requestErr.Err != nil {
+		reason = requestErr.Err.Error()
+	}
+
+	if requestErr.Parameter != nil {
+		return fmt.Sprintf("Invalid %s parameter %s: %s", requestErr.Parameter.In, requestErr.Parameter.Name, reason)
+	}
+	return fmt.Sprintf("Invalid request body: %s", reason)
+}
+
+// schemaErrorMessage drops the schema dump kin-openapi adds to its errors
+func schemaErrorMessage(err *openapi3.SchemaError) string {
+	if pointer := err.JSONPointer(); len(pointer) > 0 {
+		return fmt.Sprintf("%s: %s", strings.Join(pointer, "."), err.Reason)
+	}
+	return err.Reason
+}
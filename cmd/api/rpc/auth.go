@@ -0,0 +1,166 @@
+package rpc
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// authorizationKey is the metadata key carrying the bearer token
+const authorizationKey = "authorization"
+
+// unaryAuthenticate verifies the bearer token of every unary call
+func unaryAuthenticate(verifier infrastructure.TokenVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, verifier)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamAuthenticate verifies the bearer token of every streaming call
+func streamAuthenticate(verifier infrastructure.TokenVerifier) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), verifier)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticatedStream carries the caller in the context of a stream
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate stores the caller of a bearer token in the context
+func authenticate(ctx context.Context, verifier infrastructure.TokenVerifier) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		return nil, statusError(domain.ErrUnauthenticated)
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return nil, statusError(domain.ErrUnauthenticated)
+	}
+
+	principal, err := verifier.Verify(ctx, strings.TrimSpace(token))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return domain.ContextWithPrincipal(ctx, principal), nil
+}
+
+// authorizer applies the REST ownership rules, admins bypass them
+type authorizer struct {
+	walletService      primary.WalletService
+	transactionService primary.TransactionService
+}
+
+func newAuthorizer(walletService primary.WalletService, transactionService primary.TransactionService) *authorizer {
+	return &authorizer{
+		walletService:      walletService,
+		transactionService: transactionService,
+	}
+}
+
+func currentPrincipal(ctx context.Context) (*domain.Principal, error) {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, statusError(domain.ErrUnauthenticated)
+	}
+	return principal, nil
+}
+
+// admin allows the call when the caller is an admin
+func (a *authorizer) admin(ctx context.Context) error {
+	principal, err := currentPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !principal.IsAdmin() {
+		return statusError(domain.ErrAccessDenied)
+	}
+
+	return nil
+}
+
+// user allows the call when the caller is the given user
+func (a *authorizer) user(ctx context.Context, userID int) error {
+	principal, err := currentPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !principal.CanActAsUser(userID) {
+		return statusError(domain.ErrAccessDenied)
+	}
+
+	return nil
+}
+
+// wallet allows the call when the caller owns at least one of the wallets
+func (a *authorizer) wallet(ctx context.Context, walletIDs ...int) error {
+	principal, err := currentPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+
+	if principal.IsAdmin() {
+		return nil
+	}
+
+	for _, walletID := range walletIDs {
+		wallet, err := a.walletService.GetWallet(ctx, walletID)
+		if err != nil {
+			return statusError(err)
+		}
+
+		if wallet.UserID == principal.UserID {
+			return nil
+		}
+	}
+
+	return statusError(domain.ErrAccessDenied)
+}
+
+// transaction allows the call when the caller owns either side of a transaction
+func (a *authorizer) transaction(ctx context.Context, transactionID int) error {
+	principal, err := currentPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+
+	if principal.IsAdmin() {
+		return nil
+	}
+
+	transaction, err := a.transactionService.GetTransaction(ctx, transactionID)
+	if err != nil {
+		return statusError(err)
+	}
+
+	walletIDs := []int{transaction.WalletID}
+	if transaction.ToWalletID != nil {
+		walletIDs = append(walletIDs, *transaction.ToWalletID)
+	}
+
+	return a.wallet(ctx, walletIDs...)
+}
@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"ports-and-adapters-architecture/api/rpc/ewalletpb"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toWalletPB(wallet *domain.Wallet) *ewalletpb.Wallet {
+	return &ewalletpb.Wallet{
+		Id:           int64(wallet.ID),
+		UserId:       int64(wallet.UserID),
+		Balance:      int64(wallet.Balance),
+		CurrencyCode: wallet.CurrencyCode,
+		Description:  wallet.Description,
+		Status:       string(wallet.Status),
+		CreatedAt:    timestamppb.New(wallet.CreatedAt),
+		UpdatedAt:    timestamppb.New(wallet.UpdatedAt),
+	}
+}
+
+func toTransactionPB(transaction *domain.Transaction) *ewalletpb.Transaction {
+	pb := &ewalletpb.Transaction{
+		Id:          int64(transaction.ID),
+		WalletId:    int64(transaction.WalletID),
+		Type:        string(transaction.Type),
+		Amount:      int64(transaction.Amount),
+		Fee:         int64(transaction.Fee),
+		Status:      string(transaction.Status),
+		Reference:   transaction.Reference,
+		Description: transaction.Description,
+		CreatedAt:   timestamppb.New(transaction.CreatedAt),
+		UpdatedAt:   timestamppb.New(transaction.UpdatedAt),
+		CompletedAt: optionalTimestamp(transaction.CompletedAt),
+	}
+
+	if transaction.ToWalletID != nil {
+		toWalletID := int64(*transaction.ToWalletID)
+		pb.ToWalletId = &toWalletID
+	}
+
+	return pb
+}
+
+func toPaymentPB(payment *domain.Payment) *ewalletpb.Payment {
+	return &ewalletpb.Payment{
+		Id:            int64(payment.ID),
+		TransactionId: int64(payment.TransactionID),
+		Amount:        int64(payment.Amount),
+		Provider:      string(payment.Provider),
+		Status:        string(payment.Status),
+		ExternalId:    payment.ExternalID,
+		PaymentUrl:    payment.PaymentURL,
+		Description:   payment.Description,
+		CreatedAt:     timestamppb.New(payment.CreatedAt),
+		UpdatedAt:     timestamppb.New(payment.UpdatedAt),
+		CompletedAt:   optionalTimestamp(payment.CompletedAt),
+	}
+}
+
+func toUserPB(user *domain.User) *ewalletpb.User {
+	return &ewalletpb.User{
+		Id:        int64(user.ID),
+		Fullname:  user.Fullname,
+		Email:     user.Email,
+		Phone:     user.Phone,
+		Handle:    user.Handle,
+		Status:    string(user.Status),
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
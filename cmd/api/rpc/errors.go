@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"net/http"
+	"ports-and-adapters-architecture/api/rest/handlers"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError converts service errors to gRPC status errors, using the same
+// mapping as the REST API
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	httpStatus, message := handlers.ServiceErrorStatus(err)
+	return status.Error(grpcCode(httpStatus), message)
+}
+
+// invalidArgument reports a request that failed validation
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// grpcCode maps an HTTP status to the closest gRPC code
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict, http.StatusGone:
+		return codes.FailedPrecondition
+	case http.StatusRequestEntityTooLarge, http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
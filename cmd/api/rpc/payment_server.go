@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"context"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/api/rpc/ewalletpb"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// paymentServer implements the PaymentService RPCs
+type paymentServer struct {
+	ewalletpb.UnimplementedPaymentServiceServer
+
+	paymentService primary.PaymentService
+	authorizer     *authorizer
+	validate       *validator.Validate
+}
+
+func (s *paymentServer) ProcessPayment(ctx context.Context, req *ewalletpb.ProcessPaymentRequest) (*ewalletpb.Payment, error) {
+	body := handlers.ProcessPaymentRequest{
+		WalletID:        int(req.GetWalletId()),
+		Amount:          int(req.GetAmount()),
+		Description:     req.GetDescription(),
+		PaymentProvider: domain.PaymentProvider(req.GetPaymentProvider()),
+		RedirectURL:     req.GetRedirectUrl(),
+		CallbackURL:     req.GetCallbackUrl(),
+	}
+	if err := s.validate.Struct(body); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.authorizer.wallet(ctx, body.WalletID); err != nil {
+		return nil, err
+	}
+
+	payment, err := s.paymentService.ProcessPayment(ctx, primary.PaymentRequest{
+		WalletID:        body.WalletID,
+		Amount:          body.Amount,
+		Description:     body.Description,
+		PaymentProvider: body.PaymentProvider,
+		RedirectURL:     body.RedirectURL,
+		CallbackURL:     body.CallbackURL,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toPaymentPB(payment), nil
+}
+
+func (s *paymentServer) GetPayment(ctx context.Context, req *ewalletpb.GetPaymentRequest) (*ewalletpb.Payment, error) {
+	payment, err := s.authorizedPayment(ctx, int(req.GetPaymentId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return toPaymentPB(payment), nil
+}
+
+func (s *paymentServer) VerifyPayment(ctx context.Context, req *ewalletpb.GetPaymentRequest) (*ewalletpb.Payment, error) {
+	payment, err := s.authorizedPayment(ctx, int(req.GetPaymentId()))
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err = s.paymentService.VerifyPayment(ctx, payment.ID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toPaymentPB(payment), nil
+}
+
+func (s *paymentServer) CancelPayment(ctx context.Context, req *ewalletpb.GetPaymentRequest) (*ewalletpb.Payment, error) {
+	payment, err := s.authorizedPayment(ctx, int(req.GetPaymentId()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.paymentService.CancelPayment(ctx, payment.ID); err != nil {
+		return nil, statusError(err)
+	}
+
+	payment, err = s.paymentService.GetPaymentID(ctx, payment.ID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toPaymentPB(payment), nil
+}
+
+// authorizedPayment fetches a payment the caller owns the transaction of
+func (s *paymentServer) authorizedPayment(ctx context.Context, paymentID int) (*domain.Payment, error) {
+	payment, err := s.paymentService.GetPaymentID(ctx, paymentID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if err := s.authorizer.transaction(ctx, payment.TransactionID); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
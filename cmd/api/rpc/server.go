@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"ports-and-adapters-architecture/api/rpc/ewalletpb"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc"
+)
+
+// Config configures the gRPC API
+type Config struct {
+	Port string `mapstructure:"port"`
+	// WatchInterval is how often WatchWallet streams check the wallet for changes
+	WatchInterval time.Duration `mapstructure:"watch_interval"`
+}
+
+// NewServer creates the gRPC server exposing the wallet, payment and user
+// services. Every call requires a bearer token in the authorization metadata
+// and is held to the same ownership rules as the REST API
+func NewServer(
+	userService primary.UserService,
+	walletService primary.WalletService,
+	transactionService primary.TransactionService,
+	paymentService primary.PaymentService,
+	tokenVerifier infrastructure.TokenVerifier,
+	config Config,
+) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryAuthenticate(tokenVerifier)),
+		grpc.ChainStreamInterceptor(streamAuthenticate(tokenVerifier)),
+	)
+
+	authorizer := newAuthorizer(walletService, transactionService)
+	validate := validator.New()
+
+	ewalletpb.RegisterWalletServiceServer(server, &walletServer{
+		walletService: walletService,
+		authorizer:    authorizer,
+		validate:      validate,
+		watchInterval: config.WatchInterval,
+	})
+	ewalletpb.RegisterPaymentServiceServer(server, &paymentServer{
+		paymentService: paymentService,
+		authorizer:     authorizer,
+		validate:       validate,
+	})
+	ewalletpb.RegisterUserServiceServer(server, &userServer{
+		userService: userService,
+		authorizer:  authorizer,
+		validate:    validate,
+	})
+
+	return server
+}
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/api/rpc/ewalletpb"
+	"ports-and-adapters-architecture/internal/ports/primary"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// userServer implements the UserService RPCs
+type userServer struct {
+	ewalletpb.UnimplementedUserServiceServer
+
+	userService primary.UserService
+	authorizer  *authorizer
+	validate    *validator.Validate
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *ewalletpb.CreateUserRequest) (*ewalletpb.User, error) {
+	if err := s.authorizer.admin(ctx); err != nil {
+		return nil, err
+	}
+
+	body := handlers.CreateUserRequest{
+		Fullname: req.GetFullname(),
+		Email:    req.GetEmail(),
+		Phone:    req.GetPhone(),
+	}
+	if err := s.validate.Struct(body); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	user, err := s.userService.CreateUser(ctx, body.Fullname, body.Email, body.Phone)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toUserPB(user), nil
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *ewalletpb.GetUserRequest) (*ewalletpb.User, error) {
+	userID := int(req.GetUserId())
+	if err := s.authorizer.user(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.GetUser(ctx, userID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toUserPB(user), nil
+}
+
+func (s *userServer) UpdateUser(ctx context.Context, req *ewalletpb.UpdateUserRequest) (*ewalletpb.User, error) {
+	body := handlers.UpdateUserRequest{
+		Fullname: req.GetFullname(),
+		Email:    req.GetEmail(),
+		Phone:    req.GetPhone(),
+	}
+	if err := s.validate.Struct(body); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	userID := int(req.GetUserId())
+	if err := s.authorizer.user(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.UpdateUser(ctx, userID, body.Fullname, body.Email, body.Phone)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toUserPB(user), nil
+}
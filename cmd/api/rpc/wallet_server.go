@@ -0,0 +1,234 @@
+package rpc
+
+import (
+	"context"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/api/rpc/ewalletpb"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// defaultWatchInterval is used when the watch interval is not configured
+const defaultWatchInterval = 2 * time.Second
+
+// walletServer implements the WalletService RPCs
+type walletServer struct {
+	ewalletpb.UnimplementedWalletServiceServer
+
+	walletService primary.WalletService
+	authorizer    *authorizer
+	validate      *validator.Validate
+	watchInterval time.Duration
+}
+
+func (s *walletServer) CreateWallet(ctx context.Context, req *ewalletpb.CreateWalletRequest) (*ewalletpb.Wallet, error) {
+	body := handlers.CreateWalletRequest{
+		UserID:       int(req.GetUserId()),
+		CurrencyCode: req.GetCurrencyCode(),
+		Description:  req.GetDescription(),
+	}
+	if err := s.validate.Struct(body); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := s.authorizer.user(ctx, body.UserID); err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.walletService.CreateWallet(ctx, body.UserID, body.CurrencyCode, body.Description)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toWalletPB(wallet), nil
+}
+
+func (s *walletServer) GetWallet(ctx context.Context, req *ewalletpb.GetWalletRequest) (*ewalletpb.Wallet, error) {
+	walletID := int(req.GetWalletId())
+	if err := s.authorizer.wallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.walletService.GetWallet(ctx, walletID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toWalletPB(wallet), nil
+}
+
+func (s *walletServer) ListUserWallets(ctx context.Context, req *ewalletpb.ListUserWalletsRequest) (*ewalletpb.ListUserWalletsResponse, error) {
+	userID := int(req.GetUserId())
+	if err := s.authorizer.user(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	wallets, err := s.walletService.GetWalletsByUserID(ctx, userID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &ewalletpb.ListUserWalletsResponse{}
+	for _, wallet := range wallets {
+		resp.Wallets = append(resp.Wallets, toWalletPB(wallet))
+	}
+
+	return resp, nil
+}
+
+func (s *walletServer) Deposit(ctx context.Context, req *ewalletpb.DepositRequest) (*ewalletpb.Transaction, error) {
+	body := handlers.DepositRequest{
+		Amount:      int(req.GetAmount()),
+		Description: req.GetDescription(),
+	}
+	if err := s.validate.Struct(body); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	walletID := int(req.GetWalletId())
+	if err := s.authorizer.wallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.walletService.Deposit(ctx, walletID, body.Amount, body.Description)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toTransactionPB(transaction), nil
+}
+
+func (s *walletServer) Withdraw(ctx context.Context, req *ewalletpb.WithdrawRequest) (*ewalletpb.Transaction, error) {
+	body := handlers.WithdrawRequest{
+		Amount:      int(req.GetAmount()),
+		Description: req.GetDescription(),
+	}
+	if err := s.validate.Struct(body); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	walletID := int(req.GetWalletId())
+	if err := s.authorizer.wallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.walletService.Withdraw(ctx, walletID, body.Amount, body.Description)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toTransactionPB(transaction), nil
+}
+
+func (s *walletServer) Transfer(ctx context.Context, req *ewalletpb.TransferRequest) (*ewalletpb.Transaction, error) {
+	body := handlers.TransferRequest{
+		ToWalletID:  int(req.GetToWalletId()),
+		Amount:      int(req.GetAmount()),
+		Description: req.GetDescription(),
+	}
+	if err := s.validate.Struct(body); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	walletID := int(req.GetWalletId())
+	if err := s.authorizer.wallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.walletService.Transfer(ctx, walletID, body.ToWalletID, body.Amount, body.Description)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toTransactionPB(transaction), nil
+}
+
+func (s *walletServer) GetBalance(ctx context.Context, req *ewalletpb.GetBalanceRequest) (*ewalletpb.Balance, error) {
+	walletID := int(req.GetWalletId())
+	if err := s.authorizer.wallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	balance, currencyCode, err := s.walletService.GetBalance(ctx, walletID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &ewalletpb.Balance{
+		WalletId:     int64(walletID),
+		Balance:      int64(balance),
+		CurrencyCode: currencyCode,
+	}, nil
+}
+
+func (s *walletServer) ListTransactions(ctx context.Context, req *ewalletpb.ListTransactionsRequest) (*ewalletpb.ListTransactionsResponse, error) {
+	walletID := int(req.GetWalletId())
+	if err := s.authorizer.wallet(ctx, walletID); err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	page, err := s.walletService.GetTransactionHistoryPage(ctx, walletID, primary.TransactionHistoryQuery{
+		Limit:  limit,
+		Cursor: req.GetCursor(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &ewalletpb.ListTransactionsResponse{NextCursor: page.NextCursor}
+	for _, transaction := range page.Transactions {
+		resp.Transactions = append(resp.Transactions, toTransactionPB(transaction))
+	}
+
+	return resp, nil
+}
+
+// WatchWallet sends the wallet, then again whenever its balance or status
+// changes. The stream ends once the wallet is closed
+func (s *walletServer) WatchWallet(req *ewalletpb.WatchWalletRequest, stream ewalletpb.WalletService_WatchWalletServer) error {
+	ctx := stream.Context()
+	walletID := int(req.GetWalletId())
+	if err := s.authorizer.wallet(ctx, walletID); err != nil {
+		return err
+	}
+
+	interval := s.watchInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *domain.Wallet
+	for {
+		wallet, err := s.walletService.GetWallet(ctx, walletID)
+		if err != nil {
+			return statusError(err)
+		}
+
+		if last == nil || wallet.Balance != last.Balance || wallet.Status != last.Status || !wallet.UpdatedAt.Equal(last.UpdatedAt) {
+			if err := stream.Send(toWalletPB(wallet)); err != nil {
+				return err
+			}
+			last = wallet
+		}
+
+		if wallet.Status == domain.WalletStatusClosed {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
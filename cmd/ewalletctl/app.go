@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"ports-and-adapters-architecture/internal/adapters/cache"
+	"ports-and-adapters-architecture/internal/adapters/messaging"
+	"ports-and-adapters-architecture/internal/adapters/payment"
+	"ports-and-adapters-architecture/internal/adapters/persistence"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/adapters/statement"
+	"ports-and-adapters-architecture/internal/config"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/usecase"
+)
+
+// app holds the use cases the commands drive. Risk checks are not wired,
+// no command starts a withdrawal, transfer or payment
+type app struct {
+	userService        primary.UserService
+	walletService      primary.WalletService
+	transactionService primary.TransactionService
+	paymentService     primary.PaymentService
+	statementService   primary.StatementService
+	deadEventService   primary.DeadEventService
+	out                *printer
+
+	closers []func() error
+}
+
+func newApp(cfg *config.Config, out *printer) (*app, error) {
+	// A SQLite file belongs to the API server that migrates it on start
+	if cfg.Database.Driver != config.DriverPostgres {
+		return nil, fmt.Errorf("ewalletctl requires database.driver postgres, got %s", cfg.Database.Driver)
+	}
+
+	db, err := persistence.NewPostgresConnection(cfg.Database.PostgresConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	kafkaPublisher := messaging.NewKafkaEventPublisher(
+		cfg.Kafka.Brokers,
+	)
+
+	a := &app{
+		out:     out,
+		closers: []func() error{kafkaPublisher.Close, db.Close},
+	}
+
+	// The memory cache of the API server cannot be reached from here, so
+	// there is nothing to invalidate with that driver
+	var appCache infrastructure.Cache = memory.NewInMemoryCache()
+	if cfg.Cache.Driver == config.CacheDriverRedis {
+		redisCache := cache.NewRedisCache(
+			cfg.Redis.Addr,
+			cfg.Redis.Password,
+			cfg.Redis.DB,
+		)
+		a.closers = append(a.closers, redisCache.Close)
+
+		// Cached reads fall back to the database, only invalidation is lost
+		if err := redisCache.Ping(context.Background()); err != nil {
+			log.Printf("Warning: Redis connection failed: %v", err)
+		}
+
+		appCache = redisCache
+	}
+
+	if err := a.wire(cfg, db, appCache, kafkaPublisher); err != nil {
+		a.Close()
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// wire builds the use cases the same way as the API server
+func (a *app) wire(
+	cfg *config.Config,
+	db *sql.DB,
+	appCache infrastructure.Cache,
+	kafkaPublisher *messaging.KafkaEventPublisher,
+) error {
+	paymentConfig := cfg.Payment
+
+	userRepo := persistence.NewPostgresUserRepository(db)
+	walletRepo := persistence.NewPostgresWalletRepository(db)
+	transactionRepo := persistence.NewPostgresTransactionRepository(db)
+	paymentRepo := persistence.NewPostgresPaymentRepository(db)
+	deadEventRepo := persistence.NewPostgresDeadEventRepository(db)
+
+	paymentService := usecase.NewPaymentService(
+		paymentRepo,
+		walletRepo,
+		transactionRepo,
+		kafkaPublisher,
+		appCache,
+	)
+	paymentService.RegisterGateway(domain.PaymentProviderMidtrans, payment.NewMidtransGateway(
+		paymentConfig.Midtrans.ServerKey,
+		paymentConfig.Midtrans.ClientKey,
+		paymentConfig.Midtrans.IsProduction,
+	))
+	paymentService.RegisterGateway(domain.PaymentProviderStripe, payment.NewStripeGateway(
+		paymentConfig.Stripe.APIKey,
+		paymentConfig.Stripe.WebhookSecret,
+		paymentConfig.Stripe.IsTest,
+	))
+
+	walletService := usecase.NewWalletService(
+		walletRepo,
+		userRepo,
+		transactionRepo,
+		kafkaPublisher,
+		appCache,
+	)
+	transactionService := usecase.NewTransactionService(
+		transactionRepo,
+		walletRepo,
+		kafkaPublisher,
+		appCache,
+	)
+
+	// Payments verify withholds fees and transactions reconcile settles them,
+	// both need the same schedule and revenue wallets as the API server
+	if cfg.Fees.Enabled {
+		schedule, err := domain.NewFeeSchedule(cfg.Fees.Rules)
+		if err != nil {
+			return fmt.Errorf("failed to initialize fee schedule: %w", err)
+		}
+
+		feeService := usecase.NewFeeService(schedule, cfg.Fees.RevenueWallets, walletRepo, transactionRepo, appCache)
+		walletService.SetFeeService(feeService)
+		paymentService.SetFeeService(feeService)
+		transactionService.SetFeeService(feeService)
+	}
+
+	a.userService = usecase.NewUserService(userRepo, kafkaPublisher, appCache)
+	a.walletService = walletService
+	a.transactionService = transactionService
+	a.paymentService = paymentService
+	a.statementService = usecase.NewStatementService(
+		walletRepo,
+		transactionRepo,
+		statement.NewCSVRenderer(),
+		statement.NewPDFRenderer(),
+	)
+	a.deadEventService = usecase.NewDeadEventService(deadEventRepo, kafkaPublisher)
+
+	return nil
+}
+
+// Close flushes pending events and closes the connections, once
+func (a *app) Close() {
+	for _, closer := range a.closers {
+		if err := closer(); err != nil {
+			log.Printf("Warning: failed to close: %v", err)
+		}
+	}
+	a.closers = nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+func listDeadEvents(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("events list")
+	limit := fs.Int("limit", 50, "maximum number of events")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	deadEvents, err := a.deadEventService.GetPendingDeadEvents(ctx, *limit)
+	if err != nil {
+		return err
+	}
+
+	return a.out.deadEvents(deadEvents, deadEvents...)
+}
+
+func redriveDeadEvents(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("events redrive")
+	all := fs.Bool("all", false, "redrive every pending dead event, oldest first")
+	limit := fs.Int("limit", 50, "maximum number of events with -all")
+	dryRun := fs.Bool("dry-run", false, "list the events without publishing them")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if *all == (len(args) > 0) {
+		return errors.New("either -all or event IDs are required")
+	}
+
+	var deadEvents []*domain.DeadEvent
+	if *all {
+		deadEvents, err = a.deadEventService.GetPendingDeadEvents(ctx, *limit)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, arg := range args {
+		id, err := parseIDArg(arg)
+		if err != nil {
+			return err
+		}
+
+		deadEvent, err := a.deadEventService.GetDeadEvent(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if deadEvent.RedrivenAt != nil {
+			return domain.ErrDeadEventRedriven
+		}
+
+		deadEvents = append(deadEvents, deadEvent)
+	}
+
+	if *dryRun {
+		dryRunf("would publish %d dead events to their topics again", len(deadEvents))
+		return a.out.deadEvents(deadEvents, deadEvents...)
+	}
+
+	// Print what was redriven even when a later event fails
+	var redriven []*domain.DeadEvent
+	for _, deadEvent := range deadEvents {
+		deadEvent, err = a.deadEventService.Redrive(ctx, deadEvent.ID)
+		if err != nil {
+			break
+		}
+		redriven = append(redriven, deadEvent)
+	}
+
+	if printErr := a.out.deadEvents(redriven, redriven...); printErr != nil {
+		return printErr
+	}
+
+	return err
+}
@@ -0,0 +1,188 @@
+// Command ewalletctl operates the wallet system from the command line, using
+// the same configuration and use cases as the API server.
+//
+//	go run ./cmd/ewalletctl wallets get 1
+//	go run ./cmd/ewalletctl -output json transactions list -wallet 1 -status PENDING
+//	go run ./cmd/ewalletctl wallets freeze -reason SUSPECTED_FRAUD -actor ops 1 -dry-run
+//
+// Mutating commands accept -dry-run to show what would change without changing it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"ports-and-adapters-architecture/internal/config"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// command is a subcommand, named by resource and action
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = []command{
+	{"users get", "<id>", getUser},
+	{"users find", "-email <email> | -phone <phone>", findUser},
+	{"wallets get", "<id>", getWallet},
+	{"wallets list", "-user <id>", listWallets},
+	{"wallets freeze", "-reason <reason> -actor <actor> [-dry-run] <id>", freezeWallet},
+	{"wallets unfreeze", "-reason <reason> -actor <actor> [-dry-run] <id>", unfreezeWallet},
+	{"wallets close", "-reason <reason> -actor <actor> [-sweep-to <id>] [-dry-run] <id>", closeWallet},
+	{"transactions get", "<id>", getTransaction},
+	{"transactions list", "-wallet <id> [-status <status>] [-type <type>] [-limit <n>] [-cursor <cursor>]", listTransactions},
+	{"transactions reconcile", "[-dry-run]", reconcileTransactions},
+	{"payments get", "<id>", getPayment},
+	{"payments verify", "[-dry-run] <id>", verifyPayment},
+	{"payments cancel", "[-dry-run] <id>", cancelPayment},
+	{"events list", "[-limit <n>]", listDeadEvents},
+	{"events redrive", "[-all] [-limit <n>] [-dry-run] [<id>...]", redriveDeadEvents},
+	{"statements export", "-wallet <id> -from <date> -to <date> [-format csv|pdf] [-out <file>]", exportStatement},
+}
+
+func main() {
+	output := flag.String("output", "table", "output format, table or json")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(os.Stderr, "unknown output format %q\n", *output)
+		os.Exit(2)
+	}
+
+	args := flag.Args()
+	if len(args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := findCommand(args[0] + " " + args[1])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0]+" "+args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+
+	a, err := newApp(cfg, &printer{format: *output, w: os.Stdout})
+	if err != nil {
+		log.Fatalf("Failed to initialize: %v", err)
+	}
+	defer a.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := cmd.run(ctx, a, args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.name, err)
+		a.Close()
+		os.Exit(1)
+	}
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: ewalletctl [-output table|json] <resource> <action> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-24s %s\n", cmd.name, cmd.usage)
+	}
+}
+
+// parseFlags parses flags given before, between or after positional arguments
+// and returns the positional arguments
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseID parses the single ID argument of a command
+func parseID(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("expected exactly one ID, got %d arguments", len(args))
+	}
+
+	return parseIDArg(args[0])
+}
+
+func parseIDArg(arg string) (int, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID %q", arg)
+	}
+	return id, nil
+}
+
+// newFlagSet creates the flag set of a command, errors are returned rather
+// than exiting so they are reported like any other failure
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: ewalletctl %s [flags]\n", name)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// dryRunf reports what a mutating command would do. It goes to stderr so JSON
+// output stays parseable
+func dryRunf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "dry run: "+format+"\n", args...)
+}
+
+// splitList splits a comma separated flag value into upper-cased values
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, strings.ToUpper(item))
+		}
+	}
+	return items
+}
+
+// parseTime parses an RFC 3339 time or a YYYY-MM-DD day in UTC, like the API
+func parseTime(value string) (time.Time, bool, error) {
+	if day, err := time.Parse(time.DateOnly, value); err == nil {
+		return day, true, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	return t.UTC(), false, err
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"ports-and-adapters-architecture/internal/domain"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// printer writes command results as an aligned table or as indented JSON
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+// print writes value as JSON, or the rows under the given headers as a table
+func (p *printer) print(value interface{}, headers []string, rows [][]string) error {
+	if p.format == "json" {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (p *printer) users(value interface{}, users ...*domain.User) error {
+	rows := make([][]string, len(users))
+	for i, user := range users {
+		rows[i] = []string{
+			strconv.Itoa(user.ID),
+			user.Fullname,
+			user.Email,
+			user.Phone,
+			orDash(user.Handle),
+			string(user.Status),
+			formatTime(user.CreatedAt),
+		}
+	}
+	return p.print(value, []string{"ID", "NAME", "EMAIL", "PHONE", "HANDLE", "STATUS", "CREATED"}, rows)
+}
+
+func (p *printer) wallets(value interface{}, wallets ...*domain.Wallet) error {
+	rows := make([][]string, len(wallets))
+	for i, wallet := range wallets {
+		rows[i] = []string{
+			strconv.Itoa(wallet.ID),
+			strconv.Itoa(wallet.UserID),
+			strconv.Itoa(wallet.Balance),
+			wallet.CurrencyCode,
+			string(wallet.Status),
+			orDash(string(wallet.StatusReason)),
+			orDash(wallet.Description),
+			formatTime(wallet.UpdatedAt),
+		}
+	}
+	return p.print(value, []string{"ID", "USER", "BALANCE", "CURRENCY", "STATUS", "REASON", "DESCRIPTION", "UPDATED"}, rows)
+}
+
+func (p *printer) transactions(value interface{}, transactions ...*domain.Transaction) error {
+	rows := make([][]string, len(transactions))
+	for i, transaction := range transactions {
+		toWallet := "-"
+		if transaction.ToWalletID != nil {
+			toWallet = strconv.Itoa(*transaction.ToWalletID)
+		}
+
+		rows[i] = []string{
+			strconv.Itoa(transaction.ID),
+			strconv.Itoa(transaction.WalletID),
+			string(transaction.Type),
+			strconv.Itoa(transaction.Amount),
+			strconv.Itoa(transaction.Fee),
+			string(transaction.Status),
+			toWallet,
+			orDash(transaction.Reference),
+			formatTime(transaction.CreatedAt),
+		}
+	}
+	return p.print(value, []string{"ID", "WALLET", "TYPE", "AMOUNT", "FEE", "STATUS", "TO WALLET", "REFERENCE", "CREATED"}, rows)
+}
+
+func (p *printer) payments(value interface{}, payments ...*domain.Payment) error {
+	rows := make([][]string, len(payments))
+	for i, payment := range payments {
+		rows[i] = []string{
+			strconv.Itoa(payment.ID),
+			strconv.Itoa(payment.TransactionID),
+			strconv.Itoa(payment.Amount),
+			string(payment.Provider),
+			string(payment.Status),
+			orDash(payment.ExternalID),
+			formatTime(payment.CreatedAt),
+		}
+	}
+	return p.print(value, []string{"ID", "TRANSACTION", "AMOUNT", "PROVIDER", "STATUS", "EXTERNAL ID", "CREATED"}, rows)
+}
+
+func (p *printer) deadEvents(value interface{}, deadEvents ...*domain.DeadEvent) error {
+	rows := make([][]string, len(deadEvents))
+	for i, deadEvent := range deadEvents {
+		redriven := "-"
+		if deadEvent.RedrivenAt != nil {
+			redriven = formatTime(*deadEvent.RedrivenAt)
+		}
+
+		rows[i] = []string{
+			strconv.Itoa(deadEvent.ID),
+			deadEvent.Topic,
+			deadEvent.EventType,
+			orDash(deadEvent.EventID),
+			formatTime(deadEvent.FailedAt),
+			redriven,
+			deadEvent.Error,
+		}
+	}
+	return p.print(value, []string{"ID", "TOPIC", "TYPE", "EVENT ID", "FAILED", "REDRIVEN", "ERROR"}, rows)
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// notef writes a message after a table, JSON output gets none so it stays
+// parseable
+func (p *printer) notef(format string, args ...interface{}) {
+	if p.format == "json" {
+		return
+	}
+	fmt.Fprintf(p.w, format+"\n", args...)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+func getPayment(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("payments get")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	payment, err := a.paymentService.GetPaymentID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.out.payments(payment, payment)
+}
+
+func verifyPayment(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("payments verify")
+	dryRun := fs.Bool("dry-run", false, "show the payment without checking it with the provider")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		payment, err := a.paymentService.GetPaymentID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		dryRunf("would verify payment %d with %s, currently %s", payment.ID, payment.Provider, payment.Status)
+		return a.out.payments(payment, payment)
+	}
+
+	payment, err := a.paymentService.VerifyPayment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.out.payments(payment, payment)
+}
+
+func cancelPayment(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("payments cancel")
+	dryRun := fs.Bool("dry-run", false, "show the result without cancelling the payment")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		payment, err := a.paymentService.GetPaymentID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		from := payment.Status
+		if err := payment.Cancel(); err != nil {
+			return err
+		}
+
+		dryRunf("would cancel payment %d with %s, %s -> %s", payment.ID, payment.Provider, from, domain.PaymentStatusCancelled)
+		return a.out.payments(payment, payment)
+	}
+
+	if err := a.paymentService.CancelPayment(ctx, id); err != nil {
+		return err
+	}
+
+	payment, err := a.paymentService.GetPaymentID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.out.payments(payment, payment)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"strings"
+)
+
+func exportStatement(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("statements export")
+	walletID := fs.Int("wallet", 0, "wallet ID")
+	fromValue := fs.String("from", "", "start of the period, YYYY-MM-DD or RFC 3339")
+	toValue := fs.String("to", "", "end of the period, a day includes the whole day")
+	format := fs.String("format", "csv", "csv or pdf")
+	outPath := fs.String("out", "", "file to write, defaults to the statement name, - for stdout")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *walletID <= 0 || *fromValue == "" || *toValue == "" {
+		return errors.New("-wallet, -from and -to are required")
+	}
+
+	from, _, err := parseTime(*fromValue)
+	if err != nil {
+		return fmt.Errorf("invalid -from: %w", err)
+	}
+
+	to, wholeDay, err := parseTime(*toValue)
+	if err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+	if wholeDay {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	req := primary.StatementRequest{
+		WalletID: *walletID,
+		From:     from,
+		To:       to,
+		Format:   domain.StatementFormat(strings.ToLower(*format)),
+	}
+
+	// The file is only created once the statement is known to be valid
+	var file *os.File
+	var written string
+	output := func(contentType, filename string) io.Writer {
+		if *outPath == "-" {
+			return os.Stdout
+		}
+
+		written = filename
+		if *outPath != "" {
+			written = *outPath
+		}
+
+		file, err = os.Create(written)
+		if err != nil {
+			return failingWriter{err: err}
+		}
+		return file
+	}
+
+	exportErr := a.statementService.ExportStatement(ctx, req, output)
+	if file != nil {
+		if closeErr := file.Close(); exportErr == nil {
+			exportErr = closeErr
+		}
+	}
+	if exportErr != nil {
+		return exportErr
+	}
+
+	if written != "" {
+		fmt.Fprintf(os.Stderr, "statement written to %s\n", written)
+	}
+
+	return nil
+}
+
+// failingWriter reports an output that could not be opened on the first write
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+)
+
+func getTransaction(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("transactions get")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := a.transactionService.GetTransaction(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.out.transactions(transaction, transaction)
+}
+
+func listTransactions(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("transactions list")
+	walletID := fs.Int("wallet", 0, "wallet ID")
+	statuses := fs.String("status", "", "comma separated statuses, e.g. PENDING,FAILED")
+	types := fs.String("type", "", "comma separated types, e.g. DEPOSIT,TRANSFER")
+	limit := fs.Int("limit", 20, "transactions per page, at most 100")
+	cursor := fs.String("cursor", "", "cursor of the next page from a previous listing")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *walletID <= 0 {
+		return errors.New("-wallet is required")
+	}
+
+	var filter domain.TransactionFilter
+	for _, status := range splitList(*statuses) {
+		filter.Statuses = append(filter.Statuses, domain.TransactionStatus(status))
+	}
+	for _, txType := range splitList(*types) {
+		filter.Types = append(filter.Types, domain.TransactionType(txType))
+	}
+
+	page, err := a.walletService.GetTransactionHistoryPage(ctx, *walletID, primary.TransactionHistoryQuery{
+		Filter: filter,
+		Limit:  *limit,
+		Cursor: *cursor,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := a.out.transactions(page, page.Transactions...); err != nil {
+		return err
+	}
+
+	if a.out.format == "table" && page.NextCursor != "" {
+		a.out.notef("next page: -cursor %s", page.NextCursor)
+	}
+
+	return nil
+}
+
+func reconcileTransactions(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("transactions reconcile")
+	dryRun := fs.Bool("dry-run", false, "list the transactions that would be failed")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	stale, err := a.transactionService.GetStalePendingTransactions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		dryRunf("would mark %d stale pending transactions as failed", len(stale))
+		return a.out.transactions(stale, stale...)
+	}
+
+	if err := a.transactionService.ReconcileFailedTransactions(ctx); err != nil {
+		return err
+	}
+
+	a.out.notef("reconciled %d stale pending transactions", len(stale))
+	return nil
+}
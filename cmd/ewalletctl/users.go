@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"errors"
+)
+
+func getUser(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("users get")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	user, err := a.userService.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.out.users(user, user)
+}
+
+func findUser(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("users find")
+	email := fs.String("email", "", "email address")
+	phone := fs.String("phone", "", "phone number in E.164 format")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if (*email == "") == (*phone == "") {
+		return errors.New("exactly one of -email or -phone is required")
+	}
+
+	lookup := a.userService.GetUserByEmail
+	key := *email
+	if *phone != "" {
+		lookup = a.userService.GetUserByPhone
+		key = *phone
+	}
+
+	user, err := lookup(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	return a.out.users(user, user)
+}
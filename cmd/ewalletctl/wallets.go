@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/usecase"
+)
+
+func getWallet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("wallets get")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	wallet, err := a.walletService.GetWallet(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.out.wallets(wallet, wallet)
+}
+
+func listWallets(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("wallets list")
+	userID := fs.Int("user", 0, "user ID")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *userID <= 0 {
+		return errors.New("-user is required")
+	}
+
+	wallets, err := a.walletService.GetWalletsByUserID(ctx, *userID)
+	if err != nil {
+		return err
+	}
+
+	return a.out.wallets(wallets, wallets...)
+}
+
+// walletStatusFlags are the flags shared by the status changing commands
+type walletStatusFlags struct {
+	reason *string
+	actor  *string
+	dryRun *bool
+}
+
+func newWalletStatusFlags(name string) (*walletStatusFlags, func(args []string) (int, error)) {
+	fs := newFlagSet(name)
+	flags := &walletStatusFlags{
+		reason: fs.String("reason", "", "reason code, e.g. SUSPECTED_FRAUD or RESOLVED"),
+		actor:  fs.String("actor", "", "who is making the change, recorded on the wallet"),
+		dryRun: fs.Bool("dry-run", false, "show the result without changing the wallet"),
+	}
+
+	parse := func(args []string) (int, error) {
+		args, err := parseFlags(fs, args)
+		if err != nil {
+			return 0, err
+		}
+		return parseID(args)
+	}
+
+	return flags, parse
+}
+
+func freezeWallet(ctx context.Context, a *app, args []string) error {
+	flags, parse := newWalletStatusFlags("wallets freeze")
+	id, err := parse(args)
+	if err != nil {
+		return err
+	}
+
+	reason := domain.WalletStatusReason(*flags.reason)
+	if *flags.dryRun {
+		return a.previewWallet(ctx, id, "freeze", func(wallet *domain.Wallet) error {
+			return wallet.Freeze(reason, *flags.actor)
+		})
+	}
+
+	wallet, err := a.walletService.FreezeWallet(ctx, id, reason, *flags.actor)
+	if err != nil {
+		return err
+	}
+
+	return a.out.wallets(wallet, wallet)
+}
+
+func unfreezeWallet(ctx context.Context, a *app, args []string) error {
+	flags, parse := newWalletStatusFlags("wallets unfreeze")
+	id, err := parse(args)
+	if err != nil {
+		return err
+	}
+
+	reason := domain.WalletStatusReason(*flags.reason)
+	if *flags.dryRun {
+		return a.previewWallet(ctx, id, "unfreeze", func(wallet *domain.Wallet) error {
+			return wallet.Unfreeze(reason, *flags.actor)
+		})
+	}
+
+	wallet, err := a.walletService.UnfreezeWallet(ctx, id, reason, *flags.actor)
+	if err != nil {
+		return err
+	}
+
+	return a.out.wallets(wallet, wallet)
+}
+
+func closeWallet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("wallets close")
+	reason := fs.String("reason", "", "reason code, e.g. CUSTOMER_REQUEST")
+	actor := fs.String("actor", "", "who is closing the wallet, recorded on the wallet")
+	sweepTo := fs.Int("sweep-to", 0, "wallet receiving the remaining balance")
+	dryRun := fs.Bool("dry-run", false, "show the result without closing the wallet")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(args)
+	if err != nil {
+		return err
+	}
+
+	req := primary.CloseWalletRequest{
+		WalletID:        id,
+		SweepToWalletID: *sweepTo,
+		Reason:          domain.WalletStatusReason(*reason),
+		Actor:           *actor,
+	}
+
+	if *dryRun {
+		return a.previewWallet(ctx, id, "close", func(wallet *domain.Wallet) error {
+			if wallet.Balance > 0 {
+				if req.SweepToWalletID == 0 {
+					return usecase.ErrSweepWalletRequired
+				}
+
+				target, err := a.walletService.GetWallet(ctx, req.SweepToWalletID)
+				if err != nil {
+					return err
+				}
+
+				if target.ID == wallet.ID || target.CurrencyCode != wallet.CurrencyCode {
+					return usecase.ErrInvalidSweepWallet
+				}
+
+				if err := target.CheckCanReceive(); err != nil {
+					return err
+				}
+
+				dryRunf("would sweep %d %s to wallet %d", wallet.Balance, wallet.CurrencyCode, target.ID)
+				if _, err := wallet.SweepBalance(); err != nil {
+					return err
+				}
+			}
+			return wallet.Close(req.Reason, req.Actor)
+		})
+	}
+
+	wallet, err := a.walletService.CloseWallet(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return a.out.wallets(wallet, wallet)
+}
+
+// previewWallet applies a status change to a copy of the wallet and prints
+// the result, so a dry run fails for the same reasons the change would
+func (a *app) previewWallet(ctx context.Context, id int, action string, change func(wallet *domain.Wallet) error) error {
+	wallet, err := a.walletService.GetWallet(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	from := wallet.Status
+	if err := change(wallet); err != nil {
+		return err
+	}
+
+	dryRunf("would %s wallet %d, %s -> %s", action, wallet.ID, from, wallet.Status)
+	return a.out.wallets(wallet, wallet)
+}
@@ -0,0 +1,438 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"ports-and-adapters-architecture/internal/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// supported signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// clockSkew tolerates small clock differences between the issuer and this service
+const clockSkew = 30 * time.Second
+
+// minSecretLength is the shortest HS256 secret accepted, shorter keys are guessable
+const minSecretLength = 32
+
+// JWTConfig holds the signing and verification settings,
+// usually loaded from the auth section of the YAML config
+type JWTConfig struct {
+	Algorithm string `mapstructure:"algorithm"`
+	// Secret is the shared HS256 key
+	Secret string `mapstructure:"secret"`
+	// PrivateKeyFile is a PEM RSA key used to issue RS256 tokens locally
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+	// JWKSFile holds the RSA public keys RS256 tokens are verified with
+	JWKSFile string `mapstructure:"jwks_file"`
+	// KeyID is written to the kid header of issued RS256 tokens
+	KeyID    string        `mapstructure:"key_id"`
+	Issuer   string        `mapstructure:"issuer"`
+	Audience string        `mapstructure:"audience"`
+	TTL      time.Duration `mapstructure:"ttl"`
+}
+
+// JWTService issues and verifies JSON Web Tokens using only the standard library
+type JWTService struct {
+	config     JWTConfig
+	secret     []byte
+	privateKey *rsa.PrivateKey
+	publicKeys map[string]*rsa.PublicKey
+}
+
+// NewJWTService validates the configuration and loads the configured keys
+func NewJWTService(config JWTConfig) (*JWTService, error) {
+	if config.TTL <= 0 {
+		config.TTL = time.Hour
+	}
+
+	s := &JWTService{
+		config:     config,
+		publicKeys: make(map[string]*rsa.PublicKey),
+	}
+
+	switch config.Algorithm {
+	case AlgorithmHS256:
+		if len(config.Secret) < minSecretLength {
+			return nil, fmt.Errorf("auth secret must be at least %d characters for %s", minSecretLength, AlgorithmHS256)
+		}
+		s.secret = []byte(config.Secret)
+
+	case AlgorithmRS256:
+		if config.PrivateKeyFile != "" {
+			key, err := loadPrivateKey(config.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			s.privateKey = key
+			s.publicKeys[config.KeyID] = &key.PublicKey
+		}
+
+		if config.JWKSFile != "" {
+			keys, err := LoadJWKS(config.JWKSFile)
+			if err != nil {
+				return nil, err
+			}
+			for kid, key := range keys {
+				s.publicKeys[kid] = key
+			}
+		}
+
+		if len(s.publicKeys) == 0 {
+			return nil, fmt.Errorf("auth %s requires a jwks_file or private_key_file", AlgorithmRS256)
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported auth algorithm %q", config.Algorithm)
+	}
+
+	return s, nil
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	Roles     []string `json:"roles,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  audience `json:"aud,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+// audience accepts both the string and the array form of the aud claim
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (a audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// Issue signs a token for a principal
+func (s *JWTService) Issue(ctx context.Context, principal domain.Principal, ttl time.Duration) (string, time.Time, error) {
+	if s.config.Algorithm == AlgorithmRS256 && s.privateKey == nil {
+		return "", time.Time{}, errors.New("issuing RS256 tokens requires a private_key_file")
+	}
+
+	if ttl <= 0 {
+		ttl = s.config.TTL
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims := jwtClaims{
+		Subject:   strconv.Itoa(principal.UserID),
+		Roles:     principal.Roles,
+		Issuer:    s.config.Issuer,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+	if s.config.Audience != "" {
+		claims.Audience = audience{s.config.Audience}
+	}
+
+	header := jwtHeader{Algorithm: s.config.Algorithm, Type: "JWT"}
+	if s.config.Algorithm == AlgorithmRS256 {
+		header.KeyID = s.config.KeyID
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to encode token header: %w", err)
+	}
+
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	signingInput := encodeSegment(headerJSON) + "." + encodeSegment(claimsJSON)
+
+	signature, err := s.sign(signingInput)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signingInput + "." + encodeSegment(signature), expiresAt, nil
+}
+
+// Verify checks the token signature, its time window, issuer and audience
+func (s *JWTService) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, domain.ErrInvalidToken
+	}
+
+	var header jwtHeader
+	if err := decodeSegmentJSON(parts[0], &header); err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	// The algorithm is pinned by configuration, never chosen by the token,
+	// otherwise "none" or an HS256 token keyed with the RSA public key would pass
+	if header.Algorithm != s.config.Algorithm {
+		return nil, domain.ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if err := s.verifySignature(parts[0]+"."+parts[1], signature, header.KeyID); err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	var claims jwtClaims
+	if err := decodeSegmentJSON(parts[1], &claims); err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	now := time.Now()
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, domain.ErrInvalidToken
+	}
+	if claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, domain.ErrInvalidToken
+	}
+	if s.config.Issuer != "" && claims.Issuer != s.config.Issuer {
+		return nil, domain.ErrInvalidToken
+	}
+	if s.config.Audience != "" && !claims.Audience.contains(s.config.Audience) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil || userID <= 0 {
+		return nil, domain.ErrInvalidToken
+	}
+
+	return &domain.Principal{UserID: userID, Roles: claims.Roles}, nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, aud := range a {
+		if aud == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *JWTService) sign(signingInput string) ([]byte, error) {
+	switch s.config.Algorithm {
+	case AlgorithmHS256:
+		mac := hmac.New(sha256.New, s.secret)
+		mac.Write([]byte(signingInput))
+		return mac.Sum(nil), nil
+
+	case AlgorithmRS256:
+		digest := sha256.Sum256([]byte(signingInput))
+		return rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+	}
+
+	return nil, fmt.Errorf("unsupported auth algorithm %q", s.config.Algorithm)
+}
+
+func (s *JWTService) verifySignature(signingInput string, signature []byte, keyID string) error {
+	switch s.config.Algorithm {
+	case AlgorithmHS256:
+		mac := hmac.New(sha256.New, s.secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+
+	case AlgorithmRS256:
+		key, err := s.publicKey(keyID)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	}
+
+	return fmt.Errorf("unsupported auth algorithm %q", s.config.Algorithm)
+}
+
+// publicKey looks up a verification key by kid, a token without kid is only
+// accepted when a single key is configured
+func (s *JWTService) publicKey(keyID string) (*rsa.PublicKey, error) {
+	if key, ok := s.publicKeys[keyID]; ok {
+		return key, nil
+	}
+
+	if keyID == "" && len(s.publicKeys) == 1 {
+		for _, key := range s.publicKeys {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown key id %q", keyID)
+}
+
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// LoadJWKS reads the RSA signing keys of a JSON Web Key Set file, indexed by kid
+func LoadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks file: %w", err)
+	}
+
+	var set jwkSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks file: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.KeyType != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		if k.Algorithm != "" && k.Algorithm != AlgorithmRS256 {
+			continue
+		}
+
+		key, err := parseRSAJWK(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwk %q: %w", k.KeyID, err)
+		}
+		keys[k.KeyID] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("jwks file contains no RS256 signing keys")
+	}
+
+	return keys, nil
+}
+
+func parseRSAJWK(k jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.Modulus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode modulus: %w", err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.Exponent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("exponent out of range")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+// PublicJWKS renders RSA public keys as a JSON Web Key Set
+func PublicJWKS(keys map[string]*rsa.PublicKey) ([]byte, error) {
+	set := jwkSet{Keys: make([]jwk, 0, len(keys))}
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, jwk{
+			KeyType:   "RSA",
+			KeyID:     kid,
+			Use:       "sig",
+			Algorithm: AlgorithmRS256,
+			Modulus:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+
+	return json.MarshalIndent(set, "", "  ")
+}
+
+func loadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key file is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+
+	return key, nil
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSegmentJSON(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
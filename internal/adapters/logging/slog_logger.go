@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"strings"
+)
+
+// Log formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config holds the logging settings
+type Config struct {
+	// Level is debug, info, warn or error
+	Level string `mapstructure:"level"`
+	// Format is json or text
+	Format string `mapstructure:"format"`
+}
+
+// SlogLogger implements the Logger interface using log/slog
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger creates a logger writing to w in the configured level and format
+func NewSlogLogger(config Config, w io.Writer) (*SlogLogger, error) {
+	var level slog.Level
+	if config.Level != "" {
+		if err := level.UnmarshalText([]byte(config.Level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", config.Level, err)
+		}
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(config.Format) {
+	case FormatJSON, "":
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q, must be %s or %s", config.Format, FormatJSON, FormatText)
+	}
+
+	return &SlogLogger{logger: slog.New(handler)}, nil
+}
+
+// Slog returns the underlying slog logger, e.g. to make it the default logger
+func (l *SlogLogger) Slog() *slog.Logger {
+	return l.logger
+}
+
+// Debug logs a message useful when diagnosing a problem
+func (l *SlogLogger) Debug(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelDebug, msg, args)
+}
+
+// Info logs a message about normal operation
+func (l *SlogLogger) Info(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelInfo, msg, args)
+}
+
+// Warn logs a message about a problem the service recovered from
+func (l *SlogLogger) Warn(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelWarn, msg, args)
+}
+
+// Error logs a message about a failed operation
+func (l *SlogLogger) Error(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelError, msg, args)
+}
+
+// With returns a logger adding args to every message
+func (l *SlogLogger) With(args ...any) infrastructure.Logger {
+	return &SlogLogger{logger: l.logger.With(args...)}
+}
+
+// log adds the request ID carried by ctx so one ID traces a request through
+// the API, the use cases and the event consumers
+func (l *SlogLogger) log(ctx context.Context, level slog.Level, msg string, args []any) {
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	if requestID := domain.RequestIDFromContext(ctx); requestID != "" {
+		args = append(args[:len(args):len(args)], "request_id", requestID)
+	}
+
+	l.logger.Log(ctx, level, msg, args...)
+}
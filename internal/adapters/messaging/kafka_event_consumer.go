@@ -4,21 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
 	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
 	"sync"
 
 	"github.com/segmentio/kafka-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // KafkaEventConsumer implements the EventConsumer interface using Kafka
 type KafkaEventConsumer struct {
 	readers   map[string]*kafka.Reader
 	handlers  map[string]infrastructure.EventHandler
+	onFailure infrastructure.FailureHandler
 	mu        sync.RWMutex
 	brokers   []string
 	groupID   string
 	isRunning bool
 	cancel    context.CancelFunc
+	logger    infrastructure.Logger
 }
 
 // NewKafkaEventConsumer creates a new Kafka event consumer
@@ -28,6 +33,7 @@ func NewKafkaEventConsumer(brokers []string, groupID string) *KafkaEventConsumer
 		handlers: make(map[string]infrastructure.EventHandler),
 		brokers:  brokers,
 		groupID:  groupID,
+		logger:   infrastructure.NopLogger{},
 	}
 }
 
@@ -67,6 +73,23 @@ func (c *KafkaEventConsumer) SubscribeWithGroup(topic string, groupID string, ha
 	return nil
 }
 
+// SetFailureHandler sets the handler receiving events whose handler failed,
+// e.g. to keep them as dead events
+func (c *KafkaEventConsumer) SetFailureHandler(handler infrastructure.FailureHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onFailure = handler
+}
+
+// SetLogger reports events that could not be read or handled
+func (c *KafkaEventConsumer) SetLogger(logger infrastructure.Logger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.logger = logger
+}
+
 // Unsubscribe removes a handler for a specific topic
 func (c *KafkaEventConsumer) Unsubscribe(topic string) error {
 	c.mu.Lock()
@@ -135,6 +158,10 @@ func (c *KafkaEventConsumer) Start(ctx context.Context) error {
 
 // consumeTopic consumes events from a specific topic
 func (c *KafkaEventConsumer) consumeTopic(ctx context.Context, topic string, reader *kafka.Reader) {
+	c.mu.RLock()
+	logger := c.logger.With("topic", topic)
+	c.mu.RUnlock()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -147,34 +174,64 @@ func (c *KafkaEventConsumer) consumeTopic(ctx context.Context, topic string, rea
 					return // Context cancelled
 				}
 				// Log error and continue
-				fmt.Printf("failed to read message from topic %s: %v\n", topic, err)
+				logger.Error(ctx, "failed to read message", "error", err)
 				continue
 			}
 
+			// Handle the event under the request ID it was published with
+			msgCtx := ctx
+			if requestID := messageRequestID(msg); requestID != "" {
+				msgCtx = domain.ContextWithRequestID(ctx, requestID)
+			}
+
 			// Unmarshal event
 			var event infrastructure.Event
 			if err := json.Unmarshal(msg.Value, &event); err != nil {
-				fmt.Printf("failed to unmarshal event from topic %s: %v\n", topic, err)
+				logger.Error(msgCtx, "failed to unmarshal event", "offset", msg.Offset, "error", err)
 				continue
 			}
 
 			// Get handler
 			c.mu.RLock()
 			handler, exists := c.handlers[topic]
+			onFailure := c.onFailure
 			c.mu.RUnlock()
 
 			if !exists {
-				fmt.Printf("no handler found for topic %s\n", topic)
+				logger.Warn(msgCtx, "no handler found for topic")
 				continue
 			}
 
-			// Handle event
-			if err := handler(ctx, event); err != nil {
-				fmt.Printf("failed to handle event from topic %s: %v\n", topic, err)
-				// In production, you might want to implement retry logic or dead letter queue
+			// Handle event in a span continuing the trace that published it
+			msgCtx, span := startSpan(messageContext(msgCtx, &msg), topic, "process", trace.SpanKindConsumer,
+				semconv.MessagingMessageID(event.ID),
+				semconv.MessagingKafkaConsumerGroup(reader.Config().GroupID),
+				semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+			)
+
+			err = handler(msgCtx, event)
+			if err != nil {
+				logger.Error(msgCtx, "failed to handle event", "event_id", event.ID, "event_type", event.Type, "error", err)
+
+				if onFailure != nil {
+					if err := onFailure(msgCtx, topic, event, err); err != nil {
+						logger.Error(msgCtx, "failed to record failed event", "event_id", event.ID, "error", err)
+					}
+				}
 			}
+			endSpan(span, err)
+		}
+	}
+}
+
+// messageRequestID returns the request ID header set by the publisher
+func messageRequestID(msg kafka.Message) string {
+	for _, header := range msg.Headers {
+		if header.Key == requestIDHeader {
+			return string(header.Value)
 		}
 	}
+	return ""
 }
 
 // Stop stops consuming events
@@ -4,18 +4,25 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
 	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
 	"sync"
 	"time"
 
 	"github.com/segmentio/kafka-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// requestIDHeader carries the ID of the request that published an event
+const requestIDHeader = "X-Request-Id"
+
 // KafkaEventPublisher implements the EventPublisher interface using Kafka
 type KafkaEventPublisher struct {
 	writers map[string]*kafka.Writer
 	mu      sync.RWMutex
 	brokers []string
+	logger  infrastructure.Logger
 }
 
 // NewKafkaEventPublisher creates a new Kafka event publisher
@@ -23,9 +30,15 @@ func NewKafkaEventPublisher(brokers []string) *KafkaEventPublisher {
 	return &KafkaEventPublisher{
 		writers: make(map[string]*kafka.Writer),
 		brokers: brokers,
+		logger:  infrastructure.NopLogger{},
 	}
 }
 
+// SetLogger reports failed asynchronous publishes
+func (p *KafkaEventPublisher) SetLogger(logger infrastructure.Logger) {
+	p.logger = logger
+}
+
 // getWriter gets or creates a writer for a topic
 func (p *KafkaEventPublisher) getWriter(topic string) *kafka.Writer {
 	p.mu.RLock()
@@ -59,7 +72,7 @@ func (p *KafkaEventPublisher) getWriter(topic string) *kafka.Writer {
 }
 
 // Publish publishes an event to a specified topic
-func (p *KafkaEventPublisher) Publish(ctx context.Context, topic string, event infrastructure.Event) error {
+func (p *KafkaEventPublisher) Publish(ctx context.Context, topic string, event infrastructure.Event) (err error) {
 	// Set event metadata if not already set
 	if event.ID == "" {
 		event.ID = generateEventID()
@@ -68,6 +81,9 @@ func (p *KafkaEventPublisher) Publish(ctx context.Context, topic string, event i
 		event.Time = time.Now().UnixMilli()
 	}
 
+	ctx, span := startSpan(ctx, topic, "publish", trace.SpanKindProducer, semconv.MessagingMessageID(event.ID))
+	defer func() { endSpan(span, err) }()
+
 	// Marshal event to JSON
 	data, err := json.Marshal(event)
 	if err != nil {
@@ -79,8 +95,9 @@ func (p *KafkaEventPublisher) Publish(ctx context.Context, topic string, event i
 
 	// Create Kafka message
 	msg := kafka.Message{
-		Key:   []byte(event.ID),
-		Value: data,
+		Key:     []byte(event.ID),
+		Value:   data,
+		Headers: messageHeaders(ctx),
 	}
 
 	// Write message
@@ -111,37 +128,47 @@ func (p *KafkaEventPublisher) PublishAsync(ctx context.Context, topic string, ev
 	// Get writer for topic
 	writer := p.getWriter(topic)
 
+	// The publish span lasts until the message is written
+	ctx, span := startSpan(ctx, topic, "publish", trace.SpanKindProducer, semconv.MessagingMessageID(event.ID))
+
 	// Create Kafka message
 	msg := kafka.Message{
-		Key:   []byte(event.ID),
-		Value: data,
+		Key:     []byte(event.ID),
+		Value:   data,
+		Headers: messageHeaders(ctx),
 	}
 
 	// Write message asynchronously
 	go func() {
-		// Create a new context with timeout for async operation
-		asyncCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		// Create a new context with timeout for async operation, outliving the caller
+		asyncCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
 		defer cancel()
 
-		if err := writer.WriteMessages(asyncCtx, msg); err != nil {
-			// In production, this should be logged properly
-			fmt.Printf("failed to publish async event to topic %s: %v\n", topic, err)
+		err := writer.WriteMessages(asyncCtx, msg)
+		if err != nil {
+			p.logger.Error(asyncCtx, "failed to publish async event",
+				"topic", topic, "event_id", event.ID, "event_type", event.Type, "error", err)
 		}
+		endSpan(span, err)
 	}()
 
 	return nil
 }
 
 // PublishBatch publishes multiple events to the same topic
-func (p *KafkaEventPublisher) PublishBatch(ctx context.Context, topic string, events []infrastructure.Event) error {
+func (p *KafkaEventPublisher) PublishBatch(ctx context.Context, topic string, events []infrastructure.Event) (err error) {
 	if len(events) == 0 {
 		return nil
 	}
 
+	ctx, span := startSpan(ctx, topic, "publish", trace.SpanKindProducer, semconv.MessagingBatchMessageCount(len(events)))
+	defer func() { endSpan(span, err) }()
+
 	// Get writer for topic
 	writer := p.getWriter(topic)
 
 	// Prepare messages
+	headers := messageHeaders(ctx)
 	messages := make([]kafka.Message, 0, len(events))
 	for _, event := range events {
 		// Set event metadata if not already set
@@ -159,13 +186,14 @@ func (p *KafkaEventPublisher) PublishBatch(ctx context.Context, topic string, ev
 		}
 
 		messages = append(messages, kafka.Message{
-			Key:   []byte(event.ID),
-			Value: data,
+			Key:     []byte(event.ID),
+			Value:   data,
+			Headers: headers,
 		})
 	}
 
 	// Write messages
-	err := writer.WriteMessages(ctx, messages...)
+	err = writer.WriteMessages(ctx, messages...)
 	if err != nil {
 		return fmt.Errorf("failed to publish batch events to topic %s: %w", topic, err)
 	}
@@ -173,6 +201,34 @@ func (p *KafkaEventPublisher) PublishBatch(ctx context.Context, topic string, ev
 	return nil
 }
 
+// CheckHealth reads the cluster metadata from the first broker that answers
+func (p *KafkaEventPublisher) CheckHealth(ctx context.Context) error {
+	if len(p.brokers) == 0 {
+		return fmt.Errorf("no kafka brokers configured")
+	}
+
+	var lastErr error
+	for _, broker := range p.brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetDeadline(deadline)
+		}
+		_, err = conn.Brokers()
+		conn.Close()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+
+	return fmt.Errorf("failed to read kafka metadata: %w", lastErr)
+}
+
 // Flush waits for all async events to be published
 func (p *KafkaEventPublisher) Flush(ctx context.Context) error {
 	p.mu.RLock()
@@ -202,6 +258,15 @@ func (p *KafkaEventPublisher) Close() error {
 	return nil
 }
 
+// requestIDHeaders passes the request ID carried by ctx on to the consumers
+func requestIDHeaders(ctx context.Context) []kafka.Header {
+	requestID := domain.RequestIDFromContext(ctx)
+	if requestID == "" {
+		return nil
+	}
+	return []kafka.Header{{Key: requestIDHeader, Value: []byte(requestID)}}
+}
+
 // generateEventID generates a unique event ID
 func generateEventID() string {
 	return fmt.Sprintf("evt_%d_%s", time.Now().UnixNano(), generateRandomString(8))
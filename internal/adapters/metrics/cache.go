@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+)
+
+// Cache result label values
+const (
+	cacheHit  = "hit"
+	cacheMiss = "miss"
+)
+
+var _ infrastructure.Cache = (*Cache)(nil)
+
+// Cache counts the hits and misses of the reads of the cache it decorates.
+// A read that fails for any reason is a miss to its caller
+type Cache struct {
+	infrastructure.Cache
+	metrics *Metrics
+}
+
+// NewCache decorates cache with hit and miss counters
+func NewCache(cache infrastructure.Cache, metrics *Metrics) *Cache {
+	return &Cache{
+		Cache:   cache,
+		metrics: metrics,
+	}
+}
+
+// Get retrieves a value from the cache
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.Cache.Get(ctx, key)
+	c.record(err)
+	return value, err
+}
+
+// GetObject retrieves and deserializes an object from the cache
+func (c *Cache) GetObject(ctx context.Context, key string, obj interface{}) error {
+	err := c.Cache.GetObject(ctx, key, obj)
+	c.record(err)
+	return err
+}
+
+func (c *Cache) record(err error) {
+	result := cacheHit
+	if err != nil {
+		result = cacheMiss
+	}
+	c.metrics.cacheRequests.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"time"
+)
+
+// The decorators must keep satisfying the ports they wrap
+var (
+	_ infrastructure.EventPublisher = (*EventPublisher)(nil)
+	_ infrastructure.EventConsumer  = (*EventConsumer)(nil)
+)
+
+// EventPublisher counts the events published through the publisher it decorates
+type EventPublisher struct {
+	infrastructure.EventPublisher
+	metrics *Metrics
+}
+
+// NewEventPublisher decorates publisher with publish counters
+func NewEventPublisher(publisher infrastructure.EventPublisher, metrics *Metrics) *EventPublisher {
+	return &EventPublisher{
+		EventPublisher: publisher,
+		metrics:        metrics,
+	}
+}
+
+// Publish publishes an event to a specified topic
+func (p *EventPublisher) Publish(ctx context.Context, topic string, event infrastructure.Event) error {
+	err := p.EventPublisher.Publish(ctx, topic, event)
+	p.metrics.eventsPublished.WithLabelValues(topic, status(err)).Inc()
+	return err
+}
+
+// PublishAsync publishes an event asynchronously and returns immediately.
+// Only failures to queue the event are counted as errors
+func (p *EventPublisher) PublishAsync(ctx context.Context, topic string, event infrastructure.Event) error {
+	err := p.EventPublisher.PublishAsync(ctx, topic, event)
+	p.metrics.eventsPublished.WithLabelValues(topic, status(err)).Inc()
+	return err
+}
+
+// PublishBatch publishes multiple events to the same topic
+func (p *EventPublisher) PublishBatch(ctx context.Context, topic string, events []infrastructure.Event) error {
+	err := p.EventPublisher.PublishBatch(ctx, topic, events)
+	p.metrics.eventsPublished.WithLabelValues(topic, status(err)).Add(float64(len(events)))
+	return err
+}
+
+// EventConsumer counts the events handled through the consumer it decorates
+// and times their handlers
+type EventConsumer struct {
+	infrastructure.EventConsumer
+	metrics *Metrics
+}
+
+// NewEventConsumer decorates consumer with consume counters and handler durations
+func NewEventConsumer(consumer infrastructure.EventConsumer, metrics *Metrics) *EventConsumer {
+	return &EventConsumer{
+		EventConsumer: consumer,
+		metrics:       metrics,
+	}
+}
+
+// Subscribe registers a handler for a specific topic
+func (c *EventConsumer) Subscribe(topic string, handler infrastructure.EventHandler) error {
+	return c.EventConsumer.Subscribe(topic, c.instrument(topic, handler))
+}
+
+// SubscribeWithGroup registers a handler for a specific topic with a consumer group
+func (c *EventConsumer) SubscribeWithGroup(topic string, groupID string, handler infrastructure.EventHandler) error {
+	return c.EventConsumer.SubscribeWithGroup(topic, groupID, c.instrument(topic, handler))
+}
+
+// instrument wraps handler to record each event it handles
+func (c *EventConsumer) instrument(topic string, handler infrastructure.EventHandler) infrastructure.EventHandler {
+	return func(ctx context.Context, event infrastructure.Event) error {
+		start := time.Now()
+		err := handler(ctx, event)
+
+		c.metrics.handlerDuration.WithLabelValues(topic).Observe(since(start))
+		c.metrics.eventsConsumed.WithLabelValues(topic, status(err)).Inc()
+		return err
+	}
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute labels requests no route matched, so unknown paths do not
+// add a label value each
+const unmatchedRoute = "unmatched"
+
+// HTTPMiddleware records the rate, errors and duration of requests per route
+func (m *Metrics) HTTPMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			code := c.Response().Status
+			if err != nil && !c.Response().Committed {
+				// The error handler writes the response after the middleware returns
+				code = http.StatusInternalServerError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					code = httpErr.Code
+				}
+			}
+
+			method := c.Request().Method
+			m.httpRequests.WithLabelValues(method, route, strconv.Itoa(code)).Inc()
+			m.httpDuration.WithLabelValues(method, route).Observe(since(start))
+
+			return err
+		}
+	}
+}
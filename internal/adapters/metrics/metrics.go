@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric of the service
+const namespace = "ewallet"
+
+// Status label values
+const (
+	statusSuccess = "success"
+	statusError   = "error"
+)
+
+// Metrics holds the Prometheus collectors of the service. The decorators in
+// this package record into it, so the use cases and adapters stay unaware of
+// the metrics
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	walletOperations *prometheus.CounterVec
+
+	gatewayDuration *prometheus.HistogramVec
+	gatewayErrors   *prometheus.CounterVec
+
+	eventsPublished *prometheus.CounterVec
+	eventsConsumed  *prometheus.CounterVec
+	handlerDuration *prometheus.HistogramVec
+
+	cacheRequests *prometheus.CounterVec
+}
+
+// NewMetrics creates the collectors and registers them, along with the Go
+// runtime and process metrics, on a registry of their own
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+
+		walletOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "wallet_operations_total",
+			Help:      "Deposits, withdrawals and transfers by status and currency.",
+		}, []string{"operation", "status", "currency"}),
+
+		gatewayDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "payment_gateway_request_duration_seconds",
+			Help:      "Payment gateway call latency by provider and operation.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"provider", "operation"}),
+		gatewayErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "payment_gateway_errors_total",
+			Help:      "Failed payment gateway calls by provider and operation.",
+		}, []string{"provider", "operation"}),
+
+		eventsPublished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "events_published_total",
+			Help:      "Published events by topic and status.",
+		}, []string{"topic", "status"}),
+		eventsConsumed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "events_consumed_total",
+			Help:      "Consumed events by topic and handler status.",
+		}, []string{"topic", "status"}),
+		handlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "event_handler_duration_seconds",
+			Help:      "Event handler latency by topic.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"topic"}),
+
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_requests_total",
+			Help:      "Cache reads by result, hit or miss.",
+		}, []string{"result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.walletOperations,
+		m.gatewayDuration,
+		m.gatewayErrors,
+		m.eventsPublished,
+		m.eventsConsumed,
+		m.handlerDuration,
+		m.cacheRequests,
+	)
+
+	return m
+}
+
+// RegisterDB exports the connection pool stats of db under the given name
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Registry returns the registry the collectors are registered on
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// status returns the status label of an operation that returned err
+func status(err error) string {
+	if err != nil {
+		return statusError
+	}
+	return statusSuccess
+}
+
+// since returns the seconds elapsed since start
+func since(start time.Time) float64 {
+	return time.Since(start).Seconds()
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/ports/secondary/external"
+	"time"
+)
+
+var _ external.PaymentGateway = (*PaymentGateway)(nil)
+
+// PaymentGateway records the latency and errors of the payment gateway it
+// decorates, labelled with its provider
+type PaymentGateway struct {
+	external.PaymentGateway
+	metrics  *Metrics
+	provider string
+}
+
+// NewPaymentGateway decorates gateway with latency and error metrics
+func NewPaymentGateway(gateway external.PaymentGateway, metrics *Metrics) *PaymentGateway {
+	return &PaymentGateway{
+		PaymentGateway: gateway,
+		metrics:        metrics,
+		provider:       string(gateway.GetProvider()),
+	}
+}
+
+// ProcessPayment process a payment through the payment gateway
+func (g *PaymentGateway) ProcessPayment(ctx context.Context, request external.PaymentRequest) (*external.PaymentResponse, error) {
+	start := time.Now()
+	response, err := g.PaymentGateway.ProcessPayment(ctx, request)
+	g.record("process_payment", start, err)
+	return response, err
+}
+
+// CheckPaymentStatus checks the status of a payment
+func (g *PaymentGateway) CheckPaymentStatus(ctx context.Context, transactionID string) (*external.PaymentResponse, error) {
+	start := time.Now()
+	response, err := g.PaymentGateway.CheckPaymentStatus(ctx, transactionID)
+	g.record("check_payment_status", start, err)
+	return response, err
+}
+
+// CancelPayment cancels a payment
+func (g *PaymentGateway) CancelPayment(ctx context.Context, transactionID string) error {
+	start := time.Now()
+	err := g.PaymentGateway.CancelPayment(ctx, transactionID)
+	g.record("cancel_payment", start, err)
+	return err
+}
+
+// RefundRepayment refunds a payment partially or fully
+func (g *PaymentGateway) RefundRepayment(ctx context.Context, request external.RefundRequest) (*external.RefundResponse, error) {
+	start := time.Now()
+	response, err := g.PaymentGateway.RefundRepayment(ctx, request)
+	g.record("refund_payment", start, err)
+	return response, err
+}
+
+// ValidateCallback validates and process a callback from the payment gateway
+func (g *PaymentGateway) ValidateCallback(ctx context.Context, requestBody []byte, headers map[string]string) (*external.PaymentResponse, error) {
+	start := time.Now()
+	response, err := g.PaymentGateway.ValidateCallback(ctx, requestBody, headers)
+	g.record("validate_callback", start, err)
+	return response, err
+}
+
+func (g *PaymentGateway) record(operation string, start time.Time, err error) {
+	g.metrics.gatewayDuration.WithLabelValues(g.provider, operation).Observe(since(start))
+	if err != nil {
+		g.metrics.gatewayErrors.WithLabelValues(g.provider, operation).Inc()
+	}
+}
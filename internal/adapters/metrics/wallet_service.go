@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"strings"
+	"sync"
+)
+
+// unknownCurrency labels operations on a wallet that could not be found
+const unknownCurrency = "unknown"
+
+var _ primary.WalletService = (*WalletService)(nil)
+
+// WalletService counts the deposits, withdrawals and transfers of the wallet
+// service it decorates. Every other call is passed through as is
+type WalletService struct {
+	primary.WalletService
+	metrics *Metrics
+
+	// currencies caches the currency of each wallet seen, it never changes
+	currencies sync.Map
+}
+
+// NewWalletService decorates walletService with operation counters
+func NewWalletService(walletService primary.WalletService, metrics *Metrics) *WalletService {
+	return &WalletService{
+		WalletService: walletService,
+		metrics:       metrics,
+	}
+}
+
+// Deposit adds funds to a wallet
+func (s *WalletService) Deposit(ctx context.Context, walletID int, amount int, description string) (*domain.Transaction, error) {
+	transaction, err := s.WalletService.Deposit(ctx, walletID, amount, description)
+	s.record(ctx, "deposit", walletID, transaction, err)
+	return transaction, err
+}
+
+// Withdraw removes funds from a wallet
+func (s *WalletService) Withdraw(ctx context.Context, walletID int, amount int, description string) (*domain.Transaction, error) {
+	transaction, err := s.WalletService.Withdraw(ctx, walletID, amount, description)
+	s.record(ctx, "withdrawal", walletID, transaction, err)
+	return transaction, err
+}
+
+// Transfer transfers funds from one wallet to another
+func (s *WalletService) Transfer(
+	ctx context.Context,
+	fromWalletID int,
+	toWalletID int,
+	amount int,
+	description string,
+) (*domain.Transaction, error) {
+	transaction, err := s.WalletService.Transfer(ctx, fromWalletID, toWalletID, amount, description)
+	s.record(ctx, "transfer", fromWalletID, transaction, err)
+	return transaction, err
+}
+
+// TransferWithReference transfers funds and tags the transaction with a reference
+func (s *WalletService) TransferWithReference(
+	ctx context.Context,
+	fromWalletID int,
+	toWalletID int,
+	amount int,
+	description string,
+	reference string,
+) (*domain.Transaction, error) {
+	transaction, err := s.WalletService.TransferWithReference(ctx, fromWalletID, toWalletID, amount, description, reference)
+	s.record(ctx, "transfer", fromWalletID, transaction, err)
+	return transaction, err
+}
+
+// record counts an operation under the status of its transaction, or failed
+// when the operation returned an error
+func (s *WalletService) record(ctx context.Context, operation string, walletID int, transaction *domain.Transaction, err error) {
+	status := strings.ToLower(string(domain.TransactionStatusFailed))
+	if err == nil && transaction != nil {
+		status = strings.ToLower(string(transaction.Status))
+	}
+
+	s.metrics.walletOperations.WithLabelValues(operation, status, s.currency(ctx, walletID)).Inc()
+}
+
+// currency returns the currency of a wallet, looked up once per wallet
+func (s *WalletService) currency(ctx context.Context, walletID int) string {
+	if currency, ok := s.currencies.Load(walletID); ok {
+		return currency.(string)
+	}
+
+	wallet, err := s.WalletService.GetWallet(ctx, walletID)
+	if err != nil || wallet == nil {
+		return unknownCurrency
+	}
+
+	s.currencies.Store(walletID, wallet.CurrencyCode)
+	return wallet.CurrencyCode
+}
@@ -0,0 +1,41 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// stripeBaseURL is the Stripe API, test and live mode share it
+const stripeBaseURL = "https://api.stripe.com"
+
+// healthClient is used for reachability checks only, the checks are bounded
+// by their context
+var healthClient = &http.Client{}
+
+// CheckHealth checks that the Midtrans API can be reached
+func (g *MidtransGateway) CheckHealth(ctx context.Context) error {
+	return checkReachable(ctx, g.baseURL)
+}
+
+// CheckHealth checks that the Stripe API can be reached
+func (g *StripeGateway) CheckHealth(ctx context.Context) error {
+	return checkReachable(ctx, stripeBaseURL)
+}
+
+// checkReachable sends a HEAD request to a provider API. Any response counts,
+// unauthenticated requests are expected to be refused
+func checkReachable(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := healthClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", url, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"sync"
+	"time"
+)
+
+// InMemoryBalanceSnapshotRepository implements BalanceSnapshotRepository interface for testing
+type InMemoryBalanceSnapshotRepository struct {
+	mu        sync.RWMutex
+	snapshots map[int][]*domain.BalanceSnapshot
+	nextID    int
+}
+
+// NewInMemoryBalanceSnapshotRepository creates a new in-memory balance snapshot repository
+func NewInMemoryBalanceSnapshotRepository() *InMemoryBalanceSnapshotRepository {
+	return &InMemoryBalanceSnapshotRepository{
+		snapshots: make(map[int][]*domain.BalanceSnapshot),
+		nextID:    1,
+	}
+}
+
+func (r *InMemoryBalanceSnapshotRepository) Save(ctx context.Context, snapshot *domain.BalanceSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshotCopy := *snapshot
+	snapshots := r.snapshots[snapshot.WalletID]
+	for i, existing := range snapshots {
+		if existing.TakenAt.Equal(snapshot.TakenAt) {
+			snapshotCopy.ID = existing.ID
+			snapshot.ID = existing.ID
+			snapshots[i] = &snapshotCopy
+			return nil
+		}
+	}
+
+	snapshotCopy.ID = r.nextID
+	snapshot.ID = r.nextID
+	r.nextID++
+	r.snapshots[snapshot.WalletID] = append(snapshots, &snapshotCopy)
+
+	return nil
+}
+
+func (r *InMemoryBalanceSnapshotRepository) FindLatest(ctx context.Context, walletID int, at time.Time) (*domain.BalanceSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *domain.BalanceSnapshot
+	for _, snapshot := range r.snapshots[walletID] {
+		if snapshot.TakenAt.After(at) {
+			continue
+		}
+		if latest == nil || snapshot.TakenAt.After(latest.TakenAt) {
+			latest = snapshot
+		}
+	}
+
+	if latest == nil {
+		return nil, nil
+	}
+
+	snapshotCopy := *latest
+	return &snapshotCopy, nil
+}
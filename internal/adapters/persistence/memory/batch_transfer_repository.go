@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// InMemoryBatchTransferRepository implements BatchTransferRepository interface for testing
+type InMemoryBatchTransferRepository struct {
+	mu      sync.RWMutex
+	batches map[int]*domain.BatchTransfer
+	nextID  int
+}
+
+// NewInMemoryBatchTransferRepository creates a new in-memory batch transfer repository
+func NewInMemoryBatchTransferRepository() *InMemoryBatchTransferRepository {
+	return &InMemoryBatchTransferRepository{
+		batches: make(map[int]*domain.BatchTransfer),
+		nextID:  1,
+	}
+}
+
+func (r *InMemoryBatchTransferRepository) FindByID(ctx context.Context, id int) (*domain.BatchTransfer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	batch, exists := r.batches[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return copyBatchTransfer(batch), nil
+}
+
+func (r *InMemoryBatchTransferRepository) FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.BatchTransfer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var batches []*domain.BatchTransfer
+	for _, batch := range r.batches {
+		if batch.FromWalletID == walletID {
+			batches = append(batches, copyBatchTransfer(batch))
+		}
+	}
+
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].ID > batches[j].ID
+	})
+
+	start := offset
+	if start > len(batches) {
+		return []*domain.BatchTransfer{}, nil
+	}
+
+	end := start + limit
+	if end > len(batches) {
+		end = len(batches)
+	}
+
+	return batches[start:end], nil
+}
+
+func (r *InMemoryBatchTransferRepository) FindUnfinished(ctx context.Context, updatedBefore time.Time, limit int) ([]*domain.BatchTransfer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var batches []*domain.BatchTransfer
+	for _, batch := range r.batches {
+		if batch.IsFinished() || !batch.UpdatedAt.Before(updatedBefore) {
+			continue
+		}
+		batches = append(batches, copyBatchTransfer(batch))
+	}
+
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].UpdatedAt.Before(batches[j].UpdatedAt)
+	})
+
+	if len(batches) > limit {
+		batches = batches[:limit]
+	}
+
+	return batches, nil
+}
+
+func (r *InMemoryBatchTransferRepository) Claim(ctx context.Context, id int, updatedBefore time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batch, exists := r.batches[id]
+	if !exists || batch.IsFinished() || !batch.UpdatedAt.Before(updatedBefore) {
+		return false, nil
+	}
+
+	batch.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+func (r *InMemoryBatchTransferRepository) Create(ctx context.Context, batch *domain.BatchTransfer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if batch.ID == 0 {
+		batch.ID = r.nextID
+		r.nextID++
+	}
+
+	r.batches[batch.ID] = copyBatchTransfer(batch)
+
+	return nil
+}
+
+func (r *InMemoryBatchTransferRepository) Update(ctx context.Context, batch *domain.BatchTransfer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.batches[batch.ID]; !exists {
+		return fmt.Errorf("batch transfer not found: %d", batch.ID)
+	}
+
+	r.batches[batch.ID] = copyBatchTransfer(batch)
+
+	return nil
+}
+
+// copyBatchTransfer copies a batch including its items
+func copyBatchTransfer(batch *domain.BatchTransfer) *domain.BatchTransfer {
+	batchCopy := *batch
+	batchCopy.Items = append([]domain.BatchTransferItem{}, batch.Items...)
+	return &batchCopy
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e cacheEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// InMemoryCache implements Cache interface for testing
+type InMemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// NewInMemoryCache creates a new in-memory cache
+func NewInMemoryCache() *InMemoryCache {
+	return &InMemoryCache{
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *InMemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.get(key)
+	if !ok {
+		return nil, fmt.Errorf("key not found: %s", key)
+	}
+
+	return append([]byte(nil), entry.value...), nil
+}
+
+func (c *InMemoryCache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := cacheEntry{value: append([]byte(nil), value...)}
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+
+	c.entries[key] = entry
+	return nil
+}
+
+func (c *InMemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	return nil
+}
+
+func (c *InMemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.get(key)
+	return ok, nil
+}
+
+func (c *InMemoryCache) Increment(ctx context.Context, key string, value int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var current int64
+	entry, ok := c.get(key)
+	if ok {
+		n, err := strconv.ParseInt(string(entry.value), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to increment key %s: value is not an integer", key)
+		}
+		current = n
+	}
+
+	// Like Redis, changing the value keeps the expiry of the key
+	entry.value = []byte(strconv.FormatInt(current+value, 10))
+	c.entries[key] = entry
+
+	return current + value, nil
+}
+
+func (c *InMemoryCache) Decrement(ctx context.Context, key string, value int64) (int64, error) {
+	return c.Increment(ctx, key, -value)
+}
+
+func (c *InMemoryCache) SetObject(ctx context.Context, key string, obj interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal object: %w", err)
+	}
+
+	return c.Set(ctx, key, data, expiration)
+}
+
+func (c *InMemoryCache) GetObject(ctx context.Context, key string, obj interface{}) error {
+	data, err := c.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, obj); err != nil {
+		return fmt.Errorf("failed to unmarshal object: %w", err)
+	}
+
+	return nil
+}
+
+func (c *InMemoryCache) FlushAll(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]cacheEntry)
+	return nil
+}
+
+// get returns a live entry and drops it when it expired. Callers must hold mu
+func (c *InMemoryCache) get(key string) (cacheEntry, bool) {
+	entry, ok := c.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	if entry.expired(time.Now()) {
+		delete(c.entries, key)
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"sync"
+)
+
+// InMemoryDeadEventRepository implements DeadEventRepository interface for testing
+type InMemoryDeadEventRepository struct {
+	mu     sync.RWMutex
+	events map[int]*domain.DeadEvent
+	nextID int
+}
+
+// NewInMemoryDeadEventRepository creates a new in-memory dead event repository
+func NewInMemoryDeadEventRepository() *InMemoryDeadEventRepository {
+	return &InMemoryDeadEventRepository{
+		events: make(map[int]*domain.DeadEvent),
+		nextID: 1,
+	}
+}
+
+func (r *InMemoryDeadEventRepository) Create(ctx context.Context, event *domain.DeadEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event.ID = r.nextID
+	r.nextID++
+
+	eventCopy := *event
+	r.events[event.ID] = &eventCopy
+
+	return nil
+}
+
+func (r *InMemoryDeadEventRepository) FindByID(ctx context.Context, id int) (*domain.DeadEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	event, exists := r.events[id]
+	if !exists {
+		return nil, nil
+	}
+
+	eventCopy := *event
+	return &eventCopy, nil
+}
+
+func (r *InMemoryDeadEventRepository) FindPending(ctx context.Context, limit int) ([]*domain.DeadEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.DeadEvent
+	for id := 1; id < r.nextID && len(result) < limit; id++ {
+		event, exists := r.events[id]
+		if !exists || event.RedrivenAt != nil {
+			continue
+		}
+
+		eventCopy := *event
+		result = append(result, &eventCopy)
+	}
+
+	return result, nil
+}
+
+func (r *InMemoryDeadEventRepository) Update(ctx context.Context, event *domain.DeadEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.events[event.ID]; !exists {
+		return fmt.Errorf("dead event not found: %d", event.ID)
+	}
+
+	eventCopy := *event
+	r.events[event.ID] = &eventCopy
+
+	return nil
+}
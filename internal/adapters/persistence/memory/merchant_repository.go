@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"sort"
+	"sync"
+)
+
+// InMemoryMerchantRepository implements MerchantRepository interface for testing
+type InMemoryMerchantRepository struct {
+	mu        sync.RWMutex
+	merchants map[int]*domain.Merchant
+	keys      map[int]*domain.APIKey
+	nextID    int
+	nextKeyID int
+}
+
+// NewInMemoryMerchantRepository creates a new in-memory merchant repository
+func NewInMemoryMerchantRepository() *InMemoryMerchantRepository {
+	return &InMemoryMerchantRepository{
+		merchants: make(map[int]*domain.Merchant),
+		keys:      make(map[int]*domain.APIKey),
+		nextID:    1,
+		nextKeyID: 1,
+	}
+}
+
+func (r *InMemoryMerchantRepository) FindByID(ctx context.Context, id int) (*domain.Merchant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	merchant, exists := r.merchants[id]
+	if !exists {
+		return nil, nil
+	}
+
+	merchantCopy := *merchant
+	merchantCopy.Scopes = append([]domain.APIScope(nil), merchant.Scopes...)
+	return &merchantCopy, nil
+}
+
+func (r *InMemoryMerchantRepository) Create(ctx context.Context, merchant *domain.Merchant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if merchant.ID == 0 {
+		merchant.ID = r.nextID
+		r.nextID++
+	}
+
+	merchantCopy := *merchant
+	merchantCopy.Scopes = append([]domain.APIScope(nil), merchant.Scopes...)
+	r.merchants[merchant.ID] = &merchantCopy
+
+	return nil
+}
+
+func (r *InMemoryMerchantRepository) FindAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.KeyHash == keyHash {
+			keyCopy := *key
+			return &keyCopy, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *InMemoryMerchantRepository) FindAPIKeysByMerchantID(ctx context.Context, merchantID int) ([]*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var keys []*domain.APIKey
+	for _, key := range r.keys {
+		if key.MerchantID == merchantID {
+			keyCopy := *key
+			keys = append(keys, &keyCopy)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID > keys[j].ID
+	})
+
+	return keys, nil
+}
+
+func (r *InMemoryMerchantRepository) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key.ID == 0 {
+		key.ID = r.nextKeyID
+		r.nextKeyID++
+	}
+
+	keyCopy := *key
+	r.keys[key.ID] = &keyCopy
+
+	return nil
+}
+
+func (r *InMemoryMerchantRepository) UpdateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.keys[key.ID]; !exists {
+		return fmt.Errorf("api key not found: %d", key.ID)
+	}
+
+	keyCopy := *key
+	r.keys[key.ID] = &keyCopy
+
+	return nil
+}
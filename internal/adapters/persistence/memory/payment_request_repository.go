@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// InMemoryPaymentRequestRepository implements PaymentRequestRepository interface for testing
+type InMemoryPaymentRequestRepository struct {
+	mu       sync.RWMutex
+	requests map[int]*domain.PaymentRequest
+	nextID   int
+}
+
+// NewInMemoryPaymentRequestRepository creates a new in-memory payment request repository
+func NewInMemoryPaymentRequestRepository() *InMemoryPaymentRequestRepository {
+	return &InMemoryPaymentRequestRepository{
+		requests: make(map[int]*domain.PaymentRequest),
+		nextID:   1,
+	}
+}
+
+func (r *InMemoryPaymentRequestRepository) FindByID(ctx context.Context, id int) (*domain.PaymentRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	request, exists := r.requests[id]
+	if !exists {
+		return nil, nil
+	}
+
+	requestCopy := *request
+	return &requestCopy, nil
+}
+
+func (r *InMemoryPaymentRequestRepository) FindByPayerUserID(ctx context.Context, userID int, status domain.PaymentRequestStatus, limit, offset int) ([]*domain.PaymentRequest, error) {
+	return r.find(func(request *domain.PaymentRequest) bool {
+		return request.PayerUserID == userID && (status == "" || request.Status == status)
+	}, limit, offset), nil
+}
+
+func (r *InMemoryPaymentRequestRepository) FindByRequesterUserID(ctx context.Context, userID int, status domain.PaymentRequestStatus, limit, offset int) ([]*domain.PaymentRequest, error) {
+	return r.find(func(request *domain.PaymentRequest) bool {
+		return request.RequesterUserID == userID && (status == "" || request.Status == status)
+	}, limit, offset), nil
+}
+
+func (r *InMemoryPaymentRequestRepository) FindExpired(ctx context.Context, before time.Time, limit int) ([]*domain.PaymentRequest, error) {
+	requests := r.find(func(request *domain.PaymentRequest) bool {
+		return request.IsExpired(before)
+	}, limit, 0)
+
+	return requests, nil
+}
+
+// FindStaleProcessing retrieves requests claimed by an accept that were last
+// updated before a specified time
+func (r *InMemoryPaymentRequestRepository) FindStaleProcessing(ctx context.Context, updatedBefore time.Time, limit int) ([]*domain.PaymentRequest, error) {
+	requests := r.find(func(request *domain.PaymentRequest) bool {
+		return request.Status == domain.PaymentRequestStatusProcessing && request.UpdatedAt.Before(updatedBefore)
+	}, limit, 0)
+
+	return requests, nil
+}
+
+func (r *InMemoryPaymentRequestRepository) Create(ctx context.Context, request *domain.PaymentRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if request.ID == 0 {
+		request.ID = r.nextID
+		r.nextID++
+	}
+
+	requestCopy := *request
+	r.requests[request.ID] = &requestCopy
+
+	return nil
+}
+
+func (r *InMemoryPaymentRequestRepository) UpdateIfStatus(ctx context.Context, request *domain.PaymentRequest, expected domain.PaymentRequestStatus) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.requests[request.ID]
+	if !exists || stored.Status != expected {
+		return false, nil
+	}
+
+	requestCopy := *request
+	r.requests[request.ID] = &requestCopy
+
+	return true, nil
+}
+
+// find returns copies of matching requests, newest first
+func (r *InMemoryPaymentRequestRepository) find(match func(*domain.PaymentRequest) bool, limit, offset int) []*domain.PaymentRequest {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var requests []*domain.PaymentRequest
+	for _, request := range r.requests {
+		if match(request) {
+			requestCopy := *request
+			requests = append(requests, &requestCopy)
+		}
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].ID > requests[j].ID
+	})
+
+	start := offset
+	if start > len(requests) {
+		return []*domain.PaymentRequest{}
+	}
+
+	end := start + limit
+	if end > len(requests) {
+		end = len(requests)
+	}
+
+	return requests[start:end]
+}
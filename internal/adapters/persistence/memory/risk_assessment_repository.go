@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"sort"
+	"sync"
+)
+
+// InMemoryRiskAssessmentRepository implements RiskAssessmentRepository interface for testing
+type InMemoryRiskAssessmentRepository struct {
+	mu          sync.RWMutex
+	assessments map[int]*domain.RiskAssessment
+	nextID      int
+}
+
+// NewInMemoryRiskAssessmentRepository creates a new in-memory risk assessment repository
+func NewInMemoryRiskAssessmentRepository() *InMemoryRiskAssessmentRepository {
+	return &InMemoryRiskAssessmentRepository{
+		assessments: make(map[int]*domain.RiskAssessment),
+		nextID:      1,
+	}
+}
+
+func (r *InMemoryRiskAssessmentRepository) FindByID(ctx context.Context, id int) (*domain.RiskAssessment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	assessment, exists := r.assessments[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return copyRiskAssessment(assessment), nil
+}
+
+func (r *InMemoryRiskAssessmentRepository) FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.RiskAssessment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var assessments []*domain.RiskAssessment
+	for _, assessment := range r.assessments {
+		if assessment.WalletID == walletID {
+			assessments = append(assessments, copyRiskAssessment(assessment))
+		}
+	}
+
+	sort.Slice(assessments, func(i, j int) bool {
+		return assessments[i].ID > assessments[j].ID
+	})
+
+	return paginateRiskAssessments(assessments, limit, offset), nil
+}
+
+func (r *InMemoryRiskAssessmentRepository) FindByReviewStatus(ctx context.Context, status domain.RiskReviewStatus, limit, offset int) ([]*domain.RiskAssessment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var assessments []*domain.RiskAssessment
+	for _, assessment := range r.assessments {
+		if assessment.ReviewStatus == status {
+			assessments = append(assessments, copyRiskAssessment(assessment))
+		}
+	}
+
+	sort.Slice(assessments, func(i, j int) bool {
+		return assessments[i].ID < assessments[j].ID
+	})
+
+	return paginateRiskAssessments(assessments, limit, offset), nil
+}
+
+func (r *InMemoryRiskAssessmentRepository) Create(ctx context.Context, assessment *domain.RiskAssessment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if assessment.ID == 0 {
+		assessment.ID = r.nextID
+		r.nextID++
+	}
+
+	r.assessments[assessment.ID] = copyRiskAssessment(assessment)
+
+	return nil
+}
+
+func (r *InMemoryRiskAssessmentRepository) Update(ctx context.Context, assessment *domain.RiskAssessment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.assessments[assessment.ID]; !exists {
+		return fmt.Errorf("risk assessment not found: %d", assessment.ID)
+	}
+
+	r.assessments[assessment.ID] = copyRiskAssessment(assessment)
+
+	return nil
+}
+
+// copyRiskAssessment copies an assessment including its rule hits
+func copyRiskAssessment(assessment *domain.RiskAssessment) *domain.RiskAssessment {
+	assessmentCopy := *assessment
+	assessmentCopy.RuleHits = append([]domain.RiskRuleHit{}, assessment.RuleHits...)
+	return &assessmentCopy
+}
+
+func paginateRiskAssessments(assessments []*domain.RiskAssessment, limit, offset int) []*domain.RiskAssessment {
+	start := offset
+	if start > len(assessments) {
+		return []*domain.RiskAssessment{}
+	}
+
+	end := start + limit
+	if end > len(assessments) {
+		end = len(assessments)
+	}
+
+	return assessments[start:end]
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"ports-and-adapters-architecture/internal/domain"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 )
@@ -48,6 +50,8 @@ func (r *InMemoryTransactionRepository) FindByWalletID(ctx context.Context, wall
 		}
 	}
 
+	sortNewestFirst(transactions)
+
 	// Apply pagination
 	start := offset
 	if start > len(transactions) {
@@ -62,6 +66,76 @@ func (r *InMemoryTransactionRepository) FindByWalletID(ctx context.Context, wall
 	return transactions[start:end], nil
 }
 
+func (r *InMemoryTransactionRepository) FindByQuery(ctx context.Context, query domain.TransactionQuery) ([]*domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transactions := r.matching(query)
+	sortNewestFirst(transactions)
+
+	// Position of the listing relative to the cursor
+	next := -1
+	if query.OldestFirst {
+		slices.Reverse(transactions)
+		next = 1
+	}
+
+	if query.After != nil {
+		start := 0
+		for start < len(transactions) && query.After.Compare(transactions[start]) != next {
+			start++
+		}
+		transactions = transactions[start:]
+	} else if query.Offset > 0 {
+		if query.Offset >= len(transactions) {
+			return []*domain.Transaction{}, nil
+		}
+		transactions = transactions[query.Offset:]
+	}
+
+	if query.Limit > 0 && len(transactions) > query.Limit {
+		transactions = transactions[:query.Limit]
+	}
+
+	return transactions, nil
+}
+
+func (r *InMemoryTransactionRepository) CountByQuery(ctx context.Context, query domain.TransactionQuery) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.matching(query)), nil
+}
+
+// SumBalanceChanges adds up how much the transactions matching a query filter changed the wallet balance
+func (r *InMemoryTransactionRepository) SumBalanceChanges(ctx context.Context, query domain.TransactionQuery) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sum := 0
+	for _, tx := range r.matching(query) {
+		sum += tx.BalanceChangeFor(query.WalletID)
+	}
+
+	return sum, nil
+}
+
+// matching copies the transactions of the queried wallet that pass its filter
+func (r *InMemoryTransactionRepository) matching(query domain.TransactionQuery) []*domain.Transaction {
+	var transactions []*domain.Transaction
+	for _, tx := range r.transactions {
+		if tx.WalletID != query.WalletID && (tx.ToWalletID == nil || *tx.ToWalletID != query.WalletID) {
+			continue
+		}
+		if !query.Filter.Matches(query.WalletID, tx) {
+			continue
+		}
+		txCopy := *tx
+		transactions = append(transactions, &txCopy)
+	}
+	return transactions
+}
+
 func (r *InMemoryTransactionRepository) FindByStatus(ctx context.Context, status domain.TransactionStatus, limit, offset int) ([]*domain.Transaction, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -74,6 +148,8 @@ func (r *InMemoryTransactionRepository) FindByStatus(ctx context.Context, status
 		}
 	}
 
+	sortNewestFirst(transactions)
+
 	// Apply pagination
 	start := offset
 	if start > len(transactions) {
@@ -165,3 +241,13 @@ func (r *InMemoryTransactionRepository) UpdateStatus(ctx context.Context, id int
 
 	return nil
 }
+
+// sortNewestFirst orders transactions like the Postgres repository
+func sortNewestFirst(transactions []*domain.Transaction) {
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].CreatedAt.Equal(transactions[j].CreatedAt) {
+			return transactions[i].ID > transactions[j].ID
+		}
+		return transactions[i].CreatedAt.After(transactions[j].CreatedAt)
+	})
+}
@@ -63,6 +63,20 @@ func (r *InMemoryUserRepository) FindByPhone(ctx context.Context, phone string)
 	return nil, nil
 }
 
+func (r *InMemoryUserRepository) FindByHandle(ctx context.Context, handle string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Handle != "" && user.Handle == handle {
+			userCopy := *user
+			return &userCopy, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func (r *InMemoryUserRepository) Save(ctx context.Context, user *domain.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
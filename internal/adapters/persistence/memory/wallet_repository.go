@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"ports-and-adapters-architecture/internal/domain"
+	"sort"
 	"sync"
 )
 
@@ -50,6 +51,29 @@ func (r *InMemoryWalletRepository) FindByUserID(ctx context.Context, userID int)
 	return wallets, nil
 }
 
+func (r *InMemoryWalletRepository) FindAfterID(ctx context.Context, afterID int, limit int) ([]*domain.Wallet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var wallets []*domain.Wallet
+	for _, wallet := range r.wallets {
+		if wallet.ID > afterID {
+			walletCopy := *wallet
+			wallets = append(wallets, &walletCopy)
+		}
+	}
+
+	sort.Slice(wallets, func(i, j int) bool {
+		return wallets[i].ID < wallets[j].ID
+	})
+
+	if len(wallets) > limit {
+		wallets = wallets[:limit]
+	}
+
+	return wallets, nil
+}
+
 func (r *InMemoryWalletRepository) Save(ctx context.Context, wallet *domain.Wallet) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
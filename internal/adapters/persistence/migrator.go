@@ -0,0 +1,326 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrationLockID is the Postgres advisory lock held while migrating, so
+// instances starting together do not apply the same migration twice
+const migrationLockID = 72_656_617_106
+
+// Migration is a versioned schema change and the statements undoing it
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports when a migration was applied, AppliedAt is nil
+// while it is pending
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// migrationDialect holds what differs between databases
+type migrationDialect struct {
+	createTable   string
+	insertVersion string
+	deleteVersion string
+	lock          func(ctx context.Context, conn *sql.Conn) error
+	unlock        func(ctx context.Context, conn *sql.Conn) error
+}
+
+var postgresMigrationDialect = migrationDialect{
+	createTable: `
+		CREATE TABLE IF NOT EXISTS schema_versions (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL
+		)
+	`,
+	insertVersion: `INSERT INTO schema_versions (version, name, applied_at) VALUES ($1, $2, $3)`,
+	deleteVersion: `DELETE FROM schema_versions WHERE version = $1`,
+	// The pool bounds every statement with database.operation_timeout. Waiting
+	// for another instance to finish and building large indexes take longer,
+	// so the locked session runs without it until the lock is released
+	lock: func(ctx context.Context, conn *sql.Conn) error {
+		if _, err := conn.ExecContext(ctx, `SET statement_timeout = 0`); err != nil {
+			return err
+		}
+
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+			_, _ = conn.ExecContext(context.WithoutCancel(ctx), `RESET statement_timeout`)
+			return err
+		}
+		return nil
+	},
+	unlock: func(ctx context.Context, conn *sql.Conn) error {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			return err
+		}
+
+		_, err := conn.ExecContext(ctx, `RESET statement_timeout`)
+		return err
+	},
+}
+
+// sqliteMigrationDialect takes no lock. SQLite runs one writer at a time, and
+// the version primary key fails a second attempt at the same migration
+var sqliteMigrationDialect = migrationDialect{
+	createTable: `
+		CREATE TABLE IF NOT EXISTS schema_versions (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL
+		)
+	`,
+	insertVersion: `INSERT INTO schema_versions (version, name, applied_at) VALUES (?, ?, ?)`,
+	deleteVersion: `DELETE FROM schema_versions WHERE version = ?`,
+	lock:          func(ctx context.Context, conn *sql.Conn) error { return nil },
+	unlock:        func(ctx context.Context, conn *sql.Conn) error { return nil },
+}
+
+// Migrator applies and rolls back the migrations of a database, recording
+// the applied versions in the schema_versions table
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	dialect    migrationDialect
+}
+
+// NewPostgresMigrator creates a migrator applying the migrations in fsys to a
+// PostgreSQL database
+func NewPostgresMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	return newMigrator(db, fsys, postgresMigrationDialect)
+}
+
+// NewSQLiteMigrator creates a migrator applying the migrations in fsys to a
+// SQLite database
+func NewSQLiteMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	return newMigrator(db, fsys, sqliteMigrationDialect)
+}
+
+func newMigrator(db *sql.DB, fsys fs.FS, dialect migrationDialect) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		dialect:    dialect,
+	}, nil
+}
+
+// LoadMigrations reads the NNN_name.up.sql and NNN_name.down.sql files of
+// fsys, ordered by version. Every version needs an up file, the down file is
+// only required to roll it back
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, file := range files {
+		base, direction, ok := strings.Cut(strings.TrimSuffix(file, ".sql"), ".")
+		versionStr, name, hasName := strings.Cut(base, "_")
+		version, err := strconv.Atoi(versionStr)
+		if !ok || !hasName || err != nil || version <= 0 || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name %q, expected NNN_name.up.sql or NNN_name.down.sql", file)
+		}
+
+		content, err := fs.ReadFile(fsys, path.Clean(file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", file, err)
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("migration %d has two names, %s and %s", version, migration.Name, name)
+		}
+
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up statements", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+
+	return migrations, nil
+}
+
+// Up applies every pending migration in version order, each in its own
+// transaction, and returns the ones applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			err := m.inTx(ctx, conn, migration.Up, m.dialect.insertVersion, migration.Version, migration.Name, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back the last steps applied migrations, newest first, and returns
+// the ones rolled back
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var rolledBack []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		applied := make([]int, 0, len(versions))
+		for version := range versions {
+			applied = append(applied, version)
+		}
+		slices.Sort(applied)
+		slices.Reverse(applied)
+
+		for _, version := range applied[:min(steps, len(applied))] {
+			i := slices.IndexFunc(m.migrations, func(migration Migration) bool { return migration.Version == version })
+			if i < 0 {
+				return fmt.Errorf("applied migration %d is unknown to this build", version)
+			}
+
+			migration := m.migrations[i]
+			if strings.TrimSpace(migration.Down) == "" {
+				return fmt.Errorf("migration %d_%s cannot be rolled back, it has no down statements", migration.Version, migration.Name)
+			}
+
+			if err := m.inTx(ctx, conn, migration.Down, m.dialect.deleteVersion, migration.Version); err != nil {
+				return fmt.Errorf("failed to roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+		return nil
+	})
+
+	return rolledBack, err
+}
+
+// Status lists every known migration with the time it was applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a connection: %w", err)
+	}
+	defer conn.Close()
+
+	versions, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i].Migration = migration
+		if appliedAt, ok := versions[migration.Version]; ok {
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// withLock runs fn on a single connection holding the migration lock, which
+// belongs to the session that took it
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get a connection: %w", err)
+	}
+	defer conn.Close()
+
+	if err := m.dialect.lock(ctx, conn); err != nil {
+		return fmt.Errorf("failed to acquire the migration lock: %w", err)
+	}
+	defer m.dialect.unlock(context.WithoutCancel(ctx), conn)
+
+	return fn(conn)
+}
+
+// appliedVersions returns when each applied version was applied, creating the
+// version table on first use
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+	if _, err := conn.ExecContext(ctx, m.dialect.createTable); err != nil {
+		return nil, fmt.Errorf("failed to create the schema_versions table: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_versions`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		versions[version] = appliedAt
+	}
+
+	return versions, rows.Err()
+}
+
+// inTx runs the statements of a migration and records the version change in
+// one transaction, so a failed migration leaves nothing behind
+func (m *Migrator) inTx(ctx context.Context, conn *sql.Conn, statements, record string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, statements); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return fmt.Errorf("failed to record the schema version: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// PostgresBalanceSnapshotRepository implements the BalanceSnapshotRepository interface for PostgreSQL
+type PostgresBalanceSnapshotRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresBalanceSnapshotRepository creates a new PostgreSQL balance snapshot repository
+func NewPostgresBalanceSnapshotRepository(db *sql.DB) *PostgresBalanceSnapshotRepository {
+	return &PostgresBalanceSnapshotRepository{
+		db: db,
+	}
+}
+
+// Save stores a snapshot, replacing any snapshot of the wallet taken at the same time
+func (r *PostgresBalanceSnapshotRepository) Save(ctx context.Context, snapshot *domain.BalanceSnapshot) error {
+	query := `
+		INSERT INTO balance_snapshots (wallet_id, balance, currency_code, taken_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (wallet_id, taken_at)
+		DO UPDATE SET balance = EXCLUDED.balance, created_at = EXCLUDED.created_at
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		snapshot.WalletID,
+		snapshot.Balance,
+		snapshot.CurrencyCode,
+		snapshot.TakenAt,
+		snapshot.CreatedAt,
+	).Scan(&snapshot.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save balance snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// FindLatest retrieves the latest snapshot of a wallet taken at or before a time
+func (r *PostgresBalanceSnapshotRepository) FindLatest(ctx context.Context, walletID int, at time.Time) (*domain.BalanceSnapshot, error) {
+	query := `
+		SELECT id, wallet_id, balance, currency_code, taken_at, created_at
+		FROM balance_snapshots
+		WHERE wallet_id = $1 AND taken_at <= $2
+		ORDER BY taken_at DESC
+		LIMIT 1
+	`
+
+	var snapshot domain.BalanceSnapshot
+	err := r.db.QueryRowContext(ctx, query, walletID, at).Scan(
+		&snapshot.ID,
+		&snapshot.WalletID,
+		&snapshot.Balance,
+		&snapshot.CurrencyCode,
+		&snapshot.TakenAt,
+		&snapshot.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query balance snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,250 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// PostgresBatchTransferRepository implements the BatchTransferRepository interface for PostgreSQL
+type PostgresBatchTransferRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresBatchTransferRepository creates a new PostgreSQL batch transfer repository
+func NewPostgresBatchTransferRepository(db *sql.DB) *PostgresBatchTransferRepository {
+	return &PostgresBatchTransferRepository{
+		db: db,
+	}
+}
+
+const batchTransferColumns = `
+	id, from_wallet_id, mode, status, description, total_amount, succeeded_count, failed_count,
+	items, created_at, updated_at, completed_at
+`
+
+// FindByID retrieves a batch transfer with its items by ID
+func (r *PostgresBatchTransferRepository) FindByID(ctx context.Context, id int) (*domain.BatchTransfer, error) {
+	query := `SELECT ` + batchTransferColumns + ` FROM batch_transfers WHERE id = $1`
+
+	batch, err := scanBatchTransfer(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query batch transfer by ID: %w", err)
+	}
+
+	return batch, nil
+}
+
+// FindByWalletID retrieves batch transfers sent from a wallet, newest first
+func (r *PostgresBatchTransferRepository) FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.BatchTransfer, error) {
+	query := `SELECT ` + batchTransferColumns + `
+		FROM batch_transfers
+		WHERE from_wallet_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	batches, err := r.query(ctx, query, walletID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query batch transfers by wallet ID: %w", err)
+	}
+	return batches, nil
+}
+
+// FindUnfinished retrieves up to limit pending or processing batch transfers
+// last updated before updatedBefore, oldest first
+func (r *PostgresBatchTransferRepository) FindUnfinished(ctx context.Context, updatedBefore time.Time, limit int) ([]*domain.BatchTransfer, error) {
+	query := `SELECT ` + batchTransferColumns + `
+		FROM batch_transfers
+		WHERE status IN ($1, $2) AND updated_at < $3
+		ORDER BY updated_at
+		LIMIT $4
+	`
+
+	batches, err := r.query(ctx, query,
+		string(domain.BatchTransferStatusPending), string(domain.BatchTransferStatusProcessing), updatedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unfinished batch transfers: %w", err)
+	}
+	return batches, nil
+}
+
+// Claim touches a pending or processing batch transfer only while it was
+// still last updated before updatedBefore
+func (r *PostgresBatchTransferRepository) Claim(ctx context.Context, id int, updatedBefore time.Time) (bool, error) {
+	query := `
+		UPDATE batch_transfers
+		SET updated_at = $1
+		WHERE id = $2 AND status IN ($3, $4) AND updated_at < $5
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		time.Now(), id,
+		string(domain.BatchTransferStatusPending), string(domain.BatchTransferStatusProcessing), updatedBefore)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim batch transfer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// query runs a batch transfer query and scans every row
+func (r *PostgresBatchTransferRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.BatchTransfer, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batches []*domain.BatchTransfer
+
+	for rows.Next() {
+		batch, err := scanBatchTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan batch transfer row: %w", err)
+		}
+
+		batches = append(batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating batch transfer rows: %w", err)
+	}
+
+	return batches, nil
+}
+
+// Create saves a new batch transfer
+func (r *PostgresBatchTransferRepository) Create(ctx context.Context, batch *domain.BatchTransfer) error {
+	query := `
+		INSERT INTO batch_transfers (from_wallet_id, mode, status, description, total_amount, items,
+		                             created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	itemsJSON, err := json.Marshal(batch.Items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch transfer items: %w", err)
+	}
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		batch.FromWalletID,
+		string(batch.Mode),
+		string(batch.Status),
+		batch.Description,
+		batch.TotalAmount,
+		itemsJSON,
+		batch.CreatedAt,
+		batch.UpdatedAt,
+	).Scan(&batch.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert batch transfer: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing batch transfer and its items
+func (r *PostgresBatchTransferRepository) Update(ctx context.Context, batch *domain.BatchTransfer) error {
+	query := `
+		UPDATE batch_transfers
+		SET status = $1, succeeded_count = $2, failed_count = $3, items = $4, updated_at = $5, completed_at = $6
+		WHERE id = $7
+	`
+
+	itemsJSON, err := json.Marshal(batch.Items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch transfer items: %w", err)
+	}
+
+	batch.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		string(batch.Status),
+		batch.SucceededCount,
+		batch.FailedCount,
+		itemsJSON,
+		batch.UpdatedAt,
+		sql.NullTime{Time: safeDerefTime(batch.CompletedAt), Valid: batch.CompletedAt != nil},
+		batch.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update batch transfer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("batch transfer not found: %d", batch.ID)
+	}
+
+	return nil
+}
+
+func scanBatchTransfer(row rowScanner) (*domain.BatchTransfer, error) {
+	var batch domain.BatchTransfer
+	var modeStr, statusStr string
+	var description sql.NullString
+	var itemsJSON []byte
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&batch.ID,
+		&batch.FromWalletID,
+		&modeStr,
+		&statusStr,
+		&description,
+		&batch.TotalAmount,
+		&batch.SucceededCount,
+		&batch.FailedCount,
+		&itemsJSON,
+		&batch.CreatedAt,
+		&batch.UpdatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	batch.Mode = domain.BatchTransferMode(modeStr)
+	batch.Status = domain.BatchTransferStatus(statusStr)
+
+	if description.Valid {
+		batch.Description = description.String
+	}
+
+	if completedAt.Valid {
+		batch.CompletedAt = &completedAt.Time
+	}
+
+	batch.Items = []domain.BatchTransferItem{}
+	if len(itemsJSON) > 0 {
+		if err := json.Unmarshal(itemsJSON, &batch.Items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal batch transfer items: %w", err)
+		}
+	}
+
+	return &batch, nil
+}
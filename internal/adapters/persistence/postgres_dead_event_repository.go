@@ -0,0 +1,173 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// PostgresDeadEventRepository implements the DeadEventRepository interface for PostgreSQL
+type PostgresDeadEventRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDeadEventRepository creates a new PostgreSQL dead event repository
+func NewPostgresDeadEventRepository(db *sql.DB) *PostgresDeadEventRepository {
+	return &PostgresDeadEventRepository{
+		db: db,
+	}
+}
+
+// Create stores a new dead event
+func (r *PostgresDeadEventRepository) Create(ctx context.Context, event *domain.DeadEvent) error {
+	query := `
+		INSERT INTO dead_events (topic, event_id, event_type, payload, event_time, error, failed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	payloadJSON, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		event.Topic,
+		sql.NullString{String: event.EventID, Valid: event.EventID != ""},
+		event.EventType,
+		payloadJSON,
+		event.EventTime,
+		event.Error,
+		event.FailedAt,
+	).Scan(&event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to insert dead event: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a dead event by ID
+func (r *PostgresDeadEventRepository) FindByID(ctx context.Context, id int) (*domain.DeadEvent, error) {
+	query := `
+		SELECT id, topic, event_id, event_type, payload, event_time, error, failed_at, redriven_at
+		FROM dead_events
+		WHERE id = $1
+	`
+
+	event, err := scanDeadEvent(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query dead event: %w", err)
+	}
+
+	return event, nil
+}
+
+// FindPending retrieves dead events not yet redriven, oldest first
+func (r *PostgresDeadEventRepository) FindPending(ctx context.Context, limit int) ([]*domain.DeadEvent, error) {
+	query := `
+		SELECT id, topic, event_id, event_type, payload, event_time, error, failed_at, redriven_at
+		FROM dead_events
+		WHERE redriven_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dead events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.DeadEvent
+	for rows.Next() {
+		event, err := scanDeadEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dead events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Update updates an existing dead event
+func (r *PostgresDeadEventRepository) Update(ctx context.Context, event *domain.DeadEvent) error {
+	query := `
+		UPDATE dead_events
+		SET error = $1, redriven_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		event.Error,
+		sql.NullTime{Time: safeDerefTime(event.RedrivenAt), Valid: event.RedrivenAt != nil},
+		event.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update dead event: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("dead event not found: %d", event.ID)
+	}
+
+	return nil
+}
+
+func scanDeadEvent(row rowScanner) (*domain.DeadEvent, error) {
+	var event domain.DeadEvent
+	var eventID sql.NullString
+	var payloadJSON []byte
+	var redrivenAt sql.NullTime
+
+	err := row.Scan(
+		&event.ID,
+		&event.Topic,
+		&eventID,
+		&event.EventType,
+		&payloadJSON,
+		&event.EventTime,
+		&event.Error,
+		&event.FailedAt,
+		&redrivenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if eventID.Valid {
+		event.EventID = eventID.String
+	}
+
+	if redrivenAt.Valid {
+		event.RedrivenAt = &redrivenAt.Time
+	}
+
+	if len(payloadJSON) > 0 {
+		if err := json.Unmarshal(payloadJSON, &event.Payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event payload: %w", err)
+		}
+	}
+
+	return &event, nil
+}
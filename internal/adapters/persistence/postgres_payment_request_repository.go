@@ -0,0 +1,261 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// PostgresPaymentRequestRepository implements the PaymentRequestRepository interface for PostgreSQL
+type PostgresPaymentRequestRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPaymentRequestRepository creates a new PostgreSQL payment request repository
+func NewPostgresPaymentRequestRepository(db *sql.DB) *PostgresPaymentRequestRepository {
+	return &PostgresPaymentRequestRepository{
+		db: db,
+	}
+}
+
+const paymentRequestColumns = `
+	id, requester_wallet_id, requester_user_id, payer_user_id, payer_wallet_id, amount, currency_code,
+	note, status, transaction_id, expires_at, created_at, updated_at, responded_at
+`
+
+// FindByID retrieves a payment request by its ID
+func (r *PostgresPaymentRequestRepository) FindByID(ctx context.Context, id int) (*domain.PaymentRequest, error) {
+	query := `SELECT ` + paymentRequestColumns + ` FROM payment_requests WHERE id = $1`
+
+	request, err := scanPaymentRequest(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query payment request by ID: %w", err)
+	}
+
+	return request, nil
+}
+
+// FindByPayerUserID retrieves requests addressed to a user, newest first
+func (r *PostgresPaymentRequestRepository) FindByPayerUserID(
+	ctx context.Context,
+	userID int,
+	status domain.PaymentRequestStatus,
+	limit, offset int,
+) ([]*domain.PaymentRequest, error) {
+	return r.findByUser(ctx, "payer_user_id", userID, status, limit, offset)
+}
+
+// FindByRequesterUserID retrieves requests sent by a user, newest first
+func (r *PostgresPaymentRequestRepository) FindByRequesterUserID(
+	ctx context.Context,
+	userID int,
+	status domain.PaymentRequestStatus,
+	limit, offset int,
+) ([]*domain.PaymentRequest, error) {
+	return r.findByUser(ctx, "requester_user_id", userID, status, limit, offset)
+}
+
+// findByUser lists requests by one of the user columns. column is never user input
+func (r *PostgresPaymentRequestRepository) findByUser(
+	ctx context.Context,
+	column string,
+	userID int,
+	status domain.PaymentRequestStatus,
+	limit, offset int,
+) ([]*domain.PaymentRequest, error) {
+	query := `SELECT ` + paymentRequestColumns + `
+		FROM payment_requests
+		WHERE ` + column + ` = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, string(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payment requests by user ID: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPaymentRequests(rows)
+}
+
+// FindExpired retrieves pending requests that expired before a specified time
+func (r *PostgresPaymentRequestRepository) FindExpired(ctx context.Context, before time.Time, limit int) ([]*domain.PaymentRequest, error) {
+	query := `SELECT ` + paymentRequestColumns + `
+		FROM payment_requests
+		WHERE status = $1 AND expires_at <= $2
+		ORDER BY expires_at
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, string(domain.PaymentRequestStatusPending), before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired payment requests: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPaymentRequests(rows)
+}
+
+// FindStaleProcessing retrieves requests claimed by an accept that were last
+// updated before a specified time, oldest first
+func (r *PostgresPaymentRequestRepository) FindStaleProcessing(ctx context.Context, updatedBefore time.Time, limit int) ([]*domain.PaymentRequest, error) {
+	query := `SELECT ` + paymentRequestColumns + `
+		FROM payment_requests
+		WHERE status = $1 AND updated_at < $2
+		ORDER BY updated_at
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, string(domain.PaymentRequestStatusProcessing), updatedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale processing payment requests: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPaymentRequests(rows)
+}
+
+// Create saves a new payment request
+func (r *PostgresPaymentRequestRepository) Create(ctx context.Context, request *domain.PaymentRequest) error {
+	query := `
+		INSERT INTO payment_requests (requester_wallet_id, requester_user_id, payer_user_id, amount, currency_code,
+		                              note, status, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		request.RequesterWalletID,
+		request.RequesterUserID,
+		request.PayerUserID,
+		request.Amount,
+		request.CurrencyCode,
+		sql.NullString{String: request.Note, Valid: request.Note != ""},
+		string(request.Status),
+		request.ExpiresAt,
+		request.CreatedAt,
+		request.UpdatedAt,
+	).Scan(&request.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert payment request: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateIfStatus updates a payment request only while its stored status still equals expected
+func (r *PostgresPaymentRequestRepository) UpdateIfStatus(
+	ctx context.Context,
+	request *domain.PaymentRequest,
+	expected domain.PaymentRequestStatus,
+) (bool, error) {
+	query := `
+		UPDATE payment_requests
+		SET status = $1, payer_wallet_id = $2, transaction_id = $3, updated_at = $4, responded_at = $5
+		WHERE id = $6 AND status = $7
+	`
+
+	request.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		string(request.Status),
+		sql.NullInt64{Int64: int64(safeDeref(request.PayerWalletID)), Valid: request.PayerWalletID != nil},
+		sql.NullInt64{Int64: int64(safeDeref(request.TransactionID)), Valid: request.TransactionID != nil},
+		request.UpdatedAt,
+		sql.NullTime{Time: safeDerefTime(request.RespondedAt), Valid: request.RespondedAt != nil},
+		request.ID,
+		string(expected),
+	)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to update payment request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func scanPaymentRequest(row rowScanner) (*domain.PaymentRequest, error) {
+	var request domain.PaymentRequest
+	var statusStr string
+	var payerWalletID, transactionID sql.NullInt64
+	var note sql.NullString
+	var respondedAt sql.NullTime
+
+	err := row.Scan(
+		&request.ID,
+		&request.RequesterWalletID,
+		&request.RequesterUserID,
+		&request.PayerUserID,
+		&payerWalletID,
+		&request.Amount,
+		&request.CurrencyCode,
+		&note,
+		&statusStr,
+		&transactionID,
+		&request.ExpiresAt,
+		&request.CreatedAt,
+		&request.UpdatedAt,
+		&respondedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Status = domain.PaymentRequestStatus(statusStr)
+
+	if payerWalletID.Valid {
+		id := int(payerWalletID.Int64)
+		request.PayerWalletID = &id
+	}
+
+	if transactionID.Valid {
+		id := int(transactionID.Int64)
+		request.TransactionID = &id
+	}
+
+	if note.Valid {
+		request.Note = note.String
+	}
+
+	if respondedAt.Valid {
+		request.RespondedAt = &respondedAt.Time
+	}
+
+	return &request, nil
+}
+
+func scanPaymentRequests(rows *sql.Rows) ([]*domain.PaymentRequest, error) {
+	var requests []*domain.PaymentRequest
+
+	for rows.Next() {
+		request, err := scanPaymentRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment request row: %w", err)
+		}
+
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment request rows: %w", err)
+	}
+
+	return requests, nil
+}
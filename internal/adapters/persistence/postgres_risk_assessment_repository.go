@@ -0,0 +1,242 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// PostgresRiskAssessmentRepository implements the RiskAssessmentRepository interface for PostgreSQL
+type PostgresRiskAssessmentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRiskAssessmentRepository creates a new PostgreSQL risk assessment repository
+func NewPostgresRiskAssessmentRepository(db *sql.DB) *PostgresRiskAssessmentRepository {
+	return &PostgresRiskAssessmentRepository{
+		db: db,
+	}
+}
+
+const riskAssessmentColumns = `
+	id, wallet_id, transaction_id, transaction_type, amount, to_wallet_id, decision, rule_hits,
+	review_status, reviewed_by, review_note, created_at, updated_at, reviewed_at
+`
+
+// FindByID retrieves a risk assessment by its ID
+func (r *PostgresRiskAssessmentRepository) FindByID(ctx context.Context, id int) (*domain.RiskAssessment, error) {
+	query := `SELECT ` + riskAssessmentColumns + ` FROM risk_assessments WHERE id = $1`
+
+	assessment, err := scanRiskAssessment(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query risk assessment by ID: %w", err)
+	}
+
+	return assessment, nil
+}
+
+// FindByWalletID retrieves risk assessments for a wallet, newest first
+func (r *PostgresRiskAssessmentRepository) FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.RiskAssessment, error) {
+	query := `SELECT ` + riskAssessmentColumns + `
+		FROM risk_assessments
+		WHERE wallet_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, walletID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query risk assessments by wallet ID: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRiskAssessments(rows)
+}
+
+// FindByReviewStatus retrieves risk assessments by review status, oldest first
+func (r *PostgresRiskAssessmentRepository) FindByReviewStatus(ctx context.Context, status domain.RiskReviewStatus, limit, offset int) ([]*domain.RiskAssessment, error) {
+	query := `SELECT ` + riskAssessmentColumns + `
+		FROM risk_assessments
+		WHERE review_status = $1
+		ORDER BY created_at
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, string(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query risk assessments by review status: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRiskAssessments(rows)
+}
+
+// Create saves a new risk assessment
+func (r *PostgresRiskAssessmentRepository) Create(ctx context.Context, assessment *domain.RiskAssessment) error {
+	query := `
+		INSERT INTO risk_assessments (wallet_id, transaction_id, transaction_type, amount, to_wallet_id,
+		                              decision, rule_hits, review_status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	ruleHitsJSON, err := json.Marshal(assessment.RuleHits)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rule hits: %w", err)
+	}
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		assessment.WalletID,
+		sql.NullInt64{Int64: int64(safeDeref(assessment.TransactionID)), Valid: assessment.TransactionID != nil},
+		string(assessment.TransactionType),
+		assessment.Amount,
+		sql.NullInt64{Int64: int64(safeDeref(assessment.ToWalletID)), Valid: assessment.ToWalletID != nil},
+		string(assessment.Decision),
+		ruleHitsJSON,
+		string(assessment.ReviewStatus),
+		assessment.CreatedAt,
+		assessment.UpdatedAt,
+	).Scan(&assessment.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert risk assessment: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing risk assessment
+func (r *PostgresRiskAssessmentRepository) Update(ctx context.Context, assessment *domain.RiskAssessment) error {
+	query := `
+		UPDATE risk_assessments
+		SET transaction_id = $1, review_status = $2, reviewed_by = $3, review_note = $4,
+		    updated_at = $5, reviewed_at = $6
+		WHERE id = $7
+	`
+
+	assessment.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		sql.NullInt64{Int64: int64(safeDeref(assessment.TransactionID)), Valid: assessment.TransactionID != nil},
+		string(assessment.ReviewStatus),
+		sql.NullString{String: assessment.ReviewedBy, Valid: assessment.ReviewedBy != ""},
+		sql.NullString{String: assessment.ReviewNote, Valid: assessment.ReviewNote != ""},
+		assessment.UpdatedAt,
+		sql.NullTime{Time: safeDerefTime(assessment.ReviewedAt), Valid: assessment.ReviewedAt != nil},
+		assessment.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update risk assessment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("risk assessment not found: %d", assessment.ID)
+	}
+
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRiskAssessment(row rowScanner) (*domain.RiskAssessment, error) {
+	var assessment domain.RiskAssessment
+	var typeStr, decisionStr, reviewStatusStr string
+	var transactionID, toWalletID sql.NullInt64
+	var reviewedBy, reviewNote sql.NullString
+	var ruleHitsJSON []byte
+	var reviewedAt sql.NullTime
+
+	err := row.Scan(
+		&assessment.ID,
+		&assessment.WalletID,
+		&transactionID,
+		&typeStr,
+		&assessment.Amount,
+		&toWalletID,
+		&decisionStr,
+		&ruleHitsJSON,
+		&reviewStatusStr,
+		&reviewedBy,
+		&reviewNote,
+		&assessment.CreatedAt,
+		&assessment.UpdatedAt,
+		&reviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	assessment.TransactionType = domain.TransactionType(typeStr)
+	assessment.Decision = domain.RiskDecision(decisionStr)
+	assessment.ReviewStatus = domain.RiskReviewStatus(reviewStatusStr)
+
+	if transactionID.Valid {
+		id := int(transactionID.Int64)
+		assessment.TransactionID = &id
+	}
+
+	if toWalletID.Valid {
+		id := int(toWalletID.Int64)
+		assessment.ToWalletID = &id
+	}
+
+	if reviewedBy.Valid {
+		assessment.ReviewedBy = reviewedBy.String
+	}
+
+	if reviewNote.Valid {
+		assessment.ReviewNote = reviewNote.String
+	}
+
+	if reviewedAt.Valid {
+		assessment.ReviewedAt = &reviewedAt.Time
+	}
+
+	assessment.RuleHits = []domain.RiskRuleHit{}
+	if len(ruleHitsJSON) > 0 {
+		if err := json.Unmarshal(ruleHitsJSON, &assessment.RuleHits); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rule hits: %w", err)
+		}
+	}
+
+	return &assessment, nil
+}
+
+func scanRiskAssessments(rows *sql.Rows) ([]*domain.RiskAssessment, error) {
+	var assessments []*domain.RiskAssessment
+
+	for rows.Next() {
+		assessment, err := scanRiskAssessment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan risk assessment row: %w", err)
+		}
+
+		assessments = append(assessments, assessment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating risk assessment rows: %w", err)
+	}
+
+	return assessments, nil
+}
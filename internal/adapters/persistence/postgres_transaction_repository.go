@@ -6,7 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"ports-and-adapters-architecture/internal/domain"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // PostgresTransactionRepository implements the TransactionRepository interface for PostgreSQL
@@ -24,15 +28,15 @@ func NewPostgresTransactionRepository(db *sql.DB) *PostgresTransactionRepository
 // FindByID retrieves a transaction by its ID
 func (r *PostgresTransactionRepository) FindByID(ctx context.Context, id int) (*domain.Transaction, error) {
 	query := `
-		SELECT id, wallet_id, type, amount, status, reference, description, to_wallet_id, 
-		       created_at, updated_at, completed_at
+		SELECT id, wallet_id, type, amount, fee, status, reference, description, to_wallet_id, 
+		       created_at, updated_at, completed_at, status_reason, status_actor
 		FROM transactions
 		WHERE id = $1
 	`
 
 	var transaction domain.Transaction
 	var typeStr, statusStr string
-	var reference, description sql.NullString
+	var reference, description, statusReason, statusActor sql.NullString
 	var toWalletID sql.NullInt64
 	var completedAt sql.NullTime
 
@@ -41,6 +45,7 @@ func (r *PostgresTransactionRepository) FindByID(ctx context.Context, id int) (*
 		&transaction.WalletID,
 		&typeStr,
 		&transaction.Amount,
+		&transaction.Fee,
 		&statusStr,
 		&reference,
 		&description,
@@ -48,6 +53,8 @@ func (r *PostgresTransactionRepository) FindByID(ctx context.Context, id int) (*
 		&transaction.CreatedAt,
 		&transaction.UpdatedAt,
 		&completedAt,
+		&statusReason,
+		&statusActor,
 	)
 
 	if err != nil {
@@ -77,17 +84,19 @@ func (r *PostgresTransactionRepository) FindByID(ctx context.Context, id int) (*
 		transaction.CompletedAt = &completedAt.Time
 	}
 
+	setTransactionStatusChange(&transaction, statusReason, statusActor)
+
 	return &transaction, nil
 }
 
 // FindByWalletID retrieves all transactions for a wallet
 func (r *PostgresTransactionRepository) FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.Transaction, error) {
 	query := `
-		SELECT id, wallet_id, type, amount, status, reference, description, to_wallet_id, 
-		       created_at, updated_at, completed_at
+		SELECT id, wallet_id, type, amount, fee, status, reference, description, to_wallet_id, 
+		       created_at, updated_at, completed_at, status_reason, status_actor
 		FROM transactions
 		WHERE wallet_id = $1 OR to_wallet_id = $1
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 		LIMIT $2 OFFSET $3
 	`
 
@@ -102,7 +111,7 @@ func (r *PostgresTransactionRepository) FindByWalletID(ctx context.Context, wall
 	for rows.Next() {
 		var transaction domain.Transaction
 		var typeStr, statusStr string
-		var reference, description sql.NullString
+		var reference, description, statusReason, statusActor sql.NullString
 		var toWalletID sql.NullInt64
 		var completedAt sql.NullTime
 
@@ -111,6 +120,7 @@ func (r *PostgresTransactionRepository) FindByWalletID(ctx context.Context, wall
 			&transaction.WalletID,
 			&typeStr,
 			&transaction.Amount,
+			&transaction.Fee,
 			&statusStr,
 			&reference,
 			&description,
@@ -118,6 +128,8 @@ func (r *PostgresTransactionRepository) FindByWalletID(ctx context.Context, wall
 			&transaction.CreatedAt,
 			&transaction.UpdatedAt,
 			&completedAt,
+			&statusReason,
+			&statusActor,
 		)
 
 		if err != nil {
@@ -144,6 +156,8 @@ func (r *PostgresTransactionRepository) FindByWalletID(ctx context.Context, wall
 			transaction.CompletedAt = &completedAt.Time
 		}
 
+		setTransactionStatusChange(&transaction, statusReason, statusActor)
+
 		transactions = append(transactions, &transaction)
 	}
 
@@ -154,11 +168,235 @@ func (r *PostgresTransactionRepository) FindByWalletID(ctx context.Context, wall
 	return transactions, nil
 }
 
+// FindByQuery retrieves the transactions of a wallet matching a query in creation
+// order. After a cursor the position does not shift as new rows arrive, unlike offsets
+func (r *PostgresTransactionRepository) FindByQuery(ctx context.Context, query domain.TransactionQuery) ([]*domain.Transaction, error) {
+	conditions, args := transactionQueryConditions(query)
+
+	order, after := "DESC", "<"
+	if query.OldestFirst {
+		order, after = "ASC", ">"
+	}
+
+	// The row comparison follows the (created_at, id) order of the wallet indexes
+	if query.After != nil {
+		args = append(args, query.After.CreatedAt, query.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) %s ($%d, $%d)", after, len(args)-1, len(args)))
+	}
+
+	limit := "ALL"
+	if query.Limit > 0 {
+		limit = strconv.Itoa(query.Limit)
+	}
+
+	offset := 0
+	if query.After == nil {
+		offset = query.Offset
+	}
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT id, wallet_id, type, amount, fee, status, reference, description, to_wallet_id,
+		       created_at, updated_at, completed_at, status_reason, status_actor
+		FROM transactions
+		WHERE %s
+		ORDER BY created_at %[2]s, id %[2]s
+		LIMIT %[3]s OFFSET %[4]d
+	`, strings.Join(conditions, " AND "), order, limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transactions by wallet ID: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*domain.Transaction
+
+	for rows.Next() {
+		var transaction domain.Transaction
+		var typeStr, statusStr string
+		var reference, description, statusReason, statusActor sql.NullString
+		var toWalletID sql.NullInt64
+		var completedAt sql.NullTime
+
+		err := rows.Scan(
+			&transaction.ID,
+			&transaction.WalletID,
+			&typeStr,
+			&transaction.Amount,
+			&transaction.Fee,
+			&statusStr,
+			&reference,
+			&description,
+			&toWalletID,
+			&transaction.CreatedAt,
+			&transaction.UpdatedAt,
+			&completedAt,
+			&statusReason,
+			&statusActor,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction row: %w", err)
+		}
+
+		transaction.Type = domain.TransactionType(typeStr)
+		transaction.Status = domain.TransactionStatus(statusStr)
+
+		if reference.Valid {
+			transaction.Reference = reference.String
+		}
+
+		if description.Valid {
+			transaction.Description = description.String
+		}
+
+		if toWalletID.Valid {
+			id := int(toWalletID.Int64)
+			transaction.ToWalletID = &id
+		}
+
+		if completedAt.Valid {
+			transaction.CompletedAt = &completedAt.Time
+		}
+
+		setTransactionStatusChange(&transaction, statusReason, statusActor)
+
+		transactions = append(transactions, &transaction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transaction rows: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// CountByQuery counts the transactions of a wallet matching a query filter
+func (r *PostgresTransactionRepository) CountByQuery(ctx context.Context, query domain.TransactionQuery) (int, error) {
+	conditions, args := transactionQueryConditions(query)
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM transactions
+		WHERE %s
+	`, strings.Join(conditions, " AND "))
+
+	var count int
+	err := r.db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transactions by query: %w", err)
+	}
+
+	return count, nil
+}
+
+// SumBalanceChanges adds up how much the transactions matching a query filter
+// changed the wallet balance. The CASE mirrors Transaction.BalanceChangeFor
+func (r *PostgresTransactionRepository) SumBalanceChanges(ctx context.Context, query domain.TransactionQuery) (int, error) {
+	conditions, args := transactionQueryConditions(query)
+	conditions = append(conditions, fmt.Sprintf("status = '%s'", domain.TransactionStatusCompleted))
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT COALESCE(SUM(
+			CASE
+				WHEN to_wallet_id = $1 THEN amount
+				WHEN type = '%s' THEN amount - fee
+				WHEN type IN ('%s', '%s') THEN -(amount + fee)
+				ELSE 0
+			END
+		), 0)
+		FROM transactions
+		WHERE %s
+	`, domain.TransactionTypeDeposit, domain.TransactionTypeWithdrawal, domain.TransactionTypeTransfer,
+		strings.Join(conditions, " AND "))
+
+	var sum int
+	err := r.db.QueryRowContext(ctx, sqlQuery, args...).Scan(&sum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum transaction balance changes: %w", err)
+	}
+
+	return sum, nil
+}
+
+// transactionQueryConditions translates the wallet and filter of a query into
+// SQL conditions with their numbered arguments
+func transactionQueryConditions(query domain.TransactionQuery) ([]string, []interface{}) {
+	args := []interface{}{query.WalletID}
+	conditions := []string{"(wallet_id = $1 OR to_wallet_id = $1)"}
+
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	filter := query.Filter
+
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.From))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.To))
+	}
+
+	if len(filter.Types) > 0 {
+		types := make([]string, len(filter.Types))
+		for i, t := range filter.Types {
+			types[i] = string(t)
+		}
+		conditions = append(conditions, "type = ANY("+arg(pq.Array(types))+")")
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, s := range filter.Statuses {
+			statuses[i] = string(s)
+		}
+		conditions = append(conditions, "status = ANY("+arg(pq.Array(statuses))+")")
+	}
+
+	if filter.MinAmount != nil {
+		conditions = append(conditions, "amount >= "+arg(*filter.MinAmount))
+	}
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, "amount <= "+arg(*filter.MaxAmount))
+	}
+
+	if filter.CounterpartyWalletID != nil {
+		counterparty := arg(*filter.CounterpartyWalletID)
+		conditions = append(conditions, fmt.Sprintf(
+			"((wallet_id = $1 AND to_wallet_id = %[1]s) OR (to_wallet_id = $1 AND wallet_id = %[1]s))", counterparty))
+	}
+
+	// Mirrors Transaction.DirectionFor
+	switch filter.Direction {
+	case domain.TransactionDirectionIn:
+		conditions = append(conditions, "(to_wallet_id = $1 OR type = "+arg(string(domain.TransactionTypeDeposit))+")")
+	case domain.TransactionDirectionOut:
+		conditions = append(conditions, "(to_wallet_id IS DISTINCT FROM $1 AND type <> "+arg(string(domain.TransactionTypeDeposit))+")")
+	}
+
+	if filter.Description != "" {
+		conditions = append(conditions, "description ILIKE "+arg("%"+escapeLike(filter.Description)+"%"))
+	}
+
+	if filter.Reference != "" {
+		conditions = append(conditions, "reference = "+arg(filter.Reference))
+	}
+
+	return conditions, args
+}
+
+// escapeLike escapes the LIKE wildcards of a search term
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
+}
+
 // FindByStatus retrieves transactions by status with optional pagination
 func (r *PostgresTransactionRepository) FindByStatus(ctx context.Context, status domain.TransactionStatus, limit, offset int) ([]*domain.Transaction, error) {
 	query := `
-		SELECT id, wallet_id, type, amount, status, reference, description, to_wallet_id, 
-		       created_at, updated_at, completed_at
+		SELECT id, wallet_id, type, amount, fee, status, reference, description, to_wallet_id, 
+		       created_at, updated_at, completed_at, status_reason, status_actor
 		FROM transactions
 		WHERE status = $1
 		ORDER BY created_at DESC
@@ -176,7 +414,7 @@ func (r *PostgresTransactionRepository) FindByStatus(ctx context.Context, status
 	for rows.Next() {
 		var transaction domain.Transaction
 		var typeStr, statusStr string
-		var reference, description sql.NullString
+		var reference, description, statusReason, statusActor sql.NullString
 		var toWalletID sql.NullInt64
 		var completedAt sql.NullTime
 
@@ -185,6 +423,7 @@ func (r *PostgresTransactionRepository) FindByStatus(ctx context.Context, status
 			&transaction.WalletID,
 			&typeStr,
 			&transaction.Amount,
+			&transaction.Fee,
 			&statusStr,
 			&reference,
 			&description,
@@ -192,6 +431,8 @@ func (r *PostgresTransactionRepository) FindByStatus(ctx context.Context, status
 			&transaction.CreatedAt,
 			&transaction.UpdatedAt,
 			&completedAt,
+			&statusReason,
+			&statusActor,
 		)
 
 		if err != nil {
@@ -218,6 +459,8 @@ func (r *PostgresTransactionRepository) FindByStatus(ctx context.Context, status
 			transaction.CompletedAt = &completedAt.Time
 		}
 
+		setTransactionStatusChange(&transaction, statusReason, statusActor)
+
 		transactions = append(transactions, &transaction)
 	}
 
@@ -231,8 +474,8 @@ func (r *PostgresTransactionRepository) FindByStatus(ctx context.Context, status
 // FindPendingTransactions retrieves pending transactions older than a specified time
 func (r *PostgresTransactionRepository) FindPendingTransactions(ctx context.Context, olderThan time.Time) ([]*domain.Transaction, error) {
 	query := `
-		SELECT id, wallet_id, type, amount, status, reference, description, to_wallet_id, 
-		       created_at, updated_at, completed_at
+		SELECT id, wallet_id, type, amount, fee, status, reference, description, to_wallet_id, 
+		       created_at, updated_at, completed_at, status_reason, status_actor
 		FROM transactions
 		WHERE status = $1 AND created_at < $2
 		ORDER BY created_at
@@ -249,7 +492,7 @@ func (r *PostgresTransactionRepository) FindPendingTransactions(ctx context.Cont
 	for rows.Next() {
 		var transaction domain.Transaction
 		var typeStr, statusStr string
-		var reference, description sql.NullString
+		var reference, description, statusReason, statusActor sql.NullString
 		var toWalletID sql.NullInt64
 		var completedAt sql.NullTime
 
@@ -258,6 +501,7 @@ func (r *PostgresTransactionRepository) FindPendingTransactions(ctx context.Cont
 			&transaction.WalletID,
 			&typeStr,
 			&transaction.Amount,
+			&transaction.Fee,
 			&statusStr,
 			&reference,
 			&description,
@@ -265,6 +509,8 @@ func (r *PostgresTransactionRepository) FindPendingTransactions(ctx context.Cont
 			&transaction.CreatedAt,
 			&transaction.UpdatedAt,
 			&completedAt,
+			&statusReason,
+			&statusActor,
 		)
 
 		if err != nil {
@@ -291,6 +537,8 @@ func (r *PostgresTransactionRepository) FindPendingTransactions(ctx context.Cont
 			transaction.CompletedAt = &completedAt.Time
 		}
 
+		setTransactionStatusChange(&transaction, statusReason, statusActor)
+
 		transactions = append(transactions, &transaction)
 	}
 
@@ -321,9 +569,9 @@ func (r *PostgresTransactionRepository) CountByWalletID(ctx context.Context, wal
 // Create saves a new transaction
 func (r *PostgresTransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) error {
 	query := `
-		INSERT INTO transactions (wallet_id, type, amount, status, reference, description, to_wallet_id, 
+		INSERT INTO transactions (wallet_id, type, amount, fee, status, reference, description, to_wallet_id, 
 		                         created_at, updated_at, completed_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id
 	`
 
@@ -340,6 +588,7 @@ func (r *PostgresTransactionRepository) Create(ctx context.Context, transaction
 		transaction.WalletID,
 		string(transaction.Type),
 		transaction.Amount,
+		transaction.Fee,
 		string(transaction.Status),
 		sql.NullString{String: transaction.Reference, Valid: transaction.Reference != ""},
 		sql.NullString{String: transaction.Description, Valid: transaction.Description != ""},
@@ -360,8 +609,9 @@ func (r *PostgresTransactionRepository) Create(ctx context.Context, transaction
 func (r *PostgresTransactionRepository) Update(ctx context.Context, transaction *domain.Transaction) error {
 	query := `
 		UPDATE transactions
-		SET status = $1, reference = $2, description = $3, updated_at = $4, completed_at = $5
-		WHERE id = $6
+		SET status = $1, fee = $2, reference = $3, description = $4, updated_at = $5, completed_at = $6,
+		    status_reason = $7, status_actor = $8
+		WHERE id = $9
 	`
 
 	transaction.UpdatedAt = time.Now()
@@ -370,10 +620,13 @@ func (r *PostgresTransactionRepository) Update(ctx context.Context, transaction
 		ctx,
 		query,
 		string(transaction.Status),
+		transaction.Fee,
 		sql.NullString{String: transaction.Reference, Valid: transaction.Reference != ""},
 		sql.NullString{String: transaction.Description, Valid: transaction.Description != ""},
 		transaction.UpdatedAt,
 		sql.NullTime{Time: safeDerefTime(transaction.CompletedAt), Valid: transaction.CompletedAt != nil},
+		sql.NullString{String: transaction.StatusReason, Valid: transaction.StatusReason != ""},
+		sql.NullString{String: transaction.StatusActor, Valid: transaction.StatusActor != ""},
 		transaction.ID,
 	)
 
@@ -429,6 +682,11 @@ func (r *PostgresTransactionRepository) UpdateStatus(ctx context.Context, id int
 	return nil
 }
 
+func setTransactionStatusChange(transaction *domain.Transaction, reason, actor sql.NullString) {
+	transaction.StatusReason = reason.String
+	transaction.StatusActor = actor.String
+}
+
 // Helper functions for handling nil pointers
 func safeDeref(ptr *int) int {
 	if ptr == nil {
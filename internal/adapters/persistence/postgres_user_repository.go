@@ -26,19 +26,21 @@ func NewPostgresUserRepository(db *sql.DB) *PostgresUserRepository {
 // FindByID retrieves a user by ID
 func (r *PostgresUserRepository) FindByID(ctx context.Context, id int) (*domain.User, error) {
 	query := `
-		SELECT id, fullname, email, phone, status, created_at, updated_at
+		SELECT id, fullname, email, phone, handle, status, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
 
 	var user domain.User
 	var statusStr string
+	var handle sql.NullString
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&user.ID,
 		&user.Fullname,
 		&user.Email,
 		&user.Phone,
+		&handle,
 		&statusStr,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -52,6 +54,7 @@ func (r *PostgresUserRepository) FindByID(ctx context.Context, id int) (*domain.
 	}
 
 	user.Status = domain.UserStatus(statusStr)
+	user.Handle = handle.String
 
 	return &user, nil
 }
@@ -59,19 +62,21 @@ func (r *PostgresUserRepository) FindByID(ctx context.Context, id int) (*domain.
 // FindByEmail retrieves a user by email
 func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, fullname, email, phone, status, created_at, updated_at
+		SELECT id, fullname, email, phone, handle, status, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
 
 	var user domain.User
 	var statusStr string
+	var handle sql.NullString
 
 	err := r.db.QueryRowContext(ctx, query, email).Scan(
 		&user.ID,
 		&user.Fullname,
 		&user.Email,
 		&user.Phone,
+		&handle,
 		&statusStr,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -85,6 +90,7 @@ func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string)
 	}
 
 	user.Status = domain.UserStatus(statusStr)
+	user.Handle = handle.String
 
 	return &user, nil
 }
@@ -92,19 +98,21 @@ func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string)
 // FindByPhone retrieves a user by phone
 func (r *PostgresUserRepository) FindByPhone(ctx context.Context, phone string) (*domain.User, error) {
 	query := `
-		SELECT id, fullname, email, phone, status, created_at, updated_at
+		SELECT id, fullname, email, phone, handle, status, created_at, updated_at
 		FROM users
 		WHERE phone = $1
 	`
 
 	var user domain.User
 	var statusStr string
+	var handle sql.NullString
 
 	err := r.db.QueryRowContext(ctx, query, phone).Scan(
 		&user.ID,
 		&user.Fullname,
 		&user.Email,
 		&user.Phone,
+		&handle,
 		&statusStr,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -118,6 +126,43 @@ func (r *PostgresUserRepository) FindByPhone(ctx context.Context, phone string)
 	}
 
 	user.Status = domain.UserStatus(statusStr)
+	user.Handle = handle.String
+
+	return &user, nil
+}
+
+// FindByHandle retrieves a user by handle
+func (r *PostgresUserRepository) FindByHandle(ctx context.Context, handle string) (*domain.User, error) {
+	query := `
+		SELECT id, fullname, email, phone, handle, status, created_at, updated_at
+		FROM users
+		WHERE handle = $1
+	`
+
+	var user domain.User
+	var statusStr string
+	var handleStr sql.NullString
+
+	err := r.db.QueryRowContext(ctx, query, handle).Scan(
+		&user.ID,
+		&user.Fullname,
+		&user.Email,
+		&user.Phone,
+		&handleStr,
+		&statusStr,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query user by handle: %w", err)
+	}
+
+	user.Status = domain.UserStatus(statusStr)
+	user.Handle = handleStr.String
 
 	return &user, nil
 }
@@ -127,8 +172,8 @@ func (r *PostgresUserRepository) Save(ctx context.Context, user *domain.User) er
 	if user.ID == 0 {
 		// Create new user
 		query := `
-			INSERT INTO users (fullname, email, phone, status, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6)
+			INSERT INTO users (fullname, email, phone, handle, status, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
 			RETURNING id
 		`
 
@@ -138,6 +183,7 @@ func (r *PostgresUserRepository) Save(ctx context.Context, user *domain.User) er
 			user.Fullname,
 			user.Email,
 			user.Phone,
+			sql.NullString{String: user.Handle, Valid: user.Handle != ""},
 			string(user.Status),
 			user.CreatedAt,
 			user.UpdatedAt,
@@ -153,8 +199,8 @@ func (r *PostgresUserRepository) Save(ctx context.Context, user *domain.User) er
 	// Update existing user
 	query := `
 		UPDATE users
-		SET fullname = $1, email = $2, phone = $3, status = $4, updated_at = $5
-		WHERE id = $6
+		SET fullname = $1, email = $2, phone = $3, handle = $4, status = $5, updated_at = $6
+		WHERE id = $7
 	`
 
 	user.UpdatedAt = time.Now()
@@ -165,6 +211,7 @@ func (r *PostgresUserRepository) Save(ctx context.Context, user *domain.User) er
 		user.Fullname,
 		user.Email,
 		user.Phone,
+		sql.NullString{String: user.Handle, Valid: user.Handle != ""},
 		string(user.Status),
 		user.UpdatedAt,
 		user.ID,
@@ -8,7 +8,9 @@ import (
 	"ports-and-adapters-architecture/internal/domain"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 // PostgresWalletRepository implements the WalletRepository interface for PostgreSQL
@@ -26,13 +28,16 @@ func NewPostgresWalletRepository(db *sql.DB) *PostgresWalletRepository {
 // FindByID retrieves a wallet by its ID
 func (r *PostgresWalletRepository) FindByID(ctx context.Context, id int) (*domain.Wallet, error) {
 	query := `
-		SELECT id, user_id, balance, currency_code, description, status, created_at, updated_at
+		SELECT id, user_id, balance, currency_code, description, status, status_reason, status_actor,
+		       status_changed_at, created_at, updated_at
 		FROM wallets
 		WHERE id = $1
 	`
 
 	var wallet domain.Wallet
 	var statusStr string
+	var statusReason, statusActor sql.NullString
+	var statusChangedAt sql.NullTime
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&wallet.ID,
@@ -41,6 +46,9 @@ func (r *PostgresWalletRepository) FindByID(ctx context.Context, id int) (*domai
 		&wallet.CurrencyCode,
 		&wallet.Description,
 		&statusStr,
+		&statusReason,
+		&statusActor,
+		&statusChangedAt,
 		&wallet.CreatedAt,
 		&wallet.UpdatedAt,
 	)
@@ -53,6 +61,7 @@ func (r *PostgresWalletRepository) FindByID(ctx context.Context, id int) (*domai
 	}
 
 	wallet.Status = domain.WalletStatus(statusStr)
+	setWalletStatusChange(&wallet, statusReason, statusActor, statusChangedAt)
 
 	return &wallet, nil
 }
@@ -60,7 +69,8 @@ func (r *PostgresWalletRepository) FindByID(ctx context.Context, id int) (*domai
 // FindByUserID retrieves all wallets for a user
 func (r *PostgresWalletRepository) FindByUserID(ctx context.Context, userID int) ([]*domain.Wallet, error) {
 	query := `
-		SELECT id, user_id, balance, currency_code, description, status, created_at, updated_at
+		SELECT id, user_id, balance, currency_code, description, status, status_reason, status_actor,
+		       status_changed_at, created_at, updated_at
 		FROM wallets
 		WHERE user_id = $1
 		ORDER BY id
@@ -77,6 +87,8 @@ func (r *PostgresWalletRepository) FindByUserID(ctx context.Context, userID int)
 	for rows.Next() {
 		var wallet domain.Wallet
 		var statusStr string
+		var statusReason, statusActor sql.NullString
+		var statusChangedAt sql.NullTime
 
 		err := rows.Scan(
 			&wallet.ID,
@@ -85,6 +97,9 @@ func (r *PostgresWalletRepository) FindByUserID(ctx context.Context, userID int)
 			&wallet.CurrencyCode,
 			&wallet.Description,
 			&statusStr,
+			&statusReason,
+			&statusActor,
+			&statusChangedAt,
 			&wallet.CreatedAt,
 			&wallet.UpdatedAt,
 		)
@@ -94,6 +109,62 @@ func (r *PostgresWalletRepository) FindByUserID(ctx context.Context, userID int)
 		}
 
 		wallet.Status = domain.WalletStatus(statusStr)
+		setWalletStatusChange(&wallet, statusReason, statusActor, statusChangedAt)
+		wallets = append(wallets, &wallet)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating wallet rows: %w", err)
+	}
+
+	return wallets, nil
+}
+
+// FindAfterID retrieves up to limit wallets with an ID above afterID, ordered by ID
+func (r *PostgresWalletRepository) FindAfterID(ctx context.Context, afterID int, limit int) ([]*domain.Wallet, error) {
+	query := `
+		SELECT id, user_id, balance, currency_code, description, status, status_reason, status_actor,
+		       status_changed_at, created_at, updated_at
+		FROM wallets
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query wallets after ID: %w", err)
+	}
+	defer rows.Close()
+
+	var wallets []*domain.Wallet
+
+	for rows.Next() {
+		var wallet domain.Wallet
+		var statusStr string
+		var statusReason, statusActor sql.NullString
+		var statusChangedAt sql.NullTime
+
+		err := rows.Scan(
+			&wallet.ID,
+			&wallet.UserID,
+			&wallet.Balance,
+			&wallet.CurrencyCode,
+			&wallet.Description,
+			&statusStr,
+			&statusReason,
+			&statusActor,
+			&statusChangedAt,
+			&wallet.CreatedAt,
+			&wallet.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan wallet row: %w", err)
+		}
+
+		wallet.Status = domain.WalletStatus(statusStr)
+		setWalletStatusChange(&wallet, statusReason, statusActor, statusChangedAt)
 		wallets = append(wallets, &wallet)
 	}
 
@@ -136,8 +207,9 @@ func (r *PostgresWalletRepository) Save(ctx context.Context, wallet *domain.Wall
 	// Update existing wallet
 	query := `
 		UPDATE wallets
-		SET user_id = $1, balance = $2, currency_code = $3, description = $4, status = $5, updated_at = $6
-		WHERE id = $7
+		SET user_id = $1, balance = $2, currency_code = $3, description = $4, status = $5,
+		    status_reason = $6, status_actor = $7, status_changed_at = $8, updated_at = $9
+		WHERE id = $10
 	`
 
 	wallet.UpdatedAt = time.Now()
@@ -150,6 +222,9 @@ func (r *PostgresWalletRepository) Save(ctx context.Context, wallet *domain.Wall
 		wallet.CurrencyCode,
 		wallet.Description,
 		string(wallet.Status),
+		sql.NullString{String: string(wallet.StatusReason), Valid: wallet.StatusReason != ""},
+		sql.NullString{String: wallet.StatusActor, Valid: wallet.StatusActor != ""},
+		sql.NullTime{Time: safeDerefTime(wallet.StatusChangedAt), Valid: wallet.StatusChangedAt != nil},
 		wallet.UpdatedAt,
 		wallet.ID,
 	)
@@ -248,20 +323,65 @@ func (r *PostgresWalletRepository) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
-// NewPostgresConnection creates a new PostgreSQL database connection
-func NewPostgresConnection(host, port, user, password, dbName string) (*sql.DB, error) {
+// setWalletStatusChange copies the nullable status change columns onto a wallet
+func setWalletStatusChange(wallet *domain.Wallet, reason, actor sql.NullString, changedAt sql.NullTime) {
+	wallet.StatusReason = domain.WalletStatusReason(reason.String)
+	wallet.StatusActor = actor.String
+
+	if changedAt.Valid {
+		wallet.StatusChangedAt = &changedAt.Time
+	}
+}
+
+// PostgresConfig holds the connection and pool settings of the database
+type PostgresConfig struct {
+	Host            string        `mapstructure:"host"`
+	Port            string        `mapstructure:"port"`
+	Name            string        `mapstructure:"name"`
+	User            string        `mapstructure:"user"`
+	Password        string        `mapstructure:"password"`
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	// OperationTimeout bounds every statement on the server, and the ping on connect
+	OperationTimeout time.Duration `mapstructure:"operation_timeout"`
+}
+
+// NewPostgresConnection creates a new PostgreSQL database connection. Queries
+// are traced as child spans of the context they run with, once a tracer
+// provider is installed
+func NewPostgresConnection(config PostgresConfig) (*sql.DB, error) {
 	connStr := fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		host, port, user, password, dbName,
+		config.Host, config.Port, config.User, config.Password, config.Name,
 	)
+	if config.OperationTimeout > 0 {
+		connStr += fmt.Sprintf(" statement_timeout=%d", config.OperationTimeout.Milliseconds())
+	}
 
-	db, err := sql.Open("postgres", connStr)
+	db, err := otelsql.Open("postgres", connStr,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
+	// Configure connection pool
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+
 	// Test the connection
-	if err = db.Ping(); err != nil {
+	ctx := context.Background()
+	if config.OperationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.OperationTimeout)
+		defer cancel()
+	}
+
+	if err = db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
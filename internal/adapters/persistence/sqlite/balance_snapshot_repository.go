@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// SQLiteBalanceSnapshotRepository implements the BalanceSnapshotRepository interface for SQLite
+type SQLiteBalanceSnapshotRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteBalanceSnapshotRepository creates a new SQLite balance snapshot repository
+func NewSQLiteBalanceSnapshotRepository(db *sql.DB) *SQLiteBalanceSnapshotRepository {
+	return &SQLiteBalanceSnapshotRepository{
+		db: db,
+	}
+}
+
+// Save stores a snapshot, replacing any snapshot of the wallet taken at the same time
+func (r *SQLiteBalanceSnapshotRepository) Save(ctx context.Context, snapshot *domain.BalanceSnapshot) error {
+	query := `
+		INSERT INTO balance_snapshots (wallet_id, balance, currency_code, taken_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (wallet_id, taken_at)
+		DO UPDATE SET balance = excluded.balance, created_at = excluded.created_at
+		RETURNING id
+	`
+
+	err := querierFor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		snapshot.WalletID,
+		snapshot.Balance,
+		snapshot.CurrencyCode,
+		utc(snapshot.TakenAt),
+		utc(snapshot.CreatedAt),
+	).Scan(&snapshot.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save balance snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// FindLatest retrieves the latest snapshot of a wallet taken at or before a time
+func (r *SQLiteBalanceSnapshotRepository) FindLatest(ctx context.Context, walletID int, at time.Time) (*domain.BalanceSnapshot, error) {
+	query := `
+		SELECT id, wallet_id, balance, currency_code, taken_at, created_at
+		FROM balance_snapshots
+		WHERE wallet_id = ? AND taken_at <= ?
+		ORDER BY taken_at DESC
+		LIMIT 1
+	`
+
+	var snapshot domain.BalanceSnapshot
+	err := querierFor(ctx, r.db).QueryRowContext(ctx, query, walletID, utc(at)).Scan(
+		&snapshot.ID,
+		&snapshot.WalletID,
+		&snapshot.Balance,
+		&snapshot.CurrencyCode,
+		&snapshot.TakenAt,
+		&snapshot.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query balance snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
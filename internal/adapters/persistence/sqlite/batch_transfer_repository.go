@@ -0,0 +1,237 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// batchTransferColumns are the columns read by scanBatchTransfer
+const batchTransferColumns = `id, from_wallet_id, mode, status, description, total_amount, succeeded_count, failed_count,
+	items, created_at, updated_at, completed_at`
+
+// SQLiteBatchTransferRepository implements the BatchTransferRepository interface for SQLite
+type SQLiteBatchTransferRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteBatchTransferRepository creates a new SQLite batch transfer repository
+func NewSQLiteBatchTransferRepository(db *sql.DB) *SQLiteBatchTransferRepository {
+	return &SQLiteBatchTransferRepository{
+		db: db,
+	}
+}
+
+// FindByID retrieves a batch transfer with its items by ID
+func (r *SQLiteBatchTransferRepository) FindByID(ctx context.Context, id int) (*domain.BatchTransfer, error) {
+	query := `SELECT ` + batchTransferColumns + ` FROM batch_transfers WHERE id = ?`
+
+	batch, err := scanBatchTransfer(querierFor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query batch transfer by ID: %w", err)
+	}
+
+	return batch, nil
+}
+
+// FindByWalletID retrieves batch transfers sent from a wallet, newest first
+func (r *SQLiteBatchTransferRepository) FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.BatchTransfer, error) {
+	query := `
+		SELECT ` + batchTransferColumns + `
+		FROM batch_transfers
+		WHERE from_wallet_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	batches, err := r.query(ctx, query, walletID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query batch transfers by wallet ID: %w", err)
+	}
+	return batches, nil
+}
+
+// FindUnfinished retrieves up to limit pending or processing batch transfers
+// last updated before updatedBefore, oldest first
+func (r *SQLiteBatchTransferRepository) FindUnfinished(ctx context.Context, updatedBefore time.Time, limit int) ([]*domain.BatchTransfer, error) {
+	query := `
+		SELECT ` + batchTransferColumns + `
+		FROM batch_transfers
+		WHERE status IN (?, ?) AND updated_at < ?
+		ORDER BY updated_at
+		LIMIT ?
+	`
+
+	batches, err := r.query(ctx, query,
+		string(domain.BatchTransferStatusPending), string(domain.BatchTransferStatusProcessing), utc(updatedBefore), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unfinished batch transfers: %w", err)
+	}
+	return batches, nil
+}
+
+// Claim touches a pending or processing batch transfer only while it was
+// still last updated before updatedBefore
+func (r *SQLiteBatchTransferRepository) Claim(ctx context.Context, id int, updatedBefore time.Time) (bool, error) {
+	query := `
+		UPDATE batch_transfers
+		SET updated_at = ?
+		WHERE id = ? AND status IN (?, ?) AND updated_at < ?
+	`
+
+	result, err := querierFor(ctx, r.db).ExecContext(ctx, query,
+		utc(time.Now()), id,
+		string(domain.BatchTransferStatusPending), string(domain.BatchTransferStatusProcessing), utc(updatedBefore))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim batch transfer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// query runs a batch transfer query and scans every row
+func (r *SQLiteBatchTransferRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.BatchTransfer, error) {
+	rows, err := querierFor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batches []*domain.BatchTransfer
+
+	for rows.Next() {
+		batch, err := scanBatchTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan batch transfer row: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating batch transfer rows: %w", err)
+	}
+
+	return batches, nil
+}
+
+// Create saves a new batch transfer
+func (r *SQLiteBatchTransferRepository) Create(ctx context.Context, batch *domain.BatchTransfer) error {
+	query := `
+		INSERT INTO batch_transfers (from_wallet_id, mode, status, description, total_amount, items,
+		                             created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	itemsJSON, err := json.Marshal(batch.Items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch transfer items: %w", err)
+	}
+
+	err = querierFor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		batch.FromWalletID,
+		string(batch.Mode),
+		string(batch.Status),
+		nullString(batch.Description),
+		batch.TotalAmount,
+		string(itemsJSON),
+		utc(batch.CreatedAt),
+		utc(batch.UpdatedAt),
+	).Scan(&batch.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert batch transfer: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing batch transfer and its items
+func (r *SQLiteBatchTransferRepository) Update(ctx context.Context, batch *domain.BatchTransfer) error {
+	query := `
+		UPDATE batch_transfers
+		SET status = ?, succeeded_count = ?, failed_count = ?, items = ?, updated_at = ?, completed_at = ?
+		WHERE id = ?
+	`
+
+	itemsJSON, err := json.Marshal(batch.Items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch transfer items: %w", err)
+	}
+
+	batch.UpdatedAt = time.Now()
+
+	result, err := querierFor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		string(batch.Status),
+		batch.SucceededCount,
+		batch.FailedCount,
+		string(itemsJSON),
+		utc(batch.UpdatedAt),
+		nullTime(batch.CompletedAt),
+		batch.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update batch transfer: %w", err)
+	}
+
+	return checkAffected(result, "batch transfer", batch.ID)
+}
+
+func scanBatchTransfer(row rowScanner) (*domain.BatchTransfer, error) {
+	var batch domain.BatchTransfer
+	var modeStr, statusStr, itemsJSON string
+	var description sql.NullString
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&batch.ID,
+		&batch.FromWalletID,
+		&modeStr,
+		&statusStr,
+		&description,
+		&batch.TotalAmount,
+		&batch.SucceededCount,
+		&batch.FailedCount,
+		&itemsJSON,
+		&batch.CreatedAt,
+		&batch.UpdatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	batch.Mode = domain.BatchTransferMode(modeStr)
+	batch.Status = domain.BatchTransferStatus(statusStr)
+	batch.Description = description.String
+
+	if completedAt.Valid {
+		batch.CompletedAt = &completedAt.Time
+	}
+
+	batch.Items = []domain.BatchTransferItem{}
+	if itemsJSON != "" {
+		if err := json.Unmarshal([]byte(itemsJSON), &batch.Items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal batch transfer items: %w", err)
+		}
+	}
+
+	return &batch, nil
+}
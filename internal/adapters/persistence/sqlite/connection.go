@@ -0,0 +1,179 @@
+// Package sqlite implements the repositories on a SQLite file, for running
+// the service as a single binary in demos and offline tests.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/XSAM/otelsql"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	_ "modernc.org/sqlite"
+)
+
+// busyTimeout is how long a statement waits for another process, such as the
+// migrate command, to release the database file
+const busyTimeout = 5 * time.Second
+
+// Config holds the location of the database
+type Config struct {
+	// Path is the database file, created when missing. ":memory:" keeps the
+	// database in memory until the connection closes
+	Path string `mapstructure:"path"`
+}
+
+// NewSQLiteConnection opens the SQLite database at config.Path. SQLite allows
+// one writer at a time, so the pool holds a single connection and statements
+// queue for it instead of failing as busy
+func NewSQLiteConnection(config Config) (*sql.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", busyTimeout.Milliseconds()))
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_time_format", "sqlite")
+	params.Set("_txlock", "immediate")
+
+	db, err := otelsql.Open("sqlite", config.Path+"?"+params.Encode(),
+		otelsql.WithAttributes(semconv.DBSystemSqlite),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	// An in-memory database lives as long as its connection, so it is never recycled
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+
+	if err = db.PingContext(context.Background()); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return db, nil
+}
+
+// txKey is the context key of the transaction begun by SQLiteDBTransaction
+type txKey struct{}
+
+// querier is satisfied by both *sql.DB and *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// querierFor returns the transaction of ctx, or db outside a transaction
+func querierFor(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// SQLiteDBTransaction implements the DBTransaction interface for SQLite.
+// Repositories called with the context returned by BeginTx run their
+// statements in the transaction. Calls with any other context wait for it
+// to finish, as the pool has a single connection
+type SQLiteDBTransaction struct {
+	db *sql.DB
+}
+
+// NewSQLiteDBTransaction creates a new SQLite transaction manager
+func NewSQLiteDBTransaction(db *sql.DB) *SQLiteDBTransaction {
+	return &SQLiteDBTransaction{
+		db: db,
+	}
+}
+
+// BeginTx starts a new transaction and returns a context with the transaction
+func (t *SQLiteDBTransaction) BeginTx(ctx context.Context) (context.Context, error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return nil, fmt.Errorf("transaction already in progress")
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	return context.WithValue(ctx, txKey{}, tx), nil
+}
+
+// CommitTx commits the transaction in the context
+func (t *SQLiteDBTransaction) CommitTx(ctx context.Context) error {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if !ok {
+		return fmt.Errorf("no transaction in context")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RollbackTx rolls back the transaction in the context
+func (t *SQLiteDBTransaction) RollbackTx(ctx context.Context) error {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if !ok {
+		return fmt.Errorf("no transaction in context")
+	}
+
+	if err := tx.Rollback(); err != nil {
+		return fmt.Errorf("failed to roll back transaction: %w", err)
+	}
+
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// utc binds a time in UTC. SQLite stores times as text, which only compares in
+// time order when every value has the same offset
+func utc(t time.Time) time.Time {
+	return t.UTC()
+}
+
+// nullTime binds an optional time in UTC
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+// nullString binds an empty string as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// checkAffected reports a missing row when an update or delete changed nothing
+func checkAffected(result sql.Result, entity string, id int) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s not found: %d", entity, id)
+	}
+
+	return nil
+}
+
+// nullInt binds an optional ID as NULL when it is missing
+func nullInt(id *int) sql.NullInt64 {
+	if id == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*id), Valid: true}
+}
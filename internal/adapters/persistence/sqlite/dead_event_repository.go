@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// deadEventColumns are the columns read by scanDeadEvent
+const deadEventColumns = `id, topic, event_id, event_type, payload, event_time, error, failed_at, redriven_at`
+
+// SQLiteDeadEventRepository implements the DeadEventRepository interface for SQLite
+type SQLiteDeadEventRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteDeadEventRepository creates a new SQLite dead event repository
+func NewSQLiteDeadEventRepository(db *sql.DB) *SQLiteDeadEventRepository {
+	return &SQLiteDeadEventRepository{
+		db: db,
+	}
+}
+
+// Create stores a new dead event
+func (r *SQLiteDeadEventRepository) Create(ctx context.Context, event *domain.DeadEvent) error {
+	query := `
+		INSERT INTO dead_events (topic, event_id, event_type, payload, event_time, error, failed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	payloadJSON, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	err = querierFor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		event.Topic,
+		nullString(event.EventID),
+		event.EventType,
+		string(payloadJSON),
+		event.EventTime,
+		event.Error,
+		utc(event.FailedAt),
+	).Scan(&event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to insert dead event: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a dead event by ID
+func (r *SQLiteDeadEventRepository) FindByID(ctx context.Context, id int) (*domain.DeadEvent, error) {
+	query := `SELECT ` + deadEventColumns + ` FROM dead_events WHERE id = ?`
+
+	event, err := scanDeadEvent(querierFor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query dead event: %w", err)
+	}
+
+	return event, nil
+}
+
+// FindPending retrieves dead events not yet redriven, oldest first
+func (r *SQLiteDeadEventRepository) FindPending(ctx context.Context, limit int) ([]*domain.DeadEvent, error) {
+	query := `
+		SELECT ` + deadEventColumns + `
+		FROM dead_events
+		WHERE redriven_at IS NULL
+		ORDER BY id
+		LIMIT ?
+	`
+
+	rows, err := querierFor(ctx, r.db).QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dead events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.DeadEvent
+
+	for rows.Next() {
+		event, err := scanDeadEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dead events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Update updates an existing dead event
+func (r *SQLiteDeadEventRepository) Update(ctx context.Context, event *domain.DeadEvent) error {
+	query := `UPDATE dead_events SET error = ?, redriven_at = ? WHERE id = ?`
+
+	result, err := querierFor(ctx, r.db).ExecContext(ctx, query, event.Error, nullTime(event.RedrivenAt), event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update dead event: %w", err)
+	}
+
+	return checkAffected(result, "dead event", event.ID)
+}
+
+func scanDeadEvent(row rowScanner) (*domain.DeadEvent, error) {
+	var event domain.DeadEvent
+	var eventID sql.NullString
+	var payloadJSON string
+	var redrivenAt sql.NullTime
+
+	err := row.Scan(
+		&event.ID,
+		&event.Topic,
+		&eventID,
+		&event.EventType,
+		&payloadJSON,
+		&event.EventTime,
+		&event.Error,
+		&event.FailedAt,
+		&redrivenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event.EventID = eventID.String
+
+	if redrivenAt.Valid {
+		event.RedrivenAt = &redrivenAt.Time
+	}
+
+	if payloadJSON != "" {
+		if err := json.Unmarshal([]byte(payloadJSON), &event.Payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event payload: %w", err)
+		}
+	}
+
+	return &event, nil
+}
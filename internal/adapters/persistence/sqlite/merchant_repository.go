@@ -0,0 +1,201 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// apiKeyColumns are the columns read by scanAPIKey
+const apiKeyColumns = `id, merchant_id, prefix, key_hash, signing_secret, expires_at, revoked_at, created_at`
+
+// SQLiteMerchantRepository implements the MerchantRepository interface for SQLite
+type SQLiteMerchantRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteMerchantRepository creates a new SQLite merchant repository
+func NewSQLiteMerchantRepository(db *sql.DB) *SQLiteMerchantRepository {
+	return &SQLiteMerchantRepository{
+		db: db,
+	}
+}
+
+// FindByID retrieves a merchant by its ID
+func (r *SQLiteMerchantRepository) FindByID(ctx context.Context, id int) (*domain.Merchant, error) {
+	query := `
+		SELECT id, name, user_id, environment, scopes, created_at, updated_at
+		FROM merchants
+		WHERE id = ?
+	`
+
+	var merchant domain.Merchant
+	var environment, scopesJSON string
+
+	err := querierFor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&merchant.ID,
+		&merchant.Name,
+		&merchant.UserID,
+		&environment,
+		&scopesJSON,
+		&merchant.CreatedAt,
+		&merchant.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query merchant by ID: %w", err)
+	}
+
+	merchant.Environment = domain.MerchantEnvironment(environment)
+
+	if err := json.Unmarshal([]byte(scopesJSON), &merchant.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal merchant scopes: %w", err)
+	}
+
+	return &merchant, nil
+}
+
+// Create saves a new merchant
+func (r *SQLiteMerchantRepository) Create(ctx context.Context, merchant *domain.Merchant) error {
+	query := `
+		INSERT INTO merchants (name, user_id, environment, scopes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	scopesJSON, err := json.Marshal(merchant.Scopes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal merchant scopes: %w", err)
+	}
+
+	err = querierFor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		merchant.Name,
+		merchant.UserID,
+		string(merchant.Environment),
+		string(scopesJSON),
+		utc(merchant.CreatedAt),
+		utc(merchant.UpdatedAt),
+	).Scan(&merchant.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert merchant: %w", err)
+	}
+
+	return nil
+}
+
+// FindAPIKeyByHash retrieves an API key by the hash of the key
+func (r *SQLiteMerchantRepository) FindAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = ?`
+
+	key, err := scanAPIKey(querierFor(ctx, r.db).QueryRowContext(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query api key by hash: %w", err)
+	}
+
+	return key, nil
+}
+
+// FindAPIKeysByMerchantID retrieves every key of a merchant, newest first
+func (r *SQLiteMerchantRepository) FindAPIKeysByMerchantID(ctx context.Context, merchantID int) ([]*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE merchant_id = ? ORDER BY id DESC`
+
+	rows, err := querierFor(ctx, r.db).QueryContext(ctx, query, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys by merchant ID: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key row: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api key rows: %w", err)
+	}
+
+	return keys, nil
+}
+
+// CreateAPIKey saves a new API key
+func (r *SQLiteMerchantRepository) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	query := `
+		INSERT INTO api_keys (merchant_id, prefix, key_hash, signing_secret, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	err := querierFor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		key.MerchantID,
+		key.Prefix,
+		key.KeyHash,
+		key.SigningSecret,
+		nullTime(key.ExpiresAt),
+		utc(key.CreatedAt),
+	).Scan(&key.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAPIKey updates the expiry and revocation of an API key
+func (r *SQLiteMerchantRepository) UpdateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	query := `UPDATE api_keys SET expires_at = ?, revoked_at = ? WHERE id = ?`
+
+	result, err := querierFor(ctx, r.db).ExecContext(ctx, query, nullTime(key.ExpiresAt), nullTime(key.RevokedAt), key.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update api key: %w", err)
+	}
+
+	return checkAffected(result, "api key", key.ID)
+}
+
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var expiresAt, revokedAt sql.NullTime
+
+	err := row.Scan(
+		&key.ID,
+		&key.MerchantID,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.SigningSecret,
+		&expiresAt,
+		&revokedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return &key, nil
+}
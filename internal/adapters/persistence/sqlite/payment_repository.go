@@ -0,0 +1,250 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// paymentColumns are the columns read by scanPayment
+const paymentColumns = `id, transaction_id, amount, provider, status, external_id, payment_url,
+	description, details, created_at, updated_at, completed_at`
+
+// SQLitePaymentRepository implements the PaymentRepository interface for SQLite
+type SQLitePaymentRepository struct {
+	db *sql.DB
+}
+
+// NewSQLitePaymentRepository creates a new SQLite payment repository
+func NewSQLitePaymentRepository(db *sql.DB) *SQLitePaymentRepository {
+	return &SQLitePaymentRepository{
+		db: db,
+	}
+}
+
+// FindByID retrieves a payment by its ID
+func (r *SQLitePaymentRepository) FindByID(ctx context.Context, id int) (*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE id = ?`
+
+	payment, err := scanPayment(querierFor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query payment by ID: %w", err)
+	}
+
+	return payment, nil
+}
+
+// FindByTransactionID retrieves all payments for a transaction
+func (r *SQLitePaymentRepository) FindByTransactionID(ctx context.Context, transactionID int) ([]*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE transaction_id = ? ORDER BY created_at DESC`
+
+	payments, err := r.query(ctx, query, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payments by transaction ID: %w", err)
+	}
+	return payments, nil
+}
+
+// FindByExternalID retrieves a payment by external ID
+func (r *SQLitePaymentRepository) FindByExternalID(ctx context.Context, externalID string) (*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE external_id = ?`
+
+	payment, err := scanPayment(querierFor(ctx, r.db).QueryRowContext(ctx, query, externalID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query payment by external ID: %w", err)
+	}
+
+	return payment, nil
+}
+
+// FindPendingPayments retrieves all pending payments with optional age limit in minutes
+func (r *SQLitePaymentRepository) FindPendingPayments(ctx context.Context, olderThanMinutes int) ([]*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE status = ?`
+
+	args := []interface{}{string(domain.PaymentStatusPending)}
+
+	if olderThanMinutes > 0 {
+		query += " AND created_at < ?"
+		cutoffTime := time.Now().Add(-time.Duration(olderThanMinutes) * time.Minute)
+		args = append(args, utc(cutoffTime))
+	}
+
+	query += " ORDER BY created_at"
+
+	payments, err := r.query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending payments: %w", err)
+	}
+	return payments, nil
+}
+
+// query runs a payment query and scans every row
+func (r *SQLitePaymentRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Payment, error) {
+	rows, err := querierFor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []*domain.Payment
+
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment row: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment rows: %w", err)
+	}
+
+	return payments, nil
+}
+
+// Create saves a new payment
+func (r *SQLitePaymentRepository) Create(ctx context.Context, payment *domain.Payment) error {
+	query := `
+		INSERT INTO payments (transaction_id, amount, provider, status, external_id, payment_url,
+		                      description, details, created_at, updated_at, completed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	details, err := marshalDetails(payment.Details)
+	if err != nil {
+		return err
+	}
+
+	if payment.Status == domain.PaymentStatusCompleted {
+		now := time.Now()
+		payment.CompletedAt = &now
+	}
+
+	err = querierFor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		payment.TransactionID,
+		payment.Amount,
+		string(payment.Provider),
+		string(payment.Status),
+		nullString(payment.ExternalID),
+		nullString(payment.PaymentURL),
+		nullString(payment.Description),
+		details,
+		utc(payment.CreatedAt),
+		utc(payment.UpdatedAt),
+		nullTime(payment.CompletedAt),
+	).Scan(&payment.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert payment: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing payment
+func (r *SQLitePaymentRepository) Update(ctx context.Context, payment *domain.Payment) error {
+	query := `
+		UPDATE payments
+		SET status = ?, external_id = ?, payment_url = ?, description = ?,
+		    details = ?, updated_at = ?, completed_at = ?
+		WHERE id = ?
+	`
+
+	details, err := marshalDetails(payment.Details)
+	if err != nil {
+		return err
+	}
+
+	payment.UpdatedAt = time.Now()
+
+	result, err := querierFor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		string(payment.Status),
+		nullString(payment.ExternalID),
+		nullString(payment.PaymentURL),
+		nullString(payment.Description),
+		details,
+		utc(payment.UpdatedAt),
+		nullTime(payment.CompletedAt),
+		payment.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	return checkAffected(result, "payment", payment.ID)
+}
+
+// marshalDetails stores payment details as JSON text, NULL when there are none
+func marshalDetails(details map[string]interface{}) (sql.NullString, error) {
+	if len(details) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to marshal payment details: %w", err)
+	}
+
+	return sql.NullString{String: string(detailsJSON), Valid: true}, nil
+}
+
+func scanPayment(row rowScanner) (*domain.Payment, error) {
+	var payment domain.Payment
+	var providerStr, statusStr string
+	var externalID, paymentURL, description, details sql.NullString
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&payment.ID,
+		&payment.TransactionID,
+		&payment.Amount,
+		&providerStr,
+		&statusStr,
+		&externalID,
+		&paymentURL,
+		&description,
+		&details,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	payment.Provider = domain.PaymentProvider(providerStr)
+	payment.Status = domain.PaymentStatus(statusStr)
+	payment.ExternalID = externalID.String
+	payment.PaymentURL = paymentURL.String
+	payment.Description = description.String
+
+	if completedAt.Valid {
+		payment.CompletedAt = &completedAt.Time
+	}
+
+	if details.Valid {
+		payment.Details = make(map[string]interface{})
+		if err := json.Unmarshal([]byte(details.String), &payment.Details); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payment details: %w", err)
+		}
+	}
+
+	return &payment, nil
+}
@@ -0,0 +1,260 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// paymentRequestColumns are the columns read by scanPaymentRequest
+const paymentRequestColumns = `id, requester_wallet_id, requester_user_id, payer_user_id, payer_wallet_id, amount,
+	currency_code, note, status, transaction_id, expires_at, created_at, updated_at, responded_at`
+
+// SQLitePaymentRequestRepository implements the PaymentRequestRepository interface for SQLite
+type SQLitePaymentRequestRepository struct {
+	db *sql.DB
+}
+
+// NewSQLitePaymentRequestRepository creates a new SQLite payment request repository
+func NewSQLitePaymentRequestRepository(db *sql.DB) *SQLitePaymentRequestRepository {
+	return &SQLitePaymentRequestRepository{
+		db: db,
+	}
+}
+
+// FindByID retrieves a payment request by its ID
+func (r *SQLitePaymentRequestRepository) FindByID(ctx context.Context, id int) (*domain.PaymentRequest, error) {
+	query := `SELECT ` + paymentRequestColumns + ` FROM payment_requests WHERE id = ?`
+
+	request, err := scanPaymentRequest(querierFor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query payment request by ID: %w", err)
+	}
+
+	return request, nil
+}
+
+// FindByPayerUserID retrieves requests addressed to a user, newest first
+func (r *SQLitePaymentRequestRepository) FindByPayerUserID(
+	ctx context.Context,
+	userID int,
+	status domain.PaymentRequestStatus,
+	limit, offset int,
+) ([]*domain.PaymentRequest, error) {
+	return r.findByUser(ctx, "payer_user_id", userID, status, limit, offset)
+}
+
+// FindByRequesterUserID retrieves requests sent by a user, newest first
+func (r *SQLitePaymentRequestRepository) FindByRequesterUserID(
+	ctx context.Context,
+	userID int,
+	status domain.PaymentRequestStatus,
+	limit, offset int,
+) ([]*domain.PaymentRequest, error) {
+	return r.findByUser(ctx, "requester_user_id", userID, status, limit, offset)
+}
+
+// findByUser lists requests by one of the user columns. column is never user input
+func (r *SQLitePaymentRequestRepository) findByUser(
+	ctx context.Context,
+	column string,
+	userID int,
+	status domain.PaymentRequestStatus,
+	limit, offset int,
+) ([]*domain.PaymentRequest, error) {
+	query := `
+		SELECT ` + paymentRequestColumns + `
+		FROM payment_requests
+		WHERE ` + column + ` = ?1 AND (?2 = '' OR status = ?2)
+		ORDER BY created_at DESC
+		LIMIT ?3 OFFSET ?4
+	`
+
+	requests, err := r.query(ctx, query, userID, string(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payment requests by user ID: %w", err)
+	}
+	return requests, nil
+}
+
+// FindExpired retrieves pending requests that expired before a specified time
+func (r *SQLitePaymentRequestRepository) FindExpired(ctx context.Context, before time.Time, limit int) ([]*domain.PaymentRequest, error) {
+	query := `
+		SELECT ` + paymentRequestColumns + `
+		FROM payment_requests
+		WHERE status = ? AND expires_at <= ?
+		ORDER BY expires_at
+		LIMIT ?
+	`
+
+	requests, err := r.query(ctx, query, string(domain.PaymentRequestStatusPending), utc(before), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired payment requests: %w", err)
+	}
+	return requests, nil
+}
+
+// FindStaleProcessing retrieves requests claimed by an accept that were last
+// updated before a specified time, oldest first
+func (r *SQLitePaymentRequestRepository) FindStaleProcessing(ctx context.Context, updatedBefore time.Time, limit int) ([]*domain.PaymentRequest, error) {
+	query := `
+		SELECT ` + paymentRequestColumns + `
+		FROM payment_requests
+		WHERE status = ? AND updated_at < ?
+		ORDER BY updated_at
+		LIMIT ?
+	`
+
+	requests, err := r.query(ctx, query, string(domain.PaymentRequestStatusProcessing), utc(updatedBefore), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale processing payment requests: %w", err)
+	}
+	return requests, nil
+}
+
+// query runs a payment request query and scans every row
+func (r *SQLitePaymentRequestRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.PaymentRequest, error) {
+	rows, err := querierFor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []*domain.PaymentRequest
+
+	for rows.Next() {
+		request, err := scanPaymentRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment request row: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment request rows: %w", err)
+	}
+
+	return requests, nil
+}
+
+// Create saves a new payment request
+func (r *SQLitePaymentRequestRepository) Create(ctx context.Context, request *domain.PaymentRequest) error {
+	query := `
+		INSERT INTO payment_requests (requester_wallet_id, requester_user_id, payer_user_id, amount, currency_code,
+		                              note, status, expires_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	err := querierFor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		request.RequesterWalletID,
+		request.RequesterUserID,
+		request.PayerUserID,
+		request.Amount,
+		request.CurrencyCode,
+		nullString(request.Note),
+		string(request.Status),
+		utc(request.ExpiresAt),
+		utc(request.CreatedAt),
+		utc(request.UpdatedAt),
+	).Scan(&request.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert payment request: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateIfStatus updates a payment request only while its stored status still equals expected
+func (r *SQLitePaymentRequestRepository) UpdateIfStatus(
+	ctx context.Context,
+	request *domain.PaymentRequest,
+	expected domain.PaymentRequestStatus,
+) (bool, error) {
+	query := `
+		UPDATE payment_requests
+		SET status = ?, payer_wallet_id = ?, transaction_id = ?, updated_at = ?, responded_at = ?
+		WHERE id = ? AND status = ?
+	`
+
+	request.UpdatedAt = time.Now()
+
+	result, err := querierFor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		string(request.Status),
+		nullInt(request.PayerWalletID),
+		nullInt(request.TransactionID),
+		utc(request.UpdatedAt),
+		nullTime(request.RespondedAt),
+		request.ID,
+		string(expected),
+	)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to update payment request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func scanPaymentRequest(row rowScanner) (*domain.PaymentRequest, error) {
+	var request domain.PaymentRequest
+	var statusStr string
+	var payerWalletID, transactionID sql.NullInt64
+	var note sql.NullString
+	var respondedAt sql.NullTime
+
+	err := row.Scan(
+		&request.ID,
+		&request.RequesterWalletID,
+		&request.RequesterUserID,
+		&request.PayerUserID,
+		&payerWalletID,
+		&request.Amount,
+		&request.CurrencyCode,
+		&note,
+		&statusStr,
+		&transactionID,
+		&request.ExpiresAt,
+		&request.CreatedAt,
+		&request.UpdatedAt,
+		&respondedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Status = domain.PaymentRequestStatus(statusStr)
+	request.Note = note.String
+
+	if payerWalletID.Valid {
+		id := int(payerWalletID.Int64)
+		request.PayerWalletID = &id
+	}
+
+	if transactionID.Valid {
+		id := int(transactionID.Int64)
+		request.TransactionID = &id
+	}
+
+	if respondedAt.Valid {
+		request.RespondedAt = &respondedAt.Time
+	}
+
+	return &request, nil
+}
@@ -0,0 +1,224 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// riskAssessmentColumns are the columns read by scanRiskAssessment
+const riskAssessmentColumns = `id, wallet_id, transaction_id, transaction_type, amount, to_wallet_id, decision, rule_hits,
+	review_status, reviewed_by, review_note, created_at, updated_at, reviewed_at`
+
+// SQLiteRiskAssessmentRepository implements the RiskAssessmentRepository interface for SQLite
+type SQLiteRiskAssessmentRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRiskAssessmentRepository creates a new SQLite risk assessment repository
+func NewSQLiteRiskAssessmentRepository(db *sql.DB) *SQLiteRiskAssessmentRepository {
+	return &SQLiteRiskAssessmentRepository{
+		db: db,
+	}
+}
+
+// FindByID retrieves a risk assessment by its ID
+func (r *SQLiteRiskAssessmentRepository) FindByID(ctx context.Context, id int) (*domain.RiskAssessment, error) {
+	query := `SELECT ` + riskAssessmentColumns + ` FROM risk_assessments WHERE id = ?`
+
+	assessment, err := scanRiskAssessment(querierFor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query risk assessment by ID: %w", err)
+	}
+
+	return assessment, nil
+}
+
+// FindByWalletID retrieves risk assessments for a wallet, newest first
+func (r *SQLiteRiskAssessmentRepository) FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.RiskAssessment, error) {
+	query := `
+		SELECT ` + riskAssessmentColumns + `
+		FROM risk_assessments
+		WHERE wallet_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	assessments, err := r.query(ctx, query, walletID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query risk assessments by wallet ID: %w", err)
+	}
+	return assessments, nil
+}
+
+// FindByReviewStatus retrieves risk assessments by review status, oldest first
+func (r *SQLiteRiskAssessmentRepository) FindByReviewStatus(ctx context.Context, status domain.RiskReviewStatus, limit, offset int) ([]*domain.RiskAssessment, error) {
+	query := `
+		SELECT ` + riskAssessmentColumns + `
+		FROM risk_assessments
+		WHERE review_status = ?
+		ORDER BY created_at
+		LIMIT ? OFFSET ?
+	`
+
+	assessments, err := r.query(ctx, query, string(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query risk assessments by review status: %w", err)
+	}
+	return assessments, nil
+}
+
+// query runs a risk assessment query and scans every row
+func (r *SQLiteRiskAssessmentRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.RiskAssessment, error) {
+	rows, err := querierFor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assessments []*domain.RiskAssessment
+
+	for rows.Next() {
+		assessment, err := scanRiskAssessment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan risk assessment row: %w", err)
+		}
+		assessments = append(assessments, assessment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating risk assessment rows: %w", err)
+	}
+
+	return assessments, nil
+}
+
+// Create saves a new risk assessment
+func (r *SQLiteRiskAssessmentRepository) Create(ctx context.Context, assessment *domain.RiskAssessment) error {
+	query := `
+		INSERT INTO risk_assessments (wallet_id, transaction_id, transaction_type, amount, to_wallet_id,
+		                              decision, rule_hits, review_status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	ruleHitsJSON, err := json.Marshal(assessment.RuleHits)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rule hits: %w", err)
+	}
+
+	err = querierFor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		assessment.WalletID,
+		nullInt(assessment.TransactionID),
+		string(assessment.TransactionType),
+		assessment.Amount,
+		nullInt(assessment.ToWalletID),
+		string(assessment.Decision),
+		string(ruleHitsJSON),
+		string(assessment.ReviewStatus),
+		utc(assessment.CreatedAt),
+		utc(assessment.UpdatedAt),
+	).Scan(&assessment.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert risk assessment: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing risk assessment
+func (r *SQLiteRiskAssessmentRepository) Update(ctx context.Context, assessment *domain.RiskAssessment) error {
+	query := `
+		UPDATE risk_assessments
+		SET transaction_id = ?, review_status = ?, reviewed_by = ?, review_note = ?,
+		    updated_at = ?, reviewed_at = ?
+		WHERE id = ?
+	`
+
+	assessment.UpdatedAt = time.Now()
+
+	result, err := querierFor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		nullInt(assessment.TransactionID),
+		string(assessment.ReviewStatus),
+		nullString(assessment.ReviewedBy),
+		nullString(assessment.ReviewNote),
+		utc(assessment.UpdatedAt),
+		nullTime(assessment.ReviewedAt),
+		assessment.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update risk assessment: %w", err)
+	}
+
+	return checkAffected(result, "risk assessment", assessment.ID)
+}
+
+func scanRiskAssessment(row rowScanner) (*domain.RiskAssessment, error) {
+	var assessment domain.RiskAssessment
+	var typeStr, decisionStr, reviewStatusStr, ruleHitsJSON string
+	var transactionID, toWalletID sql.NullInt64
+	var reviewedBy, reviewNote sql.NullString
+	var reviewedAt sql.NullTime
+
+	err := row.Scan(
+		&assessment.ID,
+		&assessment.WalletID,
+		&transactionID,
+		&typeStr,
+		&assessment.Amount,
+		&toWalletID,
+		&decisionStr,
+		&ruleHitsJSON,
+		&reviewStatusStr,
+		&reviewedBy,
+		&reviewNote,
+		&assessment.CreatedAt,
+		&assessment.UpdatedAt,
+		&reviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	assessment.TransactionType = domain.TransactionType(typeStr)
+	assessment.Decision = domain.RiskDecision(decisionStr)
+	assessment.ReviewStatus = domain.RiskReviewStatus(reviewStatusStr)
+	assessment.ReviewedBy = reviewedBy.String
+	assessment.ReviewNote = reviewNote.String
+
+	if transactionID.Valid {
+		id := int(transactionID.Int64)
+		assessment.TransactionID = &id
+	}
+
+	if toWalletID.Valid {
+		id := int(toWalletID.Int64)
+		assessment.ToWalletID = &id
+	}
+
+	if reviewedAt.Valid {
+		assessment.ReviewedAt = &reviewedAt.Time
+	}
+
+	assessment.RuleHits = []domain.RiskRuleHit{}
+	if ruleHitsJSON != "" {
+		if err := json.Unmarshal([]byte(ruleHitsJSON), &assessment.RuleHits); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rule hits: %w", err)
+		}
+	}
+
+	return &assessment, nil
+}
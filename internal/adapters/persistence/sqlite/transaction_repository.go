@@ -0,0 +1,427 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"strings"
+	"time"
+)
+
+// transactionColumns are the columns read by scanTransaction
+const transactionColumns = `id, wallet_id, type, amount, fee, status, reference, description, to_wallet_id,
+	created_at, updated_at, completed_at, status_reason, status_actor`
+
+// SQLiteTransactionRepository implements the TransactionRepository interface for SQLite
+type SQLiteTransactionRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTransactionRepository creates a new SQLite transaction repository
+func NewSQLiteTransactionRepository(db *sql.DB) *SQLiteTransactionRepository {
+	return &SQLiteTransactionRepository{
+		db: db,
+	}
+}
+
+// FindByID retrieves a transaction by its ID
+func (r *SQLiteTransactionRepository) FindByID(ctx context.Context, id int) (*domain.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE id = ?`
+
+	transaction, err := scanTransaction(querierFor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query transaction by ID: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// FindByWalletID retrieves all transactions for a wallet
+func (r *SQLiteTransactionRepository) FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE wallet_id = ?1 OR to_wallet_id = ?1
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?2 OFFSET ?3
+	`
+
+	transactions, err := r.query(ctx, query, walletID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transactions by wallet ID: %w", err)
+	}
+	return transactions, nil
+}
+
+// FindByQuery retrieves the transactions of a wallet matching a query in creation
+// order. After a cursor the position does not shift as new rows arrive, unlike offsets
+func (r *SQLiteTransactionRepository) FindByQuery(ctx context.Context, query domain.TransactionQuery) ([]*domain.Transaction, error) {
+	conditions, args := transactionQueryConditions(query)
+
+	order, after := "DESC", "<"
+	if query.OldestFirst {
+		order, after = "ASC", ">"
+	}
+
+	// The row comparison follows the (created_at, id) order of the wallet indexes
+	if query.After != nil {
+		args = append(args, utc(query.After.CreatedAt), query.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) %s (?%d, ?%d)", after, len(args)-1, len(args)))
+	}
+
+	// A negative limit has no upper bound in SQLite
+	limit := -1
+	if query.Limit > 0 {
+		limit = query.Limit
+	}
+
+	offset := 0
+	if query.After == nil {
+		offset = query.Offset
+	}
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM transactions
+		WHERE %s
+		ORDER BY created_at %[3]s, id %[3]s
+		LIMIT %[4]d OFFSET %[5]d
+	`, transactionColumns, strings.Join(conditions, " AND "), order, limit, offset)
+
+	transactions, err := r.query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transactions by wallet ID: %w", err)
+	}
+	return transactions, nil
+}
+
+// CountByQuery counts the transactions of a wallet matching a query filter
+func (r *SQLiteTransactionRepository) CountByQuery(ctx context.Context, query domain.TransactionQuery) (int, error) {
+	conditions, args := transactionQueryConditions(query)
+
+	sqlQuery := `SELECT COUNT(*) FROM transactions WHERE ` + strings.Join(conditions, " AND ")
+
+	var count int
+	err := querierFor(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transactions by query: %w", err)
+	}
+
+	return count, nil
+}
+
+// SumBalanceChanges adds up how much the transactions matching a query filter
+// changed the wallet balance. The CASE mirrors Transaction.BalanceChangeFor
+func (r *SQLiteTransactionRepository) SumBalanceChanges(ctx context.Context, query domain.TransactionQuery) (int, error) {
+	conditions, args := transactionQueryConditions(query)
+	conditions = append(conditions, fmt.Sprintf("status = '%s'", domain.TransactionStatusCompleted))
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT COALESCE(SUM(
+			CASE
+				WHEN to_wallet_id = ?1 THEN amount
+				WHEN type = '%s' THEN amount - fee
+				WHEN type IN ('%s', '%s') THEN -(amount + fee)
+				ELSE 0
+			END
+		), 0)
+		FROM transactions
+		WHERE %s
+	`, domain.TransactionTypeDeposit, domain.TransactionTypeWithdrawal, domain.TransactionTypeTransfer,
+		strings.Join(conditions, " AND "))
+
+	var sum int
+	err := querierFor(ctx, r.db).QueryRowContext(ctx, sqlQuery, args...).Scan(&sum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum transaction balance changes: %w", err)
+	}
+
+	return sum, nil
+}
+
+// transactionQueryConditions translates the wallet and filter of a query into
+// SQL conditions with their numbered arguments
+func transactionQueryConditions(query domain.TransactionQuery) ([]string, []interface{}) {
+	args := []interface{}{query.WalletID}
+	conditions := []string{"(wallet_id = ?1 OR to_wallet_id = ?1)"}
+
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("?%d", len(args))
+	}
+
+	filter := query.Filter
+
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= "+arg(utc(*filter.From)))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < "+arg(utc(*filter.To)))
+	}
+
+	if len(filter.Types) > 0 {
+		types := make([]string, len(filter.Types))
+		for i, t := range filter.Types {
+			types[i] = arg(string(t))
+		}
+		conditions = append(conditions, "type IN ("+strings.Join(types, ", ")+")")
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, s := range filter.Statuses {
+			statuses[i] = arg(string(s))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(statuses, ", ")+")")
+	}
+
+	if filter.MinAmount != nil {
+		conditions = append(conditions, "amount >= "+arg(*filter.MinAmount))
+	}
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, "amount <= "+arg(*filter.MaxAmount))
+	}
+
+	if filter.CounterpartyWalletID != nil {
+		counterparty := arg(*filter.CounterpartyWalletID)
+		conditions = append(conditions, fmt.Sprintf(
+			"((wallet_id = ?1 AND to_wallet_id = %[1]s) OR (to_wallet_id = ?1 AND wallet_id = %[1]s))", counterparty))
+	}
+
+	// Mirrors Transaction.DirectionFor
+	switch filter.Direction {
+	case domain.TransactionDirectionIn:
+		conditions = append(conditions, "(to_wallet_id = ?1 OR type = "+arg(string(domain.TransactionTypeDeposit))+")")
+	case domain.TransactionDirectionOut:
+		conditions = append(conditions, "(to_wallet_id IS NOT ?1 AND type <> "+arg(string(domain.TransactionTypeDeposit))+")")
+	}
+
+	// LIKE ignores the case of ASCII letters only, unlike ILIKE in Postgres
+	if filter.Description != "" {
+		conditions = append(conditions, "description LIKE "+arg("%"+escapeLike(filter.Description)+"%")+` ESCAPE '\'`)
+	}
+
+	if filter.Reference != "" {
+		conditions = append(conditions, "reference = "+arg(filter.Reference))
+	}
+
+	return conditions, args
+}
+
+// escapeLike escapes the LIKE wildcards of a search term
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
+}
+
+// FindByStatus retrieves transactions by status with optional pagination
+func (r *SQLiteTransactionRepository) FindByStatus(ctx context.Context, status domain.TransactionStatus, limit, offset int) ([]*domain.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE status = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	transactions, err := r.query(ctx, query, string(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transactions by status: %w", err)
+	}
+	return transactions, nil
+}
+
+// FindPendingTransactions retrieves pending transactions older than a specified time
+func (r *SQLiteTransactionRepository) FindPendingTransactions(ctx context.Context, olderThan time.Time) ([]*domain.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE status = ? AND created_at < ?
+		ORDER BY created_at
+	`
+
+	transactions, err := r.query(ctx, query, string(domain.TransactionStatusPending), utc(olderThan))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// query runs a transaction query and scans every row
+func (r *SQLiteTransactionRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Transaction, error) {
+	rows, err := querierFor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*domain.Transaction
+
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction row: %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transaction rows: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// CountByWalletID counts all transactions for a wallet
+func (r *SQLiteTransactionRepository) CountByWalletID(ctx context.Context, walletID int) (int, error) {
+	query := `SELECT COUNT(*) FROM transactions WHERE wallet_id = ?1 OR to_wallet_id = ?1`
+
+	var count int
+	err := querierFor(ctx, r.db).QueryRowContext(ctx, query, walletID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transactions by wallet ID: %w", err)
+	}
+
+	return count, nil
+}
+
+// Create saves a new transaction
+func (r *SQLiteTransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) error {
+	query := `
+		INSERT INTO transactions (wallet_id, type, amount, fee, status, reference, description, to_wallet_id,
+		                          created_at, updated_at, completed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`
+
+	if transaction.Status == domain.TransactionStatusCompleted {
+		now := time.Now()
+		transaction.CompletedAt = &now
+	}
+
+	var toWalletID sql.NullInt64
+	if transaction.ToWalletID != nil {
+		toWalletID = sql.NullInt64{Int64: int64(*transaction.ToWalletID), Valid: true}
+	}
+
+	err := querierFor(ctx, r.db).QueryRowContext(
+		ctx,
+		query,
+		transaction.WalletID,
+		string(transaction.Type),
+		transaction.Amount,
+		transaction.Fee,
+		string(transaction.Status),
+		nullString(transaction.Reference),
+		nullString(transaction.Description),
+		toWalletID,
+		utc(transaction.CreatedAt),
+		utc(transaction.UpdatedAt),
+		nullTime(transaction.CompletedAt),
+	).Scan(&transaction.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing transaction
+func (r *SQLiteTransactionRepository) Update(ctx context.Context, transaction *domain.Transaction) error {
+	query := `
+		UPDATE transactions
+		SET status = ?, fee = ?, reference = ?, description = ?, updated_at = ?, completed_at = ?,
+		    status_reason = ?, status_actor = ?
+		WHERE id = ?
+	`
+
+	transaction.UpdatedAt = time.Now()
+
+	result, err := querierFor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		string(transaction.Status),
+		transaction.Fee,
+		nullString(transaction.Reference),
+		nullString(transaction.Description),
+		utc(transaction.UpdatedAt),
+		nullTime(transaction.CompletedAt),
+		nullString(transaction.StatusReason),
+		nullString(transaction.StatusActor),
+		transaction.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	return checkAffected(result, "transaction", transaction.ID)
+}
+
+// UpdateStatus updates only the status of a transaction
+func (r *SQLiteTransactionRepository) UpdateStatus(ctx context.Context, id int, status domain.TransactionStatus) error {
+	query := `
+		UPDATE transactions
+		SET status = ?1, updated_at = ?2,
+		    completed_at = CASE WHEN ?1 = 'COMPLETED' AND completed_at IS NULL THEN ?2 ELSE completed_at END
+		WHERE id = ?3
+	`
+
+	result, err := querierFor(ctx, r.db).ExecContext(ctx, query, string(status), utc(time.Now()), id)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	return checkAffected(result, "transaction", id)
+}
+
+func scanTransaction(row rowScanner) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	var typeStr, statusStr string
+	var reference, description, statusReason, statusActor sql.NullString
+	var toWalletID sql.NullInt64
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.WalletID,
+		&typeStr,
+		&transaction.Amount,
+		&transaction.Fee,
+		&statusStr,
+		&reference,
+		&description,
+		&toWalletID,
+		&transaction.CreatedAt,
+		&transaction.UpdatedAt,
+		&completedAt,
+		&statusReason,
+		&statusActor,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.Type = domain.TransactionType(typeStr)
+	transaction.Status = domain.TransactionStatus(statusStr)
+	transaction.Reference = reference.String
+	transaction.Description = description.String
+	transaction.StatusReason = statusReason.String
+	transaction.StatusActor = statusActor.String
+
+	if toWalletID.Valid {
+		id := int(toWalletID.Int64)
+		transaction.ToWalletID = &id
+	}
+
+	if completedAt.Valid {
+		transaction.CompletedAt = &completedAt.Time
+	}
+
+	return &transaction, nil
+}
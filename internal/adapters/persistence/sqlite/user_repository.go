@@ -0,0 +1,164 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// userColumns are the columns read by scanUser
+const userColumns = `id, fullname, email, phone, handle, status, created_at, updated_at`
+
+// SQLiteUserRepository implements the UserRepository interface for SQLite
+type SQLiteUserRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteUserRepository creates a new SQLite user repository
+func NewSQLiteUserRepository(db *sql.DB) *SQLiteUserRepository {
+	return &SQLiteUserRepository{
+		db: db,
+	}
+}
+
+// FindByID retrieves a user by ID
+func (r *SQLiteUserRepository) FindByID(ctx context.Context, id int) (*domain.User, error) {
+	user, err := r.findBy(ctx, "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user by ID: %w", err)
+	}
+	return user, nil
+}
+
+// FindByEmail retrieves a user by email
+func (r *SQLiteUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	user, err := r.findBy(ctx, "email", email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user by email: %w", err)
+	}
+	return user, nil
+}
+
+// FindByPhone retrieves a user by phone
+func (r *SQLiteUserRepository) FindByPhone(ctx context.Context, phone string) (*domain.User, error) {
+	user, err := r.findBy(ctx, "phone", phone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user by phone: %w", err)
+	}
+	return user, nil
+}
+
+// FindByHandle retrieves a user by handle
+func (r *SQLiteUserRepository) FindByHandle(ctx context.Context, handle string) (*domain.User, error) {
+	user, err := r.findBy(ctx, "handle", handle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user by handle: %w", err)
+	}
+	return user, nil
+}
+
+// findBy retrieves the user whose unique column equals value, nil when there is none
+func (r *SQLiteUserRepository) findBy(ctx context.Context, column string, value interface{}) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE ` + column + ` = ?`
+
+	user, err := scanUser(querierFor(ctx, r.db).QueryRowContext(ctx, query, value))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil // Not found
+	}
+	return user, err
+}
+
+// Save creates or updates a user
+func (r *SQLiteUserRepository) Save(ctx context.Context, user *domain.User) error {
+	if user.ID == 0 {
+		// Create new user
+		query := `
+			INSERT INTO users (fullname, email, phone, handle, status, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`
+
+		err := querierFor(ctx, r.db).QueryRowContext(
+			ctx,
+			query,
+			user.Fullname,
+			user.Email,
+			user.Phone,
+			nullString(user.Handle),
+			string(user.Status),
+			utc(user.CreatedAt),
+			utc(user.UpdatedAt),
+		).Scan(&user.ID)
+
+		if err != nil {
+			return fmt.Errorf("failed to insert user: %w", err)
+		}
+
+		return nil
+	}
+
+	// Update existing user
+	query := `
+		UPDATE users
+		SET fullname = ?, email = ?, phone = ?, handle = ?, status = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	user.UpdatedAt = time.Now()
+
+	result, err := querierFor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		user.Fullname,
+		user.Email,
+		user.Phone,
+		nullString(user.Handle),
+		string(user.Status),
+		utc(user.UpdatedAt),
+		user.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return checkAffected(result, "user", user.ID)
+}
+
+// Delete removes a user
+func (r *SQLiteUserRepository) Delete(ctx context.Context, id int) error {
+	result, err := querierFor(ctx, r.db).ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return checkAffected(result, "user", id)
+}
+
+func scanUser(row rowScanner) (*domain.User, error) {
+	var user domain.User
+	var statusStr string
+	var handle sql.NullString
+
+	err := row.Scan(
+		&user.ID,
+		&user.Fullname,
+		&user.Email,
+		&user.Phone,
+		&handle,
+		&statusStr,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Status = domain.UserStatus(statusStr)
+	user.Handle = handle.String
+
+	return &user, nil
+}
@@ -0,0 +1,218 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// walletColumns are the columns read by scanWallet
+const walletColumns = `id, user_id, balance, currency_code, description, status, status_reason, status_actor,
+	status_changed_at, created_at, updated_at`
+
+// SQLiteWalletRepository implements the WalletRepository interface for SQLite
+type SQLiteWalletRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteWalletRepository creates a new SQLite wallet repository
+func NewSQLiteWalletRepository(db *sql.DB) *SQLiteWalletRepository {
+	return &SQLiteWalletRepository{
+		db: db,
+	}
+}
+
+// FindByID retrieves a wallet by its ID
+func (r *SQLiteWalletRepository) FindByID(ctx context.Context, id int) (*domain.Wallet, error) {
+	query := `SELECT ` + walletColumns + ` FROM wallets WHERE id = ?`
+
+	wallet, err := scanWallet(querierFor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not found
+		}
+		return nil, fmt.Errorf("failed to query wallet by ID: %w", err)
+	}
+
+	return wallet, nil
+}
+
+// FindByUserID retrieves all wallets for a user
+func (r *SQLiteWalletRepository) FindByUserID(ctx context.Context, userID int) ([]*domain.Wallet, error) {
+	query := `SELECT ` + walletColumns + ` FROM wallets WHERE user_id = ? ORDER BY id`
+
+	wallets, err := r.query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query wallets by user ID: %w", err)
+	}
+	return wallets, nil
+}
+
+// FindAfterID retrieves up to limit wallets with an ID above afterID, ordered by ID
+func (r *SQLiteWalletRepository) FindAfterID(ctx context.Context, afterID int, limit int) ([]*domain.Wallet, error) {
+	query := `SELECT ` + walletColumns + ` FROM wallets WHERE id > ? ORDER BY id LIMIT ?`
+
+	wallets, err := r.query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query wallets after ID: %w", err)
+	}
+	return wallets, nil
+}
+
+// query runs a wallet query and scans every row
+func (r *SQLiteWalletRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Wallet, error) {
+	rows, err := querierFor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wallets []*domain.Wallet
+
+	for rows.Next() {
+		wallet, err := scanWallet(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan wallet row: %w", err)
+		}
+		wallets = append(wallets, wallet)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating wallet rows: %w", err)
+	}
+
+	return wallets, nil
+}
+
+// Save creates or updates a wallet
+func (r *SQLiteWalletRepository) Save(ctx context.Context, wallet *domain.Wallet) error {
+	if wallet.ID == 0 {
+		// Create new wallet
+		query := `
+			INSERT INTO wallets (user_id, balance, currency_code, description, status, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`
+
+		err := querierFor(ctx, r.db).QueryRowContext(
+			ctx,
+			query,
+			wallet.UserID,
+			wallet.Balance,
+			wallet.CurrencyCode,
+			wallet.Description,
+			string(wallet.Status),
+			utc(wallet.CreatedAt),
+			utc(wallet.UpdatedAt),
+		).Scan(&wallet.ID)
+
+		if err != nil {
+			return fmt.Errorf("failed to insert wallet: %w", err)
+		}
+
+		return nil
+	}
+
+	// Update existing wallet
+	query := `
+		UPDATE wallets
+		SET user_id = ?, balance = ?, currency_code = ?, description = ?, status = ?,
+		    status_reason = ?, status_actor = ?, status_changed_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	wallet.UpdatedAt = time.Now()
+
+	result, err := querierFor(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		wallet.UserID,
+		wallet.Balance,
+		wallet.CurrencyCode,
+		wallet.Description,
+		string(wallet.Status),
+		nullString(string(wallet.StatusReason)),
+		nullString(wallet.StatusActor),
+		nullTime(wallet.StatusChangedAt),
+		utc(wallet.UpdatedAt),
+		wallet.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update wallet: %w", err)
+	}
+
+	return checkAffected(result, "wallet", wallet.ID)
+}
+
+// UpdateBalance updates only the wallet balance
+func (r *SQLiteWalletRepository) UpdateBalance(ctx context.Context, walletID int, newBalance int) error {
+	query := `UPDATE wallets SET balance = ?, updated_at = ? WHERE id = ?`
+
+	result, err := querierFor(ctx, r.db).ExecContext(ctx, query, newBalance, utc(time.Now()), walletID)
+	if err != nil {
+		return fmt.Errorf("failed to update wallet balance: %w", err)
+	}
+
+	return checkAffected(result, "wallet", walletID)
+}
+
+// UpdateStatus updates only the wallet status
+func (r *SQLiteWalletRepository) UpdateStatus(ctx context.Context, walletID int, status domain.WalletStatus) error {
+	query := `UPDATE wallets SET status = ?, updated_at = ? WHERE id = ?`
+
+	result, err := querierFor(ctx, r.db).ExecContext(ctx, query, string(status), utc(time.Now()), walletID)
+	if err != nil {
+		return fmt.Errorf("failed to update wallet status: %w", err)
+	}
+
+	return checkAffected(result, "wallet", walletID)
+}
+
+// Delete removes a wallet
+func (r *SQLiteWalletRepository) Delete(ctx context.Context, id int) error {
+	result, err := querierFor(ctx, r.db).ExecContext(ctx, `DELETE FROM wallets WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete wallet: %w", err)
+	}
+
+	return checkAffected(result, "wallet", id)
+}
+
+func scanWallet(row rowScanner) (*domain.Wallet, error) {
+	var wallet domain.Wallet
+	var statusStr string
+	var description, statusReason, statusActor sql.NullString
+	var statusChangedAt sql.NullTime
+
+	err := row.Scan(
+		&wallet.ID,
+		&wallet.UserID,
+		&wallet.Balance,
+		&wallet.CurrencyCode,
+		&description,
+		&statusStr,
+		&statusReason,
+		&statusActor,
+		&statusChangedAt,
+		&wallet.CreatedAt,
+		&wallet.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet.Description = description.String
+	wallet.Status = domain.WalletStatus(statusStr)
+	wallet.StatusReason = domain.WalletStatusReason(statusReason.String)
+	wallet.StatusActor = statusActor.String
+
+	if statusChangedAt.Valid {
+		wallet.StatusChangedAt = &statusChangedAt.Time
+	}
+
+	return &wallet, nil
+}
@@ -14,13 +14,15 @@ type RedisCache struct {
 	client *redis.Client
 }
 
-// NewRedisCache creates a new Redis cache instance
+// NewRedisCache creates a new Redis cache instance. Commands are traced as
+// child spans of the context they run with
 func NewRedisCache(addr, password string, db int) *RedisCache {
 	client := redis.NewClient(&redis.Options{
 		Addr:     addr,
 		Password: password,
 		DB:       db,
 	})
+	client.AddHook(tracingHook{})
 
 	return &RedisCache{
 		client: client,
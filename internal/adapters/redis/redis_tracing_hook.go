@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName names the tracer of the Redis spans
+const tracerName = "ports-and-adapters-architecture/redis"
+
+// tracingHook starts a client span for every command and pipeline. Only the
+// command names are recorded, keys and values stay out of the spans
+type tracingHook struct{}
+
+// BeforeProcess starts the span of a command
+func (tracingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, _ = startSpan(ctx, cmd.FullName(), attribute.String("db.operation.name", cmd.Name()))
+	return ctx, nil
+}
+
+// AfterProcess ends the span of a command
+func (tracingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	endSpan(ctx, cmd.Err())
+	return nil
+}
+
+// BeforeProcessPipeline starts the span of a pipeline
+func (tracingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	names := make([]string, len(cmds))
+	for i, cmd := range cmds {
+		names[i] = cmd.Name()
+	}
+
+	ctx, _ = startSpan(ctx, "pipeline",
+		attribute.String("db.operation.name", strings.Join(names, " ")),
+		attribute.Int("db.operation.batch.size", len(cmds)),
+	)
+	return ctx, nil
+}
+
+// AfterProcessPipeline ends the span of a pipeline with the first failure
+func (tracingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmd.Err() != nil {
+			err = cmd.Err()
+			break
+		}
+	}
+
+	endSpan(ctx, err)
+	return nil
+}
+
+// startSpan starts a client span of the global tracer provider
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemRedis),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// endSpan ends the span started in ctx. A missing key is not an error
+func endSpan(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err != nil && err != redis.Nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,274 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/external"
+	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"time"
+)
+
+// rule names recorded on every hit
+const (
+	RuleVelocity     = "velocity"
+	RuleNewRecipient = "new_recipient"
+	RuleOddHour      = "odd_hour"
+	RuleRapidInOut   = "rapid_in_out"
+)
+
+// historyLimit caps how many recent transactions are loaded per check
+const historyLimit = 200
+
+// VelocityRuleConfig flags wallets issuing too many operations of the same type in a window
+type VelocityRuleConfig struct {
+	Enabled  bool                `mapstructure:"enabled"`
+	Window   time.Duration       `mapstructure:"window"`
+	MaxCount int                 `mapstructure:"max_count"`
+	Decision domain.RiskDecision `mapstructure:"decision"`
+}
+
+// NewRecipientRuleConfig flags large transfers to a wallet never paid before
+type NewRecipientRuleConfig struct {
+	Enabled         bool                `mapstructure:"enabled"`
+	AmountThreshold int                 `mapstructure:"amount_threshold"`
+	Decision        domain.RiskDecision `mapstructure:"decision"`
+}
+
+// OddHourRuleConfig flags large amounts moved during unusual hours
+type OddHourRuleConfig struct {
+	Enabled         bool                `mapstructure:"enabled"`
+	StartHour       int                 `mapstructure:"start_hour"`
+	EndHour         int                 `mapstructure:"end_hour"`
+	Timezone        string              `mapstructure:"timezone"`
+	AmountThreshold int                 `mapstructure:"amount_threshold"`
+	Decision        domain.RiskDecision `mapstructure:"decision"`
+}
+
+// RapidInOutRuleConfig flags funds that leave a wallet shortly after arriving
+type RapidInOutRuleConfig struct {
+	Enabled  bool                `mapstructure:"enabled"`
+	Window   time.Duration       `mapstructure:"window"`
+	MinRatio float64             `mapstructure:"min_ratio"`
+	Decision domain.RiskDecision `mapstructure:"decision"`
+}
+
+// RulesConfig holds the configuration of every local rule,
+// usually loaded from the risk.rules section of the YAML config
+type RulesConfig struct {
+	Velocity     VelocityRuleConfig     `mapstructure:"velocity"`
+	NewRecipient NewRecipientRuleConfig `mapstructure:"new_recipient"`
+	OddHour      OddHourRuleConfig      `mapstructure:"odd_hour"`
+	RapidInOut   RapidInOutRuleConfig   `mapstructure:"rapid_in_out"`
+}
+
+// Validate checks that every enabled rule is usable
+func (c RulesConfig) Validate() error {
+	if c.Velocity.Enabled {
+		if c.Velocity.Window <= 0 || c.Velocity.MaxCount <= 0 {
+			return fmt.Errorf("velocity rule requires a positive window and max_count")
+		}
+		if !c.Velocity.Decision.IsValid() {
+			return fmt.Errorf("velocity rule: %w", domain.ErrInvalidRiskDecision)
+		}
+	}
+
+	if c.NewRecipient.Enabled && !c.NewRecipient.Decision.IsValid() {
+		return fmt.Errorf("new_recipient rule: %w", domain.ErrInvalidRiskDecision)
+	}
+
+	if c.OddHour.Enabled {
+		if c.OddHour.StartHour < 0 || c.OddHour.StartHour > 23 || c.OddHour.EndHour < 0 || c.OddHour.EndHour > 24 {
+			return fmt.Errorf("odd_hour rule hours must be between 0 and 24")
+		}
+		if c.OddHour.Timezone != "" {
+			if _, err := time.LoadLocation(c.OddHour.Timezone); err != nil {
+				return fmt.Errorf("odd_hour rule: invalid timezone %s: %w", c.OddHour.Timezone, err)
+			}
+		}
+		if !c.OddHour.Decision.IsValid() {
+			return fmt.Errorf("odd_hour rule: %w", domain.ErrInvalidRiskDecision)
+		}
+	}
+
+	if c.RapidInOut.Enabled {
+		if c.RapidInOut.Window <= 0 || c.RapidInOut.MinRatio <= 0 {
+			return fmt.Errorf("rapid_in_out rule requires a positive window and min_ratio")
+		}
+		if !c.RapidInOut.Decision.IsValid() {
+			return fmt.Errorf("rapid_in_out rule: %w", domain.ErrInvalidRiskDecision)
+		}
+	}
+
+	return nil
+}
+
+// RulesEngine implements the RiskEngine interface with locally configured rules
+type RulesEngine struct {
+	config          RulesConfig
+	transactionRepo persistence.TransactionRepository
+	location        *time.Location
+}
+
+// NewRulesEngine creates a new rule-based risk engine
+func NewRulesEngine(config RulesConfig, transactionRepo persistence.TransactionRepository) (*RulesEngine, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid risk rules config: %w", err)
+	}
+
+	location := time.UTC
+	if config.OddHour.Timezone != "" {
+		location, _ = time.LoadLocation(config.OddHour.Timezone)
+	}
+
+	return &RulesEngine{
+		config:          config,
+		transactionRepo: transactionRepo,
+		location:        location,
+	}, nil
+}
+
+// Evaluate runs every enabled rule against the request
+func (e *RulesEngine) Evaluate(ctx context.Context, request external.RiskCheckRequest) ([]domain.RiskRuleHit, error) {
+	if request.RequestedAt.IsZero() {
+		request.RequestedAt = time.Now()
+	}
+
+	history, err := e.transactionRepo.FindByWalletID(ctx, request.WalletID, historyLimit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction history: %w", err)
+	}
+
+	hits := []domain.RiskRuleHit{}
+
+	if hit := e.checkVelocity(request, history); hit != nil {
+		hits = append(hits, *hit)
+	}
+	if hit := e.checkNewRecipient(request, history); hit != nil {
+		hits = append(hits, *hit)
+	}
+	if hit := e.checkOddHour(request); hit != nil {
+		hits = append(hits, *hit)
+	}
+	if hit := e.checkRapidInOut(request, history); hit != nil {
+		hits = append(hits, *hit)
+	}
+
+	return hits, nil
+}
+
+// checkVelocity counts operations of the same type issued by the wallet within the window
+func (e *RulesEngine) checkVelocity(request external.RiskCheckRequest, history []*domain.Transaction) *domain.RiskRuleHit {
+	cfg := e.config.Velocity
+	if !cfg.Enabled {
+		return nil
+	}
+
+	since := request.RequestedAt.Add(-cfg.Window)
+	count := 1 // the operation being evaluated
+	for _, tx := range history {
+		if tx.WalletID == request.WalletID && tx.Type == request.TransactionType &&
+			!tx.IsFailed() && tx.CreatedAt.After(since) {
+			count++
+		}
+	}
+
+	if count <= cfg.MaxCount {
+		return nil
+	}
+
+	return &domain.RiskRuleHit{
+		Rule:     RuleVelocity,
+		Decision: cfg.Decision,
+		Reason:   fmt.Sprintf("%d %s operations within %s exceeds limit of %d", count, request.TransactionType, cfg.Window, cfg.MaxCount),
+	}
+}
+
+// checkNewRecipient flags transfers above the threshold to a wallet with no completed transfer history
+func (e *RulesEngine) checkNewRecipient(request external.RiskCheckRequest, history []*domain.Transaction) *domain.RiskRuleHit {
+	cfg := e.config.NewRecipient
+	if !cfg.Enabled || request.TransactionType != domain.TransactionTypeTransfer || request.ToWalletID == nil {
+		return nil
+	}
+
+	if request.Amount < cfg.AmountThreshold {
+		return nil
+	}
+
+	for _, tx := range history {
+		if tx.WalletID == request.WalletID && tx.Type == domain.TransactionTypeTransfer &&
+			tx.ToWalletID != nil && *tx.ToWalletID == *request.ToWalletID && tx.IsCompleted() {
+			return nil
+		}
+	}
+
+	return &domain.RiskRuleHit{
+		Rule:     RuleNewRecipient,
+		Decision: cfg.Decision,
+		Reason:   fmt.Sprintf("first transfer to wallet %d with amount %d", *request.ToWalletID, request.Amount),
+	}
+}
+
+// checkOddHour flags large amounts requested between start_hour and end_hour local time
+func (e *RulesEngine) checkOddHour(request external.RiskCheckRequest) *domain.RiskRuleHit {
+	cfg := e.config.OddHour
+	if !cfg.Enabled || request.Amount < cfg.AmountThreshold {
+		return nil
+	}
+
+	hour := request.RequestedAt.In(e.location).Hour()
+
+	inWindow := false
+	if cfg.StartHour <= cfg.EndHour {
+		inWindow = hour >= cfg.StartHour && hour < cfg.EndHour
+	} else {
+		// window wraps around midnight, e.g. 22 -> 5
+		inWindow = hour >= cfg.StartHour || hour < cfg.EndHour
+	}
+
+	if !inWindow {
+		return nil
+	}
+
+	return &domain.RiskRuleHit{
+		Rule:     RuleOddHour,
+		Decision: cfg.Decision,
+		Reason:   fmt.Sprintf("amount %d requested at %02d:00 %s", request.Amount, hour, e.location),
+	}
+}
+
+// checkRapidInOut flags outbound operations that drain most of what arrived within the window
+func (e *RulesEngine) checkRapidInOut(request external.RiskCheckRequest, history []*domain.Transaction) *domain.RiskRuleHit {
+	cfg := e.config.RapidInOut
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if request.TransactionType != domain.TransactionTypeWithdrawal && request.TransactionType != domain.TransactionTypeTransfer {
+		return nil
+	}
+
+	since := request.RequestedAt.Add(-cfg.Window)
+	inbound := 0
+	for _, tx := range history {
+		if !tx.IsCompleted() || !tx.CreatedAt.After(since) {
+			continue
+		}
+
+		isDeposit := tx.Type == domain.TransactionTypeDeposit && tx.WalletID == request.WalletID
+		isTransferIn := tx.Type == domain.TransactionTypeTransfer && tx.ToWalletID != nil && *tx.ToWalletID == request.WalletID
+		if isDeposit || isTransferIn {
+			inbound += tx.Amount
+		}
+	}
+
+	if inbound == 0 || float64(request.Amount) < float64(inbound)*cfg.MinRatio {
+		return nil
+	}
+
+	return &domain.RiskRuleHit{
+		Rule:     RuleRapidInOut,
+		Decision: cfg.Decision,
+		Reason:   fmt.Sprintf("outbound %d after inbound %d within %s", request.Amount, inbound, cfg.Window),
+	}
+}
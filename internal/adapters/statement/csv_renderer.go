@@ -0,0 +1,130 @@
+package statement
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVRenderer renders statements as CSV. The statement details, the
+// transactions and the totals are separate sections divided by blank rows
+type CSVRenderer struct{}
+
+// NewCSVRenderer creates a CSV statement renderer
+func NewCSVRenderer() *CSVRenderer {
+	return &CSVRenderer{}
+}
+
+// Format returns the CSV statement format
+func (r *CSVRenderer) Format() domain.StatementFormat {
+	return domain.StatementFormatCSV
+}
+
+// ContentType returns the CSV media type
+func (r *CSVRenderer) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+// NewWriter starts a CSV statement written to out
+func (r *CSVRenderer) NewWriter(out io.Writer) infrastructure.StatementWriter {
+	return &csvStatementWriter{w: csv.NewWriter(out)}
+}
+
+type csvStatementWriter struct {
+	w *csv.Writer
+}
+
+func (s *csvStatementWriter) WriteHeader(header domain.StatementHeader) error {
+	rows := [][]string{
+		{"Account Statement"},
+		{"Wallet ID", strconv.Itoa(header.WalletID)},
+		{"Currency", header.Currency},
+		{"From", header.From.Format(time.RFC3339)},
+		{"To", header.To.Format(time.RFC3339)},
+		{"Generated At", header.GeneratedAt.Format(time.RFC3339)},
+		{"Opening Balance", strconv.Itoa(header.OpeningBalance)},
+		{},
+		{"Date", "Transaction ID", "Type", "Reference", "Description", "Counterparty Wallet ID", "Amount", "Fee", "Change", "Balance"},
+	}
+	return s.writeAll(rows)
+}
+
+func (s *csvStatementWriter) WriteLine(line domain.StatementLine) error {
+	tx := line.Transaction
+
+	counterparty := ""
+	if line.Counterparty != nil {
+		counterparty = strconv.Itoa(*line.Counterparty)
+	}
+
+	return s.write([]string{
+		tx.CreatedAt.Format(time.RFC3339),
+		strconv.Itoa(tx.ID),
+		string(tx.Type),
+		safeCell(tx.Reference),
+		safeCell(tx.Description),
+		counterparty,
+		strconv.Itoa(tx.Amount),
+		strconv.Itoa(tx.Fee),
+		strconv.Itoa(line.Change),
+		strconv.Itoa(line.RunningBalance),
+	})
+}
+
+func (s *csvStatementWriter) WriteSummary(summary *domain.StatementSummary) error {
+	rows := [][]string{
+		{},
+		{"Type", "Count", "Credits", "Debits"},
+	}
+	for _, total := range summary.Totals {
+		rows = append(rows, []string{
+			string(total.Type),
+			strconv.Itoa(total.Count),
+			strconv.Itoa(total.Credits),
+			strconv.Itoa(total.Debits),
+		})
+	}
+	rows = append(rows,
+		[]string{},
+		[]string{"Opening Balance", strconv.Itoa(summary.OpeningBalance)},
+		[]string{"Closing Balance", strconv.Itoa(summary.ClosingBalance)},
+	)
+
+	if err := s.writeAll(rows); err != nil {
+		return err
+	}
+	s.w.Flush()
+	if err := s.w.Error(); err != nil {
+		return fmt.Errorf("failed to write statement: %w", err)
+	}
+	return nil
+}
+
+// safeCell keeps spreadsheets from evaluating user supplied text as a formula
+func safeCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (s *csvStatementWriter) write(row []string) error {
+	if err := s.w.Write(row); err != nil {
+		return fmt.Errorf("failed to write statement: %w", err)
+	}
+	return nil
+}
+
+func (s *csvStatementWriter) writeAll(rows [][]string) error {
+	for _, row := range rows {
+		if err := s.write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
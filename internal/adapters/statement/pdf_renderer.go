@@ -0,0 +1,337 @@
+package statement
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A4 page layout in points
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 40
+	pdfFontSize     = 8
+	pdfRowHeight    = 12
+	pdfMaxDescLen   = 28
+	pdfDateLayout   = "2006-01-02 15:04"
+	pdfCatalogObj   = 1
+	pdfPagesObj     = 2
+	pdfFontObj      = 3
+	pdfBoldFontObj  = 4
+	pdfReservedObjs = 4
+)
+
+// pdfColumn is a column of the transaction table. Numbers are right aligned
+// to the column edge, text starts at it
+type pdfColumn struct {
+	title   string
+	x       float64
+	numeric bool
+}
+
+var pdfColumns = []pdfColumn{
+	{title: "Date", x: 40},
+	{title: "ID", x: 140, numeric: true},
+	{title: "Type", x: 150},
+	{title: "Description", x: 210},
+	{title: "Amount", x: 395, numeric: true},
+	{title: "Fee", x: 435, numeric: true},
+	{title: "Change", x: 495, numeric: true},
+	{title: "Balance", x: 555, numeric: true},
+}
+
+// PDFRenderer renders statements as PDF 1.4 documents using the standard
+// Helvetica fonts, so no font files are embedded. Pages are written as soon
+// as they are full and only the current page is held in memory
+type PDFRenderer struct{}
+
+// NewPDFRenderer creates a PDF statement renderer
+func NewPDFRenderer() *PDFRenderer {
+	return &PDFRenderer{}
+}
+
+// Format returns the PDF statement format
+func (r *PDFRenderer) Format() domain.StatementFormat {
+	return domain.StatementFormatPDF
+}
+
+// ContentType returns the PDF media type
+func (r *PDFRenderer) ContentType() string {
+	return "application/pdf"
+}
+
+// NewWriter starts a PDF statement written to out
+func (r *PDFRenderer) NewWriter(out io.Writer) infrastructure.StatementWriter {
+	buffered := bufio.NewWriter(out)
+	return &pdfStatementWriter{
+		buffered: buffered,
+		out:      &countingWriter{w: buffered},
+		offsets:  make([]int64, pdfReservedObjs+1),
+	}
+}
+
+// countingWriter tracks the byte offsets needed for the cross-reference table
+// and keeps the first write error
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
+
+type pdfStatementWriter struct {
+	buffered *bufio.Writer
+	out      *countingWriter
+	// offsets holds the position of every object, indexed by object number
+	offsets []int64
+	pages   []int
+
+	header domain.StatementHeader
+	page   bytes.Buffer
+	y      float64
+}
+
+func (s *pdfStatementWriter) WriteHeader(header domain.StatementHeader) error {
+	s.header = header
+
+	// The binary comment marks the file as binary for transfer tools
+	fmt.Fprint(s.out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	s.writeObject(pdfCatalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObj))
+	s.writeObject(pdfFontObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	s.writeObject(pdfBoldFontObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	s.startPage()
+	s.text("F2", 16, pdfMargin, s.y, "Account Statement")
+	s.y -= 28
+
+	details := [][2]string{
+		{"Wallet ID", strconv.Itoa(header.WalletID)},
+		{"Currency", header.Currency},
+		{"Period", header.From.Format(time.RFC3339) + " to " + header.To.Format(time.RFC3339)},
+		{"Generated At", header.GeneratedAt.Format(time.RFC3339)},
+		{"Opening Balance", strconv.Itoa(header.OpeningBalance)},
+	}
+	for _, detail := range details {
+		s.text("F2", 10, pdfMargin, s.y, detail[0])
+		s.text("F1", 10, pdfMargin+100, s.y, detail[1])
+		s.y -= 14
+	}
+	s.y -= 10
+
+	s.tableHeader()
+	return s.out.err
+}
+
+func (s *pdfStatementWriter) WriteLine(line domain.StatementLine) error {
+	s.ensureSpace(pdfRowHeight)
+
+	tx := line.Transaction
+	cells := []string{
+		tx.CreatedAt.Format(pdfDateLayout),
+		strconv.Itoa(tx.ID),
+		string(tx.Type),
+		truncate(tx.Description, pdfMaxDescLen),
+		strconv.Itoa(tx.Amount),
+		strconv.Itoa(tx.Fee),
+		strconv.Itoa(line.Change),
+		strconv.Itoa(line.RunningBalance),
+	}
+	s.row("F1", cells)
+
+	return s.out.err
+}
+
+func (s *pdfStatementWriter) WriteSummary(summary *domain.StatementSummary) error {
+	s.ensureSpace(float64(len(summary.Totals)+5) * pdfRowHeight)
+
+	s.y -= pdfRowHeight
+	s.text("F2", 10, pdfMargin, s.y, "Totals by Type")
+	s.y -= pdfRowHeight + 4
+
+	s.text("F2", pdfFontSize, pdfMargin, s.y, "Type")
+	s.number("F2", 200, "Count")
+	s.number("F2", 300, "Credits")
+	s.number("F2", 400, "Debits")
+	s.y -= pdfRowHeight
+	for _, total := range summary.Totals {
+		s.text("F1", pdfFontSize, pdfMargin, s.y, string(total.Type))
+		s.number("F1", 200, strconv.Itoa(total.Count))
+		s.number("F1", 300, strconv.Itoa(total.Credits))
+		s.number("F1", 400, strconv.Itoa(total.Debits))
+		s.y -= pdfRowHeight
+	}
+
+	s.y -= 6
+	s.text("F2", 10, pdfMargin, s.y, "Closing Balance")
+	s.text("F1", 10, pdfMargin+100, s.y, strconv.Itoa(summary.ClosingBalance))
+
+	s.finishPage()
+	s.finishDocument()
+
+	if s.out.err != nil {
+		return fmt.Errorf("failed to write statement: %w", s.out.err)
+	}
+	if err := s.buffered.Flush(); err != nil {
+		return fmt.Errorf("failed to write statement: %w", err)
+	}
+	return nil
+}
+
+// startPage begins an empty page at the top margin
+func (s *pdfStatementWriter) startPage() {
+	s.page.Reset()
+	s.y = pdfPageHeight - pdfMargin - 16
+}
+
+// ensureSpace moves to a new page, continuing the table, when the current one
+// cannot fit the given height
+func (s *pdfStatementWriter) ensureSpace(height float64) {
+	if s.y-height >= pdfMargin+pdfRowHeight {
+		return
+	}
+
+	s.finishPage()
+	s.startPage()
+	s.text("F2", 10, pdfMargin, s.y, fmt.Sprintf("Account Statement - Wallet %d (continued)", s.header.WalletID))
+	s.y -= 24
+	s.tableHeader()
+}
+
+// finishPage writes the content of the current page and its page object
+func (s *pdfStatementWriter) finishPage() {
+	footer := fmt.Sprintf("Page %d", len(s.pages)+1)
+	s.text("F1", pdfFontSize, pdfMargin, pdfMargin-12, footer)
+
+	contents := s.newObject()
+	s.offsets[contents] = s.out.n
+	fmt.Fprintf(s.out, "%d 0 obj\n<< /Length %d >>\nstream\n", contents, s.page.Len())
+	_, _ = s.page.WriteTo(s.out)
+	fmt.Fprint(s.out, "\nendstream\nendobj\n")
+
+	page := s.newObject()
+	s.writeObject(page, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObj, pdfPageWidth, pdfPageHeight, pdfFontObj, pdfBoldFontObj, contents,
+	))
+	s.pages = append(s.pages, page)
+}
+
+// finishDocument writes the page tree, which is only known once every page is
+// written, followed by the cross-reference table and trailer
+func (s *pdfStatementWriter) finishDocument() {
+	kids := make([]string, len(s.pages))
+	for i, page := range s.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+	s.writeObject(pdfPagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(s.pages)))
+
+	xref := s.out.n
+	fmt.Fprintf(s.out, "xref\n0 %d\n0000000000 65535 f \n", len(s.offsets))
+	for _, offset := range s.offsets[1:] {
+		fmt.Fprintf(s.out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(s.out, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(s.offsets), pdfCatalogObj, xref)
+}
+
+// newObject allocates the next object number
+func (s *pdfStatementWriter) newObject() int {
+	s.offsets = append(s.offsets, 0)
+	return len(s.offsets) - 1
+}
+
+func (s *pdfStatementWriter) writeObject(number int, body string) {
+	s.offsets[number] = s.out.n
+	fmt.Fprintf(s.out, "%d 0 obj\n%s\nendobj\n", number, body)
+}
+
+func (s *pdfStatementWriter) tableHeader() {
+	titles := make([]string, len(pdfColumns))
+	for i, column := range pdfColumns {
+		titles[i] = column.title
+	}
+	s.row("F2", titles)
+	fmt.Fprintf(&s.page, "0.5 w %d %.2f m %d %.2f l S\n", pdfMargin, s.y+pdfRowHeight-3, pdfPageWidth-pdfMargin, s.y+pdfRowHeight-3)
+}
+
+// row draws one table row and moves down
+func (s *pdfStatementWriter) row(font string, cells []string) {
+	for i, column := range pdfColumns {
+		if column.numeric {
+			s.number(font, column.x, cells[i])
+		} else {
+			s.text(font, pdfFontSize, column.x, s.y, cells[i])
+		}
+	}
+	s.y -= pdfRowHeight
+}
+
+// number draws a table cell right aligned to x
+func (s *pdfStatementWriter) number(font string, x float64, value string) {
+	s.text(font, pdfFontSize, x-textWidth(font, value, pdfFontSize), s.y, value)
+}
+
+func (s *pdfStatementWriter) text(font string, size, x, y float64, value string) {
+	fmt.Fprintf(&s.page, "BT /%s %g Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escapePDFText(value))
+}
+
+// textWidth measures text set in Helvetica. Only the glyphs of numbers and
+// column titles are measured exactly, others use an average width
+func textWidth(font, value string, size float64) float64 {
+	units := 0
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			units += 556
+		case r == '-':
+			units += 333
+		case font == "F2":
+			units += 611
+		default:
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// escapePDFText escapes a PDF string literal. Characters outside printable
+// ASCII are replaced since the standard fonts only cover WinAnsi
+func escapePDFText(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// truncate shortens text to at most limit characters
+func truncate(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit-3]) + "..."
+}
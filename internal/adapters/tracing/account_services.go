@@ -0,0 +1,206 @@
+package tracing
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// StatementService starts a span for every statement export
+type StatementService struct {
+	service primary.StatementService
+}
+
+// NewStatementService decorates statementService with spans
+func NewStatementService(statementService primary.StatementService) *StatementService {
+	return &StatementService{service: statementService}
+}
+
+// ExportStatement streams a statement to the output
+func (s *StatementService) ExportStatement(ctx context.Context, req primary.StatementRequest, output primary.StatementOutput) error {
+	ctx, span := start(ctx, "StatementService.ExportStatement",
+		attribute.Int("wallet.id", req.WalletID),
+		attribute.String("statement.format", string(req.Format)),
+	)
+	err := s.service.ExportStatement(ctx, req, output)
+	end(span, err)
+	return err
+}
+
+// BalanceSnapshotService starts a span for every call to the balance history
+// service it decorates
+type BalanceSnapshotService struct {
+	service primary.BalanceSnapshotService
+}
+
+// NewBalanceSnapshotService decorates balanceSnapshotService with spans
+func NewBalanceSnapshotService(balanceSnapshotService primary.BalanceSnapshotService) *BalanceSnapshotService {
+	return &BalanceSnapshotService{service: balanceSnapshotService}
+}
+
+// GetBalanceAt retrieves the balance of a wallet at a point in time
+func (s *BalanceSnapshotService) GetBalanceAt(ctx context.Context, walletID int, at time.Time) (*domain.HistoricalBalance, error) {
+	ctx, span := start(ctx, "BalanceSnapshotService.GetBalanceAt", attribute.Int("wallet.id", walletID))
+	balance, err := s.service.GetBalanceAt(ctx, walletID, at)
+	end(span, err)
+	return balance, err
+}
+
+// TakeSnapshots stores the current balance of every wallet
+func (s *BalanceSnapshotService) TakeSnapshots(ctx context.Context) (int, error) {
+	ctx, span := start(ctx, "BalanceSnapshotService.TakeSnapshots")
+	count, err := s.service.TakeSnapshots(ctx)
+	if err == nil {
+		span.SetAttributes(attribute.Int("snapshot.count", count))
+	}
+	end(span, err)
+	return count, err
+}
+
+// RiskService starts a span for every call to the risk review service it decorates
+type RiskService struct {
+	service primary.RiskService
+}
+
+// NewRiskService decorates riskService with spans
+func NewRiskService(riskService primary.RiskService) *RiskService {
+	return &RiskService{service: riskService}
+}
+
+// GetAssessment retrieves a risk assessment by ID
+func (s *RiskService) GetAssessment(ctx context.Context, assessmentID int) (*domain.RiskAssessment, error) {
+	ctx, span := start(ctx, "RiskService.GetAssessment", attribute.Int("assessment.id", assessmentID))
+	assessment, err := s.service.GetAssessment(ctx, assessmentID)
+	end(span, err)
+	return assessment, err
+}
+
+// GetAssessmentsByWalletID retrieves the risk audit trail of a wallet
+func (s *RiskService) GetAssessmentsByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.RiskAssessment, error) {
+	ctx, span := start(ctx, "RiskService.GetAssessmentsByWalletID", attribute.Int("wallet.id", walletID))
+	assessments, err := s.service.GetAssessmentsByWalletID(ctx, walletID, limit, offset)
+	end(span, err)
+	return assessments, err
+}
+
+// GetReviewQueue retrieves flagged assessments waiting for manual review
+func (s *RiskService) GetReviewQueue(ctx context.Context, limit, offset int) ([]*domain.RiskAssessment, error) {
+	ctx, span := start(ctx, "RiskService.GetReviewQueue")
+	assessments, err := s.service.GetReviewQueue(ctx, limit, offset)
+	end(span, err)
+	return assessments, err
+}
+
+// ApproveReview clears a flagged assessment
+func (s *RiskService) ApproveReview(ctx context.Context, assessmentID int, reviewer, note string) (*domain.RiskAssessment, error) {
+	ctx, span := start(ctx, "RiskService.ApproveReview", attribute.Int("assessment.id", assessmentID))
+	assessment, err := s.service.ApproveReview(ctx, assessmentID, reviewer, note)
+	end(span, err)
+	return assessment, err
+}
+
+// RejectReview confirms a flagged assessment as suspicious
+func (s *RiskService) RejectReview(ctx context.Context, assessmentID int, reviewer, note string) (*domain.RiskAssessment, error) {
+	ctx, span := start(ctx, "RiskService.RejectReview", attribute.Int("assessment.id", assessmentID))
+	assessment, err := s.service.RejectReview(ctx, assessmentID, reviewer, note)
+	end(span, err)
+	return assessment, err
+}
+
+// FeeService starts a span for every call to the fee service it decorates
+type FeeService struct {
+	service primary.FeeService
+}
+
+// NewFeeService decorates feeService with spans
+func NewFeeService(feeService primary.FeeService) *FeeService {
+	return &FeeService{service: feeService}
+}
+
+// QuoteFee previews the fee of an operation without moving any funds
+func (s *FeeService) QuoteFee(ctx context.Context, req primary.FeeQuoteRequest) (*domain.FeeQuote, error) {
+	ctx, span := start(ctx, "FeeService.QuoteFee",
+		attribute.Int("wallet.id", req.WalletID),
+		attribute.String("transaction.type", string(req.TransactionType)),
+		attribute.Int("amount", req.Amount),
+	)
+	quote, err := s.service.QuoteFee(ctx, req)
+	end(span, err)
+	return quote, err
+}
+
+// GetFeeSchedule returns the configured fee rules
+func (s *FeeService) GetFeeSchedule(ctx context.Context) (*domain.FeeSchedule, error) {
+	ctx, span := start(ctx, "FeeService.GetFeeSchedule")
+	schedule, err := s.service.GetFeeSchedule(ctx)
+	end(span, err)
+	return schedule, err
+}
+
+// MerchantService starts a span for every call to the merchant service it
+// decorates. Keys, secrets and signatures are left out of the spans
+type MerchantService struct {
+	service primary.MerchantService
+}
+
+// NewMerchantService decorates merchantService with spans
+func NewMerchantService(merchantService primary.MerchantService) *MerchantService {
+	return &MerchantService{service: merchantService}
+}
+
+// CreateMerchant registers an API client and issues its first key
+func (s *MerchantService) CreateMerchant(ctx context.Context, req primary.CreateMerchantRequest) (*domain.Merchant, *domain.APICredentials, error) {
+	ctx, span := start(ctx, "MerchantService.CreateMerchant",
+		attribute.Int("user.id", req.UserID),
+		attribute.String("merchant.environment", string(req.Environment)),
+	)
+	merchant, credentials, err := s.service.CreateMerchant(ctx, req)
+	end(span, err)
+	return merchant, credentials, err
+}
+
+// GetMerchant retrieves a merchant by ID
+func (s *MerchantService) GetMerchant(ctx context.Context, merchantID int) (*domain.Merchant, error) {
+	ctx, span := start(ctx, "MerchantService.GetMerchant", attribute.Int("merchant.id", merchantID))
+	merchant, err := s.service.GetMerchant(ctx, merchantID)
+	end(span, err)
+	return merchant, err
+}
+
+// GetAPIKeys retrieves every key of a merchant, newest first
+func (s *MerchantService) GetAPIKeys(ctx context.Context, merchantID int) ([]*domain.APIKey, error) {
+	ctx, span := start(ctx, "MerchantService.GetAPIKeys", attribute.Int("merchant.id", merchantID))
+	keys, err := s.service.GetAPIKeys(ctx, merchantID)
+	end(span, err)
+	return keys, err
+}
+
+// RotateAPIKey issues a new key
+func (s *MerchantService) RotateAPIKey(ctx context.Context, merchantID int) (*domain.APICredentials, error) {
+	ctx, span := start(ctx, "MerchantService.RotateAPIKey", attribute.Int("merchant.id", merchantID))
+	credentials, err := s.service.RotateAPIKey(ctx, merchantID)
+	end(span, err)
+	return credentials, err
+}
+
+// RevokeAPIKey stops a key immediately
+func (s *MerchantService) RevokeAPIKey(ctx context.Context, merchantID, keyID int) error {
+	ctx, span := start(ctx, "MerchantService.RevokeAPIKey",
+		attribute.Int("merchant.id", merchantID),
+		attribute.Int("api_key.id", keyID),
+	)
+	err := s.service.RevokeAPIKey(ctx, merchantID, keyID)
+	end(span, err)
+	return err
+}
+
+// AuthenticateRequest verifies the key and signature of a request
+func (s *MerchantService) AuthenticateRequest(ctx context.Context, req primary.SignedRequest) (*domain.Principal, error) {
+	ctx, span := start(ctx, "MerchantService.AuthenticateRequest")
+	principal, err := s.service.AuthenticateRequest(ctx, req)
+	end(span, err)
+	return principal, err
+}
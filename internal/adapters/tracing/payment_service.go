@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PaymentService starts a span for every call to the payment service it decorates
+type PaymentService struct {
+	service primary.PaymentService
+}
+
+// NewPaymentService decorates paymentService with spans
+func NewPaymentService(paymentService primary.PaymentService) *PaymentService {
+	return &PaymentService{service: paymentService}
+}
+
+// ProcessPayment initiates a payment through a payment gateway
+func (s *PaymentService) ProcessPayment(ctx context.Context, req primary.PaymentRequest) (*domain.Payment, error) {
+	ctx, span := start(ctx, "PaymentService.ProcessPayment",
+		attribute.Int("wallet.id", req.WalletID),
+		attribute.Int("amount", req.Amount),
+		attribute.String("payment.provider", string(req.PaymentProvider)),
+	)
+	payment, err := s.service.ProcessPayment(ctx, req)
+	end(span, err)
+	return payment, err
+}
+
+// VerifyPayment checks the status of a payment and updates it
+func (s *PaymentService) VerifyPayment(ctx context.Context, paymentID int) (*domain.Payment, error) {
+	ctx, span := start(ctx, "PaymentService.VerifyPayment", attribute.Int("payment.id", paymentID))
+	payment, err := s.service.VerifyPayment(ctx, paymentID)
+	end(span, err)
+	return payment, err
+}
+
+// CancelPayment cancels a pending payment
+func (s *PaymentService) CancelPayment(ctx context.Context, paymentID int) error {
+	ctx, span := start(ctx, "PaymentService.CancelPayment", attribute.Int("payment.id", paymentID))
+	err := s.service.CancelPayment(ctx, paymentID)
+	end(span, err)
+	return err
+}
+
+// GetPaymentID retrieves a payment by its ID
+func (s *PaymentService) GetPaymentID(ctx context.Context, paymentID int) (*domain.Payment, error) {
+	ctx, span := start(ctx, "PaymentService.GetPaymentID", attribute.Int("payment.id", paymentID))
+	payment, err := s.service.GetPaymentID(ctx, paymentID)
+	end(span, err)
+	return payment, err
+}
+
+// GetPaymentsByTransactionID retrieves all payments for a transaction
+func (s *PaymentService) GetPaymentsByTransactionID(ctx context.Context, transactionID int) ([]*domain.Payment, error) {
+	ctx, span := start(ctx, "PaymentService.GetPaymentsByTransactionID", attribute.Int("transaction.id", transactionID))
+	payments, err := s.service.GetPaymentsByTransactionID(ctx, transactionID)
+	end(span, err)
+	return payments, err
+}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TransactionService starts a span for every call to the transaction service it decorates
+type TransactionService struct {
+	service primary.TransactionService
+}
+
+// NewTransactionService decorates transactionService with spans
+func NewTransactionService(transactionService primary.TransactionService) *TransactionService {
+	return &TransactionService{service: transactionService}
+}
+
+// GetTransaction retrieves a transaction by ID
+func (s *TransactionService) GetTransaction(ctx context.Context, transactionID int) (*domain.Transaction, error) {
+	ctx, span := start(ctx, "TransactionService.GetTransaction", attribute.Int("transaction.id", transactionID))
+	transaction, err := s.service.GetTransaction(ctx, transactionID)
+	end(span, err)
+	return transaction, err
+}
+
+// GetTransactionByWalletID retrieves the transactions of a wallet
+func (s *TransactionService) GetTransactionByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.Transaction, int, error) {
+	ctx, span := start(ctx, "TransactionService.GetTransactionByWalletID", attribute.Int("wallet.id", walletID))
+	transactions, total, err := s.service.GetTransactionByWalletID(ctx, walletID, limit, offset)
+	end(span, err)
+	return transactions, total, err
+}
+
+// GetTransactionsByStatus retrieves transactions in a status, newest first
+func (s *TransactionService) GetTransactionsByStatus(ctx context.Context, status domain.TransactionStatus, limit, offset int) ([]*domain.Transaction, error) {
+	ctx, span := start(ctx, "TransactionService.GetTransactionsByStatus", attribute.String("status", string(status)))
+	transactions, err := s.service.GetTransactionsByStatus(ctx, status, limit, offset)
+	end(span, err)
+	return transactions, err
+}
+
+// CreateTransaction creates a new transaction
+func (s *TransactionService) CreateTransaction(ctx context.Context, transaction *domain.Transaction) error {
+	ctx, span := start(ctx, "TransactionService.CreateTransaction", attribute.Int("wallet.id", transaction.WalletID))
+	err := s.service.CreateTransaction(ctx, transaction)
+	end(span, err)
+	return err
+}
+
+// UpdateTransactionStatus overrides the status of a transaction
+func (s *TransactionService) UpdateTransactionStatus(
+	ctx context.Context,
+	transactionID int,
+	status domain.TransactionStatus,
+	reason, actor string,
+) error {
+	ctx, span := start(ctx, "TransactionService.UpdateTransactionStatus",
+		attribute.Int("transaction.id", transactionID),
+		attribute.String("status", string(status)),
+	)
+	err := s.service.UpdateTransactionStatus(ctx, transactionID, status, reason, actor)
+	end(span, err)
+	return err
+}
+
+// GetStalePendingTransactions retrieves the pending transactions ReconcileFailedTransactions would resolve
+func (s *TransactionService) GetStalePendingTransactions(ctx context.Context) ([]*domain.Transaction, error) {
+	ctx, span := start(ctx, "TransactionService.GetStalePendingTransactions")
+	transactions, err := s.service.GetStalePendingTransactions(ctx)
+	end(span, err)
+	return transactions, err
+}
+
+// ReconcileFailedTransactions fails the stale pending transactions
+func (s *TransactionService) ReconcileFailedTransactions(ctx context.Context) error {
+	ctx, span := start(ctx, "TransactionService.ReconcileFailedTransactions")
+	err := s.service.ReconcileFailedTransactions(ctx)
+	end(span, err)
+	return err
+}
@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// BatchTransferService starts a span for every call to the batch transfer
+// service it decorates. Items are paid in the background through the wallet
+// service, their spans belong to the trace of the request that created the batch
+type BatchTransferService struct {
+	service primary.BatchTransferService
+}
+
+// NewBatchTransferService decorates batchTransferService with spans
+func NewBatchTransferService(batchTransferService primary.BatchTransferService) *BatchTransferService {
+	return &BatchTransferService{service: batchTransferService}
+}
+
+// CreateBatchTransfer validates a batch and queues it for processing
+func (s *BatchTransferService) CreateBatchTransfer(ctx context.Context, req primary.BatchTransferRequest) (*domain.BatchTransfer, error) {
+	ctx, span := start(ctx, "BatchTransferService.CreateBatchTransfer",
+		attribute.Int("wallet.id", req.FromWalletID),
+		attribute.Int("batch.items", len(req.Items)),
+	)
+	batch, err := s.service.CreateBatchTransfer(ctx, req)
+	end(span, err)
+	return batch, err
+}
+
+// GetBatchTransfer retrieves a batch transfer with per-item results
+func (s *BatchTransferService) GetBatchTransfer(ctx context.Context, batchID int) (*domain.BatchTransfer, error) {
+	ctx, span := start(ctx, "BatchTransferService.GetBatchTransfer", attribute.Int("batch.id", batchID))
+	batch, err := s.service.GetBatchTransfer(ctx, batchID)
+	end(span, err)
+	return batch, err
+}
+
+// GetBatchTransfersByWalletID retrieves batch transfers sent from a wallet
+func (s *BatchTransferService) GetBatchTransfersByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.BatchTransfer, error) {
+	ctx, span := start(ctx, "BatchTransferService.GetBatchTransfersByWalletID", attribute.Int("wallet.id", walletID))
+	batches, err := s.service.GetBatchTransfersByWalletID(ctx, walletID, limit, offset)
+	end(span, err)
+	return batches, err
+}
+
+// PaymentRequestService starts a span for every call to the payment request
+// service it decorates
+type PaymentRequestService struct {
+	service primary.PaymentRequestService
+}
+
+// NewPaymentRequestService decorates paymentRequestService with spans
+func NewPaymentRequestService(paymentRequestService primary.PaymentRequestService) *PaymentRequestService {
+	return &PaymentRequestService{service: paymentRequestService}
+}
+
+// CreatePaymentRequest asks a user to pay into the requester wallet
+func (s *PaymentRequestService) CreatePaymentRequest(ctx context.Context, req primary.CreatePaymentRequest) (*domain.PaymentRequest, error) {
+	ctx, span := start(ctx, "PaymentRequestService.CreatePaymentRequest",
+		attribute.Int("wallet.id", req.RequesterWalletID),
+		attribute.Int("user.id", req.PayerUserID),
+		attribute.Int("amount", req.Amount),
+	)
+	request, err := s.service.CreatePaymentRequest(ctx, req)
+	end(span, err)
+	return request, err
+}
+
+// GetPaymentRequest retrieves a payment request by ID
+func (s *PaymentRequestService) GetPaymentRequest(ctx context.Context, requestID int) (*domain.PaymentRequest, error) {
+	ctx, span := start(ctx, "PaymentRequestService.GetPaymentRequest", attribute.Int("payment_request.id", requestID))
+	request, err := s.service.GetPaymentRequest(ctx, requestID)
+	end(span, err)
+	return request, err
+}
+
+// GetInbox retrieves requests addressed to a user
+func (s *PaymentRequestService) GetInbox(ctx context.Context, userID int, status domain.PaymentRequestStatus, limit, offset int) ([]*domain.PaymentRequest, error) {
+	ctx, span := start(ctx, "PaymentRequestService.GetInbox", attribute.Int("user.id", userID))
+	requests, err := s.service.GetInbox(ctx, userID, status, limit, offset)
+	end(span, err)
+	return requests, err
+}
+
+// GetOutbox retrieves requests sent by a user
+func (s *PaymentRequestService) GetOutbox(ctx context.Context, userID int, status domain.PaymentRequestStatus, limit, offset int) ([]*domain.PaymentRequest, error) {
+	ctx, span := start(ctx, "PaymentRequestService.GetOutbox", attribute.Int("user.id", userID))
+	requests, err := s.service.GetOutbox(ctx, userID, status, limit, offset)
+	end(span, err)
+	return requests, err
+}
+
+// AcceptPaymentRequest pays a request from one of the payer's wallets
+func (s *PaymentRequestService) AcceptPaymentRequest(ctx context.Context, requestID int, payerWalletID int) (*domain.PaymentRequest, error) {
+	ctx, span := start(ctx, "PaymentRequestService.AcceptPaymentRequest",
+		attribute.Int("payment_request.id", requestID),
+		attribute.Int("wallet.id", payerWalletID),
+	)
+	request, err := s.service.AcceptPaymentRequest(ctx, requestID, payerWalletID)
+	end(span, err)
+	return request, err
+}
+
+// DeclinePaymentRequest refuses a request on behalf of the payer
+func (s *PaymentRequestService) DeclinePaymentRequest(ctx context.Context, requestID int, userID int) (*domain.PaymentRequest, error) {
+	ctx, span := start(ctx, "PaymentRequestService.DeclinePaymentRequest",
+		attribute.Int("payment_request.id", requestID),
+		attribute.Int("user.id", userID),
+	)
+	request, err := s.service.DeclinePaymentRequest(ctx, requestID, userID)
+	end(span, err)
+	return request, err
+}
+
+// CancelPaymentRequest withdraws a request on behalf of the requester
+func (s *PaymentRequestService) CancelPaymentRequest(ctx context.Context, requestID int, userID int) (*domain.PaymentRequest, error) {
+	ctx, span := start(ctx, "PaymentRequestService.CancelPaymentRequest",
+		attribute.Int("payment_request.id", requestID),
+		attribute.Int("user.id", userID),
+	)
+	request, err := s.service.CancelPaymentRequest(ctx, requestID, userID)
+	end(span, err)
+	return request, err
+}
+
+// RecipientService starts a span for every call to the recipient service it
+// decorates. Recipient identifiers are left out of the spans
+type RecipientService struct {
+	service primary.RecipientService
+}
+
+// NewRecipientService decorates recipientService with spans
+func NewRecipientService(recipientService primary.RecipientService) *RecipientService {
+	return &RecipientService{service: recipientService}
+}
+
+// ResolveRecipient finds the wallet of a recipient in a currency
+func (s *RecipientService) ResolveRecipient(ctx context.Context, identifier, currencyCode string) (*domain.Recipient, error) {
+	ctx, span := start(ctx, "RecipientService.ResolveRecipient", attribute.String("currency", currencyCode))
+	recipient, err := s.service.ResolveRecipient(ctx, identifier, currencyCode)
+	end(span, err)
+	return recipient, err
+}
+
+// TransferToRecipient transfers funds to a resolved or confirmed recipient
+func (s *RecipientService) TransferToRecipient(ctx context.Context, req primary.TransferToRecipientRequest) (*domain.Transaction, error) {
+	ctx, span := start(ctx, "RecipientService.TransferToRecipient",
+		attribute.Int("wallet.id", req.FromWalletID),
+		attribute.Int("amount", req.Amount),
+	)
+	transaction, err := s.service.TransferToRecipient(ctx, req)
+	end(span, err)
+	return transaction, err
+}
@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// UserService starts a span for every call to the user service it decorates.
+// Emails and phone numbers are left out of the spans
+type UserService struct {
+	service primary.UserService
+}
+
+// NewUserService decorates userService with spans
+func NewUserService(userService primary.UserService) *UserService {
+	return &UserService{service: userService}
+}
+
+// GetUser retrieves a user by ID
+func (s *UserService) GetUser(ctx context.Context, id int) (*domain.User, error) {
+	ctx, span := start(ctx, "UserService.GetUser", attribute.Int("user.id", id))
+	user, err := s.service.GetUser(ctx, id)
+	end(span, err)
+	return user, err
+}
+
+// GetUserByEmail retrieves a user by email
+func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, span := start(ctx, "UserService.GetUserByEmail")
+	user, err := s.service.GetUserByEmail(ctx, email)
+	end(span, err)
+	return user, err
+}
+
+// GetUserByPhone retrieves a user by phone
+func (s *UserService) GetUserByPhone(ctx context.Context, phone string) (*domain.User, error) {
+	ctx, span := start(ctx, "UserService.GetUserByPhone")
+	user, err := s.service.GetUserByPhone(ctx, phone)
+	end(span, err)
+	return user, err
+}
+
+// CreateUser creates a new user
+func (s *UserService) CreateUser(ctx context.Context, fullname, email, phone string) (*domain.User, error) {
+	ctx, span := start(ctx, "UserService.CreateUser")
+	user, err := s.service.CreateUser(ctx, fullname, email, phone)
+	end(span, err)
+	return user, err
+}
+
+// UpdateUser updates an existing user
+func (s *UserService) UpdateUser(ctx context.Context, id int, fullname, email, phone string) (*domain.User, error) {
+	ctx, span := start(ctx, "UserService.UpdateUser", attribute.Int("user.id", id))
+	user, err := s.service.UpdateUser(ctx, id, fullname, email, phone)
+	end(span, err)
+	return user, err
+}
+
+// SetHandle claims a unique public handle for a user
+func (s *UserService) SetHandle(ctx context.Context, id int, handle string) (*domain.User, error) {
+	ctx, span := start(ctx, "UserService.SetHandle", attribute.Int("user.id", id))
+	user, err := s.service.SetHandle(ctx, id, handle)
+	end(span, err)
+	return user, err
+}
+
+// DeactiveUser deactivates a user
+func (s *UserService) DeactiveUser(ctx context.Context, id int) error {
+	ctx, span := start(ctx, "UserService.DeactiveUser", attribute.Int("user.id", id))
+	err := s.service.DeactiveUser(ctx, id)
+	end(span, err)
+	return err
+}
+
+// ActivateUser activates a user
+func (s *UserService) ActivateUser(ctx context.Context, id int) error {
+	ctx, span := start(ctx, "UserService.ActivateUser", attribute.Int("user.id", id))
+	err := s.service.ActivateUser(ctx, id)
+	end(span, err)
+	return err
+}
@@ -0,0 +1,153 @@
+package tracing
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WalletService starts a span for every call to the wallet service it decorates
+type WalletService struct {
+	service primary.WalletService
+}
+
+// NewWalletService decorates walletService with spans
+func NewWalletService(walletService primary.WalletService) *WalletService {
+	return &WalletService{service: walletService}
+}
+
+// CreateWallet creates a new wallet for a user
+func (s *WalletService) CreateWallet(ctx context.Context, userID int, currencyCode, description string) (*domain.Wallet, error) {
+	ctx, span := start(ctx, "WalletService.CreateWallet", attribute.Int("user.id", userID), attribute.String("currency", currencyCode))
+	wallet, err := s.service.CreateWallet(ctx, userID, currencyCode, description)
+	end(span, err)
+	return wallet, err
+}
+
+// GetWallet retrieves a wallet by ID
+func (s *WalletService) GetWallet(ctx context.Context, walletID int) (*domain.Wallet, error) {
+	ctx, span := start(ctx, "WalletService.GetWallet", attribute.Int("wallet.id", walletID))
+	wallet, err := s.service.GetWallet(ctx, walletID)
+	end(span, err)
+	return wallet, err
+}
+
+// GetWalletsByUserID retrieves all wallets for a user
+func (s *WalletService) GetWalletsByUserID(ctx context.Context, userID int) ([]*domain.Wallet, error) {
+	ctx, span := start(ctx, "WalletService.GetWalletsByUserID", attribute.Int("user.id", userID))
+	wallets, err := s.service.GetWalletsByUserID(ctx, userID)
+	end(span, err)
+	return wallets, err
+}
+
+// Deposit adds funds to a wallet
+func (s *WalletService) Deposit(ctx context.Context, walletID int, amount int, description string) (*domain.Transaction, error) {
+	ctx, span := start(ctx, "WalletService.Deposit", attribute.Int("wallet.id", walletID), attribute.Int("amount", amount))
+	transaction, err := s.service.Deposit(ctx, walletID, amount, description)
+	end(span, err)
+	return transaction, err
+}
+
+// Withdraw removes funds from a wallet
+func (s *WalletService) Withdraw(ctx context.Context, walletID int, amount int, description string) (*domain.Transaction, error) {
+	ctx, span := start(ctx, "WalletService.Withdraw", attribute.Int("wallet.id", walletID), attribute.Int("amount", amount))
+	transaction, err := s.service.Withdraw(ctx, walletID, amount, description)
+	end(span, err)
+	return transaction, err
+}
+
+// Transfer transfers funds from one wallet to another
+func (s *WalletService) Transfer(
+	ctx context.Context,
+	fromWalletID int,
+	toWalletID int,
+	amount int,
+	description string,
+) (*domain.Transaction, error) {
+	ctx, span := start(ctx, "WalletService.Transfer",
+		attribute.Int("wallet.id", fromWalletID),
+		attribute.Int("wallet.to_id", toWalletID),
+		attribute.Int("amount", amount),
+	)
+	transaction, err := s.service.Transfer(ctx, fromWalletID, toWalletID, amount, description)
+	end(span, err)
+	return transaction, err
+}
+
+// TransferWithReference transfers funds and tags the transaction with a reference
+func (s *WalletService) TransferWithReference(
+	ctx context.Context,
+	fromWalletID int,
+	toWalletID int,
+	amount int,
+	description string,
+	reference string,
+) (*domain.Transaction, error) {
+	ctx, span := start(ctx, "WalletService.TransferWithReference",
+		attribute.Int("wallet.id", fromWalletID),
+		attribute.Int("wallet.to_id", toWalletID),
+		attribute.Int("amount", amount),
+		attribute.String("transaction.reference", reference),
+	)
+	transaction, err := s.service.TransferWithReference(ctx, fromWalletID, toWalletID, amount, description, reference)
+	end(span, err)
+	return transaction, err
+}
+
+// GetTransactionHistory retrieves transaction history for a wallet
+func (s *WalletService) GetTransactionHistory(
+	ctx context.Context,
+	walletID int,
+	limit, offset int,
+) ([]*domain.Transaction, int, error) {
+	ctx, span := start(ctx, "WalletService.GetTransactionHistory", attribute.Int("wallet.id", walletID))
+	transactions, total, err := s.service.GetTransactionHistory(ctx, walletID, limit, offset)
+	end(span, err)
+	return transactions, total, err
+}
+
+// GetTransactionHistoryPage retrieves a filtered page of transaction history for a wallet
+func (s *WalletService) GetTransactionHistoryPage(ctx context.Context, walletID int, query primary.TransactionHistoryQuery) (*domain.TransactionPage, error) {
+	ctx, span := start(ctx, "WalletService.GetTransactionHistoryPage", attribute.Int("wallet.id", walletID))
+	page, err := s.service.GetTransactionHistoryPage(ctx, walletID, query)
+	end(span, err)
+	return page, err
+}
+
+// GetBalance gets the current balance of a wallet
+func (s *WalletService) GetBalance(ctx context.Context, walletID int) (int, string, error) {
+	ctx, span := start(ctx, "WalletService.GetBalance", attribute.Int("wallet.id", walletID))
+	balance, currency, err := s.service.GetBalance(ctx, walletID)
+	end(span, err)
+	return balance, currency, err
+}
+
+// FreezeWallet blocks outbound funds of a wallet
+func (s *WalletService) FreezeWallet(ctx context.Context, walletID int, reason domain.WalletStatusReason, actor string) (*domain.Wallet, error) {
+	ctx, span := start(ctx, "WalletService.FreezeWallet", attribute.Int("wallet.id", walletID), attribute.String("reason", string(reason)))
+	wallet, err := s.service.FreezeWallet(ctx, walletID, reason, actor)
+	end(span, err)
+	return wallet, err
+}
+
+// UnfreezeWallet makes a frozen wallet active again
+func (s *WalletService) UnfreezeWallet(ctx context.Context, walletID int, reason domain.WalletStatusReason, actor string) (*domain.Wallet, error) {
+	ctx, span := start(ctx, "WalletService.UnfreezeWallet", attribute.Int("wallet.id", walletID), attribute.String("reason", string(reason)))
+	wallet, err := s.service.UnfreezeWallet(ctx, walletID, reason, actor)
+	end(span, err)
+	return wallet, err
+}
+
+// CloseWallet sweeps the remaining balance and closes a wallet for good
+func (s *WalletService) CloseWallet(ctx context.Context, req primary.CloseWalletRequest) (*domain.Wallet, error) {
+	ctx, span := start(ctx, "WalletService.CloseWallet",
+		attribute.Int("wallet.id", req.WalletID),
+		attribute.Int("wallet.to_id", req.SweepToWalletID),
+		attribute.String("reason", string(req.Reason)),
+	)
+	wallet, err := s.service.CloseWallet(ctx, req)
+	end(span, err)
+	return wallet, err
+}
@@ -0,0 +1,420 @@
+// Package config loads the typed configuration shared by the API server and
+// the command line tools.
+//
+// Values come from config/config.yaml, merged with config/config.<APP_ENV>.yaml
+// when it exists, then from environment variables named after the key with
+// dots replaced by underscores, e.g. DATABASE_HOST for database.host. Secrets
+// can be read from a file named by the same key with a _file suffix, e.g.
+// DATABASE_PASSWORD_FILE=/run/secrets/db_password.
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"ports-and-adapters-architecture/internal/adapters/auth"
+	"ports-and-adapters-architecture/internal/adapters/logging"
+	"ports-and-adapters-architecture/internal/adapters/persistence"
+	"ports-and-adapters-architecture/internal/adapters/persistence/sqlite"
+	"ports-and-adapters-architecture/internal/adapters/risk"
+	"ports-and-adapters-architecture/internal/adapters/tracing"
+	"ports-and-adapters-architecture/internal/constants"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/usecase"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Config is the effective configuration of the service
+type Config struct {
+	// Environment is taken from APP_ENV, local when unset
+	Environment      string                        `mapstructure:"environment"`
+	Server           ServerConfig                  `mapstructure:"server"`
+	GRPC             GRPCConfig                    `mapstructure:"grpc"`
+	OpenAPI          OpenAPIConfig                 `mapstructure:"openapi"`
+	Metrics          MetricsConfig                 `mapstructure:"metrics"`
+	Health           HealthConfig                  `mapstructure:"health"`
+	Tracing          tracing.Config                `mapstructure:"tracing"`
+	Database         DatabaseConfig                `mapstructure:"database"`
+	Cache            CacheConfig                   `mapstructure:"cache"`
+	Redis            RedisConfig                   `mapstructure:"redis"`
+	Kafka            KafkaConfig                   `mapstructure:"kafka"`
+	Payment          PaymentConfig                 `mapstructure:"payment"`
+	Risk             RiskConfig                    `mapstructure:"risk"`
+	Auth             AuthConfig                    `mapstructure:"auth"`
+	Merchants        MerchantsConfig               `mapstructure:"merchants"`
+	RateLimits       RateLimitsConfig              `mapstructure:"rate_limits"`
+	PaymentRequests  usecase.PaymentRequestConfig  `mapstructure:"payment_requests"`
+	BalanceSnapshots usecase.BalanceSnapshotConfig `mapstructure:"balance_snapshots"`
+	BatchTransfers   usecase.BatchTransferConfig   `mapstructure:"batch_transfers"`
+	Recipients       usecase.RecipientConfig       `mapstructure:"recipients"`
+	Fees             FeesConfig                    `mapstructure:"fees"`
+	Logging          logging.Config                `mapstructure:"logging"`
+}
+
+// ServerConfig configures the HTTP server
+type ServerConfig struct {
+	Port string `mapstructure:"port"`
+	// Timeout bounds reading a request and writing its response
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// GRPCConfig configures the gRPC API
+type GRPCConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Port          string        `mapstructure:"port"`
+	WatchInterval time.Duration `mapstructure:"watch_interval"`
+}
+
+// OpenAPIConfig configures request validation against the OpenAPI spec
+type OpenAPIConfig struct {
+	ValidateRequests bool `mapstructure:"validate_requests"`
+}
+
+// MetricsConfig configures the Prometheus metrics
+type MetricsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// HealthConfig configures the dependency checks
+type HealthConfig struct {
+	usecase.HealthConfig `mapstructure:",squash"`
+	// Critical lists the dependencies that take the service down when they fail
+	Critical []string `mapstructure:"critical"`
+}
+
+// DatabaseConfig selects the database driver. The connection settings apply
+// to Postgres, SQLite only needs the path of its file
+type DatabaseConfig struct {
+	// Driver is postgres or sqlite
+	Driver                     string `mapstructure:"driver"`
+	persistence.PostgresConfig `mapstructure:",squash"`
+	SQLite                     sqlite.Config `mapstructure:"sqlite"`
+}
+
+// CacheConfig selects where cached reads, rate limit counters and merchant
+// request nonces are kept
+type CacheConfig struct {
+	// Driver is redis, or memory for a single instance without Redis
+	Driver string `mapstructure:"driver"`
+}
+
+// RedisConfig configures the cache connection
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+// KafkaConfig configures the event bus
+type KafkaConfig struct {
+	Brokers       []string `mapstructure:"brokers"`
+	ConsumerGroup string   `mapstructure:"consumer_group"`
+}
+
+// PaymentConfig holds the payment gateway credentials
+type PaymentConfig struct {
+	Midtrans MidtransConfig `mapstructure:"midtrans"`
+	Stripe   StripeConfig   `mapstructure:"stripe"`
+}
+
+// MidtransConfig holds the Midtrans credentials
+type MidtransConfig struct {
+	ServerKey    string `mapstructure:"server_key"`
+	ClientKey    string `mapstructure:"client_key"`
+	IsProduction bool   `mapstructure:"is_production"`
+}
+
+// StripeConfig holds the Stripe credentials
+type StripeConfig struct {
+	APIKey        string `mapstructure:"api_key"`
+	WebhookSecret string `mapstructure:"webhook_secret"`
+	IsTest        bool   `mapstructure:"is_test"`
+}
+
+// RiskConfig configures the risk checks
+type RiskConfig struct {
+	Enabled bool             `mapstructure:"enabled"`
+	Rules   risk.RulesConfig `mapstructure:"rules"`
+}
+
+// AuthConfig configures token verification and the dev token endpoint
+type AuthConfig struct {
+	auth.JWTConfig `mapstructure:",squash"`
+	DevTokens      bool `mapstructure:"dev_tokens"`
+}
+
+// MerchantsConfig configures the merchant API keys
+type MerchantsConfig struct {
+	usecase.MerchantConfig `mapstructure:",squash"`
+	Enabled                bool `mapstructure:"enabled"`
+	// EncryptionKey is the base64 encoded key sealing the signing secrets
+	EncryptionKey string `mapstructure:"encryption_key"`
+}
+
+// RateLimitsConfig configures the rate limits per route group
+type RateLimitsConfig struct {
+	Enabled bool                              `mapstructure:"enabled"`
+	Groups  map[string]domain.RateLimitPolicy `mapstructure:"groups"`
+}
+
+// FeesConfig configures the fee schedule
+type FeesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// RevenueWallets is the wallet receiving collected fees, per currency
+	RevenueWallets map[string]int   `mapstructure:"revenue_wallets"`
+	Rules          []domain.FeeRule `mapstructure:"rules"`
+}
+
+// secretKeys are the keys holding credentials. They can be read from a file
+// named by the key with a _file suffix, and are hidden by Print when redacted
+var secretKeys = []string{
+	"database.password",
+	"redis.password",
+	"payment.midtrans.server_key",
+	"payment.stripe.api_key",
+	"payment.stripe.webhook_secret",
+	"auth.secret",
+	"merchants.encryption_key",
+}
+
+// Load reads the configuration files, environment variables and secret files.
+// The result is not validated, see Validate
+func Load() (*Config, error) {
+	v := viper.New()
+
+	// Set config name and paths
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath("./config")
+	v.AddConfigPath(".")
+
+	// Set defaults
+	setDefaults(v)
+
+	// Read base config
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// Override with environment-specific config
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		env = EnvLocal
+	}
+
+	v.SetConfigName(fmt.Sprintf("config.%s", env))
+	if err := v.MergeInConfig(); err != nil {
+		log.Printf("No environment-specific config found for %s: %v", env, err)
+	}
+	v.Set("environment", env)
+
+	// Allow environment variables to override. Viper only looks up the
+	// environment for keys it knows about, so every key of Config is bound,
+	// including the ones without a default or a value in the files
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	for _, key := range configKeys(reflect.TypeOf(Config{}), "") {
+		if err := v.BindEnv(key); err != nil {
+			return nil, fmt.Errorf("failed to bind %s to the environment: %w", key, err)
+		}
+	}
+
+	expandPlaceholders(v)
+
+	if err := loadSecretFiles(v); err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	return &config, nil
+}
+
+// expandPlaceholders replaces ${VAR} references in string values, as used by
+// the environment-specific config files, with the environment variable
+func expandPlaceholders(v *viper.Viper) {
+	for _, key := range v.AllKeys() {
+		value, ok := v.Get(key).(string)
+		if ok && strings.Contains(value, "${") {
+			v.Set(key, os.ExpandEnv(value))
+		}
+	}
+}
+
+// loadSecretFiles replaces secrets with the content of the file named by
+// their _file key, when one is set
+func loadSecretFiles(v *viper.Viper) error {
+	for _, key := range secretKeys {
+		fileKey := key + "_file"
+		if err := v.BindEnv(fileKey); err != nil {
+			return fmt.Errorf("failed to bind %s to the environment: %w", fileKey, err)
+		}
+
+		path := v.GetString(fileKey)
+		if path == "" {
+			continue
+		}
+
+		secret, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", fileKey, err)
+		}
+		v.Set(key, strings.TrimSpace(string(secret)))
+	}
+
+	return nil
+}
+
+// configKeys lists the keys of the fields of a config struct, following
+// nested and squashed structs. Maps and slices are a single key
+func configKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, squash := fieldKey(field)
+		if name == "-" {
+			continue
+		}
+
+		key := prefix + name
+		if squash {
+			key = strings.TrimSuffix(prefix, ".")
+		}
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+			nestedPrefix := key + "."
+			if key == "" {
+				nestedPrefix = ""
+			}
+			keys = append(keys, configKeys(field.Type, nestedPrefix)...)
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// fieldKey returns the mapstructure key of a field and whether it is squashed
+// into its parent
+func fieldKey(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("mapstructure")
+	name, options, _ := strings.Cut(tag, ",")
+	if options == "squash" {
+		return "", true
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, false
+}
+
+func setDefaults(v *viper.Viper) {
+	// Server defaults
+	v.SetDefault("server.port", "8080")
+	v.SetDefault("server.timeout", "30s")
+
+	// Database defaults
+	v.SetDefault("database.driver", DriverPostgres)
+	v.SetDefault("database.host", "localhost")
+	v.SetDefault("database.port", "5432")
+	v.SetDefault("database.name", "mini_ewallet")
+	v.SetDefault("database.user", "postgres")
+	v.SetDefault("database.password", "postgres")
+	v.SetDefault("database.max_open_conns", 25)
+	v.SetDefault("database.max_idle_conns", 25)
+	v.SetDefault("database.conn_max_lifetime", "5m")
+	v.SetDefault("database.operation_timeout", constants.DBOperationTimeout)
+	v.SetDefault("database.sqlite.path", "mini_ewallet.db")
+
+	// Cache defaults
+	v.SetDefault("cache.driver", CacheDriverRedis)
+
+	// Redis defaults
+	v.SetDefault("redis.addr", "localhost:6379")
+	v.SetDefault("redis.password", "")
+	v.SetDefault("redis.db", 0)
+
+	// Kafka defaults
+	v.SetDefault("kafka.brokers", []string{"localhost:9092"})
+	v.SetDefault("kafka.consumer_group", "mini-ewallet")
+
+	// Payment gateway defaults
+	v.SetDefault("payment.midtrans.is_production", false)
+	v.SetDefault("payment.stripe.is_test", true)
+
+	// Risk defaults
+	v.SetDefault("risk.enabled", false)
+
+	// Fee defaults
+	v.SetDefault("fees.enabled", false)
+
+	// Metrics defaults
+	v.SetDefault("metrics.enabled", false)
+
+	// Tracing defaults
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.service_name", "mini-ewallet")
+	v.SetDefault("tracing.exporter", "stdout")
+	v.SetDefault("tracing.file", "traces.json")
+	v.SetDefault("tracing.endpoint", "localhost:4317")
+	v.SetDefault("tracing.insecure", true)
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	// Health check defaults
+	v.SetDefault("health.timeout", "2s")
+	v.SetDefault("health.cache_ttl", "5s")
+	v.SetDefault("health.critical", []string{"postgres"})
+
+	// Logging defaults
+	v.SetDefault("logging.level", "info")
+	v.SetDefault("logging.format", "json")
+
+	// Payment request defaults
+	v.SetDefault("payment_requests.default_expiry", "72h")
+	v.SetDefault("payment_requests.max_expiry", "720h")
+	v.SetDefault("payment_requests.expiry_check_interval", "1m")
+	v.SetDefault("payment_requests.processing_timeout", "5m")
+
+	// Balance snapshot defaults
+	v.SetDefault("balance_snapshots.check_interval", "10m")
+
+	// Batch transfer defaults
+	v.SetDefault("batch_transfers.stale_after", "5m")
+	v.SetDefault("batch_transfers.recovery_interval", "1m")
+
+	// Auth defaults
+	v.SetDefault("auth.algorithm", "HS256")
+	v.SetDefault("auth.issuer", "mini-ewallet")
+	v.SetDefault("auth.audience", "mini-ewallet-api")
+	v.SetDefault("auth.ttl", "1h")
+	v.SetDefault("auth.dev_tokens", false)
+
+	// Merchant API key defaults
+	v.SetDefault("merchants.enabled", false)
+	v.SetDefault("merchants.environment", "SANDBOX")
+	v.SetDefault("merchants.signature_tolerance", "5m")
+	v.SetDefault("merchants.rotation_overlap", "24h")
+
+	// Rate limit defaults
+	v.SetDefault("rate_limits.enabled", false)
+
+	// gRPC defaults
+	v.SetDefault("grpc.enabled", false)
+	v.SetDefault("grpc.port", "9090")
+	v.SetDefault("grpc.watch_interval", "2s")
+
+	// OpenAPI defaults
+	v.SetDefault("openapi.validate_requests", false)
+
+	// Recipient defaults
+	v.SetDefault("recipients.confirmation_ttl", "5m")
+	v.SetDefault("recipients.require_confirmation", true)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces secrets in the printed configuration
+const redacted = "REDACTED"
+
+// Print writes the effective configuration as YAML, with the secrets replaced
+// when redact is set. Empty secrets are printed as is so missing ones show
+func (c *Config) Print(w io.Writer, redact bool) error {
+	values := toMap(reflect.ValueOf(*c), "", redact)
+
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	_, err = w.Write(out)
+	return err
+}
+
+// toMap converts a config struct to nested maps keyed like the config files
+func toMap(v reflect.Value, prefix string, redact bool) map[string]any {
+	values := make(map[string]any)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, squash := fieldKey(field)
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+
+		if squash {
+			for key, value := range toMap(v.Field(i), prefix, redact) {
+				values[key] = value
+			}
+			continue
+		}
+
+		key := prefix + name
+		if redact && slices.Contains(secretKeys, key) && !v.Field(i).IsZero() {
+			values[name] = redacted
+			continue
+		}
+		values[name] = toValue(v.Field(i), key+".", redact)
+	}
+	return values
+}
+
+// toValue converts a config value, printing durations the way they are written
+func toValue(v reflect.Value, prefix string, redact bool) any {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return toMap(v, prefix, redact)
+	case reflect.Slice:
+		if v.IsNil() {
+			return []any{}
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = toValue(v.Index(i), prefix, redact)
+		}
+		return items
+	case reflect.Map:
+		items := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			items[fmt.Sprint(iter.Key().Interface())] = toValue(iter.Value(), prefix, redact)
+		}
+		return items
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/adapters/auth"
+	"ports-and-adapters-architecture/internal/adapters/tracing"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Environments without real credentials. Every other environment must
+// provide its secrets
+const (
+	EnvLocal = "local"
+	EnvTest  = "test"
+)
+
+// Database drivers
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// Cache drivers
+const (
+	CacheDriverRedis  = "redis"
+	CacheDriverMemory = "memory"
+)
+
+// placeholderPrefix marks the sample credentials shipped in config.yaml
+const placeholderPrefix = "YOUR_"
+
+// healthComponents are the dependencies health.critical can name
+var healthComponents = []string{"postgres", "sqlite", "redis", "kafka", "midtrans", "stripe"}
+
+// IsDevelopment reports whether the service runs without real credentials
+func (c *Config) IsDevelopment() bool {
+	return c.Environment == EnvLocal || c.Environment == EnvTest
+}
+
+// Validate checks the configuration before anything is started, and reports
+// every problem at once
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	// Server
+	check(isPort(c.Server.Port), "server.port %q is not a valid port", c.Server.Port)
+	check(c.Server.Timeout > 0, "server.timeout must be positive")
+	if c.GRPC.Enabled {
+		check(isPort(c.GRPC.Port), "grpc.port %q is not a valid port", c.GRPC.Port)
+		check(c.GRPC.WatchInterval > 0, "grpc.watch_interval must be positive")
+	}
+
+	// Database
+	switch c.Database.Driver {
+	case DriverPostgres:
+		check(c.Database.Host != "", "database.host is required")
+		check(isPort(c.Database.Port), "database.port %q is not a valid port", c.Database.Port)
+		check(c.Database.Name != "", "database.name is required")
+		check(c.Database.User != "", "database.user is required")
+		check(c.Database.MaxOpenConns >= 1, "database.max_open_conns must be at least 1")
+		check(c.Database.MaxIdleConns >= 0 && c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+			"database.max_idle_conns must be between 0 and database.max_open_conns")
+		check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime must not be negative")
+		check(c.Database.OperationTimeout > 0, "database.operation_timeout must be positive")
+	case DriverSQLite:
+		check(c.Database.SQLite.Path != "", "database.sqlite.path is required")
+	default:
+		check(false, "database.driver %q must be postgres or sqlite", c.Database.Driver)
+	}
+
+	// Cache and Kafka
+	switch c.Cache.Driver {
+	case CacheDriverRedis:
+		check(c.Redis.Addr != "", "redis.addr is required")
+		check(c.Redis.DB >= 0, "redis.db must not be negative")
+	case CacheDriverMemory:
+		check(!slices.Contains(c.Health.Critical, "redis"), "health.critical names redis, but cache.driver is memory")
+	default:
+		check(false, "cache.driver %q must be redis or memory", c.Cache.Driver)
+	}
+	check(len(c.Kafka.Brokers) > 0, "kafka.brokers is required")
+	check(c.Kafka.ConsumerGroup != "", "kafka.consumer_group is required")
+
+	// Health checks and tracing
+	check(c.Health.Timeout > 0, "health.timeout must be positive")
+	check(c.Health.CacheTTL >= 0, "health.cache_ttl must not be negative")
+	for _, name := range c.Health.Critical {
+		check(slices.Contains(healthComponents, name),
+			"health.critical: unknown dependency %q, expected one of %s", name, strings.Join(healthComponents, ", "))
+	}
+	if c.Tracing.Enabled {
+		check(slices.Contains([]string{tracing.ExporterStdout, tracing.ExporterFile, tracing.ExporterOTLP}, c.Tracing.Exporter),
+			"tracing.exporter %q must be stdout, file or otlp", c.Tracing.Exporter)
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	// Use cases
+	check(c.PaymentRequests.DefaultExpiry > 0, "payment_requests.default_expiry must be positive")
+	check(c.PaymentRequests.MaxExpiry >= c.PaymentRequests.DefaultExpiry,
+		"payment_requests.max_expiry must not be shorter than payment_requests.default_expiry")
+	check(c.PaymentRequests.ExpiryCheckInterval > 0, "payment_requests.expiry_check_interval must be positive")
+	check(c.PaymentRequests.ProcessingTimeout > 0, "payment_requests.processing_timeout must be positive")
+	check(c.BalanceSnapshots.CheckInterval > 0, "balance_snapshots.check_interval must be positive")
+	check(c.BatchTransfers.StaleAfter > 0, "batch_transfers.stale_after must be positive")
+	check(c.BatchTransfers.RecoveryInterval > 0, "batch_transfers.recovery_interval must be positive")
+	check(c.Recipients.ConfirmationTTL > 0, "recipients.confirmation_ttl must be positive")
+	check(c.Auth.TTL > 0, "auth.ttl must be positive")
+	if c.Merchants.Enabled {
+		check(c.Merchants.SignatureTolerance > 0, "merchants.signature_tolerance must be positive")
+		check(c.Merchants.RotationOverlap >= 0, "merchants.rotation_overlap must not be negative")
+	}
+
+	// Secrets only have usable defaults in development
+	if !c.IsDevelopment() {
+		required := func(key, value string) {
+			check(value != "" && !strings.HasPrefix(value, placeholderPrefix),
+				"%s is required in %s, set %s or %s_FILE", key, c.Environment, envName(key), envName(key))
+		}
+
+		if c.Database.Driver == DriverPostgres {
+			required("database.password", c.Database.Password)
+		}
+		required("payment.midtrans.server_key", c.Payment.Midtrans.ServerKey)
+		required("payment.stripe.api_key", c.Payment.Stripe.APIKey)
+		required("payment.stripe.webhook_secret", c.Payment.Stripe.WebhookSecret)
+		if c.Auth.Algorithm == auth.AlgorithmRS256 {
+			check(c.Auth.JWKSFile != "", "auth.jwks_file is required in %s", c.Environment)
+		} else {
+			required("auth.secret", c.Auth.Secret)
+		}
+		if c.Merchants.Enabled {
+			required("merchants.encryption_key", c.Merchants.EncryptionKey)
+		}
+		check(!c.Auth.DevTokens, "auth.dev_tokens must be disabled in %s", c.Environment)
+	}
+
+	return errors.Join(errs...)
+}
+
+// isPort reports whether s is a TCP port number
+func isPort(s string) bool {
+	port, err := strconv.Atoi(s)
+	return err == nil && port > 0 && port <= 65535
+}
+
+// envName returns the environment variable overriding a key
+func envName(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrBalanceTimeInFuture = errors.New("balance time must not be in the future")
+
+// BalanceSnapshot is the balance of a wallet as it was read at TakenAt, shortly
+// after the end of a day
+type BalanceSnapshot struct {
+	ID           int       `json:"id"`
+	WalletID     int       `json:"wallet_id"`
+	Balance      int       `json:"balance"`
+	CurrencyCode string    `json:"currency_code"`
+	TakenAt      time.Time `json:"taken_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// EndOfDay returns the instant the UTC day of t ends, which is midnight of the next day
+func EndOfDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+}
+
+// HistoricalBalance is the balance of a wallet at a point in time
+type HistoricalBalance struct {
+	WalletID     int       `json:"wallet_id"`
+	Balance      int       `json:"balance"`
+	CurrencyCode string    `json:"currency"`
+	At           time.Time `json:"at"`
+	// SnapshotAt is the snapshot the balance was worked out from, if any
+	SnapshotAt *time.Time `json:"snapshot_at,omitempty"`
+}
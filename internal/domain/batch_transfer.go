@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBatchTransferEmpty    = errors.New("batch transfer has no items")
+	ErrBatchTransferTooLarge = errors.New("batch transfer has too many items")
+	ErrInvalidBatchMode      = errors.New("invalid batch transfer mode")
+)
+
+// MaxBatchTransferItems is the maximum number of recipients in a single batch
+const MaxBatchTransferItems = 1000
+
+// BatchTransferMode controls what happens when an item of a batch fails
+type BatchTransferMode string
+
+// common batch transfer modes
+const (
+	// BatchTransferModeAllOrNothing reverses every completed item once an item fails
+	BatchTransferModeAllOrNothing BatchTransferMode = "ALL_OR_NOTHING"
+	// BatchTransferModeBestEffort keeps completed items and carries on after a failure
+	BatchTransferModeBestEffort BatchTransferMode = "BEST_EFFORT"
+)
+
+// IsValid checks if a mode is one of the known modes
+func (m BatchTransferMode) IsValid() bool {
+	return m == BatchTransferModeAllOrNothing || m == BatchTransferModeBestEffort
+}
+
+// BatchTransferStatus represents the state of a batch
+type BatchTransferStatus string
+
+// common batch transfer statuses
+const (
+	BatchTransferStatusPending            BatchTransferStatus = "PENDING"
+	BatchTransferStatusProcessing         BatchTransferStatus = "PROCESSING"
+	BatchTransferStatusCompleted          BatchTransferStatus = "COMPLETED"
+	BatchTransferStatusPartiallyCompleted BatchTransferStatus = "PARTIALLY_COMPLETED"
+	BatchTransferStatusFailed             BatchTransferStatus = "FAILED"
+	// BatchTransferStatusPartiallyReversed is an aborted all-or-nothing batch
+	// with paid items that could not be reversed, they need manual follow up
+	BatchTransferStatusPartiallyReversed BatchTransferStatus = "PARTIALLY_REVERSED"
+)
+
+// BatchTransferItemStatus represents the state of a single item of a batch
+type BatchTransferItemStatus string
+
+// common batch transfer item statuses
+const (
+	BatchTransferItemStatusPending   BatchTransferItemStatus = "PENDING"
+	BatchTransferItemStatusCompleted BatchTransferItemStatus = "COMPLETED"
+	BatchTransferItemStatusFailed    BatchTransferItemStatus = "FAILED"
+	BatchTransferItemStatusSkipped   BatchTransferItemStatus = "SKIPPED"
+	BatchTransferItemStatusReversed  BatchTransferItemStatus = "REVERSED"
+)
+
+// BatchTransferItem represents one recipient of a batch transfer
+type BatchTransferItem struct {
+	ToWalletID    int                     `json:"to_wallet_id"`
+	Amount        int                     `json:"amount"`
+	Description   string                  `json:"description,omitempty"`
+	Status        BatchTransferItemStatus `json:"status"`
+	TransactionID *int                    `json:"transaction_id,omitempty"`
+	Error         string                  `json:"error,omitempty"`
+}
+
+// BatchTransfer represents a set of transfers from one source wallet
+type BatchTransfer struct {
+	ID             int                 `json:"id"`
+	FromWalletID   int                 `json:"from_wallet_id"`
+	Mode           BatchTransferMode   `json:"mode"`
+	Status         BatchTransferStatus `json:"status"`
+	Description    string              `json:"description,omitempty"`
+	TotalAmount    int                 `json:"total_amount"`
+	SucceededCount int                 `json:"succeeded_count"`
+	FailedCount    int                 `json:"failed_count"`
+	Items          []BatchTransferItem `json:"items"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	CompletedAt    *time.Time          `json:"completed_at,omitempty"`
+}
+
+// BatchItemError describes why an item of a batch was rejected
+type BatchItemError struct {
+	Index      int    `json:"index"`
+	ToWalletID int    `json:"to_wallet_id"`
+	Message    string `json:"message"`
+}
+
+// BatchValidationError is returned when items of a batch fail validation
+type BatchValidationError struct {
+	Errors []BatchItemError `json:"errors"`
+}
+
+func (e *BatchValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, itemErr := range e.Errors {
+		messages = append(messages, fmt.Sprintf("item %d: %s", itemErr.Index, itemErr.Message))
+	}
+
+	return "invalid batch transfer: " + strings.Join(messages, "; ")
+}
+
+// Add records a rejected item
+func (e *BatchValidationError) Add(index, toWalletID int, message string) {
+	e.Errors = append(e.Errors, BatchItemError{Index: index, ToWalletID: toWalletID, Message: message})
+}
+
+// HasErrors reports whether any item was rejected
+func (e *BatchValidationError) HasErrors() bool {
+	return len(e.Errors) > 0
+}
+
+// NewBatchTransfer creates a new pending batch transfer. Items that are
+// invalid on their own (non-positive amount, self transfer) are rejected
+// here, checks that need the wallets are left to the application service
+func NewBatchTransfer(fromWalletID int, mode BatchTransferMode, description string, items []BatchTransferItem) (*BatchTransfer, error) {
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBatchMode, mode)
+	}
+
+	if len(items) == 0 {
+		return nil, ErrBatchTransferEmpty
+	}
+
+	if len(items) > MaxBatchTransferItems {
+		return nil, fmt.Errorf("%w: %d, maximum is %d", ErrBatchTransferTooLarge, len(items), MaxBatchTransferItems)
+	}
+
+	validationErr := &BatchValidationError{}
+	total := 0
+
+	batchItems := make([]BatchTransferItem, len(items))
+	for i, item := range items {
+		switch {
+		case item.ToWalletID <= 0:
+			validationErr.Add(i, item.ToWalletID, "recipient wallet is required")
+		case item.ToWalletID == fromWalletID:
+			validationErr.Add(i, item.ToWalletID, "cannot transfer to the source wallet")
+		case item.Amount <= 0:
+			validationErr.Add(i, item.ToWalletID, ErrInvalidAmount.Error())
+		}
+
+		batchItems[i] = BatchTransferItem{
+			ToWalletID:  item.ToWalletID,
+			Amount:      item.Amount,
+			Description: item.Description,
+			Status:      BatchTransferItemStatusPending,
+		}
+		total += item.Amount
+	}
+
+	if validationErr.HasErrors() {
+		return nil, validationErr
+	}
+
+	now := time.Now()
+	return &BatchTransfer{
+		FromWalletID: fromWalletID,
+		Mode:         mode,
+		Status:       BatchTransferStatusPending,
+		Description:  description,
+		TotalAmount:  total,
+		Items:        batchItems,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Start marks the batch as being processed
+func (b *BatchTransfer) Start() {
+	b.Status = BatchTransferStatusProcessing
+	b.UpdatedAt = time.Now()
+}
+
+// CompleteItem records the transaction that paid an item
+func (b *BatchTransfer) CompleteItem(index, transactionID int) {
+	b.Items[index].Status = BatchTransferItemStatusCompleted
+	b.Items[index].TransactionID = &transactionID
+	b.Items[index].Error = ""
+	b.UpdatedAt = time.Now()
+}
+
+// FailItem records why an item could not be paid
+func (b *BatchTransfer) FailItem(index int, reason string) {
+	b.Items[index].Status = BatchTransferItemStatusFailed
+	b.Items[index].Error = reason
+	b.UpdatedAt = time.Now()
+}
+
+// SkipPendingItems marks every item that has not been attempted as skipped
+func (b *BatchTransfer) SkipPendingItems(reason string) {
+	for i := range b.Items {
+		if b.Items[i].Status == BatchTransferItemStatusPending {
+			b.Items[i].Status = BatchTransferItemStatusSkipped
+			b.Items[i].Error = reason
+		}
+	}
+	b.UpdatedAt = time.Now()
+}
+
+// ReverseItem records that a completed item was paid back to the source wallet
+func (b *BatchTransfer) ReverseItem(index int, reason string) {
+	b.Items[index].Status = BatchTransferItemStatusReversed
+	b.Items[index].Error = reason
+	b.UpdatedAt = time.Now()
+}
+
+// Finish derives the final status of the batch from its items
+func (b *BatchTransfer) Finish() {
+	b.SucceededCount = 0
+	b.FailedCount = 0
+
+	for _, item := range b.Items {
+		if item.Status == BatchTransferItemStatusCompleted {
+			b.SucceededCount++
+		} else {
+			b.FailedCount++
+		}
+	}
+
+	switch {
+	case b.FailedCount == 0:
+		b.Status = BatchTransferStatusCompleted
+	case b.SucceededCount == 0:
+		b.Status = BatchTransferStatusFailed
+	case b.Mode == BatchTransferModeAllOrNothing:
+		b.Status = BatchTransferStatusPartiallyReversed
+	default:
+		b.Status = BatchTransferStatusPartiallyCompleted
+	}
+
+	now := time.Now()
+	b.CompletedAt = &now
+	b.UpdatedAt = now
+}
+
+// IsFinished reports whether the batch reached a final status
+func (b *BatchTransfer) IsFinished() bool {
+	return b.Status == BatchTransferStatusCompleted ||
+		b.Status == BatchTransferStatusPartiallyCompleted ||
+		b.Status == BatchTransferStatusPartiallyReversed ||
+		b.Status == BatchTransferStatusFailed
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrDeadEventRedriven = errors.New("dead event was already redriven")
+
+// DeadEvent is a consumed event whose handler failed. It is kept so it can be
+// published to its topic again once the cause is fixed
+type DeadEvent struct {
+	ID         int                    `json:"id"`
+	Topic      string                 `json:"topic"`
+	EventID    string                 `json:"event_id,omitempty"`
+	EventType  string                 `json:"event_type"`
+	Payload    map[string]interface{} `json:"payload"`
+	EventTime  int64                  `json:"event_time"`
+	Error      string                 `json:"error"`
+	FailedAt   time.Time              `json:"failed_at"`
+	RedrivenAt *time.Time             `json:"redriven_at,omitempty"`
+}
+
+// MarkRedriven records that the event was published again
+func (e *DeadEvent) MarkRedriven(at time.Time) error {
+	if e.RedrivenAt != nil {
+		return ErrDeadEventRedriven
+	}
+
+	e.RedrivenAt = &at
+	return nil
+}
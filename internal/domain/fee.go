@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidFeeRule   = errors.New("invalid fee rule")
+	ErrFeeExceedsAmount = errors.New("fee exceeds transaction amount")
+)
+
+// FeeType represents how a fee is calculated
+type FeeType string
+
+// common fee types
+const (
+	FeeTypeFlat       FeeType = "FLAT"
+	FeeTypePercentage FeeType = "PERCENTAGE"
+	FeeTypeTiered     FeeType = "TIERED"
+)
+
+// basisPoints is the divisor for rates expressed in basis points (1 bps = 0.01%)
+const basisPoints = 10000
+
+// FeeTier represents one band of a tiered fee. UpTo is the inclusive upper
+// bound of the band, zero means unbounded
+type FeeTier struct {
+	UpTo       int `json:"up_to" mapstructure:"up_to"`
+	FlatAmount int `json:"flat_amount,omitempty" mapstructure:"flat_amount"`
+	RateBPS    int `json:"rate_bps,omitempty" mapstructure:"rate_bps"`
+}
+
+// FeeRule represents a single entry of the fee schedule. Currency and
+// Provider are optional, an empty value matches any currency or provider
+type FeeRule struct {
+	TransactionType TransactionType `json:"transaction_type" mapstructure:"transaction_type"`
+	Currency        string          `json:"currency,omitempty" mapstructure:"currency"`
+	Provider        PaymentProvider `json:"provider,omitempty" mapstructure:"provider"`
+	Type            FeeType         `json:"type" mapstructure:"type"`
+	FlatAmount      int             `json:"flat_amount,omitempty" mapstructure:"flat_amount"`
+	RateBPS         int             `json:"rate_bps,omitempty" mapstructure:"rate_bps"`
+	Tiers           []FeeTier       `json:"tiers,omitempty" mapstructure:"tiers"`
+	MinFee          int             `json:"min_fee,omitempty" mapstructure:"min_fee"`
+	MaxFee          int             `json:"max_fee,omitempty" mapstructure:"max_fee"`
+}
+
+// Validate checks that a fee rule can be applied
+func (r FeeRule) Validate() error {
+	if r.TransactionType == "" {
+		return fmt.Errorf("%w: transaction type is required", ErrInvalidFeeRule)
+	}
+
+	if !r.TransactionType.IsValid() || r.TransactionType == TransactionTypeFee {
+		return fmt.Errorf("%w: fees cannot be charged on transaction type %s", ErrInvalidFeeRule, r.TransactionType)
+	}
+
+	// Direct deposits are not charged, only deposits made through a payment
+	// provider have their fee deducted
+	if r.TransactionType == TransactionTypeDeposit && r.Provider == "" {
+		return fmt.Errorf("%w: deposit fees require a payment provider", ErrInvalidFeeRule)
+	}
+
+	if r.FlatAmount < 0 || r.RateBPS < 0 || r.MinFee < 0 || r.MaxFee < 0 {
+		return fmt.Errorf("%w: amounts and rates must not be negative", ErrInvalidFeeRule)
+	}
+
+	if r.MaxFee > 0 && r.MinFee > r.MaxFee {
+		return fmt.Errorf("%w: min_fee is greater than max_fee", ErrInvalidFeeRule)
+	}
+
+	switch r.Type {
+	case FeeTypeFlat, FeeTypePercentage:
+		return nil
+	case FeeTypeTiered:
+		if len(r.Tiers) == 0 {
+			return fmt.Errorf("%w: tiered fee requires at least one tier", ErrInvalidFeeRule)
+		}
+		for i, tier := range r.Tiers {
+			if tier.FlatAmount < 0 || tier.RateBPS < 0 {
+				return fmt.Errorf("%w: tier %d amounts and rates must not be negative", ErrInvalidFeeRule, i)
+			}
+			if tier.UpTo == 0 && i != len(r.Tiers)-1 {
+				return fmt.Errorf("%w: only the last tier can be unbounded", ErrInvalidFeeRule)
+			}
+			if i > 0 && tier.UpTo != 0 && tier.UpTo <= r.Tiers[i-1].UpTo {
+				return fmt.Errorf("%w: tiers must be in ascending order", ErrInvalidFeeRule)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown fee type %s", ErrInvalidFeeRule, r.Type)
+	}
+}
+
+// Calculate returns the fee for an amount, applying the min/max caps
+func (r FeeRule) Calculate(amount int) int {
+	var fee int
+
+	switch r.Type {
+	case FeeTypeFlat:
+		fee = r.FlatAmount
+	case FeeTypePercentage:
+		fee = r.FlatAmount + percentOf(amount, r.RateBPS)
+	case FeeTypeTiered:
+		for _, tier := range r.Tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				fee = tier.FlatAmount + percentOf(amount, tier.RateBPS)
+				break
+			}
+		}
+	}
+
+	if r.MinFee > 0 && fee < r.MinFee {
+		fee = r.MinFee
+	}
+
+	if r.MaxFee > 0 && fee > r.MaxFee {
+		fee = r.MaxFee
+	}
+
+	return fee
+}
+
+// matches reports whether the rule applies and how specific the match is
+func (r FeeRule) matches(txType TransactionType, currency string, provider PaymentProvider) (bool, int) {
+	if r.TransactionType != txType {
+		return false, 0
+	}
+
+	score := 0
+
+	if r.Currency != "" {
+		if !strings.EqualFold(r.Currency, currency) {
+			return false, 0
+		}
+		score++
+	}
+
+	if r.Provider != "" {
+		if r.Provider != provider {
+			return false, 0
+		}
+		score += 2
+	}
+
+	return true, score
+}
+
+// percentOf applies a basis point rate to an amount, rounding half up
+func percentOf(amount, rateBPS int) int {
+	if rateBPS == 0 {
+		return 0
+	}
+	return (amount*rateBPS + basisPoints/2) / basisPoints
+}
+
+// FeeSchedule holds every configured fee rule
+type FeeSchedule struct {
+	Rules []FeeRule `json:"rules"`
+}
+
+// NewFeeSchedule creates a fee schedule after validating its rules
+func NewFeeSchedule(rules []FeeRule) (*FeeSchedule, error) {
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("fee rule %d: %w", i, err)
+		}
+	}
+
+	return &FeeSchedule{Rules: rules}, nil
+}
+
+// FindRule returns the most specific rule for the operation, a provider
+// match outranks a currency match. It returns nil when no rule applies
+func (s *FeeSchedule) FindRule(txType TransactionType, currency string, provider PaymentProvider) *FeeRule {
+	if s == nil {
+		return nil
+	}
+
+	var best *FeeRule
+	bestScore := -1
+
+	for i := range s.Rules {
+		ok, score := s.Rules[i].matches(txType, currency, provider)
+		if ok && score > bestScore {
+			best = &s.Rules[i]
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+// FeeQuote represents the fee that applies to an operation. Total is what
+// the payer is charged for outbound operations and what is credited for deposits
+type FeeQuote struct {
+	TransactionType TransactionType `json:"transaction_type"`
+	Currency        string          `json:"currency"`
+	Provider        PaymentProvider `json:"provider,omitempty"`
+	Amount          int             `json:"amount"`
+	Fee             int             `json:"fee"`
+	Total           int             `json:"total"`
+	FeeType         FeeType         `json:"fee_type,omitempty"`
+}
+
+// Quote calculates the fee for an operation. Operations without a matching rule are free
+func (s *FeeSchedule) Quote(txType TransactionType, currency string, provider PaymentProvider, amount int) *FeeQuote {
+	quote := &FeeQuote{
+		TransactionType: txType,
+		Currency:        currency,
+		Provider:        provider,
+		Amount:          amount,
+	}
+
+	if rule := s.FindRule(txType, currency, provider); rule != nil {
+		quote.Fee = rule.Calculate(amount)
+		quote.FeeType = rule.Type
+	}
+
+	// Deposit fees are withheld from the credited amount, the others are
+	// debited on top of it
+	if txType == TransactionTypeDeposit {
+		quote.Total = amount - quote.Fee
+	} else {
+		quote.Total = amount + quote.Fee
+	}
+
+	return quote
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// HealthStatus represents the health of the service or one of its dependencies
+type HealthStatus string
+
+// health statuses
+const (
+	HealthStatusUp       HealthStatus = "up"
+	HealthStatusDegraded HealthStatus = "degraded"
+	HealthStatusDown     HealthStatus = "down"
+)
+
+// ComponentHealth represents the result of the last check of a dependency.
+// A critical dependency that is down takes the service down, any other one
+// only degrades it
+type ComponentHealth struct {
+	Name      string       `json:"name"`
+	Status    HealthStatus `json:"status"`
+	Critical  bool         `json:"critical"`
+	Error     string       `json:"error,omitempty"`
+	LatencyMS int64        `json:"latency_ms"`
+	CheckedAt time.Time    `json:"checked_at"`
+}
+
+// HealthReport represents the health of the service and its dependencies
+type HealthReport struct {
+	Status     HealthStatus      `json:"status"`
+	Components []ComponentHealth `json:"components,omitempty"`
+	CheckedAt  time.Time         `json:"checked_at"`
+}
+
+// NewHealthReport creates a report from the health of the dependencies
+func NewHealthReport(components []ComponentHealth, checkedAt time.Time) *HealthReport {
+	status := HealthStatusUp
+	for _, component := range components {
+		if component.Status == HealthStatusUp {
+			continue
+		}
+		if component.Critical {
+			status = HealthStatusDown
+			break
+		}
+		status = HealthStatusDegraded
+	}
+
+	return &HealthReport{
+		Status:     status,
+		Components: components,
+		CheckedAt:  checkedAt,
+	}
+}
+
+// IsAvailable reports whether the service can take traffic, degraded or not
+func (r *HealthReport) IsAvailable() bool {
+	return r.Status != HealthStatusDown
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidAPIScope            = errors.New("api scope must be one of read, payments or transfers")
+	ErrInvalidMerchantEnvironment = errors.New("environment must be SANDBOX or LIVE")
+	ErrInvalidAPIKey              = errors.New("invalid api key")
+	ErrAPIKeyEnvironmentMismatch  = errors.New("api key belongs to another environment")
+	ErrInvalidSignature           = errors.New("invalid request signature")
+	ErrSignatureExpired           = errors.New("request timestamp is outside the allowed window")
+	ErrReplayedRequest            = errors.New("request signature was already used")
+)
+
+// APIScope represents what an API client may do
+type APIScope string
+
+// API scopes
+const (
+	APIScopeRead      APIScope = "read"
+	APIScopePayments  APIScope = "payments"
+	APIScopeTransfers APIScope = "transfers"
+)
+
+// IsValid reports whether the scope is known
+func (s APIScope) IsValid() bool {
+	switch s {
+	case APIScopeRead, APIScopePayments, APIScopeTransfers:
+		return true
+	}
+	return false
+}
+
+// MerchantEnvironment separates test integrations from ones moving real money
+type MerchantEnvironment string
+
+// merchant environments
+const (
+	MerchantEnvironmentSandbox MerchantEnvironment = "SANDBOX"
+	MerchantEnvironmentLive    MerchantEnvironment = "LIVE"
+)
+
+// IsValid reports whether the environment is known
+func (e MerchantEnvironment) IsValid() bool {
+	return e == MerchantEnvironmentSandbox || e == MerchantEnvironmentLive
+}
+
+// KeyPrefix is the prefix of API keys issued in the environment, so a key
+// pasted in the wrong place is recognisable at a glance
+func (e MerchantEnvironment) KeyPrefix() string {
+	if e == MerchantEnvironmentLive {
+		return "ek_live_"
+	}
+	return "ek_test_"
+}
+
+// Merchant represents a server-to-server API client acting on the wallets of its owner
+type Merchant struct {
+	ID          int                 `json:"id"`
+	Name        string              `json:"name"`
+	UserID      int                 `json:"user_id"`
+	Environment MerchantEnvironment `json:"environment"`
+	Scopes      []APIScope          `json:"scopes"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// NewMerchant creates a new API client owned by a user
+func NewMerchant(name string, userID int, environment MerchantEnvironment, scopes []APIScope) (*Merchant, error) {
+	if !environment.IsValid() {
+		return nil, ErrInvalidMerchantEnvironment
+	}
+
+	if len(scopes) == 0 {
+		return nil, ErrInvalidAPIScope
+	}
+
+	unique := make([]APIScope, 0, len(scopes))
+	seen := make(map[APIScope]bool)
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, ErrInvalidAPIScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	now := time.Now()
+	return &Merchant{
+		Name:        name,
+		UserID:      userID,
+		Environment: environment,
+		Scopes:      unique,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// HasScope reports whether the merchant was granted a scope
+func (m *Merchant) HasScope(scope APIScope) bool {
+	for _, s := range m.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey represents one credential of a merchant. The key itself is only stored
+// as a hash, the signing secret is stored sealed since it is needed to verify signatures
+type APIKey struct {
+	ID            int        `json:"id"`
+	MerchantID    int        `json:"merchant_id"`
+	Prefix        string     `json:"prefix"`
+	KeyHash       string     `json:"-"`
+	SigningSecret string     `json:"-"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// HashAPIKey returns the form an API key is stored and looked up by
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsActive reports whether the key can still authenticate requests
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// ExpireAt schedules the key to stop working, a key never outlives an earlier expiry
+func (k *APIKey) ExpireAt(at time.Time) {
+	if k.ExpiresAt == nil || at.Before(*k.ExpiresAt) {
+		k.ExpiresAt = &at
+	}
+}
+
+// Revoke stops the key immediately
+func (k *APIKey) Revoke() {
+	if k.RevokedAt == nil {
+		now := time.Now()
+		k.RevokedAt = &now
+	}
+}
+
+// APICredentials are handed out once when a key is issued, neither secret can be read back later
+type APICredentials struct {
+	Key           *APIKey `json:"key"`
+	APIKey        string  `json:"api_key"`
+	SigningSecret string  `json:"signing_secret"`
+}
+
+// APISigningString builds the string API clients sign with HMAC-SHA256:
+// method, path with query, unix timestamp and the hex SHA-256 of the body, one per line
+func APISigningString(method, path string, timestamp int64, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		strconv.FormatInt(timestamp, 10),
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+}
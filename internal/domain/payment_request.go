@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPaymentRequestNotPending = errors.New("payment request is not pending")
+	ErrPaymentRequestExpired    = errors.New("payment request has expired")
+	ErrSelfPaymentRequest       = errors.New("cannot request money from yourself")
+	ErrInvalidPaymentRequestTTL = errors.New("payment request expiry must be in the future")
+	ErrPaymentRequestNotAllowed = errors.New("user is not allowed to act on this payment request")
+	ErrPayerWalletMismatch      = errors.New("payer wallet does not match the payment request")
+)
+
+// PaymentRequestStatus represents the state of a money request
+type PaymentRequestStatus string
+
+// common payment request statuses
+const (
+	PaymentRequestStatusPending PaymentRequestStatus = "PENDING"
+	// PaymentRequestStatusProcessing marks a request claimed by an accept while the transfer runs
+	PaymentRequestStatusProcessing PaymentRequestStatus = "PROCESSING"
+	PaymentRequestStatusAccepted   PaymentRequestStatus = "ACCEPTED"
+	PaymentRequestStatusDeclined   PaymentRequestStatus = "DECLINED"
+	PaymentRequestStatusCancelled  PaymentRequestStatus = "CANCELLED"
+	PaymentRequestStatusExpired    PaymentRequestStatus = "EXPIRED"
+)
+
+// PaymentRequest represents a request from one user to be paid by another user
+type PaymentRequest struct {
+	ID                int                  `json:"id"`
+	RequesterWalletID int                  `json:"requester_wallet_id"`
+	RequesterUserID   int                  `json:"requester_user_id"`
+	PayerUserID       int                  `json:"payer_user_id"`
+	PayerWalletID     *int                 `json:"payer_wallet_id,omitempty"`
+	Amount            int                  `json:"amount"`
+	CurrencyCode      string               `json:"currency_code"`
+	Note              string               `json:"note,omitempty"`
+	Status            PaymentRequestStatus `json:"status"`
+	TransactionID     *int                 `json:"transaction_id,omitempty"`
+	ExpiresAt         time.Time            `json:"expires_at"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+	RespondedAt       *time.Time           `json:"responded_at,omitempty"`
+}
+
+// NewPaymentRequest creates a new pending request to be paid into the requester wallet
+func NewPaymentRequest(requesterWallet *Wallet, payerUserID int, amount int, note string, expiresAt time.Time) (*PaymentRequest, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	if payerUserID == requesterWallet.UserID {
+		return nil, ErrSelfPaymentRequest
+	}
+
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return nil, ErrInvalidPaymentRequestTTL
+	}
+
+	return &PaymentRequest{
+		RequesterWalletID: requesterWallet.ID,
+		RequesterUserID:   requesterWallet.UserID,
+		PayerUserID:       payerUserID,
+		Amount:            amount,
+		CurrencyCode:      requesterWallet.CurrencyCode,
+		Note:              note,
+		Status:            PaymentRequestStatusPending,
+		ExpiresAt:         expiresAt,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}, nil
+}
+
+// IsPending reports whether the request still waits for the payer
+func (r *PaymentRequest) IsPending() bool {
+	return r.Status == PaymentRequestStatusPending
+}
+
+// IsExpired reports whether a pending request is past its expiry
+func (r *PaymentRequest) IsExpired(now time.Time) bool {
+	return r.IsPending() && !now.Before(r.ExpiresAt)
+}
+
+// Claim reserves a pending request for the payer while the transfer runs
+func (r *PaymentRequest) Claim() error {
+	if !r.IsPending() {
+		return ErrPaymentRequestNotPending
+	}
+
+	r.Status = PaymentRequestStatusProcessing
+	r.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Release returns a claimed request to pending after a failed transfer
+func (r *PaymentRequest) Release() {
+	r.Status = PaymentRequestStatusPending
+	r.UpdatedAt = time.Now()
+}
+
+// Accept records the transfer that paid a claimed request
+func (r *PaymentRequest) Accept(payerWalletID, transactionID int) error {
+	if r.Status != PaymentRequestStatusProcessing {
+		return ErrPaymentRequestNotPending
+	}
+
+	r.PayerWalletID = &payerWalletID
+	r.TransactionID = &transactionID
+	r.respond(PaymentRequestStatusAccepted)
+
+	return nil
+}
+
+// Decline records that the payer refused the request
+func (r *PaymentRequest) Decline() error {
+	if !r.IsPending() {
+		return ErrPaymentRequestNotPending
+	}
+
+	r.respond(PaymentRequestStatusDeclined)
+
+	return nil
+}
+
+// Cancel records that the requester withdrew the request
+func (r *PaymentRequest) Cancel() error {
+	if !r.IsPending() {
+		return ErrPaymentRequestNotPending
+	}
+
+	r.respond(PaymentRequestStatusCancelled)
+
+	return nil
+}
+
+// Expire closes a pending request that was not answered in time
+func (r *PaymentRequest) Expire() error {
+	if !r.IsPending() {
+		return ErrPaymentRequestNotPending
+	}
+
+	r.Status = PaymentRequestStatusExpired
+	r.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *PaymentRequest) respond(status PaymentRequestStatus) {
+	now := time.Now()
+	r.Status = status
+	r.RespondedAt = &now
+	r.UpdatedAt = now
+}
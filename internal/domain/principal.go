@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrInvalidToken    = errors.New("invalid or expired token")
+	ErrAccessDenied    = errors.New("access denied")
+)
+
+// RoleAdmin lets a caller act on any user's wallets
+const RoleAdmin = "admin"
+
+// Principal is the authenticated caller of a request. API clients act on
+// behalf of the user owning the merchant and are limited to their scopes
+type Principal struct {
+	UserID     int        `json:"user_id"`
+	Roles      []string   `json:"roles,omitempty"`
+	MerchantID int        `json:"merchant_id,omitempty"`
+	APIKeyID   int        `json:"api_key_id,omitempty"`
+	Scopes     []APIScope `json:"scopes,omitempty"`
+}
+
+// HasRole reports whether the principal was granted a role
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAPIClient reports whether the caller authenticated with a merchant API key
+func (p *Principal) IsAPIClient() bool {
+	return p.MerchantID != 0
+}
+
+// HasScope reports whether the principal may use routes guarded by a scope.
+// User tokens are not scoped
+func (p *Principal) HasScope(scope APIScope) bool {
+	if !p.IsAPIClient() {
+		return true
+	}
+
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the principal bypasses ownership checks
+func (p *Principal) IsAdmin() bool {
+	return p.HasRole(RoleAdmin)
+}
+
+// CanActAsUser reports whether the principal may act on behalf of a user
+func (p *Principal) CanActAsUser(userID int) bool {
+	return p.IsAdmin() || p.UserID == userID
+}
+
+// Actor identifies the principal in audit records
+func (p *Principal) Actor() string {
+	if p.IsAPIClient() {
+		return fmt.Sprintf("api_key:%d", p.APIKeyID)
+	}
+	return fmt.Sprintf("user:%d", p.UserID)
+}
+
+type principalContextKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the authenticated caller
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller stored in ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimit allows a number of requests per window
+type RateLimit struct {
+	Requests int           `json:"requests" mapstructure:"requests"`
+	Window   time.Duration `json:"window" mapstructure:"window"`
+}
+
+// IsSet reports whether the limit is configured, an unset limit allows everything
+func (l RateLimit) IsSet() bool {
+	return l.Requests > 0 && l.Window > 0
+}
+
+// RateLimitPolicy holds the limits of a route group. Every request counts against
+// its client IP and, once authenticated, against its user or API key
+type RateLimitPolicy struct {
+	IP     RateLimit `json:"ip" mapstructure:"ip"`
+	User   RateLimit `json:"user" mapstructure:"user"`
+	APIKey RateLimit `json:"api_key" mapstructure:"api_key"`
+}
+
+// RateLimitSubject identifies who a request is counted against
+type RateLimitSubject struct {
+	IP       string
+	UserID   int
+	APIKeyID int
+}
+
+// RateLimitStatus is the state of the most constrained limit a request was counted against
+type RateLimitStatus struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Window    time.Duration
+	// Reset is how long until the current window ends
+	Reset time.Duration
+	// RetryAfter is how long a rejected client should wait
+	RetryAfter time.Duration
+}
+
+// Policy describes the limit in the RateLimit-Policy header format
+func (s *RateLimitStatus) Policy() string {
+	return fmt.Sprintf("%d;w=%d", s.Limit, int(s.Window.Seconds()))
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidHandle     = errors.New("handle must be 3-30 characters of letters, digits or underscores and start with a letter")
+	ErrHandleReserved    = errors.New("handle is reserved")
+	ErrInvalidRecipient  = errors.New("recipient must be a phone number, email or handle")
+	ErrRecipientIsSender = errors.New("cannot transfer to the sending wallet")
+)
+
+// RecipientType represents how a transfer recipient is addressed
+type RecipientType string
+
+// supported recipient identifiers
+const (
+	RecipientTypePhone  RecipientType = "PHONE"
+	RecipientTypeEmail  RecipientType = "EMAIL"
+	RecipientTypeHandle RecipientType = "HANDLE"
+)
+
+var (
+	handlePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{2,29}$`)
+	phonePattern  = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+)
+
+// reservedHandles can never be claimed by a user, they could be mistaken for the service itself
+var reservedHandles = map[string]bool{
+	"admin":         true,
+	"administrator": true,
+	"api":           true,
+	"billing":       true,
+	"ewallet":       true,
+	"help":          true,
+	"official":      true,
+	"payments":      true,
+	"root":          true,
+	"security":      true,
+	"staff":         true,
+	"support":       true,
+	"system":        true,
+	"wallet":        true,
+}
+
+// NormalizeHandle validates a handle and returns its canonical form. Handles are
+// case insensitive and may be given with a leading @
+func NormalizeHandle(handle string) (string, error) {
+	handle = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+
+	if !handlePattern.MatchString(handle) {
+		return "", ErrInvalidHandle
+	}
+
+	if reservedHandles[handle] {
+		return "", ErrHandleReserved
+	}
+
+	return handle, nil
+}
+
+// ParseRecipient detects whether an identifier is a phone number, an email or a
+// handle and returns it in the form users are stored with
+func ParseRecipient(identifier string) (RecipientType, string, error) {
+	identifier = strings.TrimSpace(identifier)
+
+	switch {
+	case strings.HasPrefix(identifier, "+"):
+		phone := strings.NewReplacer(" ", "", "-", "").Replace(identifier)
+		if !phonePattern.MatchString(phone) {
+			return "", "", ErrInvalidRecipient
+		}
+		return RecipientTypePhone, phone, nil
+
+	case strings.Contains(strings.TrimPrefix(identifier, "@"), "@"):
+		return RecipientTypeEmail, identifier, nil
+
+	default:
+		handle, err := NormalizeHandle(identifier)
+		if errors.Is(err, ErrInvalidHandle) {
+			return "", "", ErrInvalidRecipient
+		}
+		// Reserved handles are valid identifiers, they just never resolve
+		if errors.Is(err, ErrHandleReserved) {
+			handle = strings.ToLower(strings.TrimPrefix(identifier, "@"))
+		}
+		return RecipientTypeHandle, handle, nil
+	}
+}
+
+// Recipient represents a resolved transfer recipient. Only the masked name is
+// shown to the sender, the wallet stays hidden behind the confirmation token
+type Recipient struct {
+	Type              RecipientType `json:"type"`
+	Identifier        string        `json:"identifier"`
+	MaskedName        string        `json:"masked_name"`
+	CurrencyCode      string        `json:"currency_code"`
+	UserID            int           `json:"-"`
+	WalletID          int           `json:"-"`
+	ConfirmationToken string        `json:"confirmation_token,omitempty"`
+	ExpiresAt         *time.Time    `json:"expires_at,omitempty"`
+}
+
+// MaskName hides all but the first letter of every part of a name, e.g.
+// "John Doe" becomes "J*** D**"
+func MaskName(fullname string) string {
+	parts := strings.Fields(fullname)
+
+	for i, part := range parts {
+		runes := []rune(part)
+		if len(runes) <= 1 {
+			continue
+		}
+		parts[i] = string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	}
+
+	return strings.Join(parts, " ")
+}
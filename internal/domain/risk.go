@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRiskDecision  = errors.New("invalid risk decision")
+	ErrRiskReviewNotPending = errors.New("risk review is not pending")
+	ErrRiskReviewerRequired = errors.New("risk reviewer is required")
+)
+
+// RiskDecision represents the outcome of a risk check
+type RiskDecision string
+
+// common risk decisions, ordered from least to most severe
+const (
+	RiskDecisionAllow RiskDecision = "ALLOW"
+	// RiskDecisionReview is advisory, the operation goes through and is
+	// queued for a review after the fact
+	RiskDecisionReview RiskDecision = "REVIEW"
+	RiskDecisionDeny   RiskDecision = "DENY"
+)
+
+// Severity returns the ordering weight of a decision so the
+// most severe outcome across rules can be picked
+func (d RiskDecision) Severity() int {
+	switch d {
+	case RiskDecisionReview:
+		return 1
+	case RiskDecisionDeny:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// IsValid checks if a decision is one of the known decisions
+func (d RiskDecision) IsValid() bool {
+	return d == RiskDecisionAllow || d == RiskDecisionReview || d == RiskDecisionDeny
+}
+
+// RiskReviewStatus represents the state of a flagged transaction in the review queue
+type RiskReviewStatus string
+
+// common review statuses
+const (
+	RiskReviewStatusNone     RiskReviewStatus = "NONE"
+	RiskReviewStatusPending  RiskReviewStatus = "PENDING"
+	RiskReviewStatusApproved RiskReviewStatus = "APPROVED"
+	RiskReviewStatusRejected RiskReviewStatus = "REJECTED"
+)
+
+// RiskRuleHit records a single rule that matched during a risk check
+type RiskRuleHit struct {
+	Rule     string       `json:"rule"`
+	Decision RiskDecision `json:"decision"`
+	Reason   string       `json:"reason"`
+}
+
+// RiskAssessment represents the audited result of a risk check
+// performed before a wallet or payment operation
+type RiskAssessment struct {
+	ID              int              `json:"id"`
+	WalletID        int              `json:"wallet_id"`
+	TransactionID   *int             `json:"transaction_id,omitempty"`
+	TransactionType TransactionType  `json:"transaction_type"`
+	Amount          int              `json:"amount"`
+	ToWalletID      *int             `json:"to_wallet_id,omitempty"`
+	Decision        RiskDecision     `json:"decision"`
+	RuleHits        []RiskRuleHit    `json:"rule_hits"`
+	ReviewStatus    RiskReviewStatus `json:"review_status"`
+	ReviewedBy      string           `json:"reviewed_by,omitempty"`
+	ReviewNote      string           `json:"review_note,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	ReviewedAt      *time.Time       `json:"reviewed_at,omitempty"`
+}
+
+// NewRiskAssessment creates a new risk assessment, deriving the overall
+// decision from the most severe rule hit
+func NewRiskAssessment(walletID int, txType TransactionType, amount int, toWalletID *int, hits []RiskRuleHit) *RiskAssessment {
+	decision := RiskDecisionAllow
+	for _, hit := range hits {
+		if hit.Decision.Severity() > decision.Severity() {
+			decision = hit.Decision
+		}
+	}
+
+	reviewStatus := RiskReviewStatusNone
+	if decision == RiskDecisionReview {
+		reviewStatus = RiskReviewStatusPending
+	}
+
+	if hits == nil {
+		hits = []RiskRuleHit{}
+	}
+
+	now := time.Now()
+	return &RiskAssessment{
+		WalletID:        walletID,
+		TransactionType: txType,
+		Amount:          amount,
+		ToWalletID:      toWalletID,
+		Decision:        decision,
+		RuleHits:        hits,
+		ReviewStatus:    reviewStatus,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// IsDenied checks if the assessment blocks the operation
+func (a *RiskAssessment) IsDenied() bool {
+	return a.Decision == RiskDecisionDeny
+}
+
+// NeedsReview checks if the assessment is waiting in the review queue
+func (a *RiskAssessment) NeedsReview() bool {
+	return a.ReviewStatus == RiskReviewStatusPending
+}
+
+// LinkTransaction attaches the transaction created after the check
+func (a *RiskAssessment) LinkTransaction(transactionID int) {
+	a.TransactionID = &transactionID
+	a.UpdatedAt = time.Now()
+}
+
+// Approve marks a flagged assessment as reviewed and cleared
+func (a *RiskAssessment) Approve(reviewer, note string) error {
+	return a.resolve(RiskReviewStatusApproved, reviewer, note)
+}
+
+// Reject marks a flagged assessment as reviewed and confirmed suspicious
+func (a *RiskAssessment) Reject(reviewer, note string) error {
+	return a.resolve(RiskReviewStatusRejected, reviewer, note)
+}
+
+func (a *RiskAssessment) resolve(status RiskReviewStatus, reviewer, note string) error {
+	if a.ReviewStatus != RiskReviewStatusPending {
+		return ErrRiskReviewNotPending
+	}
+
+	if reviewer == "" {
+		return ErrRiskReviewerRequired
+	}
+
+	now := time.Now()
+	a.ReviewStatus = status
+	a.ReviewedBy = reviewer
+	a.ReviewNote = note
+	a.ReviewedAt = &now
+	a.UpdatedAt = now
+
+	return nil
+}
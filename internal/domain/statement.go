@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidStatementFormat = errors.New("statement format must be csv or pdf")
+
+// StatementFormat is the file format a statement is exported in
+type StatementFormat string
+
+// statement formats
+const (
+	StatementFormatCSV StatementFormat = "csv"
+	StatementFormatPDF StatementFormat = "pdf"
+)
+
+// IsValid reports whether the format is one of the supported formats
+func (f StatementFormat) IsValid() bool {
+	return f == StatementFormatCSV || f == StatementFormatPDF
+}
+
+// BalanceChangeFor returns how much a completed transaction changed the balance
+// of a wallet. The payer of a withdrawal or transfer is debited the fee on top of
+// the amount, so the separate fee transaction only credits the revenue wallet
+func (t *Transaction) BalanceChangeFor(walletID int) int {
+	if t.Status != TransactionStatusCompleted {
+		return 0
+	}
+
+	if t.ToWalletID != nil && *t.ToWalletID == walletID {
+		return t.Amount
+	}
+	if t.WalletID != walletID {
+		return 0
+	}
+
+	switch t.Type {
+	case TransactionTypeDeposit:
+		return t.Amount - t.Fee
+	case TransactionTypeWithdrawal, TransactionTypeTransfer:
+		return -(t.Amount + t.Fee)
+	}
+	return 0
+}
+
+// StatementHeader opens a statement of a wallet over [From, To)
+type StatementHeader struct {
+	WalletID       int
+	Currency       string
+	From           time.Time
+	To             time.Time
+	OpeningBalance int
+	GeneratedAt    time.Time
+}
+
+// StatementLine is a transaction on a statement with the balance after it
+type StatementLine struct {
+	Transaction    *Transaction
+	Counterparty   *int
+	Change         int
+	RunningBalance int
+}
+
+// StatementTotal sums the statement lines of one transaction type
+type StatementTotal struct {
+	Type    TransactionType
+	Count   int
+	Credits int
+	Debits  int
+}
+
+// StatementSummary closes a statement
+type StatementSummary struct {
+	OpeningBalance int
+	ClosingBalance int
+	// Totals are listed in the order their type first appears
+	Totals []StatementTotal
+}
+
+// NewStatementSummary starts the summary of a statement at its opening balance
+func NewStatementSummary(openingBalance int) *StatementSummary {
+	return &StatementSummary{
+		OpeningBalance: openingBalance,
+		ClosingBalance: openingBalance,
+	}
+}
+
+// Add applies a transaction to the summary and returns its statement line
+func (s *StatementSummary) Add(walletID int, transaction *Transaction) StatementLine {
+	change := transaction.BalanceChangeFor(walletID)
+	s.ClosingBalance += change
+
+	total := s.total(transaction.Type)
+	total.Count++
+	if change >= 0 {
+		total.Credits += change
+	} else {
+		total.Debits -= change
+	}
+
+	return StatementLine{
+		Transaction:    transaction,
+		Counterparty:   transaction.CounterpartyFor(walletID),
+		Change:         change,
+		RunningBalance: s.ClosingBalance,
+	}
+}
+
+func (s *StatementSummary) total(txType TransactionType) *StatementTotal {
+	for i := range s.Totals {
+		if s.Totals[i].Type == txType {
+			return &s.Totals[i]
+		}
+	}
+	s.Totals = append(s.Totals, StatementTotal{Type: txType})
+	return &s.Totals[len(s.Totals)-1]
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -9,6 +10,11 @@ var (
 	ErrInvalidTransactionAmount = errors.New("transaction amount must be greater than zero")
 	ErrInvalidTransactionType   = errors.New("invalid transaction type")
 	ErrTransactionFailed        = errors.New("transaction failed")
+	ErrInvalidTransactionStatus = errors.New("invalid transaction status")
+
+	ErrInvalidTransactionStatusTransition = errors.New("invalid transaction status transition")
+	ErrTransactionStatusReasonRequired    = errors.New("transaction status change requires a reason")
+	ErrTransactionStatusActorRequired     = errors.New("transaction status change requires an actor")
 )
 
 type TransactionType string
@@ -19,6 +25,7 @@ const (
 	TransactionTypeDeposit    TransactionType = "DEPOSIT"
 	TransactionTypeWithdrawal TransactionType = "WITHDRAWAL"
 	TransactionTypeTransfer   TransactionType = "TRANSFER"
+	TransactionTypeFee        TransactionType = "FEE"
 )
 
 // common transaction statuses
@@ -28,12 +35,31 @@ const (
 	TransactionStatusFailed    TransactionStatus = "FAILED"
 )
 
+// IsValid reports whether the type is one of the known transaction types
+func (t TransactionType) IsValid() bool {
+	switch t {
+	case TransactionTypeDeposit, TransactionTypeWithdrawal, TransactionTypeTransfer, TransactionTypeFee:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the status is one of the known transaction statuses
+func (s TransactionStatus) IsValid() bool {
+	switch s {
+	case TransactionStatusPending, TransactionStatusCompleted, TransactionStatusFailed:
+		return true
+	}
+	return false
+}
+
 // transaction represents a financial transaction in the e-wallet system
 type Transaction struct {
 	ID          int               `json:"id"`
 	WalletID    int               `json:"wallet_id"`
 	Type        TransactionType   `json:"transaction_type"`
 	Amount      int               `json:"amount"`
+	Fee         int               `json:"fee"`
 	Status      TransactionStatus `json:"transaction_status"`
 	Reference   string            `json:"reference,omitempty"`
 	Description string            `json:"description,omitempty"`
@@ -41,6 +67,10 @@ type Transaction struct {
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
 	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+
+	// StatusReason and StatusActor record a manual status override
+	StatusReason string `json:"status_reason,omitempty"`
+	StatusActor  string `json:"status_actor,omitempty"`
 }
 
 // NewTransaction creates a new transaction
@@ -50,9 +80,7 @@ func NewTransaction(walletID int, txType TransactionType, amount int, descriptio
 	}
 
 	// validate transaction type
-	if txType != TransactionTypeDeposit &&
-		txType != TransactionTypeWithdrawal &&
-		txType != TransactionTypeTransfer {
+	if !txType.IsValid() {
 		return nil, ErrInvalidTransactionType
 	}
 
@@ -100,6 +128,31 @@ func (t *Transaction) Fail() {
 	t.UpdatedAt = time.Now()
 }
 
+// ChangeStatus applies a manual status override. Only a pending transaction
+// can be failed this way, completing one would record funds that never moved
+func (t *Transaction) ChangeStatus(status TransactionStatus, reason, actor string) error {
+	if !status.IsValid() {
+		return ErrInvalidTransactionStatus
+	}
+
+	if t.Status != TransactionStatusPending || status != TransactionStatusFailed {
+		return ErrInvalidTransactionStatusTransition
+	}
+
+	if strings.TrimSpace(reason) == "" {
+		return ErrTransactionStatusReasonRequired
+	}
+
+	if actor == "" {
+		return ErrTransactionStatusActorRequired
+	}
+
+	t.Fail()
+	t.StatusReason = reason
+	t.StatusActor = actor
+	return nil
+}
+
 // IsPending Checks if a transaction is pending
 func (t *Transaction) IsPending() bool {
 	return t.Status == TransactionStatusPending
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTransactionDirection = errors.New("direction must be IN or OUT")
+	ErrInvalidDateRange            = errors.New("from must be before to")
+	ErrInvalidAmountRange          = errors.New("amount range must be positive with min not above max")
+)
+
+// TransactionDirection tells whether a transaction moved money into or out of a wallet
+type TransactionDirection string
+
+// transaction directions
+const (
+	TransactionDirectionIn  TransactionDirection = "IN"
+	TransactionDirectionOut TransactionDirection = "OUT"
+)
+
+// IsValid reports whether the direction is known
+func (d TransactionDirection) IsValid() bool {
+	return d == TransactionDirectionIn || d == TransactionDirectionOut
+}
+
+// DirectionFor returns the direction of the transaction as seen from a wallet.
+// Deposits and anything sent to the wallet come in, everything else goes out
+func (t *Transaction) DirectionFor(walletID int) TransactionDirection {
+	if t.ToWalletID != nil && *t.ToWalletID == walletID {
+		return TransactionDirectionIn
+	}
+	if t.Type == TransactionTypeDeposit {
+		return TransactionDirectionIn
+	}
+	return TransactionDirectionOut
+}
+
+// CounterpartyFor returns the wallet on the other side of the transaction, if any
+func (t *Transaction) CounterpartyFor(walletID int) *int {
+	if t.WalletID == walletID {
+		return t.ToWalletID
+	}
+	counterparty := t.WalletID
+	return &counterparty
+}
+
+// TransactionFilter narrows the transaction history of a wallet. Unset fields
+// do not filter, set ones must all match
+type TransactionFilter struct {
+	// From and To bound the creation time, From inclusive and To exclusive
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+	// Types and Statuses match any of the listed values
+	Types    []TransactionType   `json:"types,omitempty"`
+	Statuses []TransactionStatus `json:"statuses,omitempty"`
+	// MinAmount and MaxAmount bound the amount, both inclusive
+	MinAmount            *int                 `json:"min_amount,omitempty"`
+	MaxAmount            *int                 `json:"max_amount,omitempty"`
+	CounterpartyWalletID *int                 `json:"counterparty_wallet_id,omitempty"`
+	Direction            TransactionDirection `json:"direction,omitempty"`
+	// Description matches a case-insensitive part of the description
+	Description string `json:"description,omitempty"`
+	// Reference matches the whole reference, e.g. to find the transaction a
+	// caller tagged before an interruption
+	Reference string `json:"reference,omitempty"`
+}
+
+// TransactionQuery selects a page of a wallet's transactions, newest first unless
+// OldestFirst is set. The page starts after the cursor when one is given,
+// otherwise at the offset
+type TransactionQuery struct {
+	WalletID    int
+	Filter      TransactionFilter
+	OldestFirst bool
+	After       *TransactionCursor
+	Limit       int
+	Offset      int
+}
+
+// Validate checks that the filter can match anything
+func (f TransactionFilter) Validate() error {
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return ErrInvalidDateRange
+	}
+
+	for _, t := range f.Types {
+		if !t.IsValid() {
+			return ErrInvalidTransactionType
+		}
+	}
+
+	for _, s := range f.Statuses {
+		if !s.IsValid() {
+			return ErrInvalidTransactionStatus
+		}
+	}
+
+	if (f.MinAmount != nil && *f.MinAmount < 0) || (f.MaxAmount != nil && *f.MaxAmount < 0) {
+		return ErrInvalidAmountRange
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil && *f.MinAmount > *f.MaxAmount {
+		return ErrInvalidAmountRange
+	}
+
+	if f.Direction != "" && !f.Direction.IsValid() {
+		return ErrInvalidTransactionDirection
+	}
+
+	return nil
+}
+
+// Matches reports whether a transaction of the wallet passes the filter
+func (f TransactionFilter) Matches(walletID int, t *Transaction) bool {
+	if f.From != nil && t.CreatedAt.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !t.CreatedAt.Before(*f.To) {
+		return false
+	}
+
+	if len(f.Types) > 0 && !containsType(f.Types, t.Type) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !containsStatus(f.Statuses, t.Status) {
+		return false
+	}
+
+	if f.MinAmount != nil && t.Amount < *f.MinAmount {
+		return false
+	}
+	if f.MaxAmount != nil && t.Amount > *f.MaxAmount {
+		return false
+	}
+
+	if f.CounterpartyWalletID != nil {
+		counterparty := t.CounterpartyFor(walletID)
+		if counterparty == nil || *counterparty != *f.CounterpartyWalletID {
+			return false
+		}
+	}
+
+	if f.Direction != "" && t.DirectionFor(walletID) != f.Direction {
+		return false
+	}
+
+	if f.Description != "" && !strings.Contains(strings.ToLower(t.Description), strings.ToLower(f.Description)) {
+		return false
+	}
+
+	if f.Reference != "" && t.Reference != f.Reference {
+		return false
+	}
+
+	return true
+}
+
+func containsType(types []TransactionType, t TransactionType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatus(statuses []TransactionStatus, s TransactionStatus) bool {
+	for _, candidate := range statuses {
+		if candidate == s {
+			return true
+		}
+	}
+	return false
+}
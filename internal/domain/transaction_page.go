@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// TransactionCursor is the position of a transaction in a listing ordered by
+// creation time. Transactions created at the same time are ordered by ID
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        int
+}
+
+// CursorAfter returns the cursor continuing a listing after a transaction
+func CursorAfter(transaction *Transaction) *TransactionCursor {
+	return &TransactionCursor{
+		CreatedAt: transaction.CreatedAt,
+		ID:        transaction.ID,
+	}
+}
+
+// Encode returns the opaque form of the cursor handed to clients
+func (c *TransactionCursor) Encode() string {
+	raw := fmt.Sprintf("%d:%d", c.CreatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeTransactionCursor parses a cursor produced by Encode
+func DecodeTransactionCursor(encoded string) (*TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var nanos int64
+	var id int
+	if _, err := fmt.Sscanf(string(raw), "%d:%d", &nanos, &id); err != nil || id <= 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &TransactionCursor{
+		CreatedAt: time.Unix(0, nanos).UTC(),
+		ID:        id,
+	}, nil
+}
+
+// Compare returns -1 when a transaction is older than the cursor, 1 when it is
+// newer and 0 when it is the transaction the cursor points at
+func (c *TransactionCursor) Compare(transaction *Transaction) int {
+	switch {
+	case transaction.CreatedAt.Before(c.CreatedAt):
+		return -1
+	case transaction.CreatedAt.After(c.CreatedAt):
+		return 1
+	case transaction.ID < c.ID:
+		return -1
+	case transaction.ID > c.ID:
+		return 1
+	}
+	return 0
+}
+
+// TransactionPage is one page of a transaction listing
+type TransactionPage struct {
+	Transactions []*Transaction `json:"transactions"`
+	// NextCursor continues the listing, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total is only counted when asked for
+	Total *int `json:"total,omitempty"`
+}
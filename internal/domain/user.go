@@ -8,6 +8,7 @@ type User struct {
 	Fullname  string     `json:"fullname"`
 	Email     string     `json:"email"`
 	Phone     string     `json:"phone"`
+	Handle    string     `json:"handle,omitempty"`
 	Status    UserStatus `json:"status"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
@@ -34,6 +35,19 @@ func NewUser(fullname, email, phone string) *User {
 	}
 }
 
+// SetHandle validates and assigns a unique public handle
+func (u *User) SetHandle(handle string) error {
+	normalized, err := NormalizeHandle(handle)
+	if err != nil {
+		return err
+	}
+
+	u.Handle = normalized
+	u.UpdatedAt = time.Now()
+
+	return nil
+}
+
 // Notes
 // What if we have so many params on a func?
 // ans:
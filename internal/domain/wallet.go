@@ -10,6 +10,13 @@ var (
 	ErrInsufficientBalance = errors.New("insufficient balance")
 	ErrInvalidAmount       = errors.New("amount must be greater than zero")
 	ErrWalletNotActive     = errors.New("wallet is not active")
+
+	ErrWalletFrozen                  = errors.New("wallet is frozen")
+	ErrWalletClosed                  = errors.New("wallet is closed")
+	ErrInvalidWalletStatusTransition = errors.New("invalid wallet status transition")
+	ErrInvalidWalletStatusReason     = errors.New("invalid wallet status reason")
+	ErrWalletStatusActorRequired     = errors.New("wallet status change requires an actor")
+	ErrWalletBalanceNotEmpty         = errors.New("wallet balance must be swept before closing")
 )
 
 type Wallet struct {
@@ -19,8 +26,12 @@ type Wallet struct {
 	CurrencyCode string       `json:"currency_code"`
 	Description  string       `json:"description"`
 	Status       WalletStatus `json:"status"`
-	CreatedAt    time.Time    `json:"created_at"`
-	UpdatedAt    time.Time    `json:"updated_at"`
+	// StatusReason, StatusActor and StatusChangedAt record the last freeze, unfreeze or close
+	StatusReason    WalletStatusReason `json:"status_reason,omitempty"`
+	StatusActor     string             `json:"status_actor,omitempty"`
+	StatusChangedAt *time.Time         `json:"status_changed_at,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
 }
 
 type WalletStatus string
@@ -29,8 +40,43 @@ type WalletStatus string
 const (
 	WalletStatusActive   WalletStatus = "ACTIVE"
 	WalletStatusInactive WalletStatus = "INACTIVE"
+	// WalletStatusFrozen wallets can still receive funds but cannot send them
+	WalletStatusFrozen WalletStatus = "FROZEN"
+	// WalletStatusClosed wallets reject every operation, closing is final
+	WalletStatusClosed WalletStatus = "CLOSED"
 )
 
+// WalletStatusReason is the reason code recorded with a wallet status change
+type WalletStatusReason string
+
+// common reasons for wallet status changes
+const (
+	WalletStatusReasonCustomerRequest WalletStatusReason = "CUSTOMER_REQUEST"
+	WalletStatusReasonSuspectedFraud  WalletStatusReason = "SUSPECTED_FRAUD"
+	WalletStatusReasonCompliance      WalletStatusReason = "COMPLIANCE_HOLD"
+	WalletStatusReasonChargeback      WalletStatusReason = "CHARGEBACK"
+	WalletStatusReasonDormant         WalletStatusReason = "DORMANT"
+	WalletStatusReasonDeceased        WalletStatusReason = "DECEASED"
+	WalletStatusReasonResolved        WalletStatusReason = "RESOLVED"
+	WalletStatusReasonOther           WalletStatusReason = "OTHER"
+)
+
+// IsValid reports whether the reason is one of the known reason codes
+func (r WalletStatusReason) IsValid() bool {
+	switch r {
+	case WalletStatusReasonCustomerRequest,
+		WalletStatusReasonSuspectedFraud,
+		WalletStatusReasonCompliance,
+		WalletStatusReasonChargeback,
+		WalletStatusReasonDormant,
+		WalletStatusReasonDeceased,
+		WalletStatusReasonResolved,
+		WalletStatusReasonOther:
+		return true
+	}
+	return false
+}
+
 func NewWallet(userID int, currencyCode, description string) *Wallet {
 	now := time.Now()
 
@@ -51,8 +97,8 @@ func (w *Wallet) Credit(amount int) error {
 		return ErrInvalidAmount
 	}
 
-	if w.Status != WalletStatusActive {
-		return ErrWalletNotActive
+	if err := w.CheckCanReceive(); err != nil {
+		return err
 	}
 
 	// Add funds to balance
@@ -68,8 +114,8 @@ func (w *Wallet) Debit(amount int) error {
 		return ErrInvalidAmount
 	}
 
-	if w.Status != WalletStatusActive {
-		return ErrWalletNotActive
+	if err := w.CheckCanSend(); err != nil {
+		return err
 	}
 
 	if w.Balance < amount {
@@ -85,3 +131,94 @@ func (w *Wallet) Debit(amount int) error {
 func (w *Wallet) IsActive() bool {
 	return w.Status == WalletStatusActive
 }
+
+// CheckCanReceive returns an error when the wallet cannot be credited. Frozen
+// wallets still accept inbound funds
+func (w *Wallet) CheckCanReceive() error {
+	switch w.Status {
+	case WalletStatusActive, WalletStatusFrozen:
+		return nil
+	case WalletStatusClosed:
+		return ErrWalletClosed
+	default:
+		return ErrWalletNotActive
+	}
+}
+
+// CheckCanSend returns an error when the wallet cannot be debited
+func (w *Wallet) CheckCanSend() error {
+	switch w.Status {
+	case WalletStatusActive:
+		return nil
+	case WalletStatusFrozen:
+		return ErrWalletFrozen
+	case WalletStatusClosed:
+		return ErrWalletClosed
+	default:
+		return ErrWalletNotActive
+	}
+}
+
+// Freeze blocks outbound funds until the wallet is unfrozen
+func (w *Wallet) Freeze(reason WalletStatusReason, actor string) error {
+	if w.Status != WalletStatusActive {
+		return ErrInvalidWalletStatusTransition
+	}
+
+	return w.changeStatus(WalletStatusFrozen, reason, actor)
+}
+
+// Unfreeze makes a frozen wallet active again
+func (w *Wallet) Unfreeze(reason WalletStatusReason, actor string) error {
+	if w.Status != WalletStatusFrozen {
+		return ErrInvalidWalletStatusTransition
+	}
+
+	return w.changeStatus(WalletStatusActive, reason, actor)
+}
+
+// SweepBalance empties the wallet ahead of closing it and returns the amount
+// taken out. It bypasses the direction rules so frozen wallets can be closed too
+func (w *Wallet) SweepBalance() (int, error) {
+	if w.Status == WalletStatusClosed {
+		return 0, ErrWalletClosed
+	}
+
+	amount := w.Balance
+	w.Balance = 0
+	w.UpdatedAt = time.Now()
+
+	return amount, nil
+}
+
+// Close permanently closes an empty wallet
+func (w *Wallet) Close(reason WalletStatusReason, actor string) error {
+	if w.Status == WalletStatusClosed {
+		return ErrWalletClosed
+	}
+
+	if w.Balance != 0 {
+		return ErrWalletBalanceNotEmpty
+	}
+
+	return w.changeStatus(WalletStatusClosed, reason, actor)
+}
+
+func (w *Wallet) changeStatus(status WalletStatus, reason WalletStatusReason, actor string) error {
+	if !reason.IsValid() {
+		return ErrInvalidWalletStatusReason
+	}
+
+	if actor == "" {
+		return ErrWalletStatusActorRequired
+	}
+
+	now := time.Now()
+	w.Status = status
+	w.StatusReason = reason
+	w.StatusActor = actor
+	w.StatusChangedAt = &now
+	w.UpdatedAt = now
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package primary
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// BalanceSnapshotService defines the contract for balance history application service
+type BalanceSnapshotService interface {
+	// GetBalanceAt retrieves the balance of a wallet at a point in time
+	GetBalanceAt(ctx context.Context, walletID int, at time.Time) (*domain.HistoricalBalance, error)
+
+	// TakeSnapshots stores the current balance of every wallet
+	TakeSnapshots(ctx context.Context) (int, error)
+}
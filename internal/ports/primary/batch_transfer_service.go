@@ -0,0 +1,33 @@
+package primary
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// BatchTransferItemRequest represents one recipient of a batch transfer request
+type BatchTransferItemRequest struct {
+	ToWalletID  int    `json:"to_wallet_id"`
+	Amount      int    `json:"amount"`
+	Description string `json:"description"`
+}
+
+// BatchTransferRequest represents a request to pay many wallets from one source wallet
+type BatchTransferRequest struct {
+	FromWalletID int                        `json:"from_wallet_id"`
+	Mode         domain.BatchTransferMode   `json:"mode"`
+	Description  string                     `json:"description"`
+	Items        []BatchTransferItemRequest `json:"items"`
+}
+
+// BatchTransferService defines the contract for batch transfer application service
+type BatchTransferService interface {
+	// CreateBatchTransfer validates a batch and queues it for processing
+	CreateBatchTransfer(ctx context.Context, req BatchTransferRequest) (*domain.BatchTransfer, error)
+
+	// GetBatchTransfer retrieves a batch transfer with per-item results
+	GetBatchTransfer(ctx context.Context, batchID int) (*domain.BatchTransfer, error)
+
+	// GetBatchTransfersByWalletID retrieves batch transfers sent from a wallet
+	GetBatchTransfersByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.BatchTransfer, error)
+}
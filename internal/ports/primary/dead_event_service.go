@@ -0,0 +1,19 @@
+package primary
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// DeadEventService defines the contract for inspecting and redriving events
+// whose handlers failed
+type DeadEventService interface {
+	// GetDeadEvent retrieves a dead event by ID
+	GetDeadEvent(ctx context.Context, id int) (*domain.DeadEvent, error)
+
+	// GetPendingDeadEvents retrieves dead events not yet redriven, oldest first
+	GetPendingDeadEvents(ctx context.Context, limit int) ([]*domain.DeadEvent, error)
+
+	// Redrive publishes a dead event to its topic again
+	Redrive(ctx context.Context, id int) (*domain.DeadEvent, error)
+}
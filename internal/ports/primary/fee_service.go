@@ -0,0 +1,25 @@
+package primary
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// FeeQuoteRequest represents a request to preview the fee of an operation
+type FeeQuoteRequest struct {
+	WalletID        int                    `json:"wallet_id"`
+	TransactionType domain.TransactionType `json:"transaction_type"`
+	Amount          int                    `json:"amount"`
+	Currency        string                 `json:"currency"`
+	PaymentProvider domain.PaymentProvider `json:"payment_provider"`
+}
+
+// FeeService defines the contract for fee application service
+type FeeService interface {
+
+	// QuoteFee previews the fee of an operation without moving any funds
+	QuoteFee(ctx context.Context, req FeeQuoteRequest) (*domain.FeeQuote, error)
+
+	// GetFeeSchedule returns the configured fee rules
+	GetFeeSchedule(ctx context.Context) (*domain.FeeSchedule, error)
+}
@@ -0,0 +1,16 @@
+package primary
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// HealthService defines the contract for health check application service
+type HealthService interface {
+	// Liveness reports whether the process is running, without checking any
+	// dependency
+	Liveness(ctx context.Context) *domain.HealthReport
+
+	// Readiness checks every registered dependency
+	Readiness(ctx context.Context) *domain.HealthReport
+}
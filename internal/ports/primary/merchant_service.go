@@ -0,0 +1,47 @@
+package primary
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// CreateMerchantRequest represents the request to register an API client
+type CreateMerchantRequest struct {
+	Name        string                     `json:"name"`
+	UserID      int                        `json:"user_id"`
+	Environment domain.MerchantEnvironment `json:"environment"`
+	Scopes      []domain.APIScope          `json:"scopes"`
+}
+
+// SignedRequest carries what an API client sent to authenticate a request
+type SignedRequest struct {
+	APIKey    string
+	Timestamp string
+	Signature string
+	Method    string
+	Path      string
+	Body      []byte
+}
+
+// MerchantService defines the contract for merchant API client application service
+type MerchantService interface {
+	// CreateMerchant registers an API client and issues its first key
+	CreateMerchant(ctx context.Context, req CreateMerchantRequest) (*domain.Merchant, *domain.APICredentials, error)
+
+	// GetMerchant retrieves a merchant by ID
+	GetMerchant(ctx context.Context, merchantID int) (*domain.Merchant, error)
+
+	// GetAPIKeys retrieves every key of a merchant, newest first
+	GetAPIKeys(ctx context.Context, merchantID int) ([]*domain.APIKey, error)
+
+	// RotateAPIKey issues a new key. Keys issued before keep working until the
+	// rotation overlap has passed
+	RotateAPIKey(ctx context.Context, merchantID int) (*domain.APICredentials, error)
+
+	// RevokeAPIKey stops a key immediately
+	RevokeAPIKey(ctx context.Context, merchantID, keyID int) error
+
+	// AuthenticateRequest verifies the key and signature of a request and returns
+	// the principal it acts as
+	AuthenticateRequest(ctx context.Context, req SignedRequest) (*domain.Principal, error)
+}
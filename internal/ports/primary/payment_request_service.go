@@ -0,0 +1,40 @@
+package primary
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// CreatePaymentRequest represents a request to be paid by another user
+type CreatePaymentRequest struct {
+	RequesterWalletID int           `json:"requester_wallet_id"`
+	PayerUserID       int           `json:"payer_user_id"`
+	Amount            int           `json:"amount"`
+	Note              string        `json:"note"`
+	ExpiresIn         time.Duration `json:"expires_in"`
+}
+
+// PaymentRequestService defines the contract for payment request application service
+type PaymentRequestService interface {
+	// CreatePaymentRequest asks a user to pay into the requester wallet
+	CreatePaymentRequest(ctx context.Context, req CreatePaymentRequest) (*domain.PaymentRequest, error)
+
+	// GetPaymentRequest retrieves a payment request by ID
+	GetPaymentRequest(ctx context.Context, requestID int) (*domain.PaymentRequest, error)
+
+	// GetInbox retrieves requests addressed to a user
+	GetInbox(ctx context.Context, userID int, status domain.PaymentRequestStatus, limit, offset int) ([]*domain.PaymentRequest, error)
+
+	// GetOutbox retrieves requests sent by a user
+	GetOutbox(ctx context.Context, userID int, status domain.PaymentRequestStatus, limit, offset int) ([]*domain.PaymentRequest, error)
+
+	// AcceptPaymentRequest pays a request from one of the payer's wallets
+	AcceptPaymentRequest(ctx context.Context, requestID int, payerWalletID int) (*domain.PaymentRequest, error)
+
+	// DeclinePaymentRequest refuses a request on behalf of the payer
+	DeclinePaymentRequest(ctx context.Context, requestID int, userID int) (*domain.PaymentRequest, error)
+
+	// CancelPaymentRequest withdraws a request on behalf of the requester
+	CancelPaymentRequest(ctx context.Context, requestID int, userID int) (*domain.PaymentRequest, error)
+}
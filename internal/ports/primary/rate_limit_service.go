@@ -0,0 +1,14 @@
+package primary
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// RateLimitService defines the contract for request rate limiting
+type RateLimitService interface {
+
+	// Check counts a request of the subject against the limits of a route group.
+	// It returns nil when the group has no limits for the subject
+	Check(ctx context.Context, group string, subject domain.RateLimitSubject) (*domain.RateLimitStatus, error)
+}
@@ -0,0 +1,27 @@
+package primary
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// TransferToRecipientRequest represents a transfer addressed by phone, email or handle
+// instead of wallet ID. Either a confirmation token from ResolveRecipient or the
+// recipient identifier itself must be given
+type TransferToRecipientRequest struct {
+	FromWalletID      int    `json:"from_wallet_id"`
+	Recipient         string `json:"recipient"`
+	ConfirmationToken string `json:"confirmation_token"`
+	Amount            int    `json:"amount"`
+	Description       string `json:"description"`
+}
+
+// RecipientService defines the contract for recipient resolution application service
+type RecipientService interface {
+	// ResolveRecipient finds the wallet of a recipient in a currency and returns its
+	// masked name together with a confirmation token for the transfer
+	ResolveRecipient(ctx context.Context, identifier, currencyCode string) (*domain.Recipient, error)
+
+	// TransferToRecipient transfers funds to a resolved or confirmed recipient
+	TransferToRecipient(ctx context.Context, req TransferToRecipientRequest) (*domain.Transaction, error)
+}
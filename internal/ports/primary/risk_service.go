@@ -0,0 +1,25 @@
+package primary
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// RiskService defines the contract for risk review application service
+type RiskService interface {
+
+	// GetAssessment retrieves a risk assessment by ID
+	GetAssessment(ctx context.Context, assessmentID int) (*domain.RiskAssessment, error)
+
+	// GetAssessmentsByWalletID retrieves the risk audit trail of a wallet
+	GetAssessmentsByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.RiskAssessment, error)
+
+	// GetReviewQueue retrieves flagged assessments waiting for manual review
+	GetReviewQueue(ctx context.Context, limit, offset int) ([]*domain.RiskAssessment, error)
+
+	// ApproveReview clears a flagged assessment
+	ApproveReview(ctx context.Context, assessmentID int, reviewer, note string) (*domain.RiskAssessment, error)
+
+	// RejectReview confirms a flagged assessment as suspicious
+	RejectReview(ctx context.Context, assessmentID int, reviewer, note string) (*domain.RiskAssessment, error)
+}
@@ -0,0 +1,27 @@
+package primary
+
+import (
+	"context"
+	"io"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// StatementRequest selects the statement of a wallet over [From, To)
+type StatementRequest struct {
+	WalletID int                    `json:"wallet_id"`
+	From     time.Time              `json:"from"`
+	To       time.Time              `json:"to"`
+	Format   domain.StatementFormat `json:"format"`
+}
+
+// StatementOutput opens the destination of a statement once it is known to be
+// valid, given the media type and a suggested file name
+type StatementOutput func(contentType, filename string) io.Writer
+
+// StatementService defines the contract for account statement application service
+type StatementService interface {
+	// ExportStatement streams a statement to the output. Nothing is written when
+	// the request is rejected
+	ExportStatement(ctx context.Context, req StatementRequest, output StatementOutput) error
+}
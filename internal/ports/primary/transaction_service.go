@@ -14,12 +14,17 @@ type TransactionService interface {
 	// GetTransactionsByWalletID
 	GetTransactionByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.Transaction, int, error)
 
+	// GetTransactionsByStatus retrieves transactions in a status, newest first
+	GetTransactionsByStatus(ctx context.Context, status domain.TransactionStatus, limit, offset int) ([]*domain.Transaction, error)
+
 	// CreateTransaction creates a new transaction
 	CreateTransaction(ctx context.Context, transaction *domain.Transaction) error
 
-	// UpdateTransactionStatus updates the status of a transaction
-	UpdateTransactionStatus(ctx context.Context, transactionID int, status domain.TransactionStatus) error
+	// UpdateTransactionStatus overrides the status of a transaction, recording who did it and why
+	UpdateTransactionStatus(ctx context.Context, transactionID int, status domain.TransactionStatus, reason, actor string) error
 
+	// GetStalePendingTransactions retrieves the pending transactions ReconcileFailedTransactions would resolve
+	GetStalePendingTransactions(ctx context.Context) ([]*domain.Transaction, error)
 	// ReconcileFailedTransactions attempts to fix transactions
 	ReconcileFailedTransactions(ctx context.Context) error
 }
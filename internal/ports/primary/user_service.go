@@ -23,6 +23,9 @@ type UserService interface {
 	// UpdateUser updates an existing user
 	UpdateUser(ctx context.Context, id int, fullname, email, phone string) (*domain.User, error)
 
+	// SetHandle claims a unique public handle for a user
+	SetHandle(ctx context.Context, id int, handle string) (*domain.User, error)
+
 	// DeactiveUser deactives a user(sets status to inactive)
 	DeactiveUser(ctx context.Context, id int) error
 
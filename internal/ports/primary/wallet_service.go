@@ -5,6 +5,26 @@ import (
 	"ports-and-adapters-architecture/internal/domain"
 )
 
+// CloseWalletRequest represents the request to close a wallet. Any remaining
+// balance is swept to SweepToWalletID first
+type CloseWalletRequest struct {
+	WalletID        int                       `json:"wallet_id"`
+	SweepToWalletID int                       `json:"sweep_to_wallet_id"`
+	Reason          domain.WalletStatusReason `json:"reason"`
+	Actor           string                    `json:"actor"`
+}
+
+// TransactionHistoryQuery selects a page of a wallet's transaction history.
+// A cursor from a previous page continues the listing, otherwise the page
+// starts at Offset
+type TransactionHistoryQuery struct {
+	Filter       domain.TransactionFilter `json:"filter"`
+	Limit        int                      `json:"limit"`
+	Offset       int                      `json:"offset"`
+	Cursor       string                   `json:"cursor"`
+	IncludeTotal bool                     `json:"include_total"`
+}
+
 // WalletService defines the contract for wallet application service
 type WalletService interface {
 	// CreateWallet creates a new wallet for a user
@@ -31,6 +51,17 @@ type WalletService interface {
 		description string,
 	) (*domain.Transaction, error)
 
+	// TransferWithReference transfers funds and tags the transaction with a
+	// reference unique to the caller, so an interrupted caller can find it
+	TransferWithReference(
+		ctx context.Context,
+		fromWalletID int,
+		toWalletID int,
+		amount int,
+		description string,
+		reference string,
+	) (*domain.Transaction, error)
+
 	// GetTransactionHistory retrieves transaction history for a wallet
 	GetTransactionHistory(
 		ctx context.Context,
@@ -38,6 +69,18 @@ type WalletService interface {
 		limit, offset int,
 	) ([]*domain.Transaction, int, error)
 
+	// GetTransactionHistoryPage retrieves a filtered page of transaction history for a wallet
+	GetTransactionHistoryPage(ctx context.Context, walletID int, query TransactionHistoryQuery) (*domain.TransactionPage, error)
+
 	// GetBalance gets the current balance of a wallet
 	GetBalance(ctx context.Context, walletID int) (int, string, error)
+
+	// FreezeWallet blocks outbound funds of a wallet
+	FreezeWallet(ctx context.Context, walletID int, reason domain.WalletStatusReason, actor string) (*domain.Wallet, error)
+
+	// UnfreezeWallet makes a frozen wallet active again
+	UnfreezeWallet(ctx context.Context, walletID int, reason domain.WalletStatusReason, actor string) (*domain.Wallet, error)
+
+	// CloseWallet sweeps the remaining balance and closes a wallet for good
+	CloseWallet(ctx context.Context, req CloseWalletRequest) (*domain.Wallet, error)
 }
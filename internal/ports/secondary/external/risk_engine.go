@@ -0,0 +1,26 @@
+package external
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// RiskCheckRequest represents an operation to be evaluated by a risk engine
+type RiskCheckRequest struct {
+	WalletID        int                    `json:"wallet_id"`
+	UserID          int                    `json:"user_id"`
+	TransactionType domain.TransactionType `json:"transaction_type"`
+	Amount          int                    `json:"amount"`
+	Currency        string                 `json:"currency"`
+	ToWalletID      *int                   `json:"to_wallet_id,omitempty"`
+	Provider        domain.PaymentProvider `json:"provider,omitempty"`
+	RequestedAt     time.Time              `json:"requested_at"`
+}
+
+// RiskEngine defines the port for fraud and risk evaluation
+type RiskEngine interface {
+	// Evaluate runs the configured checks and returns every rule that matched.
+	// An empty result means the operation is allowed
+	Evaluate(ctx context.Context, request RiskCheckRequest) ([]domain.RiskRuleHit, error)
+}
@@ -5,13 +5,19 @@ import "context"
 // EventHandler is a function that handles an event
 type EventHandler func(ctx context.Context, event Event) error
 
+// FailureHandler is called with an event whose handler returned an error
+type FailureHandler func(ctx context.Context, topic string, event Event, cause error) error
+
 // EventConsumer defines the port for consuming events
 type EventConsumer interface {
 	// Subsribe registers a handler for a specific topic
 	Subscribe(topic string, handler EventHandler) error
 
 	// SubscribeWithGroup registers a handler for a specific topic with a consumer group
-	SubscribeWithGroup(topic string, groupID string, handler EventHandler)
+	SubscribeWithGroup(topic string, groupID string, handler EventHandler) error
+
+	// SetFailureHandler sets the handler receiving events whose handler failed
+	SetFailureHandler(handler FailureHandler)
 
 	// Unsubscribe removes a handler for a spesific topic
 	Unsubscribe(topic string) error
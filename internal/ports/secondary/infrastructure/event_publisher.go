@@ -20,7 +20,7 @@ type EventPublisher interface {
 	PublishAsync(ctx context.Context, topic string, event Event) error
 
 	// PublishBatch publishes multiple events to the same topic
-	PublishBatch(ctx context.Context, topic string, events []Event)
+	PublishBatch(ctx context.Context, topic string, events []Event) error
 
 	// Flush waits for all async events to be published
 	Flush(ctx context.Context) error
@@ -0,0 +1,18 @@
+package infrastructure
+
+import "context"
+
+// HealthChecker defines the port adapters implement to report whether the
+// dependency behind them is reachable
+type HealthChecker interface {
+	// CheckHealth returns an error when the dependency cannot be used
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthCheckFunc adapts a function such as a Ping method to a HealthChecker
+type HealthCheckFunc func(ctx context.Context) error
+
+// CheckHealth calls f
+func (f HealthCheckFunc) CheckHealth(ctx context.Context) error {
+	return f(ctx)
+}
@@ -0,0 +1,32 @@
+package infrastructure
+
+import "context"
+
+// Logger defines the port for structured logging. args are alternating keys
+// and values, and implementations add the request ID carried by ctx
+type Logger interface {
+	// Debug logs a message useful when diagnosing a problem
+	Debug(ctx context.Context, msg string, args ...any)
+
+	// Info logs a message about normal operation
+	Info(ctx context.Context, msg string, args ...any)
+
+	// Warn logs a message about a problem the service recovered from
+	Warn(ctx context.Context, msg string, args ...any)
+
+	// Error logs a message about a failed operation
+	Error(ctx context.Context, msg string, args ...any)
+
+	// With returns a logger adding args to every message
+	With(args ...any) Logger
+}
+
+// NopLogger discards every message, it is the logger of services that were
+// not given one
+type NopLogger struct{}
+
+func (NopLogger) Debug(ctx context.Context, msg string, args ...any) {}
+func (NopLogger) Info(ctx context.Context, msg string, args ...any)  {}
+func (NopLogger) Warn(ctx context.Context, msg string, args ...any)  {}
+func (NopLogger) Error(ctx context.Context, msg string, args ...any) {}
+func (l NopLogger) With(args ...any) Logger                          { return l }
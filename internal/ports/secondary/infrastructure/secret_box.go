@@ -0,0 +1,11 @@
+package infrastructure
+
+// SecretBox defines the port for encrypting secrets that have to be read back,
+// unlike passwords and API keys which are only ever compared by hash
+type SecretBox interface {
+	// Seal encrypts a secret for storage
+	Seal(plaintext string) (string, error)
+
+	// Open decrypts a secret sealed by Seal
+	Open(sealed string) (string, error)
+}
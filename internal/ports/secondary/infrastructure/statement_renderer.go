@@ -0,0 +1,31 @@
+package infrastructure
+
+import (
+	"io"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// StatementRenderer defines the port for rendering account statements in a file format
+type StatementRenderer interface {
+	// Format returns the statement format rendered
+	Format() domain.StatementFormat
+
+	// ContentType returns the media type of rendered statements
+	ContentType() string
+
+	// NewWriter starts a statement written to out
+	NewWriter(out io.Writer) StatementWriter
+}
+
+// StatementWriter streams one statement. WriteHeader comes first, then every
+// line in order and WriteSummary last, which also flushes the output
+type StatementWriter interface {
+	// WriteHeader writes the statement details and opening balance
+	WriteHeader(header domain.StatementHeader) error
+
+	// WriteLine writes a transaction with its running balance
+	WriteLine(line domain.StatementLine) error
+
+	// WriteSummary writes the totals and closing balance and ends the statement
+	WriteSummary(summary *domain.StatementSummary) error
+}
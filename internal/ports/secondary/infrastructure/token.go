@@ -0,0 +1,19 @@
+package infrastructure
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// TokenVerifier defines the port for validating access tokens
+type TokenVerifier interface {
+	// Verify checks the signature and claims of a token and returns its principal
+	Verify(ctx context.Context, token string) (*domain.Principal, error)
+}
+
+// TokenIssuer defines the port for minting access tokens
+type TokenIssuer interface {
+	// Issue signs a token for a principal valid for ttl, or the default TTL when ttl is zero
+	Issue(ctx context.Context, principal domain.Principal, ttl time.Duration) (string, time.Time, error)
+}
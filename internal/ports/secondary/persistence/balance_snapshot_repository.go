@@ -0,0 +1,16 @@
+package persistence
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// BalanceSnapshotRepository defines the port for balance snapshot data operations
+type BalanceSnapshotRepository interface {
+	// Save stores a snapshot, replacing any snapshot of the wallet taken at the same time
+	Save(ctx context.Context, snapshot *domain.BalanceSnapshot) error
+
+	// FindLatest retrieves the latest snapshot of a wallet taken at or before a time
+	FindLatest(ctx context.Context, walletID int, at time.Time) (*domain.BalanceSnapshot, error)
+}
@@ -0,0 +1,31 @@
+package persistence
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// BatchTransferRepository defines the port for batch transfer data operations
+type BatchTransferRepository interface {
+	// FindByID retrieves a batch transfer with its items by ID
+	FindByID(ctx context.Context, id int) (*domain.BatchTransfer, error)
+
+	// FindByWalletID retrieves batch transfers sent from a wallet, newest first
+	FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.BatchTransfer, error)
+
+	// FindUnfinished retrieves up to limit pending or processing batch transfers
+	// last updated before updatedBefore, oldest first
+	FindUnfinished(ctx context.Context, updatedBefore time.Time, limit int) ([]*domain.BatchTransfer, error)
+
+	// Claim touches a pending or processing batch transfer only while it was
+	// still last updated before updatedBefore. It reports false when the batch
+	// made progress or another worker claimed it first
+	Claim(ctx context.Context, id int, updatedBefore time.Time) (bool, error)
+
+	// Create saves a new batch transfer
+	Create(ctx context.Context, batch *domain.BatchTransfer) error
+
+	// Update updates an existing batch transfer and its items
+	Update(ctx context.Context, batch *domain.BatchTransfer) error
+}
@@ -0,0 +1,21 @@
+package persistence
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// DeadEventRepository defines the port for dead event data operations
+type DeadEventRepository interface {
+	// Create stores a new dead event
+	Create(ctx context.Context, event *domain.DeadEvent) error
+
+	// FindByID retrieves a dead event by ID
+	FindByID(ctx context.Context, id int) (*domain.DeadEvent, error)
+
+	// FindPending retrieves dead events not yet redriven, oldest first
+	FindPending(ctx context.Context, limit int) ([]*domain.DeadEvent, error)
+
+	// Update updates an existing dead event
+	Update(ctx context.Context, event *domain.DeadEvent) error
+}
@@ -0,0 +1,27 @@
+package persistence
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// MerchantRepository defines the port for merchant and API key data operations
+type MerchantRepository interface {
+	// FindByID retrieves a merchant by its ID
+	FindByID(ctx context.Context, id int) (*domain.Merchant, error)
+
+	// Create saves a new merchant
+	Create(ctx context.Context, merchant *domain.Merchant) error
+
+	// FindAPIKeyByHash retrieves an API key by the hash of the key
+	FindAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+
+	// FindAPIKeysByMerchantID retrieves every key of a merchant, newest first
+	FindAPIKeysByMerchantID(ctx context.Context, merchantID int) ([]*domain.APIKey, error)
+
+	// CreateAPIKey saves a new API key
+	CreateAPIKey(ctx context.Context, key *domain.APIKey) error
+
+	// UpdateAPIKey updates the expiry and revocation of an API key
+	UpdateAPIKey(ctx context.Context, key *domain.APIKey) error
+}
@@ -0,0 +1,35 @@
+package persistence
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"time"
+)
+
+// PaymentRequestRepository defines the port for payment request data operations
+type PaymentRequestRepository interface {
+	// FindByID retrieves a payment request by its ID
+	FindByID(ctx context.Context, id int) (*domain.PaymentRequest, error)
+
+	// FindByPayerUserID retrieves requests addressed to a user, newest first.
+	// An empty status matches every status
+	FindByPayerUserID(ctx context.Context, userID int, status domain.PaymentRequestStatus, limit, offset int) ([]*domain.PaymentRequest, error)
+
+	// FindByRequesterUserID retrieves requests sent by a user, newest first.
+	// An empty status matches every status
+	FindByRequesterUserID(ctx context.Context, userID int, status domain.PaymentRequestStatus, limit, offset int) ([]*domain.PaymentRequest, error)
+
+	// FindExpired retrieves pending requests that expired before a specified time
+	FindExpired(ctx context.Context, before time.Time, limit int) ([]*domain.PaymentRequest, error)
+
+	// FindStaleProcessing retrieves requests claimed by an accept that were last
+	// updated before a specified time, oldest first
+	FindStaleProcessing(ctx context.Context, updatedBefore time.Time, limit int) ([]*domain.PaymentRequest, error)
+
+	// Create saves a new payment request
+	Create(ctx context.Context, request *domain.PaymentRequest) error
+
+	// UpdateIfStatus updates a payment request only while its stored status
+	// still equals expected. It reports false when another operation got there first
+	UpdateIfStatus(ctx context.Context, request *domain.PaymentRequest, expected domain.PaymentRequestStatus) (bool, error)
+}
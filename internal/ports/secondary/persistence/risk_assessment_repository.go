@@ -0,0 +1,24 @@
+package persistence
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+)
+
+// RiskAssessmentRepository defines the port for risk assessment data operations
+type RiskAssessmentRepository interface {
+	// FindByID retrieves a risk assessment by its ID
+	FindByID(ctx context.Context, id int) (*domain.RiskAssessment, error)
+
+	// FindByWalletID retrieves risk assessments for a wallet, newest first
+	FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.RiskAssessment, error)
+
+	// FindByReviewStatus retrieves risk assessments by review status, oldest first
+	FindByReviewStatus(ctx context.Context, status domain.RiskReviewStatus, limit, offset int) ([]*domain.RiskAssessment, error)
+
+	// Create saves a new risk assessment
+	Create(ctx context.Context, assessment *domain.RiskAssessment) error
+
+	// Update updates an existing risk assessment
+	Update(ctx context.Context, assessment *domain.RiskAssessment) error
+}
@@ -14,6 +14,16 @@ type TransactionRepository interface {
 	// FindByWalletID retrieves all transactions for a wallet
 	FindByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.Transaction, error)
 
+	// FindByQuery retrieves the transactions of a wallet matching a query in creation order
+	FindByQuery(ctx context.Context, query domain.TransactionQuery) ([]*domain.Transaction, error)
+
+	// CountByQuery counts the transactions of a wallet matching a query filter
+	CountByQuery(ctx context.Context, query domain.TransactionQuery) (int, error)
+
+	// SumBalanceChanges adds up how much the transactions matching a query filter
+	// changed the wallet balance, see Transaction.BalanceChangeFor
+	SumBalanceChanges(ctx context.Context, query domain.TransactionQuery) (int, error)
+
 	// FindByStatus retrieves transactions by status with optional pagination
 	FindByStatus(ctx context.Context, status domain.TransactionStatus, limit, offset int) ([]*domain.Transaction, error)
 
@@ -16,6 +16,9 @@ type UserRepository interface {
 	// FindByPhone retrieves a user by phone
 	FindByPhone(ctx context.Context, phone string) (*domain.User, error)
 
+	// FindByHandle retrieves a user by handle
+	FindByHandle(ctx context.Context, handle string) (*domain.User, error)
+
 	// Save creates or updates a user
 	Save(ctx context.Context, user *domain.User) error
 
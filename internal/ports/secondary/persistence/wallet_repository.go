@@ -13,6 +13,9 @@ type WalletRepository interface {
 	// FindByUserID retrieves all wallet for a user
 	FindByUserID(ctx context.Context, UserID int) ([]*domain.Wallet, error)
 
+	// FindAfterID retrieves up to limit wallets with an ID above afterID, ordered by ID
+	FindAfterID(ctx context.Context, afterID int, limit int) ([]*domain.Wallet, error)
+
 	// Save creates or updates a wallet
 	Save(ctx context.Context, wallet *domain.Wallet) error
 
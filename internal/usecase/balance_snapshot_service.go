@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"time"
+)
+
+// snapshotBatchSize is the number of wallets read at a time by the snapshot job
+const snapshotBatchSize = 500
+
+// BalanceSnapshotConfig configures the daily snapshot job
+type BalanceSnapshotConfig struct {
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+}
+
+// BalanceSnapshotService implements the balance history application service.
+// A daily job stores the balance of every wallet once each day has ended, and
+// past balances are worked out from the latest snapshot before them plus the
+// transactions since
+type BalanceSnapshotService struct {
+	snapshotRepo    persistence.BalanceSnapshotRepository
+	walletRepo      persistence.WalletRepository
+	transactionRepo persistence.TransactionRepository
+	config          BalanceSnapshotConfig
+	logger          infrastructure.Logger
+}
+
+// NewBalanceSnapshotService creates a new balance snapshot service
+func NewBalanceSnapshotService(
+	snapshotRepo persistence.BalanceSnapshotRepository,
+	walletRepo persistence.WalletRepository,
+	transactionRepo persistence.TransactionRepository,
+	config BalanceSnapshotConfig,
+) *BalanceSnapshotService {
+	return &BalanceSnapshotService{
+		snapshotRepo:    snapshotRepo,
+		walletRepo:      walletRepo,
+		transactionRepo: transactionRepo,
+		config:          config,
+		logger:          infrastructure.NopLogger{},
+	}
+}
+
+// SetLogger reports the runs of the snapshot worker
+func (s *BalanceSnapshotService) SetLogger(logger infrastructure.Logger) {
+	s.logger = logger
+}
+
+// GetBalanceAt returns the balance of a wallet at a point in time. Without an
+// earlier snapshot it is the sum of the completed transactions before it
+func (s *BalanceSnapshotService) GetBalanceAt(ctx context.Context, walletID int, at time.Time) (*domain.HistoricalBalance, error) {
+	at = at.UTC()
+	if at.After(time.Now()) {
+		return nil, domain.ErrBalanceTimeInFuture
+	}
+
+	wallet, err := s.walletRepo.FindByID(ctx, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wallet: %w", err)
+	}
+
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	balance := &domain.HistoricalBalance{
+		WalletID:     wallet.ID,
+		CurrencyCode: wallet.CurrencyCode,
+		At:           at,
+	}
+
+	snapshot, err := s.snapshotRepo.FindLatest(ctx, wallet.ID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find balance snapshot: %w", err)
+	}
+
+	if snapshot == nil {
+		balance.Balance, err = s.balanceChanges(ctx, wallet.ID, nil, at)
+		if err != nil {
+			return nil, err
+		}
+		return balance, nil
+	}
+
+	changes, err := s.balanceChanges(ctx, wallet.ID, &snapshot.TakenAt, at)
+	if err != nil {
+		return nil, err
+	}
+
+	balance.Balance = snapshot.Balance + changes
+	balance.SnapshotAt = &snapshot.TakenAt
+
+	return balance, nil
+}
+
+// TakeSnapshots stores the current balance of every wallet and returns how
+// many were stored. Wallets that already have a snapshot for the current day
+// are left alone, so restarts and other replicas do not take them again
+func (s *BalanceSnapshotService) TakeSnapshots(ctx context.Context) (int, error) {
+	// The latest midnight starts the current day
+	dayStart := domain.EndOfDay(time.Now()).AddDate(0, 0, -1)
+
+	taken := 0
+	afterID := 0
+	for {
+		wallets, err := s.walletRepo.FindAfterID(ctx, afterID, snapshotBatchSize)
+		if err != nil {
+			return taken, fmt.Errorf("failed to find wallets: %w", err)
+		}
+
+		for _, wallet := range wallets {
+			afterID = wallet.ID
+
+			latest, err := s.snapshotRepo.FindLatest(ctx, wallet.ID, time.Now())
+			if err != nil {
+				return taken, fmt.Errorf("failed to find balance snapshot: %w", err)
+			}
+
+			if latest != nil && !latest.TakenAt.Before(dayStart) {
+				continue
+			}
+
+			snapshot, err := s.takeSnapshot(ctx, wallet.ID)
+			if err != nil {
+				return taken, err
+			}
+
+			if snapshot == nil {
+				continue
+			}
+
+			if err := s.snapshotRepo.Save(ctx, snapshot); err != nil {
+				return taken, fmt.Errorf("failed to save balance snapshot: %w", err)
+			}
+			taken++
+		}
+
+		if len(wallets) < snapshotBatchSize {
+			return taken, nil
+		}
+	}
+}
+
+// takeSnapshot reads the balance of a wallet with the time just before it was
+// read. Past balances add the completed transactions created from that time,
+// so the balance must hold exactly the transactions created before it. The
+// wallet is skipped, nil is returned, while a transaction is still pending or
+// when one was created since, as the balance may or may not include them
+func (s *BalanceSnapshotService) takeSnapshot(ctx context.Context, walletID int) (*domain.BalanceSnapshot, error) {
+	takenAt := time.Now().UTC()
+
+	pending, err := s.hasTransactions(ctx, walletID, domain.TransactionFilter{
+		Statuses: []domain.TransactionStatus{domain.TransactionStatusPending},
+	})
+	if err != nil || pending {
+		return nil, err
+	}
+
+	wallet, err := s.walletRepo.FindByID(ctx, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wallet: %w", err)
+	}
+
+	if wallet == nil {
+		return nil, nil
+	}
+
+	changed, err := s.hasTransactions(ctx, walletID, domain.TransactionFilter{From: &takenAt})
+	if err != nil || changed {
+		return nil, err
+	}
+
+	return &domain.BalanceSnapshot{
+		WalletID:     wallet.ID,
+		Balance:      wallet.Balance,
+		CurrencyCode: wallet.CurrencyCode,
+		TakenAt:      takenAt,
+		CreatedAt:    time.Now().UTC(),
+	}, nil
+}
+
+// hasTransactions reports whether a wallet has a transaction matching the filter
+func (s *BalanceSnapshotService) hasTransactions(ctx context.Context, walletID int, filter domain.TransactionFilter) (bool, error) {
+	transactions, err := s.transactionRepo.FindByQuery(ctx, domain.TransactionQuery{
+		WalletID: walletID,
+		Filter:   filter,
+		Limit:    1,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to find transactions: %w", err)
+	}
+
+	return len(transactions) > 0, nil
+}
+
+// RunSnapshotWorker takes the snapshots once each day has ended, on the first
+// check after midnight, until the context is cancelled. Snapshots are also
+// taken on start, so a day missed while the server was down is taken late,
+// unless they were already taken that day
+func (s *BalanceSnapshotService) RunSnapshotWorker(ctx context.Context) {
+	ticker := time.NewTicker(s.config.CheckInterval)
+	defer ticker.Stop()
+
+	var lastTaken time.Time
+	for {
+		// The latest midnight ends the last finished day
+		dayEnd := domain.EndOfDay(time.Now()).AddDate(0, 0, -1)
+		if !dayEnd.Equal(lastTaken) {
+			if taken, err := s.TakeSnapshots(ctx); err != nil {
+				s.logger.Error(ctx, "failed to take balance snapshots", "error", err)
+			} else {
+				s.logger.Info(ctx, "took balance snapshots", "count", taken, "day_end", dayEnd.Format(time.RFC3339))
+				lastTaken = dayEnd
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// balanceChanges sums the completed transactions of a wallet created from from
+// until to, or since the wallet was opened without from
+func (s *BalanceSnapshotService) balanceChanges(ctx context.Context, walletID int, from *time.Time, to time.Time) (int, error) {
+	sum, err := s.transactionRepo.SumBalanceChanges(ctx, domain.TransactionQuery{
+		WalletID: walletID,
+		Filter: domain.TransactionFilter{
+			From:     from,
+			To:       &to,
+			Statuses: []domain.TransactionStatus{domain.TransactionStatusCompleted},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum balance changes: %w", err)
+	}
+
+	return sum, nil
+}
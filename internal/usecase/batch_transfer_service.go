@@ -0,0 +1,515 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"time"
+)
+
+var (
+	ErrBatchTransferNotFound = errors.New("batch transfer not found")
+)
+
+// recoveryBatchSize bounds the interrupted batches picked up per recovery run
+const recoveryBatchSize = 100
+
+// BatchTransferConfig configures the recovery of batches interrupted by a restart
+type BatchTransferConfig struct {
+	// StaleAfter is how long an unfinished batch goes without progress before
+	// it is considered interrupted
+	StaleAfter       time.Duration `mapstructure:"stale_after"`
+	RecoveryInterval time.Duration `mapstructure:"recovery_interval"`
+}
+
+// BatchTransferService implements the batch transfer application service.
+// Batches are validated up front and then paid item by item in the
+// background through the wallet service, so every item goes through the
+// same risk checks and fees as a single transfer. Batches interrupted by a
+// restart are picked up again by the recovery worker
+type BatchTransferService struct {
+	batchRepo       persistence.BatchTransferRepository
+	walletRepo      persistence.WalletRepository
+	transactionRepo persistence.TransactionRepository
+	walletService   primary.WalletService
+	eventPublisher  infrastructure.EventPublisher
+	feeService      *FeeService
+	config          BatchTransferConfig
+	logger          infrastructure.Logger
+}
+
+// NewBatchTransferService creates a new batch transfer service
+func NewBatchTransferService(
+	batchRepo persistence.BatchTransferRepository,
+	walletRepo persistence.WalletRepository,
+	transactionRepo persistence.TransactionRepository,
+	walletService primary.WalletService,
+	eventPublisher infrastructure.EventPublisher,
+	config BatchTransferConfig,
+) *BatchTransferService {
+	return &BatchTransferService{
+		batchRepo:       batchRepo,
+		walletRepo:      walletRepo,
+		transactionRepo: transactionRepo,
+		walletService:   walletService,
+		eventPublisher:  eventPublisher,
+		config:          config,
+		logger:          infrastructure.NopLogger{},
+	}
+}
+
+// SetFeeService includes transfer fees in the up front balance check and
+// refunds them on reversed items
+func (s *BatchTransferService) SetFeeService(feeService *FeeService) {
+	s.feeService = feeService
+}
+
+// SetLogger reports failures of the background batch processing
+func (s *BatchTransferService) SetLogger(logger infrastructure.Logger) {
+	s.logger = logger
+}
+
+// CreateBatchTransfer validates a batch and queues it for processing
+func (s *BatchTransferService) CreateBatchTransfer(ctx context.Context, req primary.BatchTransferRequest) (*domain.BatchTransfer, error) {
+	if req.Mode == "" {
+		req.Mode = domain.BatchTransferModeBestEffort
+	}
+
+	// Get source wallet
+	fromWallet, err := s.walletRepo.FindByID(ctx, req.FromWalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find source wallet: %w", err)
+	}
+
+	if fromWallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	if err := fromWallet.CheckCanSend(); err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.BatchTransferItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = domain.BatchTransferItem{
+			ToWalletID:  item.ToWalletID,
+			Amount:      item.Amount,
+			Description: item.Description,
+		}
+	}
+
+	batch, err := domain.NewBatchTransfer(fromWallet.ID, req.Mode, req.Description, items)
+	if err != nil {
+		return nil, err
+	}
+
+	totalFee, err := s.validateRecipients(ctx, fromWallet, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	// All-or-nothing batches must be fully covered before anything moves
+	if batch.Mode == domain.BatchTransferModeAllOrNothing && fromWallet.Balance < batch.TotalAmount+totalFee {
+		return nil, ErrInsufficientBalance
+	}
+
+	err = s.batchRepo.Create(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch transfer: %w", err)
+	}
+
+	// Process in the background, the client polls the batch for results
+	go func(ctx context.Context, batchID int) {
+		if err := s.ProcessBatchTransfer(ctx, batchID); err != nil {
+			s.logger.Error(ctx, "failed to process batch transfer", "batch_id", batchID, "error", err)
+		}
+	}(context.WithoutCancel(ctx), batch.ID)
+
+	return batch, nil
+}
+
+// validateRecipients checks every recipient wallet and returns the fees the batch will be charged
+func (s *BatchTransferService) validateRecipients(ctx context.Context, fromWallet *domain.Wallet, batch *domain.BatchTransfer) (int, error) {
+	validationErr := &domain.BatchValidationError{}
+	recipients := make(map[int]*domain.Wallet)
+	totalFee := 0
+
+	for i, item := range batch.Items {
+		toWallet, seen := recipients[item.ToWalletID]
+		if !seen {
+			wallet, err := s.walletRepo.FindByID(ctx, item.ToWalletID)
+			if err != nil {
+				return 0, fmt.Errorf("failed to find destination wallet: %w", err)
+			}
+
+			recipients[item.ToWalletID] = wallet
+			toWallet = wallet
+		}
+
+		if toWallet == nil {
+			validationErr.Add(i, item.ToWalletID, ErrWalletNotFound.Error())
+			continue
+		}
+
+		if err := toWallet.CheckCanReceive(); err != nil {
+			validationErr.Add(i, item.ToWalletID, err.Error())
+			continue
+		}
+
+		if toWallet.CurrencyCode != fromWallet.CurrencyCode {
+			validationErr.Add(i, item.ToWalletID, "cannot transfer between wallets with different currencies")
+			continue
+		}
+
+		if s.feeService != nil {
+			quote, err := s.feeService.Calculate(domain.TransactionTypeTransfer, fromWallet.CurrencyCode, "", item.Amount)
+			if err != nil {
+				return 0, err
+			}
+			totalFee += quote.Fee
+		}
+	}
+
+	if validationErr.HasErrors() {
+		return 0, validationErr
+	}
+
+	return totalFee, nil
+}
+
+// ProcessBatchTransfer pays every pending item of a batch. In all-or-nothing
+// mode the first failure stops the batch and reverses the items already paid
+func (s *BatchTransferService) ProcessBatchTransfer(ctx context.Context, batchID int) error {
+	batch, err := s.batchRepo.FindByID(ctx, batchID)
+	if err != nil {
+		return fmt.Errorf("failed to find batch transfer: %w", err)
+	}
+
+	if batch == nil {
+		return ErrBatchTransferNotFound
+	}
+
+	return s.processBatch(ctx, batch)
+}
+
+// processBatch pays the pending items of a loaded batch
+func (s *BatchTransferService) processBatch(ctx context.Context, batch *domain.BatchTransfer) error {
+	if batch.IsFinished() {
+		return nil
+	}
+
+	// A batch still processing was interrupted, any of its pending items may
+	// have been paid without being recorded
+	if batch.Status == domain.BatchTransferStatusProcessing {
+		if err := s.reconcilePendingItems(ctx, batch); err != nil {
+			return err
+		}
+	}
+
+	batch.Start()
+	err := s.batchRepo.Update(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("failed to update batch transfer: %w", err)
+	}
+
+	for i, item := range batch.Items {
+		if item.Status != domain.BatchTransferItemStatusPending {
+			continue
+		}
+
+		transaction, err := s.walletService.TransferWithReference(ctx, batch.FromWalletID, item.ToWalletID, item.Amount,
+			batchItemDescription(batch, item), BatchItemReference(batch.ID, i))
+		if err != nil {
+			batch.FailItem(i, err.Error())
+
+			if batch.Mode == domain.BatchTransferModeAllOrNothing {
+				batch.SkipPendingItems("batch aborted after a failed item")
+				s.reverseCompletedItems(ctx, batch)
+				break
+			}
+		} else {
+			batch.CompleteItem(i, transaction.ID)
+		}
+
+		// Persist progress so the batch status reflects every attempted item.
+		// Paying on without it would leave the batch behind its transfers, so
+		// stop and let the recovery worker reconcile the items by reference
+		if err := s.batchRepo.Update(ctx, batch); err != nil {
+			return fmt.Errorf("failed to record batch transfer progress: %w", err)
+		}
+	}
+
+	batch.Finish()
+	err = s.batchRepo.Update(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("failed to update batch transfer: %w", err)
+	}
+
+	// Publish batch completed event
+	event := infrastructure.Event{
+		Type: "batch.completed",
+		Payload: map[string]interface{}{
+			"batch_id":        batch.ID,
+			"from_wallet_id":  batch.FromWalletID,
+			"mode":            string(batch.Mode),
+			"status":          string(batch.Status),
+			"total_amount":    batch.TotalAmount,
+			"succeeded_count": batch.SucceededCount,
+			"failed_count":    batch.FailedCount,
+		},
+	}
+
+	// Non-blocking event publishing
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+
+		if s.eventPublisher != nil {
+			_ = s.eventPublisher.Publish(ctx, "batch_transfers", event)
+		}
+	}()
+
+	return nil
+}
+
+// reconcilePendingItems records the pending items of an interrupted batch
+// as paid when their transfer completed after the batch was last saved. An
+// item whose transfer is still pending stops the batch until it settles
+func (s *BatchTransferService) reconcilePendingItems(ctx context.Context, batch *domain.BatchTransfer) error {
+	for i, item := range batch.Items {
+		if item.Status != domain.BatchTransferItemStatusPending {
+			continue
+		}
+
+		transactions, err := s.transactionRepo.FindByQuery(ctx, domain.TransactionQuery{
+			WalletID: batch.FromWalletID,
+			Filter: domain.TransactionFilter{
+				Types:     []domain.TransactionType{domain.TransactionTypeTransfer},
+				Statuses:  []domain.TransactionStatus{domain.TransactionStatusCompleted, domain.TransactionStatusPending},
+				Reference: BatchItemReference(batch.ID, i),
+			},
+			Limit: 1,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find transactions: %w", err)
+		}
+
+		if len(transactions) == 0 {
+			continue
+		}
+
+		if transactions[0].Status == domain.TransactionStatusPending {
+			return fmt.Errorf("transfer of batch transfer item %d is still pending", i)
+		}
+
+		batch.CompleteItem(i, transactions[0].ID)
+	}
+
+	return nil
+}
+
+// RecoverBatchTransfers resumes the batches that stopped making progress,
+// typically because the process restarted while paying them. Every replica
+// runs it, so each batch is claimed before it is resumed
+func (s *BatchTransferService) RecoverBatchTransfers(ctx context.Context) (int, error) {
+	staleBefore := time.Now().Add(-s.config.StaleAfter)
+	batches, err := s.batchRepo.FindUnfinished(ctx, staleBefore, recoveryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find unfinished batch transfers: %w", err)
+	}
+
+	recovered := 0
+	for _, batch := range batches {
+		claimed, err := s.batchRepo.Claim(ctx, batch.ID, staleBefore)
+		if err != nil {
+			s.logger.Error(ctx, "failed to claim batch transfer", "batch_id", batch.ID, "error", err)
+			continue
+		}
+
+		// Another worker resumed it, or it made progress since it was found
+		if !claimed {
+			continue
+		}
+
+		if err := s.processBatch(ctx, batch); err != nil {
+			s.logger.Error(ctx, "failed to recover batch transfer", "batch_id", batch.ID, "error", err)
+			continue
+		}
+		recovered++
+	}
+
+	return recovered, nil
+}
+
+// RunRecoveryWorker recovers interrupted batches at startup and then on the
+// configured interval until ctx is done
+func (s *BatchTransferService) RunRecoveryWorker(ctx context.Context) {
+	ticker := time.NewTicker(s.config.RecoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		if recovered, err := s.RecoverBatchTransfers(ctx); err != nil {
+			s.logger.Error(ctx, "failed to recover batch transfers", "error", err)
+		} else if recovered > 0 {
+			s.logger.Info(ctx, "recovered interrupted batch transfers", "count", recovered)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reverseCompletedItems pays the completed items of an aborted batch and
+// their fees back to the source wallet. Items that cannot be reversed stay
+// completed and the batch finishes as partially reversed
+func (s *BatchTransferService) reverseCompletedItems(ctx context.Context, batch *domain.BatchTransfer) {
+	for i, item := range batch.Items {
+		if item.Status != domain.BatchTransferItemStatusCompleted {
+			continue
+		}
+
+		if err := s.reverseItem(ctx, batch, item); err != nil {
+			// Leave the item completed so the books still match the wallets
+			s.logger.Error(ctx, "failed to reverse batch transfer item", "batch_id", batch.ID, "item", i, "error", err)
+			continue
+		}
+
+		reason := "reversed after a failed item"
+		if err := s.refundItemFee(ctx, item); err != nil {
+			s.logger.Error(ctx, "failed to refund batch transfer item fee", "batch_id", batch.ID, "item", i, "error", err)
+			reason = "reversed after a failed item, the fee was not refunded"
+		}
+
+		batch.ReverseItem(i, reason)
+	}
+}
+
+// refundItemFee pays the fee charged on a reversed item back to the source wallet
+func (s *BatchTransferService) refundItemFee(ctx context.Context, item domain.BatchTransferItem) error {
+	if s.feeService == nil || item.TransactionID == nil {
+		return nil
+	}
+
+	transaction, err := s.transactionRepo.FindByID(ctx, *item.TransactionID)
+	if err != nil {
+		return fmt.Errorf("failed to find transaction: %w", err)
+	}
+
+	if transaction == nil {
+		return ErrTransactionNotFound
+	}
+
+	return s.feeService.Refund(ctx, transaction)
+}
+
+// reverseItem moves the amount of a paid item from the recipient back to the source wallet
+func (s *BatchTransferService) reverseItem(ctx context.Context, batch *domain.BatchTransfer, item domain.BatchTransferItem) error {
+	toWallet, err := s.walletRepo.FindByID(ctx, item.ToWalletID)
+	if err != nil || toWallet == nil {
+		return fmt.Errorf("failed to find destination wallet: %w", ErrWalletNotFound)
+	}
+
+	fromWallet, err := s.walletRepo.FindByID(ctx, batch.FromWalletID)
+	if err != nil || fromWallet == nil {
+		return fmt.Errorf("failed to find source wallet: %w", ErrWalletNotFound)
+	}
+
+	transaction, err := domain.NewTransferTransaction(toWallet.ID, fromWallet.ID, item.Amount,
+		fmt.Sprintf("Reversal of batch transfer %d", batch.ID))
+	if err != nil {
+		return err
+	}
+
+	if item.TransactionID != nil {
+		transaction.Reference = fmt.Sprintf("REV-%d", *item.TransactionID)
+	}
+
+	err = s.transactionRepo.Create(ctx, transaction)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	err = toWallet.Debit(item.Amount)
+	if err == nil {
+		err = fromWallet.Credit(item.Amount)
+	}
+
+	if err == nil {
+		err = s.walletRepo.Save(ctx, toWallet)
+	}
+
+	if err != nil {
+		transaction.Fail()
+		_ = s.transactionRepo.Update(ctx, transaction)
+		return err
+	}
+
+	err = s.walletRepo.Save(ctx, fromWallet)
+	if err != nil {
+		// Put the funds back on the recipient, the reversal did not happen
+		_ = toWallet.Credit(item.Amount)
+		_ = s.walletRepo.Save(ctx, toWallet)
+
+		transaction.Fail()
+		_ = s.transactionRepo.Update(ctx, transaction)
+		return fmt.Errorf("failed to update source wallet: %w", err)
+	}
+
+	transaction.Complete()
+	err = s.transactionRepo.Update(ctx, transaction)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	return nil
+}
+
+// GetBatchTransfer retrieves a batch transfer with per-item results
+func (s *BatchTransferService) GetBatchTransfer(ctx context.Context, batchID int) (*domain.BatchTransfer, error) {
+	batch, err := s.batchRepo.FindByID(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find batch transfer: %w", err)
+	}
+
+	if batch == nil {
+		return nil, ErrBatchTransferNotFound
+	}
+
+	return batch, nil
+}
+
+// GetBatchTransfersByWalletID retrieves batch transfers sent from a wallet
+func (s *BatchTransferService) GetBatchTransfersByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.BatchTransfer, error) {
+	batches, err := s.batchRepo.FindByWalletID(ctx, walletID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch transfers: %w", err)
+	}
+
+	return batches, nil
+}
+
+// BatchItemReference is the reference recorded on the transfer paying an item
+// of a batch, it identifies the transfer when an interrupted batch is resumed
+func BatchItemReference(batchID, index int) string {
+	return fmt.Sprintf("BATCH-%d-%d", batchID, index)
+}
+
+// batchItemDescription picks the description recorded on the transfer of an item
+func batchItemDescription(batch *domain.BatchTransfer, item domain.BatchTransferItem) string {
+	if item.Description != "" {
+		return item.Description
+	}
+
+	if batch.Description != "" {
+		return batch.Description
+	}
+
+	return fmt.Sprintf("Batch transfer %d", batch.ID)
+}
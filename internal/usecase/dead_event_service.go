@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"time"
+)
+
+var ErrDeadEventNotFound = errors.New("dead event not found")
+
+// DeadEventService keeps events whose handlers failed and publishes them again
+// on request
+type DeadEventService struct {
+	deadEventRepo  persistence.DeadEventRepository
+	eventPublisher infrastructure.EventPublisher
+}
+
+// NewDeadEventService creates a new dead event service
+func NewDeadEventService(
+	deadEventRepo persistence.DeadEventRepository,
+	eventPublisher infrastructure.EventPublisher,
+) *DeadEventService {
+	return &DeadEventService{
+		deadEventRepo:  deadEventRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Record stores an event whose handler failed. It matches
+// infrastructure.FailureHandler so it can be set on an event consumer
+func (s *DeadEventService) Record(ctx context.Context, topic string, event infrastructure.Event, cause error) error {
+	deadEvent := &domain.DeadEvent{
+		Topic:     topic,
+		EventID:   event.ID,
+		EventType: event.Type,
+		Payload:   event.Payload,
+		EventTime: event.Time,
+		Error:     cause.Error(),
+		FailedAt:  time.Now(),
+	}
+
+	if err := s.deadEventRepo.Create(ctx, deadEvent); err != nil {
+		return fmt.Errorf("failed to record dead event: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeadEvent retrieves a dead event by ID
+func (s *DeadEventService) GetDeadEvent(ctx context.Context, id int) (*domain.DeadEvent, error) {
+	deadEvent, err := s.deadEventRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dead event: %w", err)
+	}
+
+	if deadEvent == nil {
+		return nil, ErrDeadEventNotFound
+	}
+
+	return deadEvent, nil
+}
+
+// GetPendingDeadEvents retrieves dead events not yet redriven, oldest first
+func (s *DeadEventService) GetPendingDeadEvents(ctx context.Context, limit int) ([]*domain.DeadEvent, error) {
+	deadEvents, err := s.deadEventRepo.FindPending(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dead events: %w", err)
+	}
+
+	return deadEvents, nil
+}
+
+// Redrive publishes a dead event to its topic again, with its original ID so
+// idempotent consumers can tell it apart from a new event
+func (s *DeadEventService) Redrive(ctx context.Context, id int) (*domain.DeadEvent, error) {
+	deadEvent, err := s.GetDeadEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deadEvent.MarkRedriven(time.Now()); err != nil {
+		return nil, err
+	}
+
+	event := infrastructure.Event{
+		Type:    deadEvent.EventType,
+		Payload: deadEvent.Payload,
+		Time:    deadEvent.EventTime,
+		ID:      deadEvent.EventID,
+	}
+
+	if err := s.eventPublisher.Publish(ctx, deadEvent.Topic, event); err != nil {
+		return nil, fmt.Errorf("failed to publish dead event: %w", err)
+	}
+
+	if err := s.deadEventRepo.Update(ctx, deadEvent); err != nil {
+		return nil, fmt.Errorf("failed to update dead event: %w", err)
+	}
+
+	return deadEvent, nil
+}
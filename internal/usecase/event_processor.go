@@ -3,7 +3,6 @@ package usecase
 import (
 	"context"
 	"fmt"
-	"log"
 	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
 )
 
@@ -12,6 +11,7 @@ type EventProcessor struct {
 	consumer       infrastructure.EventConsumer
 	walletService  *WalletService
 	paymentService *PaymentService
+	logger         infrastructure.Logger
 }
 
 // NewEventProcessor creates a new event processor
@@ -24,9 +24,15 @@ func NewEventProcessor(
 		consumer:       consumer,
 		walletService:  walletService,
 		paymentService: paymentService,
+		logger:         infrastructure.NopLogger{},
 	}
 }
 
+// SetLogger logs the processed events under the request ID they were published with
+func (p *EventProcessor) SetLogger(logger infrastructure.Logger) {
+	p.logger = logger
+}
+
 // Start registers all event handlers and starts consuming events
 func (p *EventProcessor) Start(ctx context.Context) error {
 	// Register handlers for different event types
@@ -46,6 +52,21 @@ func (p *EventProcessor) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to subscribe to transaction events: %w", err)
 	}
 
+	// Risk events
+	if err := p.consumer.Subscribe("risk", p.handleRiskEvent); err != nil {
+		return fmt.Errorf("failed to subscribe to risk events: %w", err)
+	}
+
+	// Batch transfer events
+	if err := p.consumer.Subscribe("batch_transfers", p.handleBatchTransferEvent); err != nil {
+		return fmt.Errorf("failed to subscribe to batch transfer events: %w", err)
+	}
+
+	// Payment request events
+	if err := p.consumer.Subscribe("payment_requests", p.handlePaymentRequestEvent); err != nil {
+		return fmt.Errorf("failed to subscribe to payment request events: %w", err)
+	}
+
 	// Start consuming
 	return p.consumer.Start(ctx)
 }
@@ -64,6 +85,12 @@ func (p *EventProcessor) HandleEvent(ctx context.Context, topic string, event in
 		return p.handlePaymentEvent(ctx, event)
 	case "transactions":
 		return p.handleTransactionEvent(ctx, event)
+	case "risk":
+		return p.handleRiskEvent(ctx, event)
+	case "batch_transfers":
+		return p.handleBatchTransferEvent(ctx, event)
+	case "payment_requests":
+		return p.handlePaymentRequestEvent(ctx, event)
 	default:
 		return fmt.Errorf("unknown topic: %s", topic)
 	}
@@ -71,7 +98,7 @@ func (p *EventProcessor) HandleEvent(ctx context.Context, topic string, event in
 
 // handleWalletEvent processes wallet-related events
 func (p *EventProcessor) handleWalletEvent(ctx context.Context, event infrastructure.Event) error {
-	log.Printf("Processing wallet event: %s", event.Type)
+	p.logger.Debug(ctx, "processing wallet event", "event_type", event.Type, "event_id", event.ID)
 
 	switch event.Type {
 	case "wallet.created":
@@ -86,23 +113,40 @@ func (p *EventProcessor) handleWalletEvent(ctx context.Context, event infrastruc
 
 	case "wallet.withdrawal":
 		// Handle withdrawal event
-		// Could check for suspicious activity, send notification, etc.
+		// Risk checks already ran synchronously in WalletService.Withdraw
+		// Could send notification, etc.
 		return nil
 
 	case "wallet.transfer":
 		// Handle transfer event
-		// Could update analytics, check limits, etc.
+		// Risk checks already ran synchronously in WalletService.Transfer
+		// Could update analytics, etc.
+		return nil
+
+	case "wallet.frozen", "wallet.unfrozen":
+		// Handle freeze and unfreeze events
+		// Could notify the wallet owner and the compliance team
+		p.logger.Info(ctx, "wallet status changed",
+			"wallet_id", event.Payload["wallet_id"], "status", event.Payload["status"],
+			"reason", event.Payload["reason"], "actor", event.Payload["actor"])
+		return nil
+
+	case "wallet.closed":
+		// Handle wallet close event
+		// Any remaining balance was already swept to the nominated wallet
+		p.logger.Info(ctx, "wallet closed",
+			"wallet_id", event.Payload["wallet_id"], "reason", event.Payload["reason"], "actor", event.Payload["actor"])
 		return nil
 
 	default:
-		log.Printf("Unknown wallet event type: %s", event.Type)
+		p.logger.Warn(ctx, "unknown wallet event type", "event_type", event.Type, "event_id", event.ID)
 		return nil
 	}
 }
 
 // handlePaymentEvent processes payment-related events
 func (p *EventProcessor) handlePaymentEvent(ctx context.Context, event infrastructure.Event) error {
-	log.Printf("Processing payment event: %s", event.Type)
+	p.logger.Debug(ctx, "processing payment event", "event_type", event.Type, "event_id", event.ID)
 
 	switch event.Type {
 	case "payment.initiated":
@@ -121,14 +165,14 @@ func (p *EventProcessor) handlePaymentEvent(ctx context.Context, event infrastru
 		return nil
 
 	default:
-		log.Printf("Unknown payment event type: %s", event.Type)
+		p.logger.Warn(ctx, "unknown payment event type", "event_type", event.Type, "event_id", event.ID)
 		return nil
 	}
 }
 
 // handleTransactionEvent processes transaction-related events
 func (p *EventProcessor) handleTransactionEvent(ctx context.Context, event infrastructure.Event) error {
-	log.Printf("Processing transaction event: %s", event.Type)
+	p.logger.Debug(ctx, "processing transaction event", "event_type", event.Type, "event_id", event.ID)
 
 	switch event.Type {
 	case "transaction.created":
@@ -147,7 +191,93 @@ func (p *EventProcessor) handleTransactionEvent(ctx context.Context, event infra
 		return nil
 
 	default:
-		log.Printf("Unknown transaction event type: %s", event.Type)
+		p.logger.Warn(ctx, "unknown transaction event type", "event_type", event.Type, "event_id", event.ID)
+		return nil
+	}
+}
+
+// handleBatchTransferEvent processes batch transfer events
+func (p *EventProcessor) handleBatchTransferEvent(ctx context.Context, event infrastructure.Event) error {
+	p.logger.Debug(ctx, "processing batch transfer event", "event_type", event.Type, "event_id", event.ID)
+
+	switch event.Type {
+	case "batch.completed":
+		// Handle finished batch transfer
+		// Could send the payroll report to the payer, etc.
+		p.logger.Info(ctx, "batch transfer finished",
+			"batch_id", event.Payload["batch_id"], "status", event.Payload["status"],
+			"succeeded_count", event.Payload["succeeded_count"], "failed_count", event.Payload["failed_count"])
+		return nil
+
+	default:
+		p.logger.Warn(ctx, "unknown batch transfer event type", "event_type", event.Type, "event_id", event.ID)
+		return nil
+	}
+}
+
+// handleRiskEvent processes risk-related events
+func (p *EventProcessor) handleRiskEvent(ctx context.Context, event infrastructure.Event) error {
+	p.logger.Debug(ctx, "processing risk event", "event_type", event.Type, "event_id", event.ID)
+
+	switch event.Type {
+	case "risk.flagged":
+		// Handle flagged operation
+		// The assessment is already persisted and, for REVIEW decisions, queued
+		p.logger.Warn(ctx, "risk check flagged wallet",
+			"wallet_id", event.Payload["wallet_id"], "decision", event.Payload["decision"], "rules", event.Payload["rules"])
+		return nil
+
+	case "risk.reviewed":
+		// Handle review outcome
+		// Could notify the user, lift limits, etc.
+		return nil
+
+	default:
+		p.logger.Warn(ctx, "unknown risk event type", "event_type", event.Type, "event_id", event.ID)
+		return nil
+	}
+}
+
+// handlePaymentRequestEvent notifies the users involved in a payment request
+func (p *EventProcessor) handlePaymentRequestEvent(ctx context.Context, event infrastructure.Event) error {
+	p.logger.Debug(ctx, "processing payment request event", "event_type", event.Type, "event_id", event.ID)
+
+	logger := p.logger.With(
+		"payment_request_id", event.Payload["request_id"],
+		"amount", event.Payload["amount"],
+		"currency_code", event.Payload["currency_code"],
+	)
+
+	switch event.Type {
+	case "payment_request.created":
+		// Notify the payer about the new request
+		logger.Info(ctx, "notify payer of payment request",
+			"user_id", event.Payload["payer_user_id"], "requester_user_id", event.Payload["requester_user_id"])
+		return nil
+
+	case "payment_request.accepted":
+		// Notify the requester that the request was paid
+		logger.Info(ctx, "notify requester of paid payment request", "user_id", event.Payload["requester_user_id"])
+		return nil
+
+	case "payment_request.declined":
+		// Notify the requester that the payer refused
+		logger.Info(ctx, "notify requester of declined payment request", "user_id", event.Payload["requester_user_id"])
+		return nil
+
+	case "payment_request.cancelled":
+		// Notify the payer that the request was withdrawn
+		logger.Info(ctx, "notify payer of cancelled payment request", "user_id", event.Payload["payer_user_id"])
+		return nil
+
+	case "payment_request.expired":
+		// Notify both users that the request lapsed
+		logger.Info(ctx, "notify users of expired payment request",
+			"requester_user_id", event.Payload["requester_user_id"], "payer_user_id", event.Payload["payer_user_id"])
+		return nil
+
+	default:
+		p.logger.Warn(ctx, "unknown payment request event type", "event_type", event.Type, "event_id", event.ID)
 		return nil
 	}
 }
@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"strings"
+)
+
+var (
+	ErrRevenueWalletNotConfigured = errors.New("revenue wallet not configured for currency")
+)
+
+// FeeService implements the fee application service. It quotes fees
+// from the schedule and moves collected fees to the system revenue wallets
+type FeeService struct {
+	schedule        *domain.FeeSchedule
+	revenueWallets  map[string]int
+	walletRepo      persistence.WalletRepository
+	transactionRepo persistence.TransactionRepository
+	cache           infrastructure.Cache
+}
+
+// NewFeeService creates a new fee service. revenueWallets maps a currency
+// code to the ID of the wallet that receives fees in that currency
+func NewFeeService(
+	schedule *domain.FeeSchedule,
+	revenueWallets map[string]int,
+	walletRepo persistence.WalletRepository,
+	transactionRepo persistence.TransactionRepository,
+	cache infrastructure.Cache,
+) *FeeService {
+	wallets := make(map[string]int, len(revenueWallets))
+	for currency, walletID := range revenueWallets {
+		wallets[strings.ToUpper(currency)] = walletID
+	}
+
+	return &FeeService{
+		schedule:        schedule,
+		revenueWallets:  wallets,
+		walletRepo:      walletRepo,
+		transactionRepo: transactionRepo,
+		cache:           cache,
+	}
+}
+
+// QuoteFee previews the fee of an operation without moving any funds
+func (s *FeeService) QuoteFee(ctx context.Context, req primary.FeeQuoteRequest) (*domain.FeeQuote, error) {
+	if req.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	currency := req.Currency
+	if req.WalletID > 0 {
+		wallet, err := s.walletRepo.FindByID(ctx, req.WalletID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find wallet: %w", err)
+		}
+
+		if wallet == nil {
+			return nil, ErrWalletNotFound
+		}
+
+		currency = wallet.CurrencyCode
+	}
+
+	return s.Calculate(req.TransactionType, currency, req.PaymentProvider, req.Amount)
+}
+
+// GetFeeSchedule returns the configured fee rules
+func (s *FeeService) GetFeeSchedule(ctx context.Context) (*domain.FeeSchedule, error) {
+	if s.schedule == nil {
+		return &domain.FeeSchedule{Rules: []domain.FeeRule{}}, nil
+	}
+
+	return s.schedule, nil
+}
+
+// Calculate quotes the fee of an operation and makes sure it can be collected
+func (s *FeeService) Calculate(
+	txType domain.TransactionType,
+	currency string,
+	provider domain.PaymentProvider,
+	amount int,
+) (*domain.FeeQuote, error) {
+	quote := s.schedule.Quote(txType, currency, provider, amount)
+
+	if quote.Fee > 0 {
+		if _, ok := s.revenueWallets[strings.ToUpper(currency)]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrRevenueWalletNotConfigured, currency)
+		}
+	}
+
+	return quote, nil
+}
+
+// Reserve records the fee of a transaction as a PENDING FEE transaction
+// referencing it. It is called before the payer is charged, so a fee that
+// was charged but not credited is always on record for Settle to retry
+func (s *FeeService) Reserve(ctx context.Context, payer *domain.Wallet, parent *domain.Transaction) (*domain.Transaction, error) {
+	if parent.Fee <= 0 {
+		return nil, nil
+	}
+
+	revenueWalletID, ok := s.revenueWallets[strings.ToUpper(payer.CurrencyCode)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRevenueWalletNotConfigured, payer.CurrencyCode)
+	}
+
+	feeTransaction, err := domain.NewTransaction(payer.ID, domain.TransactionTypeFee, parent.Fee,
+		fmt.Sprintf("Fee for transaction %d", parent.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	feeTransaction.ToWalletID = &revenueWalletID
+	feeTransaction.Reference = FeeReference(parent.ID)
+	feeTransaction.Status = domain.TransactionStatusPending
+
+	err = s.transactionRepo.Create(ctx, feeTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fee transaction: %w", err)
+	}
+
+	return feeTransaction, nil
+}
+
+// Settle credits a reserved fee to the revenue wallet and completes it.
+// On failure the fee stays PENDING and is retried by Reconcile
+func (s *FeeService) Settle(ctx context.Context, feeTransaction *domain.Transaction) error {
+	if feeTransaction == nil {
+		return nil
+	}
+
+	if feeTransaction.ToWalletID == nil {
+		return fmt.Errorf("fee transaction %d has no revenue wallet", feeTransaction.ID)
+	}
+
+	revenueWalletID := *feeTransaction.ToWalletID
+
+	revenueWallet, err := s.walletRepo.FindByID(ctx, revenueWalletID)
+	if err != nil {
+		return fmt.Errorf("failed to find revenue wallet: %w", err)
+	}
+
+	if revenueWallet == nil {
+		return fmt.Errorf("%w: revenue wallet %d", ErrWalletNotFound, revenueWalletID)
+	}
+
+	err = revenueWallet.Credit(feeTransaction.Amount)
+	if err == nil {
+		err = s.walletRepo.Save(ctx, revenueWallet)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to credit revenue wallet: %w", err)
+	}
+
+	feeTransaction.Complete()
+	err = s.transactionRepo.Update(ctx, feeTransaction)
+	if err != nil {
+		return fmt.Errorf("failed to update fee transaction: %w", err)
+	}
+
+	// Invalidate cache
+	if s.cache != nil {
+		_ = s.cache.Delete(ctx, fmt.Sprintf("wallet:%d", revenueWalletID))
+	}
+
+	return nil
+}
+
+// Cancel fails a reserved fee whose transaction did not go through
+func (s *FeeService) Cancel(ctx context.Context, feeTransaction *domain.Transaction) error {
+	if feeTransaction == nil {
+		return nil
+	}
+
+	feeTransaction.Fail()
+	err := s.transactionRepo.Update(ctx, feeTransaction)
+	if err != nil {
+		return fmt.Errorf("failed to update fee transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CancelReserved cancels the fee still reserved for a transaction that failed
+func (s *FeeService) CancelReserved(ctx context.Context, parent *domain.Transaction) error {
+	if parent.Fee <= 0 {
+		return nil
+	}
+
+	feeTransactions, err := s.transactionRepo.FindByQuery(ctx, domain.TransactionQuery{
+		WalletID: parent.WalletID,
+		Filter: domain.TransactionFilter{
+			Types:     []domain.TransactionType{domain.TransactionTypeFee},
+			Statuses:  []domain.TransactionStatus{domain.TransactionStatusPending},
+			Reference: FeeReference(parent.ID),
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find fee transaction: %w", err)
+	}
+
+	if len(feeTransactions) == 0 {
+		return nil
+	}
+
+	return s.Cancel(ctx, feeTransactions[0])
+}
+
+// Refund pays the fee of a reversed transaction back from the revenue wallet.
+// It is recorded as a transfer from the revenue wallet to the payer, so both
+// balance histories see the funds move
+func (s *FeeService) Refund(ctx context.Context, parent *domain.Transaction) error {
+	if parent.Fee <= 0 {
+		return nil
+	}
+
+	payer, err := s.walletRepo.FindByID(ctx, parent.WalletID)
+	if err != nil {
+		return fmt.Errorf("failed to find wallet: %w", err)
+	}
+
+	if payer == nil {
+		return ErrWalletNotFound
+	}
+
+	revenueWalletID, ok := s.revenueWallets[strings.ToUpper(payer.CurrencyCode)]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrRevenueWalletNotConfigured, payer.CurrencyCode)
+	}
+
+	revenueWallet, err := s.walletRepo.FindByID(ctx, revenueWalletID)
+	if err != nil {
+		return fmt.Errorf("failed to find revenue wallet: %w", err)
+	}
+
+	if revenueWallet == nil {
+		return fmt.Errorf("%w: revenue wallet %d", ErrWalletNotFound, revenueWalletID)
+	}
+
+	refundTransaction, err := domain.NewTransferTransaction(revenueWalletID, payer.ID, parent.Fee,
+		fmt.Sprintf("Fee refund for transaction %d", parent.ID))
+	if err != nil {
+		return err
+	}
+
+	refundTransaction.Reference = FeeRefundReference(parent.ID)
+
+	err = s.transactionRepo.Create(ctx, refundTransaction)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	err = revenueWallet.Debit(parent.Fee)
+	if err == nil {
+		err = payer.Credit(parent.Fee)
+	}
+
+	if err == nil {
+		err = s.walletRepo.Save(ctx, revenueWallet)
+	}
+
+	if err != nil {
+		refundTransaction.Fail()
+		_ = s.transactionRepo.Update(ctx, refundTransaction)
+		return fmt.Errorf("failed to debit revenue wallet: %w", err)
+	}
+
+	err = s.walletRepo.Save(ctx, payer)
+	if err != nil {
+		// Put the fee back on the revenue wallet, the refund did not happen
+		_ = revenueWallet.Credit(parent.Fee)
+		_ = s.walletRepo.Save(ctx, revenueWallet)
+
+		refundTransaction.Fail()
+		_ = s.transactionRepo.Update(ctx, refundTransaction)
+		return fmt.Errorf("failed to credit wallet: %w", err)
+	}
+
+	refundTransaction.Complete()
+	err = s.transactionRepo.Update(ctx, refundTransaction)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	// Invalidate cache
+	if s.cache != nil {
+		_ = s.cache.Delete(ctx, fmt.Sprintf("wallet:%d", revenueWalletID))
+		_ = s.cache.Delete(ctx, fmt.Sprintf("wallet:%d", payer.ID))
+	}
+
+	return nil
+}
+
+// Reconcile resolves a PENDING FEE transaction left behind by a failed
+// Settle: it is settled when the transaction it was charged for completed,
+// cancelled when that one failed, and left alone while it is still pending
+func (s *FeeService) Reconcile(ctx context.Context, feeTransaction *domain.Transaction) error {
+	var parentID int
+	if _, err := fmt.Sscanf(feeTransaction.Reference, "FEE-%d", &parentID); err != nil {
+		return fmt.Errorf("invalid fee reference %q: %w", feeTransaction.Reference, err)
+	}
+
+	parent, err := s.transactionRepo.FindByID(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to find transaction: %w", err)
+	}
+
+	if parent == nil {
+		return s.Cancel(ctx, feeTransaction)
+	}
+
+	switch parent.Status {
+	case domain.TransactionStatusCompleted:
+		return s.Settle(ctx, feeTransaction)
+	case domain.TransactionStatusPending:
+		return nil
+	default:
+		return s.Cancel(ctx, feeTransaction)
+	}
+}
+
+// FeeReference returns the reference that links a FEE transaction to the transaction it was charged for
+func FeeReference(transactionID int) string {
+	return fmt.Sprintf("FEE-%d", transactionID)
+}
+
+// FeeRefundReference returns the reference that links a fee refund to the transaction it was charged for
+func FeeRefundReference(transactionID int) string {
+	return fmt.Sprintf("REV-FEE-%d", transactionID)
+}
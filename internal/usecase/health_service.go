@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"sync"
+	"time"
+)
+
+// defaultHealthCheckTimeout bounds a check when no timeout is configured
+const defaultHealthCheckTimeout = 2 * time.Second
+
+// HealthConfig configures the dependency checks. Results are reused for
+// CacheTTL so frequent probes do not hammer the dependencies, zero checks on
+// every probe
+type HealthConfig struct {
+	Timeout  time.Duration `mapstructure:"timeout"`
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+}
+
+// healthCheck is a registered dependency with the result of its last check
+type healthCheck struct {
+	name     string
+	checker  infrastructure.HealthChecker
+	critical bool
+
+	mu   sync.Mutex
+	last *domain.ComponentHealth
+}
+
+// HealthService implements the health check application service
+type HealthService struct {
+	checks []*healthCheck
+	config HealthConfig
+	logger infrastructure.Logger
+}
+
+// NewHealthService creates a new health service without dependencies
+func NewHealthService(config HealthConfig) *HealthService {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultHealthCheckTimeout
+	}
+
+	return &HealthService{
+		config: config,
+		logger: infrastructure.NopLogger{},
+	}
+}
+
+// SetLogger reports dependencies changing status
+func (s *HealthService) SetLogger(logger infrastructure.Logger) {
+	s.logger = logger
+}
+
+// Register adds a dependency to the readiness checks. The service is down when
+// a critical dependency is down, and degraded when any other one is.
+// Dependencies must be registered before the service is used
+func (s *HealthService) Register(name string, checker infrastructure.HealthChecker, critical bool) {
+	s.checks = append(s.checks, &healthCheck{
+		name:     name,
+		checker:  checker,
+		critical: critical,
+	})
+}
+
+// Liveness reports the process as up, a dependency failing must not get it restarted
+func (s *HealthService) Liveness(ctx context.Context) *domain.HealthReport {
+	return domain.NewHealthReport(nil, time.Now())
+}
+
+// Readiness checks every dependency concurrently
+func (s *HealthService) Readiness(ctx context.Context) *domain.HealthReport {
+	components := make([]domain.ComponentHealth, len(s.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func(i int, check *healthCheck) {
+			defer wg.Done()
+			components[i] = s.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	return domain.NewHealthReport(components, time.Now())
+}
+
+// run returns the cached result of a check, or checks the dependency again
+// once the result is older than the cache TTL. Concurrent probes share a check
+func (s *HealthService) run(ctx context.Context, check *healthCheck) domain.ComponentHealth {
+	check.mu.Lock()
+	defer check.mu.Unlock()
+
+	if check.last != nil && time.Since(check.last.CheckedAt) < s.config.CacheTTL {
+		return *check.last
+	}
+
+	// The result is shared with other probes, so a probe going away must not fail it
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.config.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.checker.CheckHealth(checkCtx)
+	if err == nil && checkCtx.Err() != nil {
+		err = checkCtx.Err()
+	}
+
+	component := domain.ComponentHealth{
+		Name:      check.name,
+		Status:    domain.HealthStatusUp,
+		Critical:  check.critical,
+		LatencyMS: time.Since(start).Milliseconds(),
+		CheckedAt: time.Now(),
+	}
+	if err != nil {
+		component.Status = domain.HealthStatusDown
+		component.Error = err.Error()
+	}
+
+	if check.last == nil || check.last.Status != component.Status {
+		if err != nil {
+			s.logger.Warn(ctx, "dependency is down", "component", check.name, "critical", check.critical, "error", err)
+		} else if check.last != nil {
+			s.logger.Info(ctx, "dependency is up again", "component", check.name)
+		}
+	}
+
+	check.last = &component
+	return component
+}
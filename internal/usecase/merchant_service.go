@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrMerchantNotFound = errors.New("merchant not found")
+	ErrAPIKeyNotFound   = errors.New("api key not found")
+)
+
+// MerchantConfig holds the API key policy, usually loaded from the merchants
+// section of the YAML config
+type MerchantConfig struct {
+	// Environment is the only environment whose keys this deployment accepts
+	Environment domain.MerchantEnvironment `mapstructure:"environment"`
+	// SignatureTolerance is how far a request timestamp may drift from now
+	SignatureTolerance time.Duration `mapstructure:"signature_tolerance"`
+	// RotationOverlap is how long keys keep working after a new one is issued
+	RotationOverlap time.Duration `mapstructure:"rotation_overlap"`
+}
+
+// MerchantService implements the merchant API client application service
+type MerchantService struct {
+	merchantRepo persistence.MerchantRepository
+	userRepo     persistence.UserRepository
+	secretBox    infrastructure.SecretBox
+	cache        infrastructure.Cache
+	config       MerchantConfig
+}
+
+// NewMerchantService creates a new merchant service
+func NewMerchantService(
+	merchantRepo persistence.MerchantRepository,
+	userRepo persistence.UserRepository,
+	secretBox infrastructure.SecretBox,
+	cache infrastructure.Cache,
+	config MerchantConfig,
+) *MerchantService {
+	if config.SignatureTolerance <= 0 {
+		config.SignatureTolerance = 5 * time.Minute
+	}
+	if config.RotationOverlap < 0 {
+		config.RotationOverlap = 0
+	}
+
+	return &MerchantService{
+		merchantRepo: merchantRepo,
+		userRepo:     userRepo,
+		secretBox:    secretBox,
+		cache:        cache,
+		config:       config,
+	}
+}
+
+// CreateMerchant registers an API client and issues its first key
+func (s *MerchantService) CreateMerchant(ctx context.Context, req primary.CreateMerchantRequest) (*domain.Merchant, *domain.APICredentials, error) {
+	user, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, nil, ErrUserNotFound
+	}
+
+	merchant, err := domain.NewMerchant(req.Name, req.UserID, req.Environment, req.Scopes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.merchantRepo.Create(ctx, merchant); err != nil {
+		return nil, nil, fmt.Errorf("failed to save merchant: %w", err)
+	}
+
+	credentials, err := s.issueAPIKey(ctx, merchant)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return merchant, credentials, nil
+}
+
+// GetMerchant retrieves a merchant by ID
+func (s *MerchantService) GetMerchant(ctx context.Context, merchantID int) (*domain.Merchant, error) {
+	merchant, err := s.merchantRepo.FindByID(ctx, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merchant: %w", err)
+	}
+	if merchant == nil {
+		return nil, ErrMerchantNotFound
+	}
+
+	return merchant, nil
+}
+
+// GetAPIKeys retrieves every key of a merchant, newest first
+func (s *MerchantService) GetAPIKeys(ctx context.Context, merchantID int) ([]*domain.APIKey, error) {
+	if _, err := s.GetMerchant(ctx, merchantID); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.merchantRepo.FindAPIKeysByMerchantID(ctx, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RotateAPIKey issues a new key and schedules the keys issued before to expire
+// once the rotation overlap has passed, so integrations can switch without downtime
+func (s *MerchantService) RotateAPIKey(ctx context.Context, merchantID int) (*domain.APICredentials, error) {
+	merchant, err := s.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.merchantRepo.FindAPIKeysByMerchantID(ctx, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api keys: %w", err)
+	}
+
+	credentials, err := s.issueAPIKey(ctx, merchant)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.config.RotationOverlap)
+
+	for _, key := range keys {
+		if !key.IsActive(now) {
+			continue
+		}
+
+		key.ExpireAt(expiresAt)
+		if err := s.merchantRepo.UpdateAPIKey(ctx, key); err != nil {
+			return nil, fmt.Errorf("failed to expire previous api key: %w", err)
+		}
+	}
+
+	return credentials, nil
+}
+
+// RevokeAPIKey stops a key immediately
+func (s *MerchantService) RevokeAPIKey(ctx context.Context, merchantID, keyID int) error {
+	keys, err := s.GetAPIKeys(ctx, merchantID)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if key.ID != keyID {
+			continue
+		}
+
+		key.Revoke()
+		if err := s.merchantRepo.UpdateAPIKey(ctx, key); err != nil {
+			return fmt.Errorf("failed to revoke api key: %w", err)
+		}
+		return nil
+	}
+
+	return ErrAPIKeyNotFound
+}
+
+// AuthenticateRequest verifies the key, timestamp and HMAC signature of a request.
+// Every signature is accepted once, a captured request cannot be sent again
+func (s *MerchantService) AuthenticateRequest(ctx context.Context, req primary.SignedRequest) (*domain.Principal, error) {
+	key, err := s.merchantRepo.FindAPIKeyByHash(ctx, domain.HashAPIKey(req.APIKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+
+	now := time.Now()
+	if key == nil || !key.IsActive(now) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	merchant, err := s.merchantRepo.FindByID(ctx, key.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merchant: %w", err)
+	}
+	if merchant == nil {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	if s.config.Environment != "" && merchant.Environment != s.config.Environment {
+		return nil, domain.ErrAPIKeyEnvironmentMismatch
+	}
+
+	timestamp, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return nil, domain.ErrInvalidSignature
+	}
+
+	drift := now.Sub(time.Unix(timestamp, 0))
+	if drift > s.config.SignatureTolerance || drift < -s.config.SignatureTolerance {
+		return nil, domain.ErrSignatureExpired
+	}
+
+	secret, err := s.secretBox.Open(key.SigningSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open signing secret: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(domain.APISigningString(req.Method, req.Path, timestamp, req.Body)))
+
+	signature, err := hex.DecodeString(req.Signature)
+	if err != nil || !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, domain.ErrInvalidSignature
+	}
+
+	// Keyed by the decoded signature, a re-cased hex string is the same request
+	if err := s.checkReplay(ctx, key.ID, hex.EncodeToString(signature)); err != nil {
+		return nil, err
+	}
+
+	return &domain.Principal{
+		UserID:     merchant.UserID,
+		MerchantID: merchant.ID,
+		APIKeyID:   key.ID,
+		Scopes:     merchant.Scopes,
+	}, nil
+}
+
+// checkReplay remembers a signature for as long as its timestamp is accepted
+func (s *MerchantService) checkReplay(ctx context.Context, keyID int, signature string) error {
+	if s.cache == nil {
+		return nil
+	}
+
+	cacheKey := fmt.Sprintf("api_signature:%d:%s", keyID, signature)
+
+	seen, err := s.cache.Increment(ctx, cacheKey, 1)
+	if err != nil {
+		return fmt.Errorf("failed to record request signature: %w", err)
+	}
+	if seen > 1 {
+		return domain.ErrReplayedRequest
+	}
+
+	// A timestamp is valid from tolerance in the past to tolerance in the future
+	if err := s.cache.Set(ctx, cacheKey, []byte("1"), 2*s.config.SignatureTolerance); err != nil {
+		return fmt.Errorf("failed to record request signature: %w", err)
+	}
+
+	return nil
+}
+
+// issueAPIKey creates a key and signing secret, only their hash and sealed form are stored
+func (s *MerchantService) issueAPIKey(ctx context.Context, merchant *domain.Merchant) (*domain.APICredentials, error) {
+	keyToken, err := newAPIToken(24)
+	if err != nil {
+		return nil, err
+	}
+
+	secretToken, err := newAPIToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := merchant.Environment.KeyPrefix() + keyToken
+	signingSecret := "eks_" + secretToken
+
+	sealedSecret, err := s.secretBox.Seal(signingSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal signing secret: %w", err)
+	}
+
+	key := &domain.APIKey{
+		MerchantID:    merchant.ID,
+		Prefix:        apiKey[:len(merchant.Environment.KeyPrefix())+8],
+		KeyHash:       domain.HashAPIKey(apiKey),
+		SigningSecret: sealedSecret,
+		CreatedAt:     time.Now(),
+	}
+
+	if err := s.merchantRepo.CreateAPIKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to save api key: %w", err)
+	}
+
+	return &domain.APICredentials{
+		Key:           key,
+		APIKey:        apiKey,
+		SigningSecret: signingSecret,
+	}, nil
+}
+
+func newAPIToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,500 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"time"
+)
+
+var (
+	ErrPaymentRequestNotFound = errors.New("payment request not found")
+)
+
+// expiryBatchSize caps how many requests a single expiry run closes
+const expiryBatchSize = 500
+
+// PaymentRequestConfig holds the expiry policy of payment requests
+type PaymentRequestConfig struct {
+	DefaultExpiry       time.Duration `mapstructure:"default_expiry"`
+	MaxExpiry           time.Duration `mapstructure:"max_expiry"`
+	ExpiryCheckInterval time.Duration `mapstructure:"expiry_check_interval"`
+	// ProcessingTimeout is how long an accept can hold a request PROCESSING
+	// before it is considered interrupted and recovered
+	ProcessingTimeout time.Duration `mapstructure:"processing_timeout"`
+}
+
+// PaymentRequestService implements the payment request application service
+type PaymentRequestService struct {
+	requestRepo    persistence.PaymentRequestRepository
+	walletRepo     persistence.WalletRepository
+	userRepo       persistence.UserRepository
+	walletService  primary.WalletService
+	eventPublisher infrastructure.EventPublisher
+	config         PaymentRequestConfig
+	logger         infrastructure.Logger
+}
+
+// NewPaymentRequestService creates a new payment request service
+func NewPaymentRequestService(
+	requestRepo persistence.PaymentRequestRepository,
+	walletRepo persistence.WalletRepository,
+	userRepo persistence.UserRepository,
+	walletService primary.WalletService,
+	eventPublisher infrastructure.EventPublisher,
+	config PaymentRequestConfig,
+) *PaymentRequestService {
+	if config.DefaultExpiry <= 0 {
+		config.DefaultExpiry = 72 * time.Hour
+	}
+
+	if config.MaxExpiry < config.DefaultExpiry {
+		config.MaxExpiry = config.DefaultExpiry
+	}
+
+	if config.ExpiryCheckInterval <= 0 {
+		config.ExpiryCheckInterval = time.Minute
+	}
+
+	if config.ProcessingTimeout <= 0 {
+		config.ProcessingTimeout = 5 * time.Minute
+	}
+
+	return &PaymentRequestService{
+		requestRepo:    requestRepo,
+		walletRepo:     walletRepo,
+		userRepo:       userRepo,
+		walletService:  walletService,
+		eventPublisher: eventPublisher,
+		config:         config,
+		logger:         infrastructure.NopLogger{},
+	}
+}
+
+// SetLogger reports failures of the expiry worker and of accepts
+func (s *PaymentRequestService) SetLogger(logger infrastructure.Logger) {
+	s.logger = logger
+}
+
+// CreatePaymentRequest asks a user to pay into the requester wallet
+func (s *PaymentRequestService) CreatePaymentRequest(ctx context.Context, req primary.CreatePaymentRequest) (*domain.PaymentRequest, error) {
+	if req.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	// Get requester wallet
+	wallet, err := s.walletRepo.FindByID(ctx, req.RequesterWalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wallet: %w", err)
+	}
+
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	if err := wallet.CheckCanReceive(); err != nil {
+		return nil, err
+	}
+
+	// Verify payer exists
+	payer, err := s.userRepo.FindByID(ctx, req.PayerUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payer: %w", err)
+	}
+
+	if payer == nil {
+		return nil, ErrUserNotFound
+	}
+
+	expiresIn := req.ExpiresIn
+	if expiresIn <= 0 {
+		expiresIn = s.config.DefaultExpiry
+	}
+
+	if expiresIn > s.config.MaxExpiry {
+		expiresIn = s.config.MaxExpiry
+	}
+
+	request, err := domain.NewPaymentRequest(wallet, payer.ID, req.Amount, req.Note, time.Now().Add(expiresIn))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.requestRepo.Create(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment request: %w", err)
+	}
+
+	s.publish(ctx, "payment_request.created", request)
+
+	return request, nil
+}
+
+// GetPaymentRequest retrieves a payment request by ID
+func (s *PaymentRequestService) GetPaymentRequest(ctx context.Context, requestID int) (*domain.PaymentRequest, error) {
+	request, err := s.requestRepo.FindByID(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment request: %w", err)
+	}
+
+	if request == nil {
+		return nil, ErrPaymentRequestNotFound
+	}
+
+	return request, nil
+}
+
+// GetInbox retrieves requests addressed to a user
+func (s *PaymentRequestService) GetInbox(
+	ctx context.Context,
+	userID int,
+	status domain.PaymentRequestStatus,
+	limit, offset int,
+) ([]*domain.PaymentRequest, error) {
+	requests, err := s.requestRepo.FindByPayerUserID(ctx, userID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment request inbox: %w", err)
+	}
+
+	return requests, nil
+}
+
+// GetOutbox retrieves requests sent by a user
+func (s *PaymentRequestService) GetOutbox(
+	ctx context.Context,
+	userID int,
+	status domain.PaymentRequestStatus,
+	limit, offset int,
+) ([]*domain.PaymentRequest, error) {
+	requests, err := s.requestRepo.FindByRequesterUserID(ctx, userID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment request outbox: %w", err)
+	}
+
+	return requests, nil
+}
+
+// AcceptPaymentRequest pays a request from one of the payer's wallets. The
+// request is claimed before the transfer so it can only ever be paid once, and
+// the transfer carries the request reference so an interrupted accept is
+// resolved by RecoverPaymentRequests
+func (s *PaymentRequestService) AcceptPaymentRequest(ctx context.Context, requestID int, payerWalletID int) (*domain.PaymentRequest, error) {
+	request, err := s.GetPaymentRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkNotExpired(ctx, request); err != nil {
+		return nil, err
+	}
+
+	// Get payer wallet
+	wallet, err := s.walletRepo.FindByID(ctx, payerWalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wallet: %w", err)
+	}
+
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	if wallet.UserID != request.PayerUserID {
+		return nil, domain.ErrPaymentRequestNotAllowed
+	}
+
+	if wallet.CurrencyCode != request.CurrencyCode {
+		return nil, domain.ErrPayerWalletMismatch
+	}
+
+	// Claim the request so a concurrent accept, decline or cancel cannot race the transfer
+	if err := request.Claim(); err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.requestRepo.UpdateIfStatus(ctx, request, domain.PaymentRequestStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		return nil, domain.ErrPaymentRequestNotPending
+	}
+
+	transaction, err := s.walletService.TransferWithReference(ctx, wallet.ID, request.RequesterWalletID, request.Amount,
+		paymentRequestDescription(request), PaymentRequestReference(request.ID))
+	if err != nil {
+		// Give the request back to the payer so it can be retried, unless the
+		// transfer got far enough to move funds
+		if _, recoverErr := s.recoverClaim(ctx, request); recoverErr != nil {
+			s.logger.Error(ctx, "failed to release payment request", "request_id", request.ID, "error", recoverErr)
+		}
+		return nil, err
+	}
+
+	if err := request.Accept(wallet.ID, transaction.ID); err != nil {
+		return nil, err
+	}
+
+	// The payer has paid, so a failed update is left to RecoverPaymentRequests
+	// instead of reporting an error for a payment that went through
+	if _, err := s.requestRepo.UpdateIfStatus(ctx, request, domain.PaymentRequestStatusProcessing); err != nil {
+		s.logger.Error(ctx, "failed to record accepted payment request", "request_id", request.ID, "error", err)
+		return request, nil
+	}
+
+	s.publish(ctx, "payment_request.accepted", request)
+
+	return request, nil
+}
+
+// DeclinePaymentRequest refuses a request on behalf of the payer
+func (s *PaymentRequestService) DeclinePaymentRequest(ctx context.Context, requestID int, userID int) (*domain.PaymentRequest, error) {
+	request, err := s.GetPaymentRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.PayerUserID != userID {
+		return nil, domain.ErrPaymentRequestNotAllowed
+	}
+
+	if err := s.checkNotExpired(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if err := request.Decline(); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, request, domain.PaymentRequestStatusPending); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, "payment_request.declined", request)
+
+	return request, nil
+}
+
+// CancelPaymentRequest withdraws a request on behalf of the requester
+func (s *PaymentRequestService) CancelPaymentRequest(ctx context.Context, requestID int, userID int) (*domain.PaymentRequest, error) {
+	request, err := s.GetPaymentRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.RequesterUserID != userID {
+		return nil, domain.ErrPaymentRequestNotAllowed
+	}
+
+	if err := s.checkNotExpired(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if err := request.Cancel(); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, request, domain.PaymentRequestStatusPending); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, "payment_request.cancelled", request)
+
+	return request, nil
+}
+
+// ExpirePaymentRequests closes every pending request past its expiry and
+// returns how many were closed
+func (s *PaymentRequestService) ExpirePaymentRequests(ctx context.Context) (int, error) {
+	requests, err := s.requestRepo.FindExpired(ctx, time.Now(), expiryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired payment requests: %w", err)
+	}
+
+	expired := 0
+	for _, request := range requests {
+		if err := s.expire(ctx, request); err != nil {
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// RecoverPaymentRequests resolves requests left PROCESSING by an accept that
+// was interrupted, and returns how many were resolved. A request whose
+// transfer completed is accepted, one without a transfer is pending again
+func (s *PaymentRequestService) RecoverPaymentRequests(ctx context.Context) (int, error) {
+	requests, err := s.requestRepo.FindStaleProcessing(ctx, time.Now().Add(-s.config.ProcessingTimeout), expiryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find processing payment requests: %w", err)
+	}
+
+	recovered := 0
+	for _, request := range requests {
+		resolved, err := s.recoverClaim(ctx, request)
+		if err != nil {
+			s.logger.Error(ctx, "failed to recover payment request", "request_id", request.ID, "error", err)
+			continue
+		}
+		if resolved {
+			recovered++
+		}
+	}
+
+	return recovered, nil
+}
+
+// RunExpiryWorker expires pending requests and recovers interrupted accepts on
+// the configured interval until ctx is done
+func (s *PaymentRequestService) RunExpiryWorker(ctx context.Context) {
+	ticker := time.NewTicker(s.config.ExpiryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ExpirePaymentRequests(ctx); err != nil {
+				s.logger.Error(ctx, "failed to expire payment requests", "error", err)
+			}
+			if _, err := s.RecoverPaymentRequests(ctx); err != nil {
+				s.logger.Error(ctx, "failed to recover payment requests", "error", err)
+			}
+		}
+	}
+}
+
+// recoverClaim settles a PROCESSING request by the transfer carrying its
+// reference. A completed transfer accepts the request, a missing or failed
+// one gives it back to the payer. A transfer still pending keeps the request
+// claimed until reconciliation resolves it, and reports false
+func (s *PaymentRequestService) recoverClaim(ctx context.Context, request *domain.PaymentRequest) (bool, error) {
+	page, err := s.walletService.GetTransactionHistoryPage(ctx, request.RequesterWalletID, primary.TransactionHistoryQuery{
+		Filter: domain.TransactionFilter{
+			Types:     []domain.TransactionType{domain.TransactionTypeTransfer},
+			Reference: PaymentRequestReference(request.ID),
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to find payment request transfer: %w", err)
+	}
+
+	accepted := false
+	switch {
+	case len(page.Transactions) == 0 || page.Transactions[0].Status == domain.TransactionStatusFailed:
+		request.Release()
+	case page.Transactions[0].Status == domain.TransactionStatusCompleted:
+		transaction := page.Transactions[0]
+		if err := request.Accept(transaction.WalletID, transaction.ID); err != nil {
+			return false, err
+		}
+		accepted = true
+	default:
+		return false, nil
+	}
+
+	if err := s.save(ctx, request, domain.PaymentRequestStatusProcessing); err != nil {
+		return false, err
+	}
+
+	if accepted {
+		s.publish(ctx, "payment_request.accepted", request)
+	}
+
+	return true, nil
+}
+
+// checkNotExpired closes a pending request that expired before the worker reached it
+func (s *PaymentRequestService) checkNotExpired(ctx context.Context, request *domain.PaymentRequest) error {
+	if !request.IsExpired(time.Now()) {
+		return nil
+	}
+
+	_ = s.expire(ctx, request)
+
+	return domain.ErrPaymentRequestExpired
+}
+
+func (s *PaymentRequestService) expire(ctx context.Context, request *domain.PaymentRequest) error {
+	if err := request.Expire(); err != nil {
+		return err
+	}
+
+	if err := s.save(ctx, request, domain.PaymentRequestStatusPending); err != nil {
+		return err
+	}
+
+	s.publish(ctx, "payment_request.expired", request)
+
+	return nil
+}
+
+// save persists a status change made from the expected status
+func (s *PaymentRequestService) save(ctx context.Context, request *domain.PaymentRequest, expected domain.PaymentRequestStatus) error {
+	updated, err := s.requestRepo.UpdateIfStatus(ctx, request, expected)
+	if err != nil {
+		return fmt.Errorf("failed to update payment request: %w", err)
+	}
+
+	if !updated {
+		return domain.ErrPaymentRequestNotPending
+	}
+
+	return nil
+}
+
+// publish sends a payment request event without blocking the caller
+func (s *PaymentRequestService) publish(ctx context.Context, eventType string, request *domain.PaymentRequest) {
+	if s.eventPublisher == nil {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"request_id":          request.ID,
+		"requester_wallet_id": request.RequesterWalletID,
+		"requester_user_id":   request.RequesterUserID,
+		"payer_user_id":       request.PayerUserID,
+		"amount":              request.Amount,
+		"currency_code":       request.CurrencyCode,
+		"note":                request.Note,
+		"status":              string(request.Status),
+		"expires_at":          request.ExpiresAt,
+	}
+
+	if request.TransactionID != nil {
+		payload["transaction_id"] = *request.TransactionID
+	}
+
+	event := infrastructure.Event{
+		Type:    eventType,
+		Payload: payload,
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = s.eventPublisher.Publish(ctx, "payment_requests", event)
+	}()
+}
+
+// PaymentRequestReference returns the reference of the transfer that pays a request
+func PaymentRequestReference(requestID int) string {
+	return fmt.Sprintf("PRQ-%d", requestID)
+}
+
+// paymentRequestDescription builds the description of the transfer that pays a request
+func paymentRequestDescription(request *domain.PaymentRequest) string {
+	if request.Note != "" {
+		return fmt.Sprintf("Payment request %d: %s", request.ID, request.Note)
+	}
+
+	return fmt.Sprintf("Payment request %d", request.ID)
+}
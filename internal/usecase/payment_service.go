@@ -26,6 +26,9 @@ type PaymentService struct {
 	gateways        map[domain.PaymentProvider]external.PaymentGateway
 	eventPublisher  infrastructure.EventPublisher
 	cache           infrastructure.Cache
+	riskService     *RiskService
+	feeService      *FeeService
+	logger          infrastructure.Logger
 }
 
 // NewPaymentService creates a new payment service
@@ -43,6 +46,7 @@ func NewPaymentService(
 		gateways:        make(map[domain.PaymentProvider]external.PaymentGateway),
 		eventPublisher:  eventPublisher,
 		cache:           cache,
+		logger:          infrastructure.NopLogger{},
 	}
 }
 
@@ -51,6 +55,21 @@ func (s *PaymentService) RegisterGateway(provider domain.PaymentProvider, gatewa
 	s.gateways[provider] = gateway
 }
 
+// SetLogger reports fees that could not be credited to the revenue wallet
+func (s *PaymentService) SetLogger(logger infrastructure.Logger) {
+	s.logger = logger
+}
+
+// SetRiskService enables synchronous risk checks before payments are initiated
+func (s *PaymentService) SetRiskService(riskService *RiskService) {
+	s.riskService = riskService
+}
+
+// SetFeeService enables fee charging on payments
+func (s *PaymentService) SetFeeService(feeService *FeeService) {
+	s.feeService = feeService
+}
+
 // ProcessPayment initiates a payment through a payment gateway
 func (s *PaymentService) ProcessPayment(ctx context.Context, req primary.PaymentRequest) (*domain.Payment, error) {
 	// Validate request
@@ -68,12 +87,47 @@ func (s *PaymentService) ProcessPayment(ctx context.Context, req primary.Payment
 		return nil, ErrWalletNotFound
 	}
 
+	if err := wallet.CheckCanReceive(); err != nil {
+		return nil, err
+	}
+
 	// Get payment gateway
 	gateway, exists := s.gateways[req.PaymentProvider]
 	if !exists {
 		return nil, fmt.Errorf("payment provider %s not supported", req.PaymentProvider)
 	}
 
+	// Payment fees are deducted from the credited amount
+	fee := 0
+	if s.feeService != nil {
+		quote, err := s.feeService.Calculate(domain.TransactionTypeDeposit, wallet.CurrencyCode, req.PaymentProvider, req.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		if quote.Fee >= req.Amount {
+			return nil, domain.ErrFeeExceedsAmount
+		}
+
+		fee = quote.Fee
+	}
+
+	// Run risk checks before reaching the gateway
+	var assessment *domain.RiskAssessment
+	if s.riskService != nil {
+		assessment, err = s.riskService.Assess(ctx, external.RiskCheckRequest{
+			WalletID:        wallet.ID,
+			UserID:          wallet.UserID,
+			TransactionType: domain.TransactionTypeDeposit,
+			Amount:          req.Amount,
+			Currency:        wallet.CurrencyCode,
+			Provider:        req.PaymentProvider,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create transaction record
 	transaction, err := domain.NewTransaction(req.WalletID, domain.TransactionTypeDeposit, req.Amount, req.Description)
 	if err != nil {
@@ -81,12 +135,21 @@ func (s *PaymentService) ProcessPayment(ctx context.Context, req primary.Payment
 	}
 
 	transaction.Status = domain.TransactionStatusPending
+	transaction.Fee = fee
 
 	err = s.transactionRepo.Create(ctx, transaction)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save transaction: %w", err)
 	}
 
+	// Keep the audit trail pointing at the transaction it cleared. A REVIEW
+	// decision is advisory, the operation goes on and waits in the review queue
+	if s.riskService != nil {
+		if err := s.riskService.LinkTransaction(ctx, assessment, transaction.ID); err != nil {
+			s.logger.Error(ctx, "failed to link risk assessment", "transaction_id", transaction.ID, "error", err)
+		}
+	}
+
 	// Create payment record
 	payment, err := domain.NewPayment(transaction.ID, req.Amount, req.PaymentProvider, req.Description)
 	if err != nil {
@@ -127,6 +190,7 @@ func (s *PaymentService) ProcessPayment(ctx context.Context, req primary.Payment
 
 	// Update payment with gateway response
 	payment.SetExternalInfo(gatewayResp.ExternalID, gatewayResp.PaymentURL, gatewayResp.Details)
+	setPaymentFee(payment, fee)
 	err = s.paymentRepo.Update(ctx, payment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update payment: %w", err)
@@ -141,13 +205,14 @@ func (s *PaymentService) ProcessPayment(ctx context.Context, req primary.Payment
 				"transaction_id": transaction.ID,
 				"wallet_id":      wallet.ID,
 				"amount":         payment.Amount,
+				"fee":            fee,
 				"provider":       string(payment.Provider),
 				"payment_url":    payment.PaymentURL,
 			},
 		}
 
 		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 			defer cancel()
 			_ = s.eventPublisher.Publish(ctx, "payments", event)
 		}()
@@ -210,7 +275,11 @@ func (s *PaymentService) VerifyPayment(ctx context.Context, paymentID int) (*dom
 
 		// Update payment details
 		if gatewayResp.Details != nil {
+			fee := payment.Details["fee"]
 			payment.Details = gatewayResp.Details
+			if fee != nil {
+				payment.Details["fee"] = fee
+			}
 		}
 
 		// Save payment
@@ -223,13 +292,36 @@ func (s *PaymentService) VerifyPayment(ctx context.Context, paymentID int) (*dom
 		transaction, err := s.transactionRepo.FindByID(ctx, payment.TransactionID)
 		if err == nil && transaction != nil {
 			if newStatus == domain.PaymentStatusCompleted {
-				// Credit wallet
+				// Credit wallet with the amount net of fees
 				wallet, err := s.walletRepo.FindByID(ctx, transaction.WalletID)
 				if err == nil && wallet != nil {
-					err = wallet.Credit(transaction.Amount)
+					// Record the fee before crediting so it can always be reconciled
+					var feeTransaction *domain.Transaction
+					if s.feeService != nil && transaction.Fee > 0 {
+						feeTransaction, err = s.feeService.Reserve(ctx, wallet, transaction)
+					}
+
+					// Only a reserved fee is withheld, without one it would never reach
+					// the revenue wallet. The transaction records what was withheld
+					if feeTransaction == nil {
+						transaction.Fee = 0
+					}
+
+					if err == nil {
+						err = wallet.Credit(transaction.Amount - transaction.Fee)
+					}
 					if err == nil {
 						_ = s.walletRepo.Save(ctx, wallet)
-						_ = s.transactionRepo.UpdateStatus(ctx, transaction.ID, domain.TransactionStatusCompleted)
+
+						transaction.Complete()
+						_ = s.transactionRepo.Update(ctx, transaction)
+
+						// A failure leaves the fee PENDING for ReconcileFailedTransactions
+						if feeTransaction != nil {
+							if err := s.feeService.Settle(ctx, feeTransaction); err != nil {
+								s.logger.Error(ctx, "failed to settle fee", "transaction_id", feeTransaction.ID, "error", err)
+							}
+						}
 					}
 				}
 			} else if newStatus == domain.PaymentStatusFailed || newStatus == domain.PaymentStatusCancelled {
@@ -249,7 +341,7 @@ func (s *PaymentService) VerifyPayment(ctx context.Context, paymentID int) (*dom
 			}
 
 			go func() {
-				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 				defer cancel()
 				_ = s.eventPublisher.Publish(ctx, "payments", event)
 			}()
@@ -314,7 +406,7 @@ func (s *PaymentService) CancelPayment(ctx context.Context, paymentID int) error
 		}
 
 		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 			defer cancel()
 			_ = s.eventPublisher.Publish(ctx, "payments", event)
 		}()
@@ -394,3 +486,12 @@ func mapToDomainPaymentStatus(status external.PaymentStatus) domain.PaymentStatu
 		return domain.PaymentStatusPending
 	}
 }
+
+// setPaymentFee exposes the fee charged for a payment in its details
+func setPaymentFee(payment *domain.Payment, fee int) {
+	if payment.Details == nil {
+		payment.Details = make(map[string]interface{})
+	}
+
+	payment.Details["fee"] = fee
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"strconv"
+	"time"
+)
+
+// RateLimitService implements sliding window rate limiting on the cache. Counters
+// live in the cache, so replicas sharing a Redis instance share their limits
+type RateLimitService struct {
+	cache    infrastructure.Cache
+	policies map[string]domain.RateLimitPolicy
+}
+
+// NewRateLimitService creates a new rate limit service with the policies of each route group
+func NewRateLimitService(cache infrastructure.Cache, policies map[string]domain.RateLimitPolicy) *RateLimitService {
+	return &RateLimitService{
+		cache:    cache,
+		policies: policies,
+	}
+}
+
+// Check counts a request of the subject against the limits of a route group.
+// API clients are limited per key, other authenticated callers per user
+func (s *RateLimitService) Check(ctx context.Context, group string, subject domain.RateLimitSubject) (*domain.RateLimitStatus, error) {
+	policy, ok := s.policies[group]
+	if !ok {
+		return nil, nil
+	}
+
+	type counter struct {
+		key   string
+		limit domain.RateLimit
+	}
+
+	var counters []counter
+	if subject.IP != "" && policy.IP.IsSet() {
+		counters = append(counters, counter{key: "ip:" + subject.IP, limit: policy.IP})
+	}
+	if subject.APIKeyID != 0 {
+		if policy.APIKey.IsSet() {
+			counters = append(counters, counter{key: fmt.Sprintf("api_key:%d", subject.APIKeyID), limit: policy.APIKey})
+		}
+	} else if subject.UserID != 0 && policy.User.IsSet() {
+		counters = append(counters, counter{key: fmt.Sprintf("user:%d", subject.UserID), limit: policy.User})
+	}
+
+	now := time.Now()
+
+	var status *domain.RateLimitStatus
+	for _, c := range counters {
+		counted, err := s.count(ctx, group+":"+c.key, c.limit, now)
+		if err != nil {
+			return nil, err
+		}
+		if !counted.Allowed {
+			return counted, nil
+		}
+		if status == nil || counted.Remaining < status.Remaining {
+			status = counted
+		}
+	}
+
+	return status, nil
+}
+
+// count adds a request to the current window and estimates the sliding window
+// from it and the previous window, weighted by how much of it still overlaps
+func (s *RateLimitService) count(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (*domain.RateLimitStatus, error) {
+	window := now.UnixNano() / int64(limit.Window)
+	elapsed := time.Duration(now.UnixNano() % int64(limit.Window))
+
+	currentKey := fmt.Sprintf("rate_limit:%s:%d", key, window)
+	previousKey := fmt.Sprintf("rate_limit:%s:%d", key, window-1)
+
+	current, err := s.cache.Increment(ctx, currentKey, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count request: %w", err)
+	}
+
+	// Increment keeps the expiry of a key, so the first request of a window sets
+	// it. The window is read as the previous one afterwards, it is kept for two.
+	// A request counted by another replica in between is lost, erring on the side
+	// of allowing
+	if current == 1 {
+		if err := s.cache.Set(ctx, currentKey, []byte("1"), 2*limit.Window); err != nil {
+			return nil, fmt.Errorf("failed to count request: %w", err)
+		}
+	}
+
+	previous := s.windowCount(ctx, previousKey)
+	progress := float64(elapsed) / float64(limit.Window)
+	estimate := float64(previous)*(1-progress) + float64(current)
+
+	status := &domain.RateLimitStatus{
+		Allowed: estimate <= float64(limit.Requests),
+		Limit:   limit.Requests,
+		Window:  limit.Window,
+		Reset:   limit.Window - elapsed,
+	}
+
+	if status.Allowed {
+		status.Remaining = max(limit.Requests-int(math.Ceil(estimate)), 0)
+		return status, nil
+	}
+
+	// Rejected requests do not count, a client retrying in a loop is not locked out longer
+	if _, err := s.cache.Decrement(ctx, currentKey, 1); err != nil {
+		return nil, fmt.Errorf("failed to uncount request: %w", err)
+	}
+	current--
+
+	status.RetryAfter = retryAfter(limit, previous, current, progress)
+
+	return status, nil
+}
+
+// windowCount reads the counter of a window, a missing or unreadable one counts as empty
+func (s *RateLimitService) windowCount(ctx context.Context, key string) int64 {
+	value, err := s.cache.Get(ctx, key)
+	if err != nil {
+		return 0
+	}
+
+	count, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return count
+}
+
+// retryAfter estimates how long until one more request fits, assuming no other
+// request is made in the meantime
+func retryAfter(limit domain.RateLimit, previous, current int64, progress float64) time.Duration {
+	room := float64(limit.Requests) - float64(current) - 1
+
+	// Still within this window, once enough of the previous window has slid out
+	if room >= 0 && previous > 0 {
+		at := 1 - room/float64(previous)
+		return time.Duration((at - progress) * float64(limit.Window))
+	}
+
+	// Otherwise once enough of this window has slid out during the next one
+	at := 1 - (float64(limit.Requests)-1)/float64(current)
+	if at < 0 {
+		at = 0
+	}
+
+	return time.Duration((1 - progress + at) * float64(limit.Window))
+}
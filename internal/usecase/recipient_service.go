@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRecipientNotFound         = errors.New("recipient not found")
+	ErrRecipientWalletNotFound   = errors.New("recipient has no active wallet in this currency")
+	ErrRecipientCurrencyMismatch = errors.New("recipient was confirmed for a different currency")
+	ErrInvalidConfirmationToken  = errors.New("confirmation token is invalid or expired")
+	ErrConfirmationRequired      = errors.New("recipient must be confirmed before the transfer")
+)
+
+// RecipientConfig holds the confirmation policy of transfers addressed by phone, email or handle
+type RecipientConfig struct {
+	ConfirmationTTL     time.Duration `mapstructure:"confirmation_ttl"`
+	RequireConfirmation bool          `mapstructure:"require_confirmation"`
+}
+
+// recipientConfirmation is what a confirmation token points to in the cache
+type recipientConfirmation struct {
+	Type         domain.RecipientType `json:"type"`
+	Identifier   string               `json:"identifier"`
+	MaskedName   string               `json:"masked_name"`
+	CurrencyCode string               `json:"currency_code"`
+	UserID       int                  `json:"user_id"`
+	WalletID     int                  `json:"wallet_id"`
+}
+
+// RecipientService implements the recipient resolution application service
+type RecipientService struct {
+	userRepo      persistence.UserRepository
+	walletRepo    persistence.WalletRepository
+	walletService primary.WalletService
+	cache         infrastructure.Cache
+	config        RecipientConfig
+}
+
+// NewRecipientService creates a new recipient service
+func NewRecipientService(
+	userRepo persistence.UserRepository,
+	walletRepo persistence.WalletRepository,
+	walletService primary.WalletService,
+	cache infrastructure.Cache,
+	config RecipientConfig,
+) *RecipientService {
+	if config.ConfirmationTTL <= 0 {
+		config.ConfirmationTTL = 5 * time.Minute
+	}
+
+	return &RecipientService{
+		userRepo:      userRepo,
+		walletRepo:    walletRepo,
+		walletService: walletService,
+		cache:         cache,
+		config:        config,
+	}
+}
+
+// ResolveRecipient finds the wallet of a recipient in a currency and returns its
+// masked name together with a single use confirmation token for the transfer
+func (s *RecipientService) ResolveRecipient(ctx context.Context, identifier, currencyCode string) (*domain.Recipient, error) {
+	recipient, err := s.resolve(ctx, identifier, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a cache there is nowhere to keep the token, the sender has to
+	// transfer by identifier instead
+	if s.cache == nil {
+		return recipient, nil
+	}
+
+	token, err := newConfirmationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	confirmation := recipientConfirmation{
+		Type:         recipient.Type,
+		Identifier:   recipient.Identifier,
+		MaskedName:   recipient.MaskedName,
+		CurrencyCode: recipient.CurrencyCode,
+		UserID:       recipient.UserID,
+		WalletID:     recipient.WalletID,
+	}
+
+	err = s.cache.SetObject(ctx, confirmationCacheKey(token), confirmation, s.config.ConfirmationTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store recipient confirmation: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.config.ConfirmationTTL)
+	recipient.ConfirmationToken = token
+	recipient.ExpiresAt = &expiresAt
+
+	return recipient, nil
+}
+
+// TransferToRecipient transfers funds to a confirmed recipient, or resolves the
+// recipient on the fly when confirmation is not required
+func (s *RecipientService) TransferToRecipient(ctx context.Context, req primary.TransferToRecipientRequest) (*domain.Transaction, error) {
+	if req.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	// Get source wallet
+	fromWallet, err := s.walletRepo.FindByID(ctx, req.FromWalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find source wallet: %w", err)
+	}
+
+	if fromWallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	var recipient *domain.Recipient
+	switch {
+	case req.ConfirmationToken != "":
+		recipient, err = s.confirm(ctx, req.ConfirmationToken)
+		if err != nil {
+			return nil, err
+		}
+
+		if recipient.CurrencyCode != fromWallet.CurrencyCode {
+			return nil, ErrRecipientCurrencyMismatch
+		}
+
+	case s.config.RequireConfirmation:
+		return nil, ErrConfirmationRequired
+
+	default:
+		recipient, err = s.resolve(ctx, req.Recipient, fromWallet.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if recipient.WalletID == fromWallet.ID {
+		return nil, domain.ErrRecipientIsSender
+	}
+
+	return s.walletService.Transfer(ctx, fromWallet.ID, recipient.WalletID, req.Amount, req.Description)
+}
+
+// resolve finds the user behind an identifier and picks their active wallet in the currency
+func (s *RecipientService) resolve(ctx context.Context, identifier, currencyCode string) (*domain.Recipient, error) {
+	recipientType, value, err := domain.ParseRecipient(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+	switch recipientType {
+	case domain.RecipientTypePhone:
+		user, err = s.userRepo.FindByPhone(ctx, value)
+	case domain.RecipientTypeEmail:
+		user, err = s.userRepo.FindByEmail(ctx, value)
+	default:
+		user, err = s.userRepo.FindByHandle(ctx, value)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recipient: %w", err)
+	}
+
+	if user == nil || user.Status != domain.UserStatusActive {
+		return nil, ErrRecipientNotFound
+	}
+
+	wallets, err := s.walletRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recipient wallets: %w", err)
+	}
+
+	currencyCode = strings.ToUpper(currencyCode)
+	for _, wallet := range wallets {
+		if wallet.CurrencyCode != currencyCode || wallet.CheckCanReceive() != nil {
+			continue
+		}
+
+		return &domain.Recipient{
+			Type:         recipientType,
+			Identifier:   value,
+			MaskedName:   domain.MaskName(user.Fullname),
+			CurrencyCode: wallet.CurrencyCode,
+			UserID:       user.ID,
+			WalletID:     wallet.ID,
+		}, nil
+	}
+
+	return nil, ErrRecipientWalletNotFound
+}
+
+// confirm redeems a confirmation token. Tokens are single use so a replayed
+// request cannot repeat the transfer
+func (s *RecipientService) confirm(ctx context.Context, token string) (*domain.Recipient, error) {
+	if s.cache == nil {
+		return nil, ErrInvalidConfirmationToken
+	}
+
+	key := confirmationCacheKey(token)
+
+	var confirmation recipientConfirmation
+	if err := s.cache.GetObject(ctx, key, &confirmation); err != nil {
+		return nil, ErrInvalidConfirmationToken
+	}
+
+	if err := s.cache.Delete(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to redeem recipient confirmation: %w", err)
+	}
+
+	return &domain.Recipient{
+		Type:         confirmation.Type,
+		Identifier:   confirmation.Identifier,
+		MaskedName:   confirmation.MaskedName,
+		CurrencyCode: confirmation.CurrencyCode,
+		UserID:       confirmation.UserID,
+		WalletID:     confirmation.WalletID,
+	}, nil
+}
+
+func confirmationCacheKey(token string) string {
+	return fmt.Sprintf("recipient_confirmation:%s", token)
+}
+
+func newConfirmationToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/external"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"time"
+)
+
+var (
+	ErrTransactionDenied      = errors.New("transaction denied by risk checks")
+	ErrRiskAssessmentNotFound = errors.New("risk assessment not found")
+)
+
+// RiskService implements the risk application service. It evaluates
+// operations synchronously, keeps an audit trail of every decision
+// and exposes the review queue for flagged operations
+type RiskService struct {
+	engine         external.RiskEngine
+	assessmentRepo persistence.RiskAssessmentRepository
+	eventPublisher infrastructure.EventPublisher
+}
+
+// NewRiskService creates a new risk service
+func NewRiskService(
+	engine external.RiskEngine,
+	assessmentRepo persistence.RiskAssessmentRepository,
+	eventPublisher infrastructure.EventPublisher,
+) *RiskService {
+	return &RiskService{
+		engine:         engine,
+		assessmentRepo: assessmentRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Assess evaluates an operation and persists the decision. A denied
+// operation returns the assessment together with ErrTransactionDenied,
+// a flagged one is returned without an error and goes through
+func (s *RiskService) Assess(ctx context.Context, req external.RiskCheckRequest) (*domain.RiskAssessment, error) {
+	if req.RequestedAt.IsZero() {
+		req.RequestedAt = time.Now()
+	}
+
+	hits, err := s.engine.Evaluate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate risk: %w", err)
+	}
+
+	assessment := domain.NewRiskAssessment(req.WalletID, req.TransactionType, req.Amount, req.ToWalletID, hits)
+
+	err = s.assessmentRepo.Create(ctx, assessment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save risk assessment: %w", err)
+	}
+
+	if assessment.Decision != domain.RiskDecisionAllow {
+		s.publish(ctx, "risk.flagged", assessment)
+	}
+
+	if assessment.IsDenied() {
+		return assessment, ErrTransactionDenied
+	}
+
+	return assessment, nil
+}
+
+// LinkTransaction records the transaction created for an allowed or flagged operation
+func (s *RiskService) LinkTransaction(ctx context.Context, assessment *domain.RiskAssessment, transactionID int) error {
+	if assessment == nil {
+		return nil
+	}
+
+	assessment.LinkTransaction(transactionID)
+
+	err := s.assessmentRepo.Update(ctx, assessment)
+	if err != nil {
+		return fmt.Errorf("failed to link risk assessment: %w", err)
+	}
+
+	return nil
+}
+
+// GetAssessment retrieves a risk assessment by ID
+func (s *RiskService) GetAssessment(ctx context.Context, assessmentID int) (*domain.RiskAssessment, error) {
+	assessment, err := s.assessmentRepo.FindByID(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find risk assessment: %w", err)
+	}
+
+	if assessment == nil {
+		return nil, ErrRiskAssessmentNotFound
+	}
+
+	return assessment, nil
+}
+
+// GetAssessmentsByWalletID retrieves the risk audit trail of a wallet
+func (s *RiskService) GetAssessmentsByWalletID(ctx context.Context, walletID int, limit, offset int) ([]*domain.RiskAssessment, error) {
+	assessments, err := s.assessmentRepo.FindByWalletID(ctx, walletID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find risk assessments: %w", err)
+	}
+
+	return assessments, nil
+}
+
+// GetReviewQueue retrieves flagged assessments waiting for manual review
+func (s *RiskService) GetReviewQueue(ctx context.Context, limit, offset int) ([]*domain.RiskAssessment, error) {
+	assessments, err := s.assessmentRepo.FindByReviewStatus(ctx, domain.RiskReviewStatusPending, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review queue: %w", err)
+	}
+
+	return assessments, nil
+}
+
+// ApproveReview clears a flagged assessment
+func (s *RiskService) ApproveReview(ctx context.Context, assessmentID int, reviewer, note string) (*domain.RiskAssessment, error) {
+	return s.resolveReview(ctx, assessmentID, func(a *domain.RiskAssessment) error {
+		return a.Approve(reviewer, note)
+	})
+}
+
+// RejectReview confirms a flagged assessment as suspicious
+func (s *RiskService) RejectReview(ctx context.Context, assessmentID int, reviewer, note string) (*domain.RiskAssessment, error) {
+	return s.resolveReview(ctx, assessmentID, func(a *domain.RiskAssessment) error {
+		return a.Reject(reviewer, note)
+	})
+}
+
+func (s *RiskService) resolveReview(ctx context.Context, assessmentID int, resolve func(*domain.RiskAssessment) error) (*domain.RiskAssessment, error) {
+	assessment, err := s.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolve(assessment); err != nil {
+		return nil, err
+	}
+
+	err = s.assessmentRepo.Update(ctx, assessment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update risk assessment: %w", err)
+	}
+
+	s.publish(ctx, "risk.reviewed", assessment)
+
+	return assessment, nil
+}
+
+// publish sends a risk event without blocking the caller
+func (s *RiskService) publish(ctx context.Context, eventType string, assessment *domain.RiskAssessment) {
+	if s.eventPublisher == nil {
+		return
+	}
+
+	rules := make([]string, 0, len(assessment.RuleHits))
+	for _, hit := range assessment.RuleHits {
+		rules = append(rules, hit.Rule)
+	}
+
+	event := infrastructure.Event{
+		Type: eventType,
+		Payload: map[string]interface{}{
+			"assessment_id":    assessment.ID,
+			"wallet_id":        assessment.WalletID,
+			"transaction_type": string(assessment.TransactionType),
+			"amount":           assessment.Amount,
+			"decision":         string(assessment.Decision),
+			"review_status":    string(assessment.ReviewStatus),
+			"rules":            rules,
+		},
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = s.eventPublisher.Publish(ctx, "risk", event)
+	}()
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
+	"time"
+)
+
+// statementPageSize is the number of transactions read at a time while
+// streaming a statement
+const statementPageSize = 500
+
+// StatementService implements the account statement application service.
+// Statements are built from completed transactions and streamed page by page
+type StatementService struct {
+	walletRepo      persistence.WalletRepository
+	transactionRepo persistence.TransactionRepository
+	renderers       map[domain.StatementFormat]infrastructure.StatementRenderer
+}
+
+// NewStatementService creates a new statement service with a renderer per format
+func NewStatementService(
+	walletRepo persistence.WalletRepository,
+	transactionRepo persistence.TransactionRepository,
+	renderers ...infrastructure.StatementRenderer,
+) *StatementService {
+	byFormat := make(map[domain.StatementFormat]infrastructure.StatementRenderer, len(renderers))
+	for _, renderer := range renderers {
+		byFormat[renderer.Format()] = renderer
+	}
+
+	return &StatementService{
+		walletRepo:      walletRepo,
+		transactionRepo: transactionRepo,
+		renderers:       byFormat,
+	}
+}
+
+// ExportStatement streams the statement of a wallet. The opening balance is
+// the current balance less every completed transaction since the start of the
+// period, then each transaction of the period is applied in order
+func (s *StatementService) ExportStatement(
+	ctx context.Context,
+	req primary.StatementRequest,
+	output primary.StatementOutput,
+) error {
+	renderer, ok := s.renderers[req.Format]
+	if !ok {
+		return domain.ErrInvalidStatementFormat
+	}
+
+	if !req.From.Before(req.To) {
+		return domain.ErrInvalidDateRange
+	}
+
+	wallet, err := s.walletRepo.FindByID(ctx, req.WalletID)
+	if err != nil {
+		return fmt.Errorf("failed to find wallet: %w", err)
+	}
+
+	if wallet == nil {
+		return ErrWalletNotFound
+	}
+
+	from, to := req.From.UTC(), req.To.UTC()
+	completed := []domain.TransactionStatus{domain.TransactionStatusCompleted}
+
+	since, err := s.transactionRepo.SumBalanceChanges(ctx, domain.TransactionQuery{
+		WalletID: wallet.ID,
+		Filter:   domain.TransactionFilter{From: &from, Statuses: completed},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to compute opening balance: %w", err)
+	}
+
+	header := domain.StatementHeader{
+		WalletID:       wallet.ID,
+		Currency:       wallet.CurrencyCode,
+		From:           from,
+		To:             to,
+		OpeningBalance: wallet.Balance - since,
+		GeneratedAt:    time.Now().UTC(),
+	}
+
+	filename := fmt.Sprintf("statement-%d-%s-%s.%s",
+		wallet.ID, from.Format(time.DateOnly), to.Format(time.DateOnly), renderer.Format())
+	writer := renderer.NewWriter(output(renderer.ContentType(), filename))
+
+	if err := writer.WriteHeader(header); err != nil {
+		return err
+	}
+
+	summary := domain.NewStatementSummary(header.OpeningBalance)
+	query := domain.TransactionQuery{
+		WalletID:    wallet.ID,
+		Filter:      domain.TransactionFilter{From: &from, To: &to, Statuses: completed},
+		OldestFirst: true,
+		Limit:       statementPageSize,
+	}
+
+	for {
+		transactions, err := s.transactionRepo.FindByQuery(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to find transactions: %w", err)
+		}
+
+		for _, tx := range transactions {
+			// The fee is already on the line of the operation that charged it
+			if tx.Type == domain.TransactionTypeFee && tx.WalletID == wallet.ID {
+				continue
+			}
+
+			if err := writer.WriteLine(summary.Add(wallet.ID, tx)); err != nil {
+				return err
+			}
+		}
+
+		if len(transactions) < statementPageSize {
+			break
+		}
+		query.After = domain.CursorAfter(transactions[len(transactions)-1])
+	}
+
+	return writer.WriteSummary(summary)
+}
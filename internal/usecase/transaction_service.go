@@ -15,6 +15,7 @@ type TransactionService struct {
 	walletRepo      persistence.WalletRepository
 	eventPublisher  infrastructure.EventPublisher
 	cache           infrastructure.Cache
+	feeService      *FeeService
 }
 
 // NewTransactionService creates a new transaction service
@@ -32,6 +33,11 @@ func NewTransactionService(
 	}
 }
 
+// SetFeeService lets reconciliation retry fees that were charged but not credited
+func (s *TransactionService) SetFeeService(feeService *FeeService) {
+	s.feeService = feeService
+}
+
 // GetTransaction retrieves a transaction by ID
 func (s *TransactionService) GetTransaction(ctx context.Context, transactionID int) (*domain.Transaction, error) {
 	// Try to get from cache first
@@ -91,6 +97,24 @@ func (s *TransactionService) GetTransactionByWalletID(ctx context.Context, walle
 	return transactions, totalCount, nil
 }
 
+// GetTransactionsByStatus retrieves transactions in a status, newest first
+func (s *TransactionService) GetTransactionsByStatus(
+	ctx context.Context,
+	status domain.TransactionStatus,
+	limit, offset int,
+) ([]*domain.Transaction, error) {
+	if !status.IsValid() {
+		return nil, domain.ErrInvalidTransactionStatus
+	}
+
+	transactions, err := s.transactionRepo.FindByStatus(ctx, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transactions by status: %w", err)
+	}
+
+	return transactions, nil
+}
+
 // CreateTransaction creates a new transaction
 func (s *TransactionService) CreateTransaction(ctx context.Context, transaction *domain.Transaction) error {
 	// Validate transaction
@@ -143,7 +167,7 @@ func (s *TransactionService) CreateTransaction(ctx context.Context, transaction
 		}
 
 		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 			defer cancel()
 			_ = s.eventPublisher.Publish(ctx, "transactions", event)
 		}()
@@ -152,8 +176,18 @@ func (s *TransactionService) CreateTransaction(ctx context.Context, transaction
 	return nil
 }
 
-// UpdateTransactionStatus updates the status of a transaction
-func (s *TransactionService) UpdateTransactionStatus(ctx context.Context, transactionID int, status domain.TransactionStatus) error {
+// UpdateTransactionStatus overrides the status of a transaction on behalf of
+// an operator, see Transaction.ChangeStatus for the allowed transitions
+func (s *TransactionService) UpdateTransactionStatus(
+	ctx context.Context,
+	transactionID int,
+	status domain.TransactionStatus,
+	reason, actor string,
+) error {
+	if !status.IsValid() {
+		return domain.ErrInvalidTransactionStatus
+	}
+
 	// Get transaction
 	transaction, err := s.transactionRepo.FindByID(ctx, transactionID)
 	if err != nil {
@@ -164,16 +198,26 @@ func (s *TransactionService) UpdateTransactionStatus(ctx context.Context, transa
 		return ErrTransactionNotFound
 	}
 
-	// Update status
-	err = s.transactionRepo.UpdateStatus(ctx, transactionID, status)
-	if err != nil {
-		return fmt.Errorf("failed to update transaction status: %w", err)
+	oldStatus := transaction.Status
+	if err := transaction.ChangeStatus(status, reason, actor); err != nil {
+		return err
 	}
 
-	// Invalidate cache
-	if s.cache != nil {
-		cacheKey := fmt.Sprintf("transaction:%d", transactionID)
-		_ = s.cache.Delete(ctx, cacheKey)
+	// A fee is resolved with the transaction it was charged for
+	if transaction.Type == domain.TransactionTypeFee {
+		return fmt.Errorf("%w: fee transaction %d follows the transaction it was charged for",
+			domain.ErrInvalidTransactionStatusTransition, transactionID)
+	}
+
+	// Status, reason and actor are written together by failing it
+	if err := s.failByHand(ctx, transaction); err != nil {
+		return err
+	}
+
+	if s.feeService != nil {
+		if err := s.feeService.CancelReserved(ctx, transaction); err != nil {
+			return fmt.Errorf("transaction %d failed, but its fee is still reserved: %w", transactionID, err)
+		}
 	}
 
 	// Publish status update event
@@ -182,13 +226,15 @@ func (s *TransactionService) UpdateTransactionStatus(ctx context.Context, transa
 			Type: "transaction.status_updated",
 			Payload: map[string]interface{}{
 				"transaction_id": transactionID,
-				"old_status":     string(transaction.Status),
-				"new_status":     string(status),
+				"old_status":     string(oldStatus),
+				"new_status":     string(transaction.Status),
+				"reason":         reason,
+				"actor":          actor,
 			},
 		}
 
 		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 			defer cancel()
 			_ = s.eventPublisher.Publish(ctx, "transactions", event)
 		}()
@@ -197,22 +243,41 @@ func (s *TransactionService) UpdateTransactionStatus(ctx context.Context, transa
 	return nil
 }
 
-// ReconcileFailedTransactions attempts to fix failed transactions
-func (s *TransactionService) ReconcileFailedTransactions(ctx context.Context) error {
+// GetStalePendingTransactions retrieves the pending transactions that
+// ReconcileFailedTransactions would resolve
+func (s *TransactionService) GetStalePendingTransactions(ctx context.Context) ([]*domain.Transaction, error) {
 	// Find old pending transactions (older than 30 minutes)
 	cutoffTime := time.Now().Add(-30 * time.Minute)
 
 	pendingTransactions, err := s.transactionRepo.FindPendingTransactions(ctx, cutoffTime)
 	if err != nil {
-		return fmt.Errorf("failed to find pending transactions: %w", err)
+		return nil, fmt.Errorf("failed to find pending transactions: %w", err)
+	}
+
+	return pendingTransactions, nil
+}
+
+// ReconcileFailedTransactions resolves the stale pending transactions and
+// retries the fees that were charged but not credited
+func (s *TransactionService) ReconcileFailedTransactions(ctx context.Context) error {
+	pendingTransactions, err := s.GetStalePendingTransactions(ctx)
+	if err != nil {
+		return err
 	}
 
 	reconciled := 0
 	failed := 0
 
+	var feeTransactions []*domain.Transaction
 	for _, transaction := range pendingTransactions {
-		// Mark old pending transactions as failed
-		err := s.transactionRepo.UpdateStatus(ctx, transaction.ID, domain.TransactionStatusFailed)
+		// A pending fee is settled or cancelled with the transaction it
+		// belongs to, so it waits until that one is resolved
+		if transaction.Type == domain.TransactionTypeFee {
+			feeTransactions = append(feeTransactions, transaction)
+			continue
+		}
+
+		outcome, err := s.reconcileTransaction(ctx, transaction)
 		if err != nil {
 			failed++
 			continue
@@ -227,20 +292,210 @@ func (s *TransactionService) ReconcileFailedTransactions(ctx context.Context) er
 				Payload: map[string]interface{}{
 					"transaction_id": transaction.ID,
 					"reason":         "timeout",
+					"outcome":        outcome,
 				},
 			}
 
 			go func(txID int) {
-				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 				defer cancel()
 				_ = s.eventPublisher.Publish(ctx, "reconciliation", event)
 			}(transaction.ID)
 		}
 	}
 
+	for _, feeTransaction := range feeTransactions {
+		if s.feeService == nil {
+			continue
+		}
+
+		if err := s.feeService.Reconcile(ctx, feeTransaction); err != nil {
+			failed++
+		}
+	}
+
 	if failed > 0 {
 		return fmt.Errorf("reconciled %d transactions, but %d failed", reconciled, failed)
 	}
 
 	return nil
 }
+
+// reconcileTransaction resolves a stale pending transaction. Its status update
+// may have failed after the funds moved, so the wallet balances are compared
+// with their completed transactions first: a transaction whose funds moved is
+// completed, a transfer that charged the payer without crediting the payee is
+// refunded, and only one that moved nothing is failed. It returns the
+// resulting status
+func (s *TransactionService) reconcileTransaction(ctx context.Context, transaction *domain.Transaction) (domain.TransactionStatus, error) {
+	wallet, change, applied, err := s.appliedChange(ctx, transaction)
+	if err != nil {
+		return "", err
+	}
+
+	if !applied {
+		return domain.TransactionStatusFailed, s.failTransaction(ctx, transaction)
+	}
+
+	if transaction.Type == domain.TransactionTypeTransfer && transaction.ToWalletID != nil {
+		credited, err := s.transferCredited(ctx, transaction)
+		if err != nil {
+			return "", err
+		}
+
+		if !credited {
+			return domain.TransactionStatusFailed, s.refundTransaction(ctx, wallet, transaction, -change)
+		}
+	}
+
+	transaction.Complete()
+	if err := s.transactionRepo.Update(ctx, transaction); err != nil {
+		return "", fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	return domain.TransactionStatusCompleted, nil
+}
+
+// failByHand fails a pending transaction on an operator's request. A charge
+// it already took from its wallet is refunded, while funds it already credited
+// cannot be taken back and are left to reconciliation
+func (s *TransactionService) failByHand(ctx context.Context, transaction *domain.Transaction) error {
+	wallet, change, applied, err := s.appliedChange(ctx, transaction)
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		return s.failTransaction(ctx, transaction)
+	}
+
+	if change > 0 {
+		return fmt.Errorf("%w: transaction %d already credited wallet %d",
+			domain.ErrInvalidTransactionStatusTransition, transaction.ID, wallet.ID)
+	}
+
+	if transaction.Type == domain.TransactionTypeTransfer && transaction.ToWalletID != nil {
+		credited, err := s.transferCredited(ctx, transaction)
+		if err != nil {
+			return err
+		}
+
+		if credited {
+			return fmt.Errorf("%w: transaction %d already credited wallet %d",
+				domain.ErrInvalidTransactionStatusTransition, transaction.ID, *transaction.ToWalletID)
+		}
+	}
+
+	return s.refundTransaction(ctx, wallet, transaction, -change)
+}
+
+// appliedChange returns the wallet of a pending transaction and the change
+// completing it would record there, and reports whether the balance already
+// holds that change
+func (s *TransactionService) appliedChange(ctx context.Context, transaction *domain.Transaction) (*domain.Wallet, int, bool, error) {
+	wallet, err := s.walletRepo.FindByID(ctx, transaction.WalletID)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to find wallet: %w", err)
+	}
+
+	if wallet == nil {
+		return nil, 0, false, ErrWalletNotFound
+	}
+
+	unrecorded, err := s.unrecordedChange(ctx, wallet)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	// What completing the transaction would record for its wallet
+	completed := *transaction
+	completed.Status = domain.TransactionStatusCompleted
+	change := completed.BalanceChangeFor(wallet.ID)
+
+	switch unrecorded {
+	case 0:
+		return wallet, change, false, nil
+	case change:
+		return wallet, change, true, nil
+	default:
+		return nil, 0, false, fmt.Errorf("balance of wallet %d does not match its transactions, transaction %d needs a manual review",
+			wallet.ID, transaction.ID)
+	}
+}
+
+// transferCredited reports whether the payee of a pending transfer was credited
+func (s *TransactionService) transferCredited(ctx context.Context, transaction *domain.Transaction) (bool, error) {
+	toWallet, err := s.walletRepo.FindByID(ctx, *transaction.ToWalletID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find destination wallet: %w", err)
+	}
+
+	if toWallet == nil {
+		return false, ErrWalletNotFound
+	}
+
+	unrecorded, err := s.unrecordedChange(ctx, toWallet)
+	if err != nil {
+		return false, err
+	}
+
+	switch unrecorded {
+	case 0:
+		return false, nil
+	case transaction.Amount:
+		return true, nil
+	default:
+		return false, fmt.Errorf("balance of wallet %d does not match its transactions, transaction %d needs a manual review",
+			toWallet.ID, transaction.ID)
+	}
+}
+
+// unrecordedChange returns how much the balance of a wallet moved without a
+// completed transaction recording it
+func (s *TransactionService) unrecordedChange(ctx context.Context, wallet *domain.Wallet) (int, error) {
+	recorded, err := s.transactionRepo.SumBalanceChanges(ctx, domain.TransactionQuery{
+		WalletID: wallet.ID,
+		Filter: domain.TransactionFilter{
+			Statuses: []domain.TransactionStatus{domain.TransactionStatusCompleted},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum balance changes: %w", err)
+	}
+
+	return wallet.Balance - recorded, nil
+}
+
+// refundTransaction pays a charge back to the wallet of a transaction that
+// did not go through and fails it
+func (s *TransactionService) refundTransaction(ctx context.Context, wallet *domain.Wallet, transaction *domain.Transaction, charge int) error {
+	if err := wallet.Credit(charge); err != nil {
+		return fmt.Errorf("failed to refund wallet: %w", err)
+	}
+
+	if err := s.walletRepo.Save(ctx, wallet); err != nil {
+		return fmt.Errorf("failed to refund wallet: %w", err)
+	}
+
+	// Invalidate cache
+	if s.cache != nil {
+		_ = s.cache.Delete(ctx, fmt.Sprintf("wallet:%d", wallet.ID))
+	}
+
+	return s.failTransaction(ctx, transaction)
+}
+
+// failTransaction marks a transaction that moved no funds as failed
+func (s *TransactionService) failTransaction(ctx context.Context, transaction *domain.Transaction) error {
+	transaction.Fail()
+	if err := s.transactionRepo.Update(ctx, transaction); err != nil {
+		return fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	// Invalidate cache
+	if s.cache != nil {
+		_ = s.cache.Delete(ctx, fmt.Sprintf("transaction:%d", transaction.ID))
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 var (
 	ErrEmailAlreadyExists = errors.New("email already exists")
 	ErrPhoneAlreadyExists = errors.New("phone already exists")
+	ErrHandleAlreadyTaken = errors.New("handle already taken")
 )
 
 // UserService implements the user application service
@@ -138,7 +139,7 @@ func (s *UserService) CreateUser(ctx context.Context, fullname, email, phone str
 		}
 
 		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 			defer cancel()
 			_ = s.eventPublisher.Publish(ctx, "users", event)
 		}()
@@ -213,7 +214,67 @@ func (s *UserService) UpdateUser(ctx context.Context, id int, fullname, email, p
 		}
 
 		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			defer cancel()
+			_ = s.eventPublisher.Publish(ctx, "users", event)
+		}()
+	}
+
+	return user, nil
+}
+
+// SetHandle claims a unique public handle for a user
+func (s *UserService) SetHandle(ctx context.Context, id int, handle string) (*domain.User, error) {
+	// Get existing user
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := user.SetHandle(handle); err != nil {
+		return nil, err
+	}
+
+	// Check if the handle is already taken by someone else
+	existingUser, err := s.userRepo.FindByHandle(ctx, user.Handle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing handle: %w", err)
+	}
+
+	if existingUser != nil && existingUser.ID != id {
+		return nil, ErrHandleAlreadyTaken
+	}
+
+	// Save changes
+	err = s.userRepo.Save(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user handle: %w", err)
+	}
+
+	// Invalidate cache
+	if s.cache != nil {
+		cacheKey := fmt.Sprintf("user:%d", id)
+		_ = s.cache.Delete(ctx, cacheKey)
+	}
+
+	// Publish user updated event
+	if s.eventPublisher != nil {
+		event := infrastructure.Event{
+			Type: "user.updated",
+			Payload: map[string]interface{}{
+				"user_id":  user.ID,
+				"email":    user.Email,
+				"fullname": user.Fullname,
+				"handle":   user.Handle,
+			},
+		}
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 			defer cancel()
 			_ = s.eventPublisher.Publish(ctx, "users", event)
 		}()
@@ -260,7 +321,7 @@ func (s *UserService) DeactiveUser(ctx context.Context, id int) error {
 		}
 
 		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 			defer cancel()
 			_ = s.eventPublisher.Publish(ctx, "users", event)
 		}()
@@ -307,7 +368,7 @@ func (s *UserService) ActivateUser(ctx context.Context, id int) error {
 		}
 
 		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 			defer cancel()
 			_ = s.eventPublisher.Publish(ctx, "users", event)
 		}()
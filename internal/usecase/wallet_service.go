@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"ports-and-adapters-architecture/internal/domain"
 	"ports-and-adapters-architecture/internal/ports"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/external"
 	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
 	"ports-and-adapters-architecture/internal/ports/secondary/persistence"
 	"time"
@@ -20,6 +22,8 @@ var (
 	ErrTransferFailed      = errors.New("transfer failed")
 	ErrWalletAlreadyExists = errors.New("wallet already exists for this user and currency")
 	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrSweepWalletRequired = errors.New("a sweep wallet is required to close a wallet with funds")
+	ErrInvalidSweepWallet  = errors.New("sweep wallet must be another wallet in the same currency")
 )
 
 // WalletService defines the application logic for wallet operations
@@ -29,6 +33,9 @@ type WalletService struct {
 	transactionRepo ports.TransactionRepository
 	eventPublisher  ports.EventPublisher
 	cache           ports.Cache
+	riskService     *RiskService
+	feeService      *FeeService
+	logger          infrastructure.Logger
 }
 
 // NewWalletService creates a new wallet service
@@ -45,6 +52,68 @@ func NewWalletService(
 		transactionRepo: transactionRepo,
 		eventPublisher:  eventPublisher,
 		cache:           cache,
+		logger:          infrastructure.NopLogger{},
+	}
+}
+
+// SetLogger reports fees that could not be credited to the revenue wallet
+func (s *WalletService) SetLogger(logger infrastructure.Logger) {
+	s.logger = logger
+}
+
+// SetRiskService enables synchronous risk checks on outbound operations
+func (s *WalletService) SetRiskService(riskService *RiskService) {
+	s.riskService = riskService
+}
+
+// SetFeeService enables fee charging on outbound operations
+func (s *WalletService) SetFeeService(feeService *FeeService) {
+	s.feeService = feeService
+}
+
+// quoteFee returns the fee of an outbound operation, zero when fees are disabled
+func (s *WalletService) quoteFee(txType domain.TransactionType, wallet *domain.Wallet, amount int) (int, error) {
+	if s.feeService == nil {
+		return 0, nil
+	}
+
+	quote, err := s.feeService.Calculate(txType, wallet.CurrencyCode, "", amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return quote.Fee, nil
+}
+
+// reserveFee records the fee of an outbound operation before the payer is charged
+func (s *WalletService) reserveFee(ctx context.Context, wallet *domain.Wallet, transaction *domain.Transaction) (*domain.Transaction, error) {
+	if s.feeService == nil || transaction.Fee == 0 {
+		return nil, nil
+	}
+
+	return s.feeService.Reserve(ctx, wallet, transaction)
+}
+
+// settleFee moves an already debited fee to the revenue wallet. The payer has
+// been charged, a failure leaves the fee PENDING for ReconcileFailedTransactions
+func (s *WalletService) settleFee(ctx context.Context, feeTransaction *domain.Transaction) {
+	if feeTransaction == nil {
+		return
+	}
+
+	if err := s.feeService.Settle(ctx, feeTransaction); err != nil {
+		s.logger.Error(ctx, "failed to settle fee", "transaction_id", feeTransaction.ID, "error", err)
+	}
+}
+
+// cancelFee fails the reserved fee of an operation that did not go through
+func (s *WalletService) cancelFee(ctx context.Context, feeTransaction *domain.Transaction) {
+	if feeTransaction == nil {
+		return
+	}
+
+	if err := s.feeService.Cancel(ctx, feeTransaction); err != nil {
+		s.logger.Error(ctx, "failed to cancel fee", "transaction_id", feeTransaction.ID, "error", err)
 	}
 }
 
@@ -91,7 +160,7 @@ func (s *WalletService) CreateWallet(ctx context.Context, userID int, currencyCo
 
 	// Non-blocking event publishing
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 		defer cancel()
 
 		if s.eventPublisher != nil {
@@ -154,22 +223,53 @@ func (s *WalletService) GetWalletsByUserID(ctx context.Context, userID int) ([]*
 	return wallets, nil
 }
 
-// UpdateWalletStatus updates the status of a wallet
-func (s *WalletService) UpdateWalletStatus(ctx context.Context, walletID int, status domain.WalletStatus) error {
-	// Get the wallet first to verify it exists
+// FreezeWallet blocks outbound funds of a wallet, inbound funds are still accepted
+func (s *WalletService) FreezeWallet(
+	ctx context.Context,
+	walletID int,
+	reason domain.WalletStatusReason,
+	actor string,
+) (*domain.Wallet, error) {
+	return s.changeWalletStatus(ctx, walletID, "wallet.frozen", func(wallet *domain.Wallet) error {
+		return wallet.Freeze(reason, actor)
+	})
+}
+
+// UnfreezeWallet makes a frozen wallet active again
+func (s *WalletService) UnfreezeWallet(
+	ctx context.Context,
+	walletID int,
+	reason domain.WalletStatusReason,
+	actor string,
+) (*domain.Wallet, error) {
+	return s.changeWalletStatus(ctx, walletID, "wallet.unfrozen", func(wallet *domain.Wallet) error {
+		return wallet.Unfreeze(reason, actor)
+	})
+}
+
+// changeWalletStatus applies a status transition to a wallet and publishes it
+func (s *WalletService) changeWalletStatus(
+	ctx context.Context,
+	walletID int,
+	eventType string,
+	transition func(wallet *domain.Wallet) error,
+) (*domain.Wallet, error) {
 	wallet, err := s.walletRepo.FindByID(ctx, walletID)
 	if err != nil {
-		return fmt.Errorf("failed to find wallet: %w", err)
+		return nil, fmt.Errorf("failed to find wallet: %w", err)
 	}
 
 	if wallet == nil {
-		return ErrWalletNotFound
+		return nil, ErrWalletNotFound
 	}
 
-	// Update the status
-	err = s.walletRepo.UpdateStatus(ctx, walletID, status)
+	if err := transition(wallet); err != nil {
+		return nil, err
+	}
+
+	err = s.walletRepo.Save(ctx, wallet)
 	if err != nil {
-		return fmt.Errorf("failed to update wallet status: %w", err)
+		return nil, fmt.Errorf("failed to update wallet status: %w", err)
 	}
 
 	// Invalidate cache
@@ -178,26 +278,181 @@ func (s *WalletService) UpdateWalletStatus(ctx context.Context, walletID int, st
 		_ = s.cache.Delete(ctx, cacheKey)
 	}
 
-	// Publish wallet status updated event
+	s.publishWalletStatusEvent(ctx, eventType, wallet, nil)
+
+	return wallet, nil
+}
+
+// CloseWallet sweeps the remaining balance of a wallet to another wallet of the
+// same currency and closes it for good
+func (s *WalletService) CloseWallet(ctx context.Context, req primary.CloseWalletRequest) (*domain.Wallet, error) {
+	// Validate up front so a sweep is never followed by a rejected close
+	if !req.Reason.IsValid() {
+		return nil, domain.ErrInvalidWalletStatusReason
+	}
+
+	if req.Actor == "" {
+		return nil, domain.ErrWalletStatusActorRequired
+	}
+
+	wallet, err := s.walletRepo.FindByID(ctx, req.WalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wallet: %w", err)
+	}
+
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	if wallet.Status == domain.WalletStatusClosed {
+		return nil, domain.ErrWalletClosed
+	}
+
+	var sweep *domain.Transaction
+	if wallet.Balance > 0 {
+		sweep, err = s.sweepWallet(ctx, wallet, req)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := wallet.Close(req.Reason, req.Actor); err != nil {
+			return nil, err
+		}
+
+		if err := s.walletRepo.Save(ctx, wallet); err != nil {
+			return nil, fmt.Errorf("failed to close wallet: %w", err)
+		}
+	}
+
+	// Invalidate cache
+	if s.cache != nil {
+		_ = s.cache.Delete(ctx, fmt.Sprintf("wallet:%d", wallet.ID))
+		if sweep != nil {
+			_ = s.cache.Delete(ctx, fmt.Sprintf("wallet:%d", req.SweepToWalletID))
+		}
+	}
+
+	s.publishWalletStatusEvent(ctx, "wallet.closed", wallet, sweep)
+
+	return wallet, nil
+}
+
+// sweepWallet moves the whole balance of a wallet to the nominated wallet and
+// closes it. Sweeps are not charged and skip risk checks
+func (s *WalletService) sweepWallet(ctx context.Context, wallet *domain.Wallet, req primary.CloseWalletRequest) (*domain.Transaction, error) {
+	if req.SweepToWalletID == 0 {
+		return nil, ErrSweepWalletRequired
+	}
+
+	if req.SweepToWalletID == wallet.ID {
+		return nil, ErrInvalidSweepWallet
+	}
+
+	target, err := s.walletRepo.FindByID(ctx, req.SweepToWalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sweep wallet: %w", err)
+	}
+
+	if target == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	if target.CurrencyCode != wallet.CurrencyCode {
+		return nil, ErrInvalidSweepWallet
+	}
+
+	if err := target.CheckCanReceive(); err != nil {
+		return nil, err
+	}
+
+	original := *wallet
+
+	amount, err := wallet.SweepBalance()
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := domain.NewTransferTransaction(wallet.ID, target.ID, amount,
+		fmt.Sprintf("Balance sweep on closing wallet %d", wallet.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.Reference = fmt.Sprintf("SWEEP-%d", wallet.ID)
+
+	err = s.transactionRepo.Create(ctx, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	err = target.Credit(amount)
+	if err == nil {
+		err = wallet.Close(req.Reason, req.Actor)
+	}
+
+	if err == nil {
+		err = s.walletRepo.Save(ctx, wallet)
+	}
+
+	if err != nil {
+		*wallet = original
+		transaction.Fail()
+		_ = s.transactionRepo.Update(ctx, transaction)
+		return nil, err
+	}
+
+	err = s.walletRepo.Save(ctx, target)
+	if err != nil {
+		// Reopen the wallet with its balance, the sweep did not happen
+		*wallet = original
+		_ = s.walletRepo.Save(ctx, wallet)
+
+		transaction.Fail()
+		_ = s.transactionRepo.Update(ctx, transaction)
+		return nil, fmt.Errorf("failed to update sweep wallet: %w", err)
+	}
+
+	transaction.Complete()
+	err = s.transactionRepo.Update(ctx, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// publishWalletStatusEvent publishes a freeze, unfreeze or close of a wallet
+func (s *WalletService) publishWalletStatusEvent(ctx context.Context, eventType string, wallet *domain.Wallet, sweep *domain.Transaction) {
+	if s.eventPublisher == nil {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"wallet_id": wallet.ID,
+		"user_id":   wallet.UserID,
+		"status":    string(wallet.Status),
+		"reason":    string(wallet.StatusReason),
+		"actor":     wallet.StatusActor,
+	}
+
+	if sweep != nil {
+		payload["swept_amount"] = sweep.Amount
+		payload["sweep_to_wallet_id"] = *sweep.ToWalletID
+		payload["sweep_transaction_id"] = sweep.ID
+	}
+
 	event := infrastructure.Event{
-		Type: "wallet.status_updated",
-		Payload: map[string]interface{}{
-			"wallet_id": walletID,
-			"status":    string(status),
-		},
+		Type:    eventType,
+		Payload: payload,
 	}
 
 	// Non-blocking event publishing
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 		defer cancel()
 
-		if s.eventPublisher != nil {
-			_ = s.eventPublisher.Publish(ctx, "wallets", event)
-		}
+		_ = s.eventPublisher.Publish(ctx, "wallets", event)
 	}()
-
-	return nil
 }
 
 // Deposit adds funds to a wallet
@@ -216,9 +471,9 @@ func (s *WalletService) Deposit(ctx context.Context, walletID int, amount int, d
 		return nil, ErrWalletNotFound
 	}
 
-	// Ensure wallet is active
-	if !wallet.IsActive() {
-		return nil, domain.ErrWalletNotActive
+	// Ensure wallet accepts inbound funds
+	if err := wallet.CheckCanReceive(); err != nil {
+		return nil, err
 	}
 
 	// Create pending transaction
@@ -241,6 +496,7 @@ func (s *WalletService) Deposit(ctx context.Context, walletID int, amount int, d
 		// Mark transaction as failed
 		transaction.Fail()
 		_ = s.transactionRepo.Update(ctx, transaction)
+		return nil, err
 	}
 
 	// Update wallet in database
@@ -278,7 +534,7 @@ func (s *WalletService) Deposit(ctx context.Context, walletID int, amount int, d
 
 	// Non-blocking event publishing
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 		defer cancel()
 
 		if s.eventPublisher != nil {
@@ -305,11 +561,36 @@ func (s *WalletService) Withdraw(ctx context.Context, walletID int, amount int,
 		return nil, ErrWalletNotFound
 	}
 
-	// Check if wallet has sufficient balance
-	if wallet.Balance < amount {
+	// Ensure wallet can send funds
+	if err := wallet.CheckCanSend(); err != nil {
+		return nil, err
+	}
+
+	fee, err := s.quoteFee(domain.TransactionTypeWithdrawal, wallet, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if wallet has sufficient balance to cover the amount and the fee
+	if wallet.Balance < amount+fee {
 		return nil, ErrInsufficientBalance
 	}
 
+	// Run risk checks before any funds move
+	var assessment *domain.RiskAssessment
+	if s.riskService != nil {
+		assessment, err = s.riskService.Assess(ctx, external.RiskCheckRequest{
+			WalletID:        wallet.ID,
+			UserID:          wallet.UserID,
+			TransactionType: domain.TransactionTypeWithdrawal,
+			Amount:          amount,
+			Currency:        wallet.CurrencyCode,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create pending transaction
 	transaction, err := domain.NewTransaction(walletID, domain.TransactionTypeWithdrawal, amount, description)
 	if err != nil {
@@ -317,6 +598,7 @@ func (s *WalletService) Withdraw(ctx context.Context, walletID int, amount int,
 	}
 
 	transaction.Status = domain.TransactionStatusPending
+	transaction.Fee = fee
 
 	// Save the transaction
 	err = s.transactionRepo.Create(ctx, transaction)
@@ -324,12 +606,30 @@ func (s *WalletService) Withdraw(ctx context.Context, walletID int, amount int,
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
+	// Keep the audit trail pointing at the transaction it cleared. A REVIEW
+	// decision is advisory, the operation goes on and waits in the review queue
+	if s.riskService != nil {
+		if err := s.riskService.LinkTransaction(ctx, assessment, transaction.ID); err != nil {
+			s.logger.Error(ctx, "failed to link risk assessment", "transaction_id", transaction.ID, "error", err)
+		}
+	}
+
+	// Record the fee before the payer is charged so it can always be reconciled
+	feeTransaction, err := s.reserveFee(ctx, wallet, transaction)
+	if err != nil {
+		transaction.Fail()
+		_ = s.transactionRepo.Update(ctx, transaction)
+
+		return nil, err
+	}
+
 	// Debit the wallet (will perform additional validation)
-	err = wallet.Debit(amount)
+	err = wallet.Debit(amount + fee)
 	if err != nil {
 		// Mark transaction as failed if debit fails
 		transaction.Fail()
 		_ = s.transactionRepo.Update(ctx, transaction)
+		s.cancelFee(ctx, feeTransaction)
 
 		return nil, err
 	}
@@ -340,6 +640,7 @@ func (s *WalletService) Withdraw(ctx context.Context, walletID int, amount int,
 		// Mark transaction as failed if wallet update fails
 		transaction.Fail()
 		_ = s.transactionRepo.Update(ctx, transaction)
+		s.cancelFee(ctx, feeTransaction)
 
 		return nil, fmt.Errorf("failed to update wallet balance: %w", err)
 	}
@@ -351,6 +652,8 @@ func (s *WalletService) Withdraw(ctx context.Context, walletID int, amount int,
 		return nil, fmt.Errorf("failed to update transaction: %w", err)
 	}
 
+	s.settleFee(ctx, feeTransaction)
+
 	// Invalidate cache
 	if s.cache != nil {
 		cacheKey := fmt.Sprintf("wallet:%d", walletID)
@@ -364,13 +667,14 @@ func (s *WalletService) Withdraw(ctx context.Context, walletID int, amount int,
 			"wallet_id":      wallet.ID,
 			"transaction_id": transaction.ID,
 			"amount":         amount,
+			"fee":            fee,
 			"new_balance":    wallet.Balance,
 		},
 	}
 
 	// Non-blocking event publishing
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 		defer cancel()
 
 		if s.eventPublisher != nil {
@@ -383,6 +687,19 @@ func (s *WalletService) Withdraw(ctx context.Context, walletID int, amount int,
 
 // Transfer transfers funds from one wallet to another
 func (s *WalletService) Transfer(ctx context.Context, fromWalletID int, toWalletID int, amount int, description string) (*domain.Transaction, error) {
+	return s.TransferWithReference(ctx, fromWalletID, toWalletID, amount, description, "")
+}
+
+// TransferWithReference transfers funds from one wallet to another and tags the
+// transaction with reference, so a caller interrupted part way can find it
+func (s *WalletService) TransferWithReference(
+	ctx context.Context,
+	fromWalletID int,
+	toWalletID int,
+	amount int,
+	description string,
+	reference string,
+) (*domain.Transaction, error) {
 	if amount <= 0 {
 		return nil, ErrInvalidAmount
 	}
@@ -407,29 +724,85 @@ func (s *WalletService) Transfer(ctx context.Context, fromWalletID int, toWallet
 		return nil, ErrWalletNotFound
 	}
 
+	// Frozen wallets can receive but not send, closed wallets can do neither
+	if err := fromWallet.CheckCanSend(); err != nil {
+		return nil, err
+	}
+
+	if err := toWallet.CheckCanReceive(); err != nil {
+		return nil, err
+	}
+
 	// Check if wallets have the same currency
 	if fromWallet.CurrencyCode != toWallet.CurrencyCode {
 		return nil, errors.New("cannot transfer between wallets with different currencies")
 	}
 
+	fee, err := s.quoteFee(domain.TransactionTypeTransfer, fromWallet, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if source wallet has sufficient balance to cover the amount and the fee
+	if fromWallet.Balance < amount+fee {
+		return nil, ErrInsufficientBalance
+	}
+
+	// Run risk checks before any funds move
+	var assessment *domain.RiskAssessment
+	if s.riskService != nil {
+		assessment, err = s.riskService.Assess(ctx, external.RiskCheckRequest{
+			WalletID:        fromWallet.ID,
+			UserID:          fromWallet.UserID,
+			TransactionType: domain.TransactionTypeTransfer,
+			Amount:          amount,
+			Currency:        fromWallet.CurrencyCode,
+			ToWalletID:      &toWalletID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create transfer transaction
 	transaction, err := domain.NewTransferTransaction(fromWalletID, toWalletID, amount, description)
 	if err != nil {
 		return nil, err
 	}
 
+	transaction.Fee = fee
+	transaction.Reference = reference
+
 	// Save the transaction
 	err = s.transactionRepo.Create(ctx, transaction)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
+	// Keep the audit trail pointing at the transaction it cleared. A REVIEW
+	// decision is advisory, the operation goes on and waits in the review queue
+	if s.riskService != nil {
+		if err := s.riskService.LinkTransaction(ctx, assessment, transaction.ID); err != nil {
+			s.logger.Error(ctx, "failed to link risk assessment", "transaction_id", transaction.ID, "error", err)
+		}
+	}
+
+	// Record the fee before the payer is charged so it can always be reconciled
+	feeTransaction, err := s.reserveFee(ctx, fromWallet, transaction)
+	if err != nil {
+		transaction.Fail()
+		_ = s.transactionRepo.Update(ctx, transaction)
+
+		return nil, err
+	}
+
 	// Debit from source wallet
-	err = fromWallet.Debit(amount)
+	err = fromWallet.Debit(amount + fee)
 	if err != nil {
 		// Mark transaction as failed if debit fails
 		transaction.Fail()
 		_ = s.transactionRepo.Update(ctx, transaction)
+		s.cancelFee(ctx, feeTransaction)
 
 		return nil, err
 	}
@@ -440,6 +813,7 @@ func (s *WalletService) Transfer(ctx context.Context, fromWalletID int, toWallet
 		// Mark transaction as failed if credit fails
 		transaction.Fail()
 		_ = s.transactionRepo.Updated(ctx, transaction)
+		s.cancelFee(ctx, feeTransaction)
 		return nil, err
 	}
 
@@ -449,6 +823,7 @@ func (s *WalletService) Transfer(ctx context.Context, fromWalletID int, toWallet
 		// Mark transaction as failed if source wallet update fails
 		transaction.Fail()
 		_ = s.transactionRepo.Update(ctx, transaction)
+		s.cancelFee(ctx, feeTransaction)
 		return nil, fmt.Errorf("failed to update source wallet: %w", err)
 	}
 
@@ -458,9 +833,10 @@ func (s *WalletService) Transfer(ctx context.Context, fromWalletID int, toWallet
 		// In a real system, this would require more sophisticated recovery
 		transaction.Fail()
 		_ = s.transactionRepo.Update(ctx, transaction)
+		s.cancelFee(ctx, feeTransaction)
 
 		// Try to refund the source wallet
-		fromWallet.Credit(amount)
+		fromWallet.Credit(amount + fee)
 		_ = s.walletRepo.Save(ctx, fromWallet)
 
 		return nil, fmt.Errorf("failed to update destination wallet: %w", err)
@@ -473,6 +849,8 @@ func (s *WalletService) Transfer(ctx context.Context, fromWalletID int, toWallet
 		return nil, fmt.Errorf("failed to update transaction status: %w", err)
 	}
 
+	s.settleFee(ctx, feeTransaction)
+
 	// Invalidate cache for both wallets
 	if s.cache != nil {
 		_ = s.cache.Delete(ctx, fmt.Sprintf("wallet:%d", fromWalletID))
@@ -487,6 +865,7 @@ func (s *WalletService) Transfer(ctx context.Context, fromWalletID int, toWallet
 			"from_wallet_id": fromWalletID,
 			"to_wallet_id":   toWalletID,
 			"amount":         amount,
+			"fee":            fee,
 			"from_balance":   fromWallet.Balance,
 			"to_balance":     toWallet.Balance,
 		},
@@ -494,7 +873,7 @@ func (s *WalletService) Transfer(ctx context.Context, fromWalletID int, toWallet
 
 	// Non-blocking event publishing
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 		defer cancel()
 
 		if s.eventPublisher != nil {
@@ -536,6 +915,71 @@ func (s *WalletService) GetTransactionHistory(
 	return transactions, totalCount, nil
 }
 
+// GetTransactionHistoryPage retrieves a filtered page of transaction history for a
+// wallet. Cursor pages are read by keyset, so rows arriving meanwhile do not shift them
+func (s *WalletService) GetTransactionHistoryPage(
+	ctx context.Context,
+	walletID int,
+	query primary.TransactionHistoryQuery,
+) (*domain.TransactionPage, error) {
+	wallet, err := s.walletRepo.FindByID(ctx, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wallet: %w", err)
+	}
+
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	if err := query.Filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = 20
+	}
+
+	// One extra row tells whether another page follows
+	repoQuery := domain.TransactionQuery{
+		WalletID: walletID,
+		Filter:   query.Filter,
+		Limit:    query.Limit + 1,
+		Offset:   query.Offset,
+	}
+
+	if query.Cursor != "" {
+		repoQuery.After, err = domain.DecodeTransactionCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transactions, err := s.transactionRepo.FindByQuery(ctx, repoQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transactions: %w", err)
+	}
+
+	page := &domain.TransactionPage{Transactions: transactions}
+	if len(transactions) > query.Limit {
+		page.Transactions = transactions[:query.Limit]
+		page.NextCursor = domain.CursorAfter(page.Transactions[query.Limit-1]).Encode()
+	}
+
+	if page.Transactions == nil {
+		page.Transactions = []*domain.Transaction{}
+	}
+
+	if query.IncludeTotal {
+		total, err := s.transactionRepo.CountByQuery(ctx, repoQuery)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count transactions: %w", err)
+		}
+		page.Total = &total
+	}
+
+	return page, nil
+}
+
 // GetBalance gets the current balance of a wallet
 func (s *WalletService) GetBalance(ctx context.Context, walletID int) (int, string, error) {
 	wallet, err := s.GetWallet(ctx, walletID)
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS risk_assessments;
\ No newline at end of file
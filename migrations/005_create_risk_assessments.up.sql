@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS risk_assessments (
+    id SERIAL PRIMARY KEY,
+    wallet_id INTEGER NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL,
+    transaction_type VARCHAR(20) NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    to_wallet_id INTEGER REFERENCES wallets(id) ON DELETE SET NULL,
+    decision VARCHAR(20) NOT NULL,
+    rule_hits JSONB NOT NULL DEFAULT '[]',
+    review_status VARCHAR(20) NOT NULL DEFAULT 'NONE',
+    reviewed_by VARCHAR(255),
+    review_note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMP
+);
+
+CREATE INDEX idx_risk_assessments_wallet_id ON risk_assessments(wallet_id);
+CREATE INDEX idx_risk_assessments_transaction_id ON risk_assessments(transaction_id);
+CREATE INDEX idx_risk_assessments_decision ON risk_assessments(decision);
+CREATE INDEX idx_risk_assessments_review_status ON risk_assessments(review_status, created_at);
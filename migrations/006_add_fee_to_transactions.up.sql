@@ -0,0 +1,3 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fee INTEGER NOT NULL DEFAULT 0 CHECK (fee >= 0);
+
+CREATE INDEX idx_transactions_reference ON transactions(reference);
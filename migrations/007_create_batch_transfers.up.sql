@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS batch_transfers (
+    id SERIAL PRIMARY KEY,
+    from_wallet_id INTEGER NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    mode VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    description TEXT,
+    total_amount BIGINT NOT NULL CHECK (total_amount > 0),
+    succeeded_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    items JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_batch_transfers_from_wallet_id ON batch_transfers(from_wallet_id, created_at);
+CREATE INDEX idx_batch_transfers_status ON batch_transfers(status);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_handle;
+
+ALTER TABLE users DROP COLUMN IF EXISTS handle;
\ No newline at end of file
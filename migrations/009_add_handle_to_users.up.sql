@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS handle VARCHAR(30);
+
+-- Handles are stored lowercased, users without a handle keep it NULL
+CREATE UNIQUE INDEX idx_users_handle ON users(handle);
\ No newline at end of file
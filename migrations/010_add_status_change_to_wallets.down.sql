@@ -0,0 +1,3 @@
+ALTER TABLE wallets DROP COLUMN IF EXISTS status_changed_at;
+ALTER TABLE wallets DROP COLUMN IF EXISTS status_actor;
+ALTER TABLE wallets DROP COLUMN IF EXISTS status_reason;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS status_reason VARCHAR(50);
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS status_actor VARCHAR(255);
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_to_wallet_id_created_at;
+DROP INDEX IF EXISTS idx_transactions_wallet_id_created_at;
\ No newline at end of file
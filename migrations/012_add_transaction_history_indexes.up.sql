@@ -0,0 +1,3 @@
+-- Keyset pagination of wallet history walks these in (created_at, id) order
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_id_created_at ON transactions(wallet_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_to_wallet_id_created_at ON transactions(to_wallet_id, created_at DESC, id DESC);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_wallet_id_amount;
+DROP INDEX IF EXISTS idx_transactions_wallet_id_status_created_at;
+DROP INDEX IF EXISTS idx_transactions_wallet_id_type_created_at;
+DROP INDEX IF EXISTS idx_transactions_description_trgm;
\ No newline at end of file
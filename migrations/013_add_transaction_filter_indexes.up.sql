@@ -0,0 +1,8 @@
+-- Description search matches anywhere in the text, which needs trigrams. The
+-- pg_trgm extension is created by scripts/init-db.sql, see RUNBOOK.md
+CREATE INDEX IF NOT EXISTS idx_transactions_description_trgm ON transactions USING GIN (description gin_trgm_ops);
+
+-- History filters narrow a wallet by type, status or amount before ordering by date
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_id_type_created_at ON transactions(wallet_id, type, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_id_status_created_at ON transactions(wallet_id, status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_id_amount ON transactions(wallet_id, amount);
\ No newline at end of file
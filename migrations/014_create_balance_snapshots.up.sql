@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+    id SERIAL PRIMARY KEY,
+    wallet_id INTEGER NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    balance INTEGER NOT NULL,
+    currency_code VARCHAR(3) NOT NULL,
+    taken_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT balance_snapshots_wallet_day UNIQUE (wallet_id, taken_at)
+);
\ No newline at end of file
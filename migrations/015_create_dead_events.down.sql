@@ -0,0 +1 @@
+DROP TABLE IF EXISTS dead_events;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS dead_events (
+    id SERIAL PRIMARY KEY,
+    topic VARCHAR(100) NOT NULL,
+    event_id VARCHAR(100),
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    event_time BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL,
+    failed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    redriven_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_events_pending ON dead_events(id) WHERE redriven_at IS NULL;
\ No newline at end of file
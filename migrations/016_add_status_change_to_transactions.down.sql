@@ -0,0 +1,2 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS status_actor;
+ALTER TABLE transactions DROP COLUMN IF EXISTS status_reason;
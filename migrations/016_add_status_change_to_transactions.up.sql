@@ -0,0 +1,2 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS status_reason VARCHAR(255);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS status_actor VARCHAR(255);
@@ -0,0 +1,12 @@
+// Package migrations embeds the versioned Postgres schema migrations, so the
+// binary can apply them without the files on disk.
+//
+// Each version has a NNN_name.up.sql file and a NNN_name.down.sql file undoing it.
+package migrations
+
+import "embed"
+
+// FS holds the migration files
+//
+//go:embed *.sql
+var FS embed.FS
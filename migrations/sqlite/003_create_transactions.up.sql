@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS transactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    wallet_id INTEGER NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    fee INTEGER NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    reference TEXT,
+    description TEXT,
+    to_wallet_id INTEGER REFERENCES wallets(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+-- Keyset pagination of wallet history walks these in (created_at, id) order
+CREATE INDEX idx_transactions_wallet_id_created_at ON transactions(wallet_id, created_at DESC, id DESC);
+CREATE INDEX idx_transactions_to_wallet_id_created_at ON transactions(to_wallet_id, created_at DESC, id DESC);
+CREATE INDEX idx_transactions_status ON transactions(status);
+CREATE INDEX idx_transactions_reference ON transactions(reference);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS payments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    provider TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    external_id TEXT,
+    payment_url TEXT,
+    description TEXT,
+    -- JSON text, SQLite has no JSONB column type
+    details TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_payments_transaction_id ON payments(transaction_id);
+CREATE INDEX idx_payments_status ON payments(status);
+CREATE INDEX idx_payments_external_id ON payments(external_id);
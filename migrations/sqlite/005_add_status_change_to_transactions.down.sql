@@ -0,0 +1,2 @@
+ALTER TABLE transactions DROP COLUMN status_actor;
+ALTER TABLE transactions DROP COLUMN status_reason;
@@ -0,0 +1,2 @@
+ALTER TABLE transactions ADD COLUMN status_reason TEXT;
+ALTER TABLE transactions ADD COLUMN status_actor TEXT;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS batch_transfers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    from_wallet_id INTEGER NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    mode TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    description TEXT,
+    total_amount INTEGER NOT NULL CHECK (total_amount > 0),
+    succeeded_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    -- JSON text, SQLite has no JSONB column type
+    items TEXT NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_batch_transfers_from_wallet_id ON batch_transfers(from_wallet_id, created_at);
+CREATE INDEX idx_batch_transfers_status ON batch_transfers(status);
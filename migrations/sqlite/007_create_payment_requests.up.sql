@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS payment_requests (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    requester_wallet_id INTEGER NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    requester_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    payer_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    payer_wallet_id INTEGER REFERENCES wallets(id) ON DELETE SET NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    currency_code TEXT NOT NULL,
+    note TEXT,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP,
+    CONSTRAINT payment_requests_distinct_users CHECK (requester_user_id <> payer_user_id)
+);
+
+CREATE INDEX idx_payment_requests_payer_user_id ON payment_requests(payer_user_id, created_at);
+CREATE INDEX idx_payment_requests_requester_user_id ON payment_requests(requester_user_id, created_at);
+CREATE INDEX idx_payment_requests_pending_expiry ON payment_requests(expires_at) WHERE status = 'PENDING';
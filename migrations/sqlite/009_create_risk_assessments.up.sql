@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS risk_assessments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    wallet_id INTEGER NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL,
+    transaction_type TEXT NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    to_wallet_id INTEGER REFERENCES wallets(id) ON DELETE SET NULL,
+    decision TEXT NOT NULL,
+    -- JSON text, SQLite has no JSONB column type
+    rule_hits TEXT NOT NULL DEFAULT '[]',
+    review_status TEXT NOT NULL DEFAULT 'NONE',
+    reviewed_by TEXT,
+    review_note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP
+);
+
+CREATE INDEX idx_risk_assessments_wallet_id ON risk_assessments(wallet_id);
+CREATE INDEX idx_risk_assessments_transaction_id ON risk_assessments(transaction_id);
+CREATE INDEX idx_risk_assessments_decision ON risk_assessments(decision);
+CREATE INDEX idx_risk_assessments_review_status ON risk_assessments(review_status, created_at);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS dead_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    topic TEXT NOT NULL,
+    event_id TEXT,
+    event_type TEXT NOT NULL,
+    -- JSON text, SQLite has no JSONB column type
+    payload TEXT NOT NULL DEFAULT '{}',
+    event_time INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL,
+    failed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    redriven_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_events_pending ON dead_events(id) WHERE redriven_at IS NULL;
@@ -0,0 +1,12 @@
+// Package sqlite embeds the SQLite schema migrations used by the single binary
+// mode. They mirror the Postgres migrations, with the column types SQLite has.
+//
+// Each version has a NNN_name.up.sql file and a NNN_name.down.sql file undoing it.
+package sqlite
+
+import "embed"
+
+// FS holds the migration files
+//
+//go:embed *.sql
+var FS embed.FS
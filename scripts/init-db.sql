@@ -0,0 +1,6 @@
+-- Bootstrap run once per database by a superuser, before the migrations.
+-- The application role cannot create extensions, so the migrations expect
+-- them to exist. Docker Compose runs this on the first start of Postgres.
+
+-- Trigram indexes back the transaction description search (migration 013)
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
@@ -0,0 +1,330 @@
+package tests
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/internal/adapters/auth"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+const testAuthSecret = "test-secret-that-is-long-enough-for-hs256"
+
+func newTestTokenService(t *testing.T) *auth.JWTService {
+	t.Helper()
+
+	tokens, err := auth.NewJWTService(auth.JWTConfig{
+		Algorithm: auth.AlgorithmHS256,
+		Secret:    testAuthSecret,
+		Issuer:    "mini-ewallet",
+		Audience:  "mini-ewallet-api",
+		TTL:       time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("failed to create token service: %v", err)
+	}
+
+	return tokens
+}
+
+func issueTestToken(t *testing.T, tokens *auth.JWTService, userID int, roles ...string) string {
+	t.Helper()
+
+	token, _, err := tokens.Issue(context.Background(), domain.Principal{UserID: userID, Roles: roles}, 0)
+	if err != nil {
+		t.Fatalf("failed to issue token: %v", err)
+	}
+
+	return token
+}
+
+// signHS256 builds a token by hand so tests can craft claims the issuer never would
+func signHS256(header, claims map[string]interface{}, secret string) string {
+	headerJSON, _ := json.Marshal(header)
+	claimsJSON, _ := json.Marshal(claims)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWT_HS256(t *testing.T) {
+	ctx := context.Background()
+	tokens := newTestTokenService(t)
+
+	token := issueTestToken(t, tokens, 7, domain.RoleAdmin)
+
+	principal, err := tokens.Verify(ctx, token)
+	if err != nil {
+		t.Fatalf("expected token to verify, got %v", err)
+	}
+	if principal.UserID != 7 || !principal.IsAdmin() {
+		t.Errorf("expected admin user 7, got %+v", principal)
+	}
+
+	header := map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+	valid := map[string]interface{}{
+		"sub": "7",
+		"iss": "mini-ewallet",
+		"aud": []string{"other-api", "mini-ewallet-api"},
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	if _, err := tokens.Verify(ctx, signHS256(header, valid, testAuthSecret)); err != nil {
+		t.Errorf("expected audience array to verify, got %v", err)
+	}
+
+	unsigned := signHS256(map[string]interface{}{"alg": "none"}, valid, testAuthSecret)
+	unsigned = unsigned[:strings.LastIndex(unsigned, ".")+1]
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"Garbage", "not-a-token"},
+		{"TamperedSignature", token[:len(token)-2] + "xx"},
+		{"WrongSecret", signHS256(header, valid, "another-secret-that-is-long-enough")},
+		{"AlgNone", unsigned},
+		{"Expired", signHS256(header, map[string]interface{}{"sub": "7", "iss": "mini-ewallet", "aud": "mini-ewallet-api", "exp": time.Now().Add(-time.Hour).Unix()}, testAuthSecret)},
+		{"NotYetValid", signHS256(header, map[string]interface{}{"sub": "7", "iss": "mini-ewallet", "aud": "mini-ewallet-api", "nbf": time.Now().Add(time.Hour).Unix(), "exp": time.Now().Add(2 * time.Hour).Unix()}, testAuthSecret)},
+		{"MissingExpiry", signHS256(header, map[string]interface{}{"sub": "7", "iss": "mini-ewallet", "aud": "mini-ewallet-api"}, testAuthSecret)},
+		{"WrongIssuer", signHS256(header, map[string]interface{}{"sub": "7", "iss": "someone-else", "aud": "mini-ewallet-api", "exp": time.Now().Add(time.Hour).Unix()}, testAuthSecret)},
+		{"WrongAudience", signHS256(header, map[string]interface{}{"sub": "7", "iss": "mini-ewallet", "aud": "other-api", "exp": time.Now().Add(time.Hour).Unix()}, testAuthSecret)},
+		{"NonNumericSubject", signHS256(header, map[string]interface{}{"sub": "alice", "iss": "mini-ewallet", "aud": "mini-ewallet-api", "exp": time.Now().Add(time.Hour).Unix()}, testAuthSecret)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tokens.Verify(ctx, tt.token); !errors.Is(err, domain.ErrInvalidToken) {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+
+	if _, err := auth.NewJWTService(auth.JWTConfig{Algorithm: auth.AlgorithmHS256, Secret: "short"}); err == nil {
+		t.Error("expected short secret to be rejected")
+	}
+}
+
+func TestJWT_RS256WithJWKS(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	privateKeyFile := filepath.Join(dir, "signing.pem")
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(privateKeyFile, privatePEM, 0o600); err != nil {
+		t.Fatalf("failed to write private key: %v", err)
+	}
+
+	jwks, err := auth.PublicJWKS(map[string]*rsa.PublicKey{"key-1": &key.PublicKey})
+	if err != nil {
+		t.Fatalf("failed to render jwks: %v", err)
+	}
+	jwksFile := filepath.Join(dir, "jwks.json")
+	if err := os.WriteFile(jwksFile, jwks, 0o644); err != nil {
+		t.Fatalf("failed to write jwks: %v", err)
+	}
+
+	issuer, err := auth.NewJWTService(auth.JWTConfig{
+		Algorithm:      auth.AlgorithmRS256,
+		PrivateKeyFile: privateKeyFile,
+		KeyID:          "key-1",
+	})
+	if err != nil {
+		t.Fatalf("failed to create issuer: %v", err)
+	}
+
+	verifier, err := auth.NewJWTService(auth.JWTConfig{
+		Algorithm: auth.AlgorithmRS256,
+		JWKSFile:  jwksFile,
+	})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+
+	token, _, err := issuer.Issue(ctx, domain.Principal{UserID: 3}, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to issue token: %v", err)
+	}
+
+	principal, err := verifier.Verify(ctx, token)
+	if err != nil {
+		t.Fatalf("expected token to verify against the jwks, got %v", err)
+	}
+	if principal.UserID != 3 || principal.IsAdmin() {
+		t.Errorf("expected non-admin user 3, got %+v", principal)
+	}
+
+	if _, _, err := verifier.Issue(ctx, domain.Principal{UserID: 3}, 0); err == nil {
+		t.Error("expected issuing without a private key to fail")
+	}
+
+	// An HS256 token signed with the public key must not be accepted by an RS256 verifier
+	confused := signHS256(
+		map[string]interface{}{"alg": "HS256", "kid": "key-1"},
+		map[string]interface{}{"sub": "1", "roles": []string{domain.RoleAdmin}, "exp": time.Now().Add(time.Hour).Unix()},
+		string(jwks),
+	)
+	if _, err := verifier.Verify(ctx, confused); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("expected algorithm confusion to be rejected, got %v", err)
+	}
+
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherJWKS, _ := auth.PublicJWKS(map[string]*rsa.PublicKey{"key-1": &otherKey.PublicKey})
+	otherFile := filepath.Join(dir, "other.json")
+	_ = os.WriteFile(otherFile, otherJWKS, 0o644)
+
+	rotated, err := auth.NewJWTService(auth.JWTConfig{Algorithm: auth.AlgorithmRS256, JWKSFile: otherFile})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	if _, err := rotated.Verify(ctx, token); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("expected token signed by another key to be rejected, got %v", err)
+	}
+}
+
+func setupAuthRoutes(t *testing.T, ctx context.Context) (*echo.Echo, *auth.JWTService) {
+	t.Helper()
+
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	for userID := 1; userID <= 2; userID++ {
+		wallet := domain.NewWallet(userID, "USD", "Main wallet")
+		wallet.ID = userID
+		wallet.Balance = 1000
+		_ = walletRepo.Save(ctx, wallet)
+	}
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	transactionService := usecase.NewTransactionService(transactionRepo, walletRepo, nil, nil)
+	authorizer := handlers.NewAuthorizer(walletService, transactionService)
+	walletHandler := handlers.NewWalletHandler(walletService, nil, authorizer)
+	tokens := newTestTokenService(t)
+
+	e := echo.New()
+	e.Validator = &testValidator{validator: validator.New()}
+
+	e.POST("/api/v1/auth/dev-token", handlers.NewAuthHandler(tokens).IssueDevToken)
+
+	v1 := e.Group("/api/v1", handlers.Authenticate(tokens, nil))
+	ownsWallet := authorizer.WalletOwner("id")
+
+	wallets := v1.Group("/wallets")
+	wallets.POST("", walletHandler.CreateWallet)
+	wallets.GET("/:id", walletHandler.GetWallet, ownsWallet)
+	wallets.POST("/:id/withdraw", walletHandler.Withdraw, ownsWallet)
+	v1.GET("/users/:user_id/wallets", walletHandler.GetWalletsByUserID, authorizer.UserSelf("user_id"))
+
+	admin := v1.Group("/admin", handlers.RequireRole(domain.RoleAdmin))
+	admin.POST("/wallets/:id/freeze", walletHandler.FreezeWallet)
+
+	return e, tokens
+}
+
+func doAuthRequest(e *echo.Echo, token, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestAuth_WalletOwnership(t *testing.T) {
+	ctx := context.Background()
+	e, tokens := setupAuthRoutes(t, ctx)
+
+	owner := issueTestToken(t, tokens, 1)
+	admin := issueTestToken(t, tokens, 99, domain.RoleAdmin)
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{"MissingToken", "", http.MethodGet, "/api/v1/wallets/1", "", http.StatusUnauthorized},
+		{"InvalidToken", "bogus", http.MethodGet, "/api/v1/wallets/1", "", http.StatusUnauthorized},
+		{"OwnerReadsWallet", owner, http.MethodGet, "/api/v1/wallets/1", "", http.StatusOK},
+		{"OwnerWithdraws", owner, http.MethodPost, "/api/v1/wallets/1/withdraw", `{"amount":100}`, http.StatusOK},
+		{"OtherUsersWallet", owner, http.MethodPost, "/api/v1/wallets/2/withdraw", `{"amount":100}`, http.StatusForbidden},
+		{"OtherUsersWalletList", owner, http.MethodGet, "/api/v1/users/2/wallets", "", http.StatusForbidden},
+		{"CreateWalletForOtherUser", owner, http.MethodPost, "/api/v1/wallets", `{"user_id":2,"currency_code":"EUR"}`, http.StatusForbidden},
+		{"AdminBypassesOwnership", admin, http.MethodPost, "/api/v1/wallets/2/withdraw", `{"amount":100}`, http.StatusOK},
+		{"CustomerOnAdminRoute", owner, http.MethodPost, "/api/v1/admin/wallets/1/freeze", `{"reason":"SUSPECTED_FRAUD","actor":"ops"}`, http.StatusForbidden},
+		{"AdminOnAdminRoute", admin, http.MethodPost, "/api/v1/admin/wallets/2/freeze", `{"reason":"SUSPECTED_FRAUD","actor":"ops"}`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doAuthRequest(e, tt.token, tt.method, tt.target, tt.body)
+			if rec.Code != tt.status {
+				t.Errorf("expected %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuth_DevToken(t *testing.T) {
+	ctx := context.Background()
+	e, _ := setupAuthRoutes(t, ctx)
+
+	rec := doAuthRequest(e, "", http.MethodPost, "/api/v1/auth/dev-token", `{"user_id":2}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp struct {
+		Data struct {
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+		} `json:"data"`
+	}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+
+	if resp.Data.TokenType != "Bearer" || resp.Data.AccessToken == "" {
+		t.Fatalf("expected a bearer token, got %+v", resp.Data)
+	}
+
+	if rec := doAuthRequest(e, resp.Data.AccessToken, http.MethodGet, "/api/v1/wallets/2", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected dev token to grant access to the user's wallet, got %d", rec.Code)
+	}
+	if rec := doAuthRequest(e, resp.Data.AccessToken, http.MethodGet, "/api/v1/wallets/1", ""); rec.Code != http.StatusForbidden {
+		t.Errorf("expected dev token to be denied another user's wallet, got %d", rec.Code)
+	}
+}
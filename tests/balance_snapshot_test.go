@@ -0,0 +1,256 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// setupBalanceHistory creates wallet 1 on January 1st 2024 with a deposit of
+// 1000 on the 2nd, a withdrawal of 200 with a fee of 5 on the 3rd and 300
+// received on the 5th, leaving 1095
+func setupBalanceHistory(t *testing.T, ctx context.Context) (*usecase.BalanceSnapshotService, *memory.InMemoryWalletRepository, *memory.InMemoryBalanceSnapshotRepository) {
+	t.Helper()
+
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	snapshotRepo := memory.NewInMemoryBalanceSnapshotRepository()
+
+	wallet := domain.NewWallet(1, "USD", "Main wallet")
+	wallet.ID = 1
+	wallet.Balance = 1095
+	wallet.CreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_ = walletRepo.Save(ctx, wallet)
+
+	add := func(walletID int, txType domain.TransactionType, amount, fee int, toWalletID *int, createdAt time.Time) {
+		transaction, err := domain.NewTransaction(walletID, txType, amount, string(txType))
+		if err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+		transaction.Fee = fee
+		transaction.ToWalletID = toWalletID
+		transaction.Status = domain.TransactionStatusCompleted
+		transaction.CreatedAt = createdAt
+		_ = transactionRepo.Create(ctx, transaction)
+	}
+
+	add(1, domain.TransactionTypeDeposit, 1000, 0, nil, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC))
+	add(1, domain.TransactionTypeWithdrawal, 200, 5, nil, time.Date(2024, 1, 3, 15, 0, 0, 0, time.UTC))
+	add(2, domain.TransactionTypeTransfer, 300, 0, intPtr(1), time.Date(2024, 1, 5, 8, 0, 0, 0, time.UTC))
+
+	balanceService := usecase.NewBalanceSnapshotService(snapshotRepo, walletRepo, transactionRepo, usecase.BalanceSnapshotConfig{
+		CheckInterval: time.Minute,
+	})
+
+	return balanceService, walletRepo, snapshotRepo
+}
+
+func TestBalanceSnapshot_GetBalanceAt(t *testing.T) {
+	ctx := context.Background()
+	balanceService, walletRepo, _ := setupBalanceHistory(t, ctx)
+
+	tests := []struct {
+		at       time.Time
+		expected int
+	}{
+		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 0},
+		{time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC), 1000},
+		{time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), 795},
+		{time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), 1095},
+	}
+
+	for _, tt := range tests {
+		balance, err := balanceService.GetBalanceAt(ctx, 1, tt.at)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if balance.Balance != tt.expected || balance.SnapshotAt != nil {
+			t.Errorf("at %s: expected %d without a snapshot, got %+v", tt.at, tt.expected, balance)
+		}
+	}
+
+	// Snapshots store the current balance of every wallet
+	other := domain.NewWallet(2, "USD", "Later wallet")
+	other.ID = 2
+	_ = walletRepo.Save(ctx, other)
+
+	taken, err := balanceService.TakeSnapshots(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if taken != 2 {
+		t.Errorf("expected 2 snapshots, got %d", taken)
+	}
+
+	// Once taken the snapshot is the starting point, even if the current
+	// balance disagrees with it
+	_ = walletRepo.UpdateBalance(ctx, 1, 5000)
+
+	balance, err := balanceService.GetBalanceAt(ctx, 1, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if balance.Balance != 1095 || balance.SnapshotAt == nil {
+		t.Errorf("expected 1095 from the snapshot, got %+v", balance)
+	}
+
+	// Times before the snapshot still come from the transactions
+	balance, err = balanceService.GetBalanceAt(ctx, 1, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if balance.Balance != 795 || balance.SnapshotAt != nil {
+		t.Errorf("expected 795 without a snapshot, got %+v", balance)
+	}
+
+	_, err = balanceService.GetBalanceAt(ctx, 1, time.Now().Add(time.Hour))
+	if !errors.Is(err, domain.ErrBalanceTimeInFuture) {
+		t.Errorf("expected ErrBalanceTimeInFuture, got %v", err)
+	}
+
+	_, err = balanceService.GetBalanceAt(ctx, 7, tests[0].at)
+	if !errors.Is(err, usecase.ErrWalletNotFound) {
+		t.Errorf("expected ErrWalletNotFound, got %v", err)
+	}
+}
+
+func TestBalanceSnapshot_StoresBalanceWhenTaken(t *testing.T) {
+	ctx := context.Background()
+	balanceService, walletRepo, snapshotRepo := setupBalanceHistory(t, ctx)
+
+	// A balance the transactions do not add up to is stored as it is read,
+	// not worked out from the transactions
+	_ = walletRepo.UpdateBalance(ctx, 1, 1195)
+
+	before := time.Now()
+	if _, err := balanceService.TakeSnapshots(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	snapshot, _ := snapshotRepo.FindLatest(ctx, 1, time.Now())
+	if snapshot == nil || snapshot.Balance != 1195 {
+		t.Fatalf("expected a snapshot of 1195, got %+v", snapshot)
+	}
+	if snapshot.TakenAt.Before(before) {
+		t.Errorf("expected the snapshot to be taken when the wallet was read, got %s", snapshot.TakenAt)
+	}
+
+	if earlier, _ := snapshotRepo.FindLatest(ctx, 1, before.Add(-time.Second)); earlier != nil {
+		t.Errorf("expected no snapshot before it was taken, got %+v", earlier)
+	}
+}
+
+func TestBalanceSnapshot_BalanceAtQueryParam(t *testing.T) {
+	ctx := context.Background()
+	balanceService, _, _ := setupBalanceHistory(t, ctx)
+
+	e := echo.New()
+	e.GET("/api/v1/wallets/:id/balance", handlers.NewWalletHandler(nil, balanceService, nil).GetBalance)
+
+	tests := []struct {
+		at       string
+		status   int
+		expected int
+	}{
+		// A day means the end of that day
+		{"2024-01-03", http.StatusOK, 795},
+		{"2024-01-03T12:00:00Z", http.StatusOK, 1000},
+		{"2024-01-03T12:00:00%2B07:00", http.StatusOK, 1000},
+		{"march", http.StatusBadRequest, 0},
+		{time.Now().AddDate(0, 0, 2).Format(time.DateOnly), http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/1/balance?at="+tt.at, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("at=%s: expected status %d, got %d: %s", tt.at, tt.status, rec.Code, rec.Body.String())
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+
+		var resp struct {
+			Data domain.HistoricalBalance `json:"data"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Data.Balance != tt.expected || resp.Data.CurrencyCode != "USD" {
+			t.Errorf("at=%s: expected a balance of %d USD, got %+v", tt.at, tt.expected, resp.Data)
+		}
+	}
+}
+
+func TestBalanceSnapshot_SkipsUnsettledWallets(t *testing.T) {
+	ctx := context.Background()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	snapshotRepo := memory.NewInMemoryBalanceSnapshotRepository()
+
+	for id, balance := range map[int]int{1: 500, 2: 0} {
+		wallet := domain.NewWallet(id, "USD", "Wallet")
+		wallet.ID = id
+		wallet.Balance = balance
+		_ = walletRepo.Save(ctx, wallet)
+	}
+
+	// The deposit credited wallet 1 but is not completed yet
+	deposit, _ := domain.NewTransaction(1, domain.TransactionTypeDeposit, 500, "Top up")
+	deposit.Status = domain.TransactionStatusPending
+	_ = transactionRepo.Create(ctx, deposit)
+
+	balanceService := usecase.NewBalanceSnapshotService(snapshotRepo, walletRepo, transactionRepo, usecase.BalanceSnapshotConfig{
+		CheckInterval: time.Minute,
+	})
+
+	taken, err := balanceService.TakeSnapshots(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if taken != 1 {
+		t.Errorf("expected only the settled wallet to be taken, got %d", taken)
+	}
+	if snapshot, _ := snapshotRepo.FindLatest(ctx, 1, time.Now()); snapshot != nil {
+		t.Errorf("expected no snapshot while a transaction is pending, got %+v", snapshot)
+	}
+
+	// Once settled the wallet is taken, the other one already has its
+	// snapshot for the day
+	deposit.Complete()
+	_ = transactionRepo.Update(ctx, deposit)
+
+	taken, err = balanceService.TakeSnapshots(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if taken != 1 {
+		t.Errorf("expected the settled wallet to be taken, got %d", taken)
+	}
+
+	if taken, _ := balanceService.TakeSnapshots(ctx); taken != 0 {
+		t.Errorf("expected no snapshot to be taken twice in a day, got %d", taken)
+	}
+
+	// The deposit is counted once, in the snapshot
+	balance, err := balanceService.GetBalanceAt(ctx, 1, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if balance.Balance != 500 || balance.SnapshotAt == nil {
+		t.Errorf("expected 500 from the snapshot, got %+v", balance)
+	}
+}
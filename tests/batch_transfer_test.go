@@ -0,0 +1,538 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/adapters/risk"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/usecase"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitForBatchTransfer polls a batch until background processing finishes
+func waitForBatchTransfer(t *testing.T, service *usecase.BatchTransferService, batchID int) *domain.BatchTransfer {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		batch, err := service.GetBatchTransfer(context.Background(), batchID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if batch.IsFinished() {
+			return batch
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("batch transfer %d did not finish in time", batchID)
+	return nil
+}
+
+func setupBatchWallets(ctx context.Context, walletRepo *memory.InMemoryWalletRepository, sourceBalance int) {
+	source := domain.NewWallet(1, "USD", "Payroll")
+	source.ID = 1
+	source.Balance = sourceBalance
+	_ = walletRepo.Save(ctx, source)
+
+	for id := 2; id <= 4; id++ {
+		wallet := domain.NewWallet(id, "USD", "Employee")
+		wallet.ID = id
+		_ = walletRepo.Save(ctx, wallet)
+	}
+
+	euroWallet := domain.NewWallet(5, "EUR", "Contractor")
+	euroWallet.ID = 5
+	_ = walletRepo.Save(ctx, euroWallet)
+}
+
+func TestBatchTransferService_Validation(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	batchRepo := memory.NewInMemoryBatchTransferRepository()
+	setupBatchWallets(ctx, walletRepo, 1000)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	batchService := usecase.NewBatchTransferService(batchRepo, walletRepo, transactionRepo, walletService, nil, usecase.BatchTransferConfig{})
+
+	// Unknown recipient and currency mismatch are reported per item
+	_, err := batchService.CreateBatchTransfer(ctx, primary.BatchTransferRequest{
+		FromWalletID: 1,
+		Items: []primary.BatchTransferItemRequest{
+			{ToWalletID: 2, Amount: 100},
+			{ToWalletID: 99, Amount: 100},
+			{ToWalletID: 5, Amount: 100},
+		},
+	})
+
+	var validationErr *domain.BatchValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected BatchValidationError, got %v", err)
+	}
+
+	if len(validationErr.Errors) != 2 || validationErr.Errors[0].Index != 1 || validationErr.Errors[1].Index != 2 {
+		t.Errorf("expected errors for items 1 and 2, got %+v", validationErr.Errors)
+	}
+
+	// All-or-nothing batches must be covered by the balance
+	_, err = batchService.CreateBatchTransfer(ctx, primary.BatchTransferRequest{
+		FromWalletID: 1,
+		Mode:         domain.BatchTransferModeAllOrNothing,
+		Items: []primary.BatchTransferItemRequest{
+			{ToWalletID: 2, Amount: 600},
+			{ToWalletID: 3, Amount: 600},
+		},
+	})
+	if !errors.Is(err, usecase.ErrInsufficientBalance) {
+		t.Errorf("expected ErrInsufficientBalance, got %v", err)
+	}
+
+	batches, _ := batchService.GetBatchTransfersByWalletID(ctx, 1, 10, 0)
+	if len(batches) != 0 {
+		t.Errorf("expected no stored batches, got %d", len(batches))
+	}
+}
+
+func TestBatchTransferService_BestEffort(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	batchRepo := memory.NewInMemoryBatchTransferRepository()
+	setupBatchWallets(ctx, walletRepo, 1000)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	batchService := usecase.NewBatchTransferService(batchRepo, walletRepo, transactionRepo, walletService, nil, usecase.BatchTransferConfig{})
+
+	// The second item cannot be covered once the first one is paid
+	batch, err := batchService.CreateBatchTransfer(ctx, primary.BatchTransferRequest{
+		FromWalletID: 1,
+		Mode:         domain.BatchTransferModeBestEffort,
+		Description:  "March payroll",
+		Items: []primary.BatchTransferItemRequest{
+			{ToWalletID: 2, Amount: 700},
+			{ToWalletID: 3, Amount: 700},
+			{ToWalletID: 4, Amount: 300},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch = waitForBatchTransfer(t, batchService, batch.ID)
+
+	if batch.Status != domain.BatchTransferStatusPartiallyCompleted {
+		t.Errorf("expected partially completed batch, got %s", batch.Status)
+	}
+
+	wantStatuses := []domain.BatchTransferItemStatus{
+		domain.BatchTransferItemStatusCompleted,
+		domain.BatchTransferItemStatusFailed,
+		domain.BatchTransferItemStatusCompleted,
+	}
+	for i, want := range wantStatuses {
+		if batch.Items[i].Status != want {
+			t.Errorf("expected item %d to be %s, got %s", i, want, batch.Items[i].Status)
+		}
+	}
+
+	if batch.SucceededCount != 2 || batch.FailedCount != 1 {
+		t.Errorf("expected 2 succeeded and 1 failed, got %d and %d", batch.SucceededCount, batch.FailedCount)
+	}
+
+	source, _ := walletRepo.FindByID(ctx, 1)
+	if source.Balance != 0 {
+		t.Errorf("expected source balance 0, got %d", source.Balance)
+	}
+}
+
+func TestBatchTransferService_AllOrNothingReversesOnFailure(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	batchRepo := memory.NewInMemoryBatchTransferRepository()
+	setupBatchWallets(ctx, walletRepo, 1000)
+
+	// Deny the third transfer in a row so the batch fails mid-way
+	engine, _ := risk.NewRulesEngine(risk.RulesConfig{
+		Velocity: risk.VelocityRuleConfig{
+			Enabled:  true,
+			Window:   time.Hour,
+			MaxCount: 2,
+			Decision: domain.RiskDecisionDeny,
+		},
+	}, transactionRepo)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	walletService.SetRiskService(usecase.NewRiskService(engine, memory.NewInMemoryRiskAssessmentRepository(), nil))
+	batchService := usecase.NewBatchTransferService(batchRepo, walletRepo, transactionRepo, walletService, nil, usecase.BatchTransferConfig{})
+
+	batch, err := batchService.CreateBatchTransfer(ctx, primary.BatchTransferRequest{
+		FromWalletID: 1,
+		Mode:         domain.BatchTransferModeAllOrNothing,
+		Items: []primary.BatchTransferItemRequest{
+			{ToWalletID: 2, Amount: 100},
+			{ToWalletID: 3, Amount: 100},
+			{ToWalletID: 4, Amount: 100},
+			{ToWalletID: 2, Amount: 100},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch = waitForBatchTransfer(t, batchService, batch.ID)
+
+	if batch.Status != domain.BatchTransferStatusFailed {
+		t.Errorf("expected failed batch, got %s", batch.Status)
+	}
+
+	wantStatuses := []domain.BatchTransferItemStatus{
+		domain.BatchTransferItemStatusReversed,
+		domain.BatchTransferItemStatusReversed,
+		domain.BatchTransferItemStatusFailed,
+		domain.BatchTransferItemStatusSkipped,
+	}
+	for i, want := range wantStatuses {
+		if batch.Items[i].Status != want {
+			t.Errorf("expected item %d to be %s, got %s", i, want, batch.Items[i].Status)
+		}
+	}
+
+	// Every wallet is back where it started
+	for id, want := range map[int]int{1: 1000, 2: 0, 3: 0, 4: 0} {
+		wallet, _ := walletRepo.FindByID(ctx, id)
+		if wallet.Balance != want {
+			t.Errorf("expected wallet %d balance %d, got %d", id, want, wallet.Balance)
+		}
+	}
+}
+
+func TestBatchTransferService_AllOrNothingRefundsFees(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	batchRepo := memory.NewInMemoryBatchTransferRepository()
+	setupBatchWallets(ctx, walletRepo, 1000)
+
+	revenueWallet := domain.NewWallet(99, "USD", "Fee revenue")
+	revenueWallet.ID = 99
+	_ = walletRepo.Save(ctx, revenueWallet)
+
+	// Recipient 2 is frozen, it is paid but cannot send the funds back
+	frozen, _ := walletRepo.FindByID(ctx, 2)
+	frozen.Status = domain.WalletStatusFrozen
+	_ = walletRepo.Save(ctx, frozen)
+
+	schedule, _ := domain.NewFeeSchedule([]domain.FeeRule{
+		{TransactionType: domain.TransactionTypeTransfer, Type: domain.FeeTypeFlat, FlatAmount: 10},
+	})
+	feeService := usecase.NewFeeService(schedule, map[string]int{"USD": 99}, walletRepo, transactionRepo, nil)
+
+	// Deny the third transfer in a row so the batch fails mid-way
+	engine, _ := risk.NewRulesEngine(risk.RulesConfig{
+		Velocity: risk.VelocityRuleConfig{
+			Enabled:  true,
+			Window:   time.Hour,
+			MaxCount: 2,
+			Decision: domain.RiskDecisionDeny,
+		},
+	}, transactionRepo)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	walletService.SetRiskService(usecase.NewRiskService(engine, memory.NewInMemoryRiskAssessmentRepository(), nil))
+	walletService.SetFeeService(feeService)
+	batchService := usecase.NewBatchTransferService(batchRepo, walletRepo, transactionRepo, walletService, nil, usecase.BatchTransferConfig{})
+	batchService.SetFeeService(feeService)
+
+	batch, _ := domain.NewBatchTransfer(1, domain.BatchTransferModeAllOrNothing, "", []domain.BatchTransferItem{
+		{ToWalletID: 2, Amount: 100},
+		{ToWalletID: 3, Amount: 100},
+		{ToWalletID: 4, Amount: 100},
+	})
+	_ = batchRepo.Create(ctx, batch)
+
+	if err := batchService.ProcessBatchTransfer(ctx, batch.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch, _ = batchService.GetBatchTransfer(ctx, batch.ID)
+	if batch.Status != domain.BatchTransferStatusPartiallyReversed {
+		t.Errorf("expected partially reversed batch, got %s", batch.Status)
+	}
+
+	wantStatuses := []domain.BatchTransferItemStatus{
+		domain.BatchTransferItemStatusCompleted,
+		domain.BatchTransferItemStatusReversed,
+		domain.BatchTransferItemStatusFailed,
+	}
+	for i, want := range wantStatuses {
+		if batch.Items[i].Status != want {
+			t.Errorf("expected item %d to be %s, got %s", i, want, batch.Items[i].Status)
+		}
+	}
+
+	// The reversed item and its fee are back on the source wallet, the
+	// frozen recipient keeps its item and the fee it was charged
+	for id, want := range map[int]int{1: 890, 2: 100, 3: 0, 99: 10} {
+		wallet, _ := walletRepo.FindByID(ctx, id)
+		if wallet.Balance != want {
+			t.Errorf("expected wallet %d balance %d, got %d", id, want, wallet.Balance)
+		}
+	}
+}
+
+func TestBatchTransferService_RecoverInterruptedBatch(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	batchRepo := memory.NewInMemoryBatchTransferRepository()
+	setupBatchWallets(ctx, walletRepo, 1000)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	batchService := usecase.NewBatchTransferService(batchRepo, walletRepo, transactionRepo, walletService, nil,
+		usecase.BatchTransferConfig{StaleAfter: time.Minute, RecoveryInterval: time.Minute})
+
+	// A batch that stopped while paying its first item, the transfer went
+	// through but the batch was not saved again. A transfer with the same
+	// amount and recipient outside the batch is not mistaken for it
+	interrupted, _ := domain.NewBatchTransfer(1, domain.BatchTransferModeBestEffort, "March payroll", []domain.BatchTransferItem{
+		{ToWalletID: 2, Amount: 100},
+		{ToWalletID: 3, Amount: 200},
+	})
+	interrupted.Start()
+	interrupted.UpdatedAt = time.Now().Add(-10 * time.Minute)
+	_ = batchRepo.Create(ctx, interrupted)
+
+	if _, err := walletService.Transfer(ctx, 1, 2, 100, "March payroll"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	paid, err := walletService.TransferWithReference(ctx, 1, 2, 100, "March payroll",
+		usecase.BatchItemReference(interrupted.ID, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A batch that never started, and one that is still being worked on
+	queued, _ := domain.NewBatchTransfer(1, domain.BatchTransferModeBestEffort, "", []domain.BatchTransferItem{
+		{ToWalletID: 4, Amount: 300},
+	})
+	queued.UpdatedAt = time.Now().Add(-10 * time.Minute)
+	_ = batchRepo.Create(ctx, queued)
+
+	active, _ := domain.NewBatchTransfer(1, domain.BatchTransferModeBestEffort, "", []domain.BatchTransferItem{
+		{ToWalletID: 4, Amount: 50},
+	})
+	_ = batchRepo.Create(ctx, active)
+
+	recovered, err := batchService.RecoverBatchTransfers(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if recovered != 2 {
+		t.Errorf("expected 2 recovered batches, got %d", recovered)
+	}
+
+	interrupted, _ = batchService.GetBatchTransfer(ctx, interrupted.ID)
+	if interrupted.Status != domain.BatchTransferStatusCompleted {
+		t.Errorf("expected the interrupted batch to complete, got %s", interrupted.Status)
+	}
+
+	if id := interrupted.Items[0].TransactionID; id == nil || *id != paid.ID {
+		t.Errorf("expected the first item to be matched with transaction %d, got %v", paid.ID, id)
+	}
+
+	queued, _ = batchService.GetBatchTransfer(ctx, queued.ID)
+	if queued.Status != domain.BatchTransferStatusCompleted {
+		t.Errorf("expected the queued batch to complete, got %s", queued.Status)
+	}
+
+	active, _ = batchService.GetBatchTransfer(ctx, active.ID)
+	if active.Status != domain.BatchTransferStatusPending {
+		t.Errorf("expected the active batch to be left alone, got %s", active.Status)
+	}
+
+	// Every item was paid exactly once
+	for id, want := range map[int]int{1: 300, 2: 200, 3: 200, 4: 300} {
+		wallet, _ := walletRepo.FindByID(ctx, id)
+		if wallet.Balance != want {
+			t.Errorf("expected wallet %d balance %d, got %d", id, want, wallet.Balance)
+		}
+	}
+}
+
+// flakyBatchRepo fails the first update that records a completed item
+type flakyBatchRepo struct {
+	*memory.InMemoryBatchTransferRepository
+	failed bool
+}
+
+func (r *flakyBatchRepo) Update(ctx context.Context, batch *domain.BatchTransfer) error {
+	if !r.failed && batch.Items[0].Status == domain.BatchTransferItemStatusCompleted {
+		r.failed = true
+		return errors.New("connection reset")
+	}
+	return r.InMemoryBatchTransferRepository.Update(ctx, batch)
+}
+
+func TestBatchTransferService_StopsWhenProgressIsNotRecorded(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	batchRepo := &flakyBatchRepo{InMemoryBatchTransferRepository: memory.NewInMemoryBatchTransferRepository()}
+	setupBatchWallets(ctx, walletRepo, 1000)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	batchService := usecase.NewBatchTransferService(batchRepo, walletRepo, transactionRepo, walletService, nil,
+		usecase.BatchTransferConfig{StaleAfter: time.Minute, RecoveryInterval: time.Minute})
+
+	batch, _ := domain.NewBatchTransfer(1, domain.BatchTransferModeBestEffort, "", []domain.BatchTransferItem{
+		{ToWalletID: 2, Amount: 100},
+		{ToWalletID: 3, Amount: 200},
+		{ToWalletID: 4, Amount: 300},
+	})
+	_ = batchRepo.Create(ctx, batch)
+
+	// The first item is paid but cannot be recorded, nothing else is paid
+	if err := batchService.ProcessBatchTransfer(ctx, batch.ID); err == nil {
+		t.Fatal("expected the batch to stop when its progress cannot be recorded")
+	}
+
+	for id, want := range map[int]int{1: 900, 2: 100, 3: 0, 4: 0} {
+		wallet, _ := walletRepo.FindByID(ctx, id)
+		if wallet.Balance != want {
+			t.Errorf("expected wallet %d balance %d, got %d", id, want, wallet.Balance)
+		}
+	}
+
+	// The stale batch is resumed and its paid item found by reference
+	stored, _ := batchRepo.FindByID(ctx, batch.ID)
+	if stored.Items[0].Status != domain.BatchTransferItemStatusPending {
+		t.Fatalf("expected the paid item to be unrecorded, got %s", stored.Items[0].Status)
+	}
+	stored.UpdatedAt = time.Now().Add(-10 * time.Minute)
+	_ = batchRepo.InMemoryBatchTransferRepository.Update(ctx, stored)
+
+	recovered, err := batchService.RecoverBatchTransfers(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if recovered != 1 {
+		t.Errorf("expected 1 recovered batch, got %d", recovered)
+	}
+
+	stored, _ = batchService.GetBatchTransfer(ctx, batch.ID)
+	if stored.Status != domain.BatchTransferStatusCompleted {
+		t.Errorf("expected the batch to complete, got %s", stored.Status)
+	}
+
+	// Every item was paid exactly once
+	for id, want := range map[int]int{1: 400, 2: 100, 3: 200, 4: 300} {
+		wallet, _ := walletRepo.FindByID(ctx, id)
+		if wallet.Balance != want {
+			t.Errorf("expected wallet %d balance %d, got %d", id, want, wallet.Balance)
+		}
+	}
+}
+
+// barrierBatchRepo holds every FindUnfinished call until all the expected
+// callers found their batches, so concurrent recoveries see the same batches
+type barrierBatchRepo struct {
+	*memory.InMemoryBatchTransferRepository
+	found sync.WaitGroup
+}
+
+func (r *barrierBatchRepo) FindUnfinished(ctx context.Context, updatedBefore time.Time, limit int) ([]*domain.BatchTransfer, error) {
+	batches, err := r.InMemoryBatchTransferRepository.FindUnfinished(ctx, updatedBefore, limit)
+	r.found.Done()
+	r.found.Wait()
+	return batches, err
+}
+
+func TestBatchTransferService_ConcurrentRecoveryPaysOnce(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	batchRepo := &barrierBatchRepo{InMemoryBatchTransferRepository: memory.NewInMemoryBatchTransferRepository()}
+	setupBatchWallets(ctx, walletRepo, 1000)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	config := usecase.BatchTransferConfig{StaleAfter: time.Minute, RecoveryInterval: time.Minute}
+
+	// Two replicas share the same repositories and both find the batch
+	batchRepo.found.Add(2)
+	replicas := []*usecase.BatchTransferService{
+		usecase.NewBatchTransferService(batchRepo, walletRepo, transactionRepo, walletService, nil, config),
+		usecase.NewBatchTransferService(batchRepo, walletRepo, transactionRepo, walletService, nil, config),
+	}
+
+	interrupted, _ := domain.NewBatchTransfer(1, domain.BatchTransferModeBestEffort, "March payroll", []domain.BatchTransferItem{
+		{ToWalletID: 2, Amount: 100},
+		{ToWalletID: 3, Amount: 200},
+		{ToWalletID: 4, Amount: 300},
+	})
+	interrupted.UpdatedAt = time.Now().Add(-10 * time.Minute)
+	_ = batchRepo.Create(ctx, interrupted)
+
+	var wg sync.WaitGroup
+	recovered := make([]int, len(replicas))
+	for i, replica := range replicas {
+		wg.Add(1)
+		go func(i int, replica *usecase.BatchTransferService) {
+			defer wg.Done()
+
+			count, err := replica.RecoverBatchTransfers(ctx)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			recovered[i] = count
+		}(i, replica)
+	}
+	wg.Wait()
+
+	if total := recovered[0] + recovered[1]; total != 1 {
+		t.Errorf("expected the batch to be recovered once, got %d", total)
+	}
+
+	batch, _ := replicas[0].GetBatchTransfer(ctx, interrupted.ID)
+	if batch.Status != domain.BatchTransferStatusCompleted {
+		t.Errorf("expected the batch to complete, got %s", batch.Status)
+	}
+
+	// Every item was paid exactly once
+	for id, want := range map[int]int{1: 400, 2: 100, 3: 200, 4: 300} {
+		wallet, _ := walletRepo.FindByID(ctx, id)
+		if wallet.Balance != want {
+			t.Errorf("expected wallet %d balance %d, got %d", id, want, wallet.Balance)
+		}
+	}
+
+	// A claimed batch is not picked up again while it is fresh
+	batchRepo.found.Add(1)
+	if count, _ := replicas[1].RecoverBatchTransfers(ctx); count != 0 {
+		t.Errorf("expected nothing left to recover, got %d", count)
+	}
+}
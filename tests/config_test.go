@@ -0,0 +1,221 @@
+package tests
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"ports-and-adapters-architecture/internal/config"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useConfigFiles runs the test from a directory holding the repository
+// config.yaml and the given environment files
+func useConfigFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	base, err := os.ReadFile(filepath.Join("..", "config", "config.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read config.yaml: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "config"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files["config.yaml"] = string(base)
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, "config", name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Chdir(dir)
+}
+
+func TestConfig_EnvironmentOverridesNestedKeys(t *testing.T) {
+	useConfigFiles(t, map[string]string{})
+	t.Setenv("APP_ENV", "test")
+	t.Setenv("DATABASE_HOST", "db.internal")
+	t.Setenv("DATABASE_MAX_OPEN_CONNS", "40")
+	t.Setenv("SERVER_TIMEOUT", "15s")
+	t.Setenv("KAFKA_BROKERS", "kafka-1:9092,kafka-2:9092")
+	t.Setenv("AUTH_ISSUER", "issuer-from-env")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("expected config to load, got %v", err)
+	}
+
+	if cfg.Environment != "test" {
+		t.Errorf("expected environment test, got %q", cfg.Environment)
+	}
+	if cfg.Database.Host != "db.internal" || cfg.Database.MaxOpenConns != 40 {
+		t.Errorf("expected database overrides to apply, got host %q and %d connections", cfg.Database.Host, cfg.Database.MaxOpenConns)
+	}
+	if cfg.Server.Timeout != 15*time.Second {
+		t.Errorf("expected server timeout 15s, got %s", cfg.Server.Timeout)
+	}
+	if len(cfg.Kafka.Brokers) != 2 || cfg.Kafka.Brokers[1] != "kafka-2:9092" {
+		t.Errorf("expected two brokers, got %v", cfg.Kafka.Brokers)
+	}
+	if cfg.Auth.Issuer != "issuer-from-env" {
+		t.Errorf("expected squashed auth keys to be overridden, got issuer %q", cfg.Auth.Issuer)
+	}
+	if cfg.Database.OperationTimeout != 3*time.Second {
+		t.Errorf("expected the default operation timeout, got %s", cfg.Database.OperationTimeout)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected the base config to be valid, got %v", err)
+	}
+}
+
+func TestConfig_SecretsFromFilesAndPlaceholders(t *testing.T) {
+	useConfigFiles(t, map[string]string{
+		"config.staging.yaml": "auth:\n  secret: ${TEST_JWT_SECRET}\n",
+	})
+	t.Setenv("APP_ENV", "staging")
+	t.Setenv("TEST_JWT_SECRET", "secret-from-placeholder")
+
+	passwordFile := filepath.Join(t.TempDir(), "db_password")
+	if err := os.WriteFile(passwordFile, []byte("secret-from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DATABASE_PASSWORD_FILE", passwordFile)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("expected config to load, got %v", err)
+	}
+
+	if cfg.Database.Password != "secret-from-file" {
+		t.Errorf("expected the password from the file without the newline, got %q", cfg.Database.Password)
+	}
+	if cfg.Auth.Secret != "secret-from-placeholder" {
+		t.Errorf("expected the placeholder to be expanded, got %q", cfg.Auth.Secret)
+	}
+
+	t.Setenv("DATABASE_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+	if _, err := config.Load(); err == nil {
+		t.Error("expected a missing secret file to fail loading")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	useConfigFiles(t, map[string]string{})
+	t.Setenv("APP_ENV", "test")
+
+	tests := []struct {
+		name   string
+		modify func(cfg *config.Config)
+		errs   []string
+	}{
+		{
+			name: "pool sizes and durations",
+			modify: func(cfg *config.Config) {
+				cfg.Database.MaxOpenConns = 10
+				cfg.Database.MaxIdleConns = 20
+				cfg.Server.Timeout = 0
+				cfg.Health.Critical = []string{"mongodb"}
+			},
+			errs: []string{"database.max_idle_conns", "server.timeout", "mongodb"},
+		},
+		{
+			name: "secrets outside development",
+			modify: func(cfg *config.Config) {
+				cfg.Environment = "prod"
+				cfg.Database.Password = ""
+				cfg.Auth.Secret = ""
+				cfg.Auth.DevTokens = true
+			},
+			errs: []string{"DATABASE_PASSWORD_FILE", "payment.midtrans.server_key", "auth.secret", "auth.dev_tokens"},
+		},
+		{
+			name: "database driver",
+			modify: func(cfg *config.Config) {
+				cfg.Database.Driver = "mysql"
+			},
+			errs: []string{`database.driver "mysql"`},
+		},
+		{
+			name: "sqlite without a path",
+			modify: func(cfg *config.Config) {
+				cfg.Database.Driver = config.DriverSQLite
+				cfg.Database.SQLite.Path = ""
+			},
+			errs: []string{"database.sqlite.path"},
+		},
+		{
+			name: "cache driver",
+			modify: func(cfg *config.Config) {
+				cfg.Cache.Driver = "memcached"
+			},
+			errs: []string{`cache.driver "memcached"`},
+		},
+		{
+			name: "memory cache with redis critical",
+			modify: func(cfg *config.Config) {
+				cfg.Cache.Driver = config.CacheDriverMemory
+				cfg.Redis.Addr = ""
+				cfg.Health.Critical = []string{"redis"}
+			},
+			errs: []string{"health.critical names redis"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.Load()
+			if err != nil {
+				t.Fatalf("expected config to load, got %v", err)
+			}
+			tt.modify(cfg)
+
+			err = cfg.Validate()
+			if err == nil {
+				t.Fatal("expected validation to fail")
+			}
+			for _, want := range tt.errs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected an error about %s, got:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_PrintRedacted(t *testing.T) {
+	useConfigFiles(t, map[string]string{})
+	t.Setenv("APP_ENV", "test")
+	t.Setenv("PAYMENT_STRIPE_API_KEY", "sk_live_do_not_print")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("expected config to load, got %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := cfg.Print(&out, true); err != nil {
+		t.Fatalf("expected config to print, got %v", err)
+	}
+
+	printed := out.String()
+	if strings.Contains(printed, "sk_live_do_not_print") {
+		t.Error("expected the secret to be redacted")
+	}
+	if !strings.Contains(printed, "api_key: REDACTED") {
+		t.Errorf("expected redacted secrets to be marked, got:\n%s", printed)
+	}
+	if !strings.Contains(printed, "operation_timeout: 3s") {
+		t.Errorf("expected durations to print as written, got:\n%s", printed)
+	}
+
+	out.Reset()
+	if err := cfg.Print(&out, false); err != nil {
+		t.Fatalf("expected config to print, got %v", err)
+	}
+	if !strings.Contains(out.String(), "sk_live_do_not_print") {
+		t.Error("expected secrets to be printed when not redacted")
+	}
+}
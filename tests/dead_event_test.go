@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/usecase"
+	"testing"
+)
+
+// recordingPublisher keeps published events and fails while err is set
+type recordingPublisher struct {
+	topics []string
+	events []infrastructure.Event
+	err    error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, topic string, event infrastructure.Event) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.topics = append(p.topics, topic)
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) PublishAsync(ctx context.Context, topic string, event infrastructure.Event) error {
+	return p.Publish(ctx, topic, event)
+}
+
+func (p *recordingPublisher) PublishBatch(ctx context.Context, topic string, events []infrastructure.Event) error {
+	for _, event := range events {
+		if err := p.Publish(ctx, topic, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *recordingPublisher) Flush(ctx context.Context) error {
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
+
+func TestDeadEvent_RecordAndRedrive(t *testing.T) {
+	ctx := context.Background()
+	publisher := &recordingPublisher{}
+	deadEventService := usecase.NewDeadEventService(memory.NewInMemoryDeadEventRepository(), publisher)
+
+	event := infrastructure.Event{
+		Type:    "payment.completed",
+		Payload: map[string]interface{}{"payment_id": float64(7)},
+		Time:    1700000000000,
+		ID:      "evt-1",
+	}
+
+	var handler infrastructure.FailureHandler = deadEventService.Record
+	if err := handler(ctx, "payments", event, errors.New("wallet locked")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = handler(ctx, "wallets", infrastructure.Event{Type: "wallet.created", ID: "evt-2"}, errors.New("timeout"))
+
+	pending, err := deadEventService.GetPendingDeadEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pending) != 2 || pending[0].Topic != "payments" || pending[0].Error != "wallet locked" {
+		t.Fatalf("expected both dead events oldest first, got %+v", pending)
+	}
+
+	// A failed publish leaves the event pending
+	publisher.err = errors.New("broker down")
+	if _, err := deadEventService.Redrive(ctx, pending[0].ID); err == nil {
+		t.Fatal("expected the redrive to fail")
+	}
+	publisher.err = nil
+
+	redriven, err := deadEventService.Redrive(ctx, pending[0].ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if redriven.RedrivenAt == nil {
+		t.Error("expected the event to be marked as redriven")
+	}
+	if len(publisher.events) != 1 || publisher.topics[0] != "payments" || publisher.events[0].ID != "evt-1" ||
+		publisher.events[0].Time != event.Time || publisher.events[0].Payload["payment_id"] != float64(7) {
+		t.Errorf("expected the original event on its topic, got %v %+v", publisher.topics, publisher.events)
+	}
+
+	if _, err := deadEventService.Redrive(ctx, pending[0].ID); !errors.Is(err, domain.ErrDeadEventRedriven) {
+		t.Errorf("expected ErrDeadEventRedriven, got %v", err)
+	}
+
+	if _, err := deadEventService.Redrive(ctx, 99); !errors.Is(err, usecase.ErrDeadEventNotFound) {
+		t.Errorf("expected ErrDeadEventNotFound, got %v", err)
+	}
+
+	pending, _ = deadEventService.GetPendingDeadEvents(ctx, 10)
+	if len(pending) != 1 || pending[0].Topic != "wallets" {
+		t.Errorf("expected only the wallet event to be pending, got %+v", pending)
+	}
+}
@@ -0,0 +1,560 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/adapters/persistence/sqlite"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/ports/secondary/external"
+	"ports-and-adapters-architecture/internal/usecase"
+	"testing"
+	"time"
+)
+
+// completedGateway reports every payment as paid
+type completedGateway struct{}
+
+func (completedGateway) GetProvider() external.PaymentGatewayProvider {
+	return external.ProviderStripe
+}
+
+func (completedGateway) GetSupportedPaymentMethods() []external.PaymentMethod {
+	return []external.PaymentMethod{external.PaymentMethodCreditCard}
+}
+
+func (completedGateway) ProcessPayment(ctx context.Context, request external.PaymentRequest) (*external.PaymentResponse, error) {
+	return &external.PaymentResponse{ExternalID: request.ReferenceID, Status: external.PaymentStatusPending}, nil
+}
+
+func (completedGateway) CheckPaymentStatus(ctx context.Context, transactionID string) (*external.PaymentResponse, error) {
+	return &external.PaymentResponse{ExternalID: transactionID, Status: external.PaymentStatusCompleted}, nil
+}
+
+func (completedGateway) CancelPayment(ctx context.Context, transactionID string) error {
+	return nil
+}
+
+func (completedGateway) RefundRepayment(ctx context.Context, request external.RefundRequest) (*external.RefundResponse, error) {
+	return nil, errors.New("refunds are not supported")
+}
+
+func (completedGateway) ValidateCallback(ctx context.Context, requestBody []byte, headers map[string]string) (*external.PaymentResponse, error) {
+	return nil, errors.New("callbacks are not supported")
+}
+
+func TestFeeSchedule_Quote(t *testing.T) {
+	schedule, err := domain.NewFeeSchedule([]domain.FeeRule{
+		{TransactionType: domain.TransactionTypeWithdrawal, Type: domain.FeeTypeFlat, FlatAmount: 25},
+		{TransactionType: domain.TransactionTypeWithdrawal, Currency: "EUR", Type: domain.FeeTypeFlat, FlatAmount: 40},
+		{
+			TransactionType: domain.TransactionTypeDeposit,
+			Provider:        domain.PaymentProviderStripe,
+			Type:            domain.FeeTypePercentage,
+			RateBPS:         290,
+			MinFee:          30,
+			MaxFee:          500,
+		},
+		{
+			TransactionType: domain.TransactionTypeTransfer,
+			Type:            domain.FeeTypeTiered,
+			Tiers: []domain.FeeTier{
+				{UpTo: 1000},
+				{UpTo: 0, FlatAmount: 10, RateBPS: 100},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		txType    domain.TransactionType
+		currency  string
+		provider  domain.PaymentProvider
+		amount    int
+		wantFee   int
+		wantTotal int
+	}{
+		{"Flat fee", domain.TransactionTypeWithdrawal, "USD", "", 1000, 25, 1025},
+		{"Currency specific rule wins", domain.TransactionTypeWithdrawal, "EUR", "", 1000, 40, 1040},
+		{"Percentage fee", domain.TransactionTypeDeposit, "USD", domain.PaymentProviderStripe, 10000, 290, 9710},
+		{"Percentage fee capped at min", domain.TransactionTypeDeposit, "USD", domain.PaymentProviderStripe, 100, 30, 70},
+		{"Percentage fee capped at max", domain.TransactionTypeDeposit, "USD", domain.PaymentProviderStripe, 100000, 500, 99500},
+		{"No rule for provider", domain.TransactionTypeDeposit, "USD", domain.PaymentProviderMidtrans, 10000, 0, 10000},
+		{"Free tier", domain.TransactionTypeTransfer, "USD", "", 1000, 0, 1000},
+		{"Upper tier", domain.TransactionTypeTransfer, "USD", "", 5000, 60, 5060},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := schedule.Quote(tt.txType, tt.currency, tt.provider, tt.amount)
+
+			if quote.Fee != tt.wantFee {
+				t.Errorf("expected fee %d, got %d", tt.wantFee, quote.Fee)
+			}
+
+			if quote.Total != tt.wantTotal {
+				t.Errorf("expected total %d, got %d", tt.wantTotal, quote.Total)
+			}
+		})
+	}
+
+	// Invalid rules are rejected
+	_, err = domain.NewFeeSchedule([]domain.FeeRule{
+		{TransactionType: domain.TransactionTypeWithdrawal, Type: domain.FeeTypeFlat, MinFee: 10, MaxFee: 5},
+	})
+	if !errors.Is(err, domain.ErrInvalidFeeRule) {
+		t.Errorf("expected ErrInvalidFeeRule, got %v", err)
+	}
+
+	// Direct deposits are never charged, so deposit rules need a provider
+	_, err = domain.NewFeeSchedule([]domain.FeeRule{
+		{TransactionType: domain.TransactionTypeDeposit, Type: domain.FeeTypeFlat, FlatAmount: 25},
+	})
+	if !errors.Is(err, domain.ErrInvalidFeeRule) {
+		t.Errorf("expected ErrInvalidFeeRule for a deposit rule without provider, got %v", err)
+	}
+
+	// A misspelled transaction type is rejected when the schedule loads
+	_, err = domain.NewFeeSchedule([]domain.FeeRule{
+		{TransactionType: "WITHDRAW", Type: domain.FeeTypeFlat, FlatAmount: 25},
+	})
+	if !errors.Is(err, domain.ErrInvalidFeeRule) {
+		t.Errorf("expected ErrInvalidFeeRule for an unknown transaction type, got %v", err)
+	}
+}
+
+func TestWalletService_WithdrawWithFee(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	wallet := domain.NewWallet(1, "USD", "Test wallet")
+	wallet.ID = 1
+	wallet.Balance = 1000
+	_ = walletRepo.Save(ctx, wallet)
+
+	revenueWallet := domain.NewWallet(99, "USD", "Fee revenue")
+	revenueWallet.ID = 99
+	_ = walletRepo.Save(ctx, revenueWallet)
+
+	schedule, _ := domain.NewFeeSchedule([]domain.FeeRule{
+		{TransactionType: domain.TransactionTypeWithdrawal, Type: domain.FeeTypeFlat, FlatAmount: 50},
+	})
+
+	feeService := usecase.NewFeeService(schedule, map[string]int{"usd": 99}, walletRepo, transactionRepo, nil)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	walletService.SetFeeService(feeService)
+
+	// The fee must be covered by the balance as well
+	if _, err := walletService.Withdraw(ctx, 1, 980, "Too much"); !errors.Is(err, usecase.ErrInsufficientBalance) {
+		t.Fatalf("expected ErrInsufficientBalance, got %v", err)
+	}
+
+	transaction, err := walletService.Withdraw(ctx, 1, 500, "Cash out")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if transaction.Fee != 50 {
+		t.Errorf("expected fee 50, got %d", transaction.Fee)
+	}
+
+	updatedWallet, _ := walletRepo.FindByID(ctx, 1)
+	if updatedWallet.Balance != 450 {
+		t.Errorf("expected balance 450, got %d", updatedWallet.Balance)
+	}
+
+	updatedRevenue, _ := walletRepo.FindByID(ctx, 99)
+	if updatedRevenue.Balance != 50 {
+		t.Errorf("expected revenue balance 50, got %d", updatedRevenue.Balance)
+	}
+
+	// The fee is recorded as a separate transaction linked to the withdrawal
+	revenueTransactions, _ := transactionRepo.FindByWalletID(ctx, 99, 10, 0)
+	if len(revenueTransactions) != 1 {
+		t.Fatalf("expected 1 fee transaction, got %d", len(revenueTransactions))
+	}
+
+	feeTransaction := revenueTransactions[0]
+	if feeTransaction.Type != domain.TransactionTypeFee || feeTransaction.Amount != 50 ||
+		feeTransaction.Status != domain.TransactionStatusCompleted ||
+		feeTransaction.Reference != usecase.FeeReference(transaction.ID) {
+		t.Errorf("unexpected fee transaction: %+v", feeTransaction)
+	}
+}
+
+func TestTransactionService_ReconcileRetriesPendingFees(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	wallet := domain.NewWallet(1, "USD", "Test wallet")
+	wallet.ID = 1
+	wallet.Balance = 1000
+	_ = walletRepo.Save(ctx, wallet)
+
+	// The revenue wallet cannot receive the fee yet
+	revenueWallet := domain.NewWallet(99, "USD", "Fee revenue")
+	revenueWallet.ID = 99
+	revenueWallet.Status = domain.WalletStatusInactive
+	_ = walletRepo.Save(ctx, revenueWallet)
+
+	schedule, _ := domain.NewFeeSchedule([]domain.FeeRule{
+		{TransactionType: domain.TransactionTypeWithdrawal, Type: domain.FeeTypeFlat, FlatAmount: 50},
+	})
+
+	feeService := usecase.NewFeeService(schedule, map[string]int{"USD": 99}, walletRepo, transactionRepo, nil)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	walletService.SetFeeService(feeService)
+
+	transactionService := usecase.NewTransactionService(transactionRepo, walletRepo, nil, nil)
+	transactionService.SetFeeService(feeService)
+
+	transaction, err := walletService.Withdraw(ctx, 1, 500, "Cash out")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The payer is charged and the fee is kept on record
+	updatedWallet, _ := walletRepo.FindByID(ctx, 1)
+	if updatedWallet.Balance != 450 {
+		t.Errorf("expected balance 450, got %d", updatedWallet.Balance)
+	}
+
+	revenueTransactions, _ := transactionRepo.FindByWalletID(ctx, 99, 10, 0)
+	if len(revenueTransactions) != 1 || revenueTransactions[0].Status != domain.TransactionStatusPending ||
+		revenueTransactions[0].Reference != usecase.FeeReference(transaction.ID) {
+		t.Fatalf("expected a pending fee transaction, got %+v", revenueTransactions)
+	}
+
+	// Once the revenue wallet is active again the stale fee is credited
+	revenueWallet.Status = domain.WalletStatusActive
+	_ = walletRepo.Save(ctx, revenueWallet)
+
+	feeTransaction := revenueTransactions[0]
+	feeTransaction.CreatedAt = feeTransaction.CreatedAt.Add(-time.Hour)
+	_ = transactionRepo.Update(ctx, feeTransaction)
+
+	if err := transactionService.ReconcileFailedTransactions(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updatedRevenue, _ := walletRepo.FindByID(ctx, 99)
+	if updatedRevenue.Balance != 50 {
+		t.Errorf("expected revenue balance 50, got %d", updatedRevenue.Balance)
+	}
+
+	feeTransaction, _ = transactionRepo.FindByID(ctx, feeTransaction.ID)
+	if feeTransaction.Status != domain.TransactionStatusCompleted {
+		t.Errorf("expected the fee to be completed, got %s", feeTransaction.Status)
+	}
+}
+
+func TestFeeService_QuoteFee(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	wallet := domain.NewWallet(1, "EUR", "Test wallet")
+	wallet.ID = 1
+	_ = walletRepo.Save(ctx, wallet)
+
+	schedule, _ := domain.NewFeeSchedule([]domain.FeeRule{
+		{TransactionType: domain.TransactionTypeTransfer, Type: domain.FeeTypePercentage, RateBPS: 150},
+	})
+
+	feeService := usecase.NewFeeService(schedule, map[string]int{"USD": 99}, walletRepo, transactionRepo, nil)
+
+	quote, err := feeService.QuoteFee(ctx, primary.FeeQuoteRequest{
+		TransactionType: domain.TransactionTypeTransfer,
+		Amount:          2000,
+		Currency:        "USD",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if quote.Fee != 30 || quote.Total != 2030 {
+		t.Errorf("expected fee 30 and total 2030, got %d and %d", quote.Fee, quote.Total)
+	}
+
+	// The wallet currency has no revenue wallet to collect into
+	_, err = feeService.QuoteFee(ctx, primary.FeeQuoteRequest{
+		WalletID:        1,
+		TransactionType: domain.TransactionTypeTransfer,
+		Amount:          2000,
+	})
+	if !errors.Is(err, usecase.ErrRevenueWalletNotConfigured) {
+		t.Errorf("expected ErrRevenueWalletNotConfigured, got %v", err)
+	}
+}
+
+func TestPaymentService_VerifyCreditsFullAmountWithoutFeeService(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	db := openSQLite(t, filepath.Join(t.TempDir(), "ewallet.db"))
+	wallet := seedSQLiteWallet(t, ctx, db, "payer@example.com", 0)
+
+	walletRepo := sqlite.NewSQLiteWalletRepository(db)
+	transactionRepo := sqlite.NewSQLiteTransactionRepository(db)
+	paymentRepo := sqlite.NewSQLitePaymentRepository(db)
+
+	// The payment was started while fees were enabled
+	transaction, _ := domain.NewTransaction(wallet.ID, domain.TransactionTypeDeposit, 1000, "Card top up")
+	transaction.Status = domain.TransactionStatusPending
+	transaction.Fee = 30
+	if err := transactionRepo.Create(ctx, transaction); err != nil {
+		t.Fatalf("failed to save transaction: %v", err)
+	}
+
+	payment, _ := domain.NewPayment(transaction.ID, 1000, domain.PaymentProviderStripe, "Card top up")
+	payment.ExternalID = "pi_123"
+	if err := paymentRepo.Create(ctx, payment); err != nil {
+		t.Fatalf("failed to save payment: %v", err)
+	}
+
+	paymentService := usecase.NewPaymentService(paymentRepo, walletRepo, transactionRepo, nil, nil)
+	paymentService.RegisterGateway(domain.PaymentProviderStripe, completedGateway{})
+
+	verified, err := paymentService.VerifyPayment(ctx, payment.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if verified.Status != domain.PaymentStatusCompleted {
+		t.Errorf("expected the payment to be completed, got %s", verified.Status)
+	}
+
+	// No fee was reserved, so none is withheld from the customer
+	updatedWallet, _ := walletRepo.FindByID(ctx, wallet.ID)
+	if updatedWallet.Balance != 1000 {
+		t.Errorf("expected balance 1000, got %d", updatedWallet.Balance)
+	}
+
+	// The transaction records that no fee was withheld
+	transaction, _ = transactionRepo.FindByID(ctx, transaction.ID)
+	if transaction.Status != domain.TransactionStatusCompleted || transaction.Fee != 0 {
+		t.Errorf("expected a completed transaction without fee, got %s and %d", transaction.Status, transaction.Fee)
+	}
+}
+
+func TestTransactionService_ReconcileResolvesChargedTransactions(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	for id := 1; id <= 3; id++ {
+		wallet := domain.NewWallet(id, "USD", "Test wallet")
+		wallet.ID = id
+		_ = walletRepo.Save(ctx, wallet)
+	}
+
+	revenueWallet := domain.NewWallet(99, "USD", "Fee revenue")
+	revenueWallet.ID = 99
+	_ = walletRepo.Save(ctx, revenueWallet)
+
+	schedule, _ := domain.NewFeeSchedule([]domain.FeeRule{
+		{TransactionType: domain.TransactionTypeWithdrawal, Type: domain.FeeTypeFlat, FlatAmount: 50},
+		{TransactionType: domain.TransactionTypeTransfer, Type: domain.FeeTypeFlat, FlatAmount: 20},
+	})
+
+	feeService := usecase.NewFeeService(schedule, map[string]int{"USD": 99}, walletRepo, transactionRepo, nil)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	transactionService := usecase.NewTransactionService(transactionRepo, walletRepo, nil, nil)
+	transactionService.SetFeeService(feeService)
+
+	// Fund the wallets through recorded deposits so their balances match their transactions
+	_, _ = walletService.Deposit(ctx, 1, 1000, "Top up")
+	_, _ = walletService.Deposit(ctx, 2, 1000, "Top up")
+
+	// stuck leaves a stale pending transaction with its reserved fee,
+	// charging the payer and crediting the payee as requested
+	stuck := func(transaction *domain.Transaction, charged, credited bool) *domain.Transaction {
+		transaction.Status = domain.TransactionStatusPending
+		transaction.CreatedAt = time.Now().Add(-time.Hour)
+		_ = transactionRepo.Create(ctx, transaction)
+
+		payer, _ := walletRepo.FindByID(ctx, transaction.WalletID)
+		feeTransaction, err := feeService.Reserve(ctx, payer, transaction)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		feeTransaction.CreatedAt = time.Now().Add(-time.Hour)
+		_ = transactionRepo.Update(ctx, feeTransaction)
+
+		if charged {
+			_ = payer.Debit(transaction.Amount + transaction.Fee)
+			_ = walletRepo.Save(ctx, payer)
+		}
+
+		if credited {
+			payee, _ := walletRepo.FindByID(ctx, *transaction.ToWalletID)
+			_ = payee.Credit(transaction.Amount)
+			_ = walletRepo.Save(ctx, payee)
+		}
+
+		return feeTransaction
+	}
+
+	// The withdrawal went through but was never marked completed
+	withdrawal, _ := domain.NewTransaction(1, domain.TransactionTypeWithdrawal, 300, "Cash out")
+	withdrawal.Fee = 50
+	withdrawalFee := stuck(withdrawal, true, false)
+
+	// The transfer charged the payer but never reached the payee
+	transfer, _ := domain.NewTransferTransaction(2, 3, 200, "Rent")
+	transfer.Fee = 20
+	transferFee := stuck(transfer, true, false)
+
+	if err := transactionService.ReconcileFailedTransactions(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantStatuses := map[int]domain.TransactionStatus{
+		withdrawal.ID:    domain.TransactionStatusCompleted,
+		withdrawalFee.ID: domain.TransactionStatusCompleted,
+		transfer.ID:      domain.TransactionStatusFailed,
+		transferFee.ID:   domain.TransactionStatusFailed,
+	}
+	for id, want := range wantStatuses {
+		transaction, _ := transactionRepo.FindByID(ctx, id)
+		if transaction.Status != want {
+			t.Errorf("expected transaction %d to be %s, got %s", id, want, transaction.Status)
+		}
+	}
+
+	// The withdrawal fee reached the revenue wallet and the transfer payer got amount and fee back
+	for id, want := range map[int]int{1: 650, 2: 1000, 3: 0, 99: 50} {
+		wallet, _ := walletRepo.FindByID(ctx, id)
+		if wallet.Balance != want {
+			t.Errorf("expected wallet %d balance %d, got %d", id, want, wallet.Balance)
+		}
+	}
+
+	// A transaction that moved no funds is failed and its fee cancelled
+	untouched, _ := domain.NewTransferTransaction(2, 3, 100, "Groceries")
+	untouched.Fee = 20
+	untouchedFee := stuck(untouched, false, false)
+
+	if err := transactionService.ReconcileFailedTransactions(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	untouched, _ = transactionRepo.FindByID(ctx, untouched.ID)
+	untouchedFee, _ = transactionRepo.FindByID(ctx, untouchedFee.ID)
+	if untouched.Status != domain.TransactionStatusFailed || untouchedFee.Status != domain.TransactionStatusFailed {
+		t.Errorf("expected the transfer and its fee to fail, got %s and %s", untouched.Status, untouchedFee.Status)
+	}
+
+	if wallet, _ := walletRepo.FindByID(ctx, 2); wallet.Balance != 1000 {
+		t.Errorf("expected wallet 2 balance 1000, got %d", wallet.Balance)
+	}
+}
+
+func TestTransactionService_ManualFailRefundsChargeAndCancelsFee(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	for id := 1; id <= 3; id++ {
+		wallet := domain.NewWallet(id, "USD", "Test wallet")
+		wallet.ID = id
+		_ = walletRepo.Save(ctx, wallet)
+	}
+
+	revenueWallet := domain.NewWallet(99, "USD", "Fee revenue")
+	revenueWallet.ID = 99
+	_ = walletRepo.Save(ctx, revenueWallet)
+
+	schedule, _ := domain.NewFeeSchedule([]domain.FeeRule{
+		{TransactionType: domain.TransactionTypeWithdrawal, Type: domain.FeeTypeFlat, FlatAmount: 50},
+		{TransactionType: domain.TransactionTypeTransfer, Type: domain.FeeTypeFlat, FlatAmount: 20},
+	})
+
+	feeService := usecase.NewFeeService(schedule, map[string]int{"USD": 99}, walletRepo, transactionRepo, nil)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	transactionService := usecase.NewTransactionService(transactionRepo, walletRepo, nil, nil)
+	transactionService.SetFeeService(feeService)
+
+	_, _ = walletService.Deposit(ctx, 1, 1000, "Top up")
+	_, _ = walletService.Deposit(ctx, 2, 1000, "Top up")
+
+	// The withdrawal charged the payer, but the provider never paid it out
+	withdrawal, _ := domain.NewTransaction(1, domain.TransactionTypeWithdrawal, 300, "Cash out")
+	withdrawal.Fee = 50
+	withdrawal.Status = domain.TransactionStatusPending
+	_ = transactionRepo.Create(ctx, withdrawal)
+
+	payer, _ := walletRepo.FindByID(ctx, 1)
+	withdrawalFee, _ := feeService.Reserve(ctx, payer, withdrawal)
+	_ = payer.Debit(350)
+	_ = walletRepo.Save(ctx, payer)
+
+	err := transactionService.UpdateTransactionStatus(ctx, withdrawal.ID, domain.TransactionStatusFailed, "Rejected by the provider", "user:1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	withdrawal, _ = transactionRepo.FindByID(ctx, withdrawal.ID)
+	withdrawalFee, _ = transactionRepo.FindByID(ctx, withdrawalFee.ID)
+	if withdrawal.Status != domain.TransactionStatusFailed || withdrawalFee.Status != domain.TransactionStatusFailed {
+		t.Errorf("expected the withdrawal and its fee to fail, got %s and %s", withdrawal.Status, withdrawalFee.Status)
+	}
+
+	// Amount and fee are back with the payer and nothing reached the revenue wallet
+	for id, want := range map[int]int{1: 1000, 99: 0} {
+		wallet, _ := walletRepo.FindByID(ctx, id)
+		if wallet.Balance != want {
+			t.Errorf("expected wallet %d balance %d, got %d", id, want, wallet.Balance)
+		}
+	}
+
+	// A transfer that already paid the payee cannot be failed by hand
+	transfer, _ := domain.NewTransferTransaction(2, 3, 200, "Rent")
+	transfer.Fee = 20
+	transfer.Status = domain.TransactionStatusPending
+	_ = transactionRepo.Create(ctx, transfer)
+
+	payer, _ = walletRepo.FindByID(ctx, 2)
+	transferFee, _ := feeService.Reserve(ctx, payer, transfer)
+	_ = payer.Debit(220)
+	_ = walletRepo.Save(ctx, payer)
+
+	payee, _ := walletRepo.FindByID(ctx, 3)
+	_ = payee.Credit(200)
+	_ = walletRepo.Save(ctx, payee)
+
+	err = transactionService.UpdateTransactionStatus(ctx, transfer.ID, domain.TransactionStatusFailed, "Customer complaint", "user:1")
+	if !errors.Is(err, domain.ErrInvalidTransactionStatusTransition) {
+		t.Fatalf("expected ErrInvalidTransactionStatusTransition, got %v", err)
+	}
+
+	// Nor can its fee, which follows the transfer
+	err = transactionService.UpdateTransactionStatus(ctx, transferFee.ID, domain.TransactionStatusFailed, "Customer complaint", "user:1")
+	if !errors.Is(err, domain.ErrInvalidTransactionStatusTransition) {
+		t.Fatalf("expected ErrInvalidTransactionStatusTransition, got %v", err)
+	}
+
+	transfer, _ = transactionRepo.FindByID(ctx, transfer.ID)
+	transferFee, _ = transactionRepo.FindByID(ctx, transferFee.ID)
+	if transfer.Status != domain.TransactionStatusPending || transferFee.Status != domain.TransactionStatusPending {
+		t.Errorf("expected the transfer and its fee to stay pending, got %s and %s", transfer.Status, transferFee.Status)
+	}
+}
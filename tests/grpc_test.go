@@ -0,0 +1,241 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"ports-and-adapters-architecture/api/rpc"
+	"ports-and-adapters-architecture/api/rpc/ewalletpb"
+	"ports-and-adapters-architecture/internal/adapters/auth"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/usecase"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// setupGRPC serves the gRPC API over an in-memory listener with wallets 1 and
+// 2 holding 1000 each, owned by users 1 and 2
+func setupGRPC(t *testing.T, ctx context.Context) (*grpc.ClientConn, *auth.JWTService) {
+	t.Helper()
+
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	for userID := 1; userID <= 2; userID++ {
+		wallet := domain.NewWallet(userID, "USD", "Main wallet")
+		wallet.ID = userID
+		wallet.Balance = 1000
+		_ = walletRepo.Save(ctx, wallet)
+	}
+
+	userService := usecase.NewUserService(userRepo, nil, nil)
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	transactionService := usecase.NewTransactionService(transactionRepo, walletRepo, nil, nil)
+	tokens := newTestTokenService(t)
+
+	server := rpc.NewServer(userService, walletService, transactionService, nil, tokens, rpc.Config{
+		WatchInterval: 10 * time.Millisecond,
+	})
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial gRPC server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn, tokens
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func TestGRPC_Authorization(t *testing.T) {
+	ctx := context.Background()
+	conn, tokens := setupGRPC(t, ctx)
+	wallets := ewalletpb.NewWalletServiceClient(conn)
+	users := ewalletpb.NewUserServiceClient(conn)
+
+	owner := issueTestToken(t, tokens, 1)
+	admin := issueTestToken(t, tokens, 99, domain.RoleAdmin)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		call func(ctx context.Context) error
+		code codes.Code
+	}{
+		{"MissingToken", ctx, func(ctx context.Context) error {
+			_, err := wallets.GetWallet(ctx, &ewalletpb.GetWalletRequest{WalletId: 1})
+			return err
+		}, codes.Unauthenticated},
+		{"InvalidToken", withToken(ctx, "bogus"), func(ctx context.Context) error {
+			_, err := wallets.GetWallet(ctx, &ewalletpb.GetWalletRequest{WalletId: 1})
+			return err
+		}, codes.Unauthenticated},
+		{"OwnerReadsWallet", withToken(ctx, owner), func(ctx context.Context) error {
+			_, err := wallets.GetWallet(ctx, &ewalletpb.GetWalletRequest{WalletId: 1})
+			return err
+		}, codes.OK},
+		{"OtherUsersWallet", withToken(ctx, owner), func(ctx context.Context) error {
+			_, err := wallets.Withdraw(ctx, &ewalletpb.WithdrawRequest{WalletId: 2, Amount: 100})
+			return err
+		}, codes.PermissionDenied},
+		{"OtherUsersWalletList", withToken(ctx, owner), func(ctx context.Context) error {
+			_, err := wallets.ListUserWallets(ctx, &ewalletpb.ListUserWalletsRequest{UserId: 2})
+			return err
+		}, codes.PermissionDenied},
+		{"AdminBypassesOwnership", withToken(ctx, admin), func(ctx context.Context) error {
+			_, err := wallets.Withdraw(ctx, &ewalletpb.WithdrawRequest{WalletId: 2, Amount: 100})
+			return err
+		}, codes.OK},
+		{"CustomerCreatesUser", withToken(ctx, owner), func(ctx context.Context) error {
+			_, err := users.CreateUser(ctx, &ewalletpb.CreateUserRequest{Fullname: "Jane Doe", Email: "jane@example.com", Phone: "+6281234567890"})
+			return err
+		}, codes.PermissionDenied},
+		{"AdminCreatesUser", withToken(ctx, admin), func(ctx context.Context) error {
+			_, err := users.CreateUser(ctx, &ewalletpb.CreateUserRequest{Fullname: "Jane Doe", Email: "jane@example.com", Phone: "+6281234567890"})
+			return err
+		}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(tt.ctx)
+			if status.Code(err) != tt.code {
+				t.Errorf("expected %s, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestGRPC_WalletOperations(t *testing.T) {
+	ctx := context.Background()
+	conn, tokens := setupGRPC(t, ctx)
+	wallets := ewalletpb.NewWalletServiceClient(conn)
+	ctx = withToken(ctx, issueTestToken(t, tokens, 1))
+
+	transaction, err := wallets.Deposit(ctx, &ewalletpb.DepositRequest{WalletId: 1, Amount: 500, Description: "Top up"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if transaction.GetType() != string(domain.TransactionTypeDeposit) || transaction.GetAmount() != 500 {
+		t.Errorf("expected a deposit of 500, got %+v", transaction)
+	}
+
+	transfer, err := wallets.Transfer(ctx, &ewalletpb.TransferRequest{WalletId: 1, ToWalletId: 2, Amount: 300})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if transfer.ToWalletId == nil || transfer.GetToWalletId() != 2 {
+		t.Errorf("expected a transfer to wallet 2, got %+v", transfer)
+	}
+
+	balance, err := wallets.GetBalance(ctx, &ewalletpb.GetBalanceRequest{WalletId: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if balance.GetBalance() != 1200 || balance.GetCurrencyCode() != "USD" {
+		t.Errorf("expected 1200 USD, got %+v", balance)
+	}
+
+	page, err := wallets.ListTransactions(ctx, &ewalletpb.ListTransactionsRequest{WalletId: 1, Limit: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.GetTransactions()) != 1 || page.GetNextCursor() == "" {
+		t.Errorf("expected one transaction and a cursor, got %+v", page)
+	}
+
+	errorTests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"InvalidAmount", func() error {
+			_, err := wallets.Deposit(ctx, &ewalletpb.DepositRequest{WalletId: 1})
+			return err
+		}, codes.InvalidArgument},
+		{"InsufficientBalance", func() error {
+			_, err := wallets.Withdraw(ctx, &ewalletpb.WithdrawRequest{WalletId: 1, Amount: 5000})
+			return err
+		}, codes.InvalidArgument},
+		{"UnknownWallet", func() error {
+			_, err := wallets.Transfer(ctx, &ewalletpb.TransferRequest{WalletId: 1, ToWalletId: 7, Amount: 10})
+			return err
+		}, codes.NotFound},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if status.Code(err) != tt.code {
+				t.Errorf("expected %s, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestGRPC_WatchWallet(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, tokens := setupGRPC(t, ctx)
+	wallets := ewalletpb.NewWalletServiceClient(conn)
+	ctx = withToken(ctx, issueTestToken(t, tokens, 1))
+
+	stream, err := wallets.WatchWallet(ctx, &ewalletpb.WatchWalletRequest{WalletId: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	wallet, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("expected the current wallet, got %v", err)
+	}
+	if wallet.GetBalance() != 1000 {
+		t.Errorf("expected a balance of 1000, got %d", wallet.GetBalance())
+	}
+
+	if _, err := wallets.Deposit(ctx, &ewalletpb.DepositRequest{WalletId: 1, Amount: 250}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	wallet, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("expected the updated wallet, got %v", err)
+	}
+	if wallet.GetBalance() != 1250 {
+		t.Errorf("expected a balance of 1250, got %d", wallet.GetBalance())
+	}
+
+	// Other users cannot watch the wallet
+	other := withToken(context.Background(), issueTestToken(t, tokens, 2))
+	denied, err := wallets.WatchWallet(other, &ewalletpb.WatchWalletRequest{WalletId: 1})
+	if err == nil {
+		_, err = denied.Recv()
+	}
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+}
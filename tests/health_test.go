@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/usecase"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// countingChecker fails with err and counts how often it is called
+type countingChecker struct {
+	err   error
+	calls atomic.Int32
+}
+
+func (c *countingChecker) CheckHealth(ctx context.Context) error {
+	c.calls.Add(1)
+	return c.err
+}
+
+func TestHealthService_CriticalAndDegradedDependencies(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		databaseErr error
+		cacheErr    error
+		status      domain.HealthStatus
+	}{
+		{"all up", nil, nil, domain.HealthStatusUp},
+		{"non critical down", nil, errors.New("connection refused"), domain.HealthStatusDegraded},
+		{"critical down", errors.New("connection refused"), nil, domain.HealthStatusDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthService := usecase.NewHealthService(usecase.HealthConfig{})
+			healthService.Register("postgres", &countingChecker{err: tt.databaseErr}, true)
+			healthService.Register("redis", &countingChecker{err: tt.cacheErr}, false)
+
+			report := healthService.Readiness(ctx)
+			if report.Status != tt.status {
+				t.Errorf("expected status %s, got %s", tt.status, report.Status)
+			}
+			if len(report.Components) != 2 || report.Components[0].Name != "postgres" || report.Components[1].Name != "redis" {
+				t.Fatalf("expected a component per dependency in registration order, got %+v", report.Components)
+			}
+			if tt.cacheErr != nil && report.Components[1].Error != tt.cacheErr.Error() {
+				t.Errorf("expected the check error to be reported, got %q", report.Components[1].Error)
+			}
+
+			if live := healthService.Liveness(ctx); live.Status != domain.HealthStatusUp {
+				t.Errorf("expected liveness not to depend on dependencies, got %s", live.Status)
+			}
+		})
+	}
+}
+
+func TestHealthService_TimeoutAndCaching(t *testing.T) {
+	ctx := context.Background()
+
+	hanging := infrastructure.HealthCheckFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	cached := &countingChecker{}
+
+	healthService := usecase.NewHealthService(usecase.HealthConfig{
+		Timeout:  50 * time.Millisecond,
+		CacheTTL: time.Minute,
+	})
+	healthService.Register("kafka", hanging, false)
+	healthService.Register("redis", cached, false)
+
+	start := time.Now()
+	report := healthService.Readiness(ctx)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the check to time out, took %v", elapsed)
+	}
+	if report.Components[0].Status != domain.HealthStatusDown {
+		t.Errorf("expected the hanging dependency to be down, got %s", report.Components[0].Status)
+	}
+
+	healthService.Readiness(ctx)
+	healthService.Readiness(ctx)
+	if calls := cached.calls.Load(); calls != 1 {
+		t.Errorf("expected the result to be cached, got %d checks", calls)
+	}
+}
+
+func TestHealthHandler_StatusCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		critical bool
+		path     string
+		code     int
+	}{
+		{"ready with degraded dependency", false, "/health/ready", http.StatusOK},
+		{"ready with critical dependency down", true, "/health/ready", http.StatusServiceUnavailable},
+		{"legacy path with critical dependency down", true, "/health", http.StatusServiceUnavailable},
+		{"live with critical dependency down", true, "/health/live", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthService := usecase.NewHealthService(usecase.HealthConfig{})
+			healthService.Register("postgres", &countingChecker{err: errors.New("connection refused")}, tt.critical)
+
+			healthHandler := handlers.NewHealthHandler(healthService)
+			e := echo.New()
+			e.GET("/health", healthHandler.Ready)
+			e.GET("/health/live", healthHandler.Live)
+			e.GET("/health/ready", healthHandler.Ready)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.code {
+				t.Errorf("expected status code %d, got %d", tt.code, rec.Code)
+			}
+
+			var report domain.HealthReport
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatalf("expected a health report, got %s", rec.Body.String())
+			}
+		})
+	}
+}
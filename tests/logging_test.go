@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"ports-and-adapters-architecture/api/rest"
+	"ports-and-adapters-architecture/internal/adapters/logging"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/usecase"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// requestIDPublisher hands the request ID of every published event to ids
+type requestIDPublisher struct {
+	recordingPublisher
+	ids chan string
+}
+
+func (p *requestIDPublisher) Publish(ctx context.Context, topic string, event infrastructure.Event) error {
+	p.ids <- domain.RequestIDFromContext(ctx)
+	return nil
+}
+
+// syncBuffer lets the test read log lines written by other goroutines
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSlogLogger_LevelFormatAndRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.NewSlogLogger(logging.Config{Level: "warn", Format: "json"}, &buf)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ctx := domain.ContextWithRequestID(context.Background(), "req-1")
+	logger.Info(ctx, "below the level")
+	logger.With("component", "test").Warn(ctx, "wallet locked", "wallet_id", 7)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected only the warning to be logged, got %q", buf.String())
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("expected a JSON log line, got %q", lines[0])
+	}
+	if entry["msg"] != "wallet locked" || entry["level"] != "WARN" || entry["request_id"] != "req-1" ||
+		entry["component"] != "test" || entry["wallet_id"] != float64(7) {
+		t.Errorf("unexpected log entry %v", entry)
+	}
+
+	buf.Reset()
+	textLogger, err := logging.NewSlogLogger(logging.Config{Level: "debug", Format: "text"}, &buf)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	textLogger.Debug(context.Background(), "details")
+	if !strings.Contains(buf.String(), "level=DEBUG msg=details") || strings.Contains(buf.String(), "request_id") {
+		t.Errorf("expected a text line without request ID, got %q", buf.String())
+	}
+
+	if _, err := logging.NewSlogLogger(logging.Config{Level: "loud"}, &buf); err == nil {
+		t.Error("expected an invalid level to be rejected")
+	}
+	if _, err := logging.NewSlogLogger(logging.Config{Format: "xml"}, &buf); err == nil {
+		t.Error("expected an invalid format to be rejected")
+	}
+}
+
+func TestRequestID_PropagatesFromHTTPToEvents(t *testing.T) {
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	publisher := &requestIDPublisher{ids: make(chan string, 1)}
+
+	user := domain.NewUser("Alice", "alice@example.com", "+628123456789")
+	_ = userRepo.Save(ctx, user)
+
+	userService := usecase.NewUserService(userRepo, nil, nil)
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, publisher, nil)
+	transactionService := usecase.NewTransactionService(transactionRepo, walletRepo, nil, nil)
+	tokens := newTestTokenService(t)
+
+	logs := &syncBuffer{}
+	logger, err := logging.NewSlogLogger(logging.Config{Level: "info", Format: "json"}, logs)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	e := echo.New()
+	rest.SetupRoutes(e, userService, walletService, transactionService, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, tokens, nil, logger)
+
+	body := fmt.Sprintf(`{"user_id":%d,"currency_code":"USD","description":"Main wallet"}`, user.ID)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallets", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+issueTestToken(t, tokens, user.ID))
+	req.Header.Set(echo.HeaderXRequestID, "trace-42")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get(echo.HeaderXRequestID) != "trace-42" {
+		t.Errorf("expected the caller's request ID to be echoed, got %q", rec.Header().Get(echo.HeaderXRequestID))
+	}
+
+	select {
+	case id := <-publisher.ids:
+		if id != "trace-42" {
+			t.Errorf("expected the event to carry request ID trace-42, got %q", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the wallet created event to be published")
+	}
+
+	if !strings.Contains(logs.String(), `"request_id":"trace-42"`) {
+		t.Errorf("expected the request to be logged under its request ID, got %q", logs.String())
+	}
+}
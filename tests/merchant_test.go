@@ -0,0 +1,314 @@
+package tests
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/internal/adapters/auth"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/usecase"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+func newTestMerchantService(t *testing.T, ctx context.Context, environment domain.MerchantEnvironment) (*usecase.MerchantService, *memory.InMemoryUserRepository) {
+	t.Helper()
+
+	secretBox, err := auth.NewAESSecretBox(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	if err != nil {
+		t.Fatalf("failed to create secret box: %v", err)
+	}
+
+	userRepo := memory.NewInMemoryUserRepository()
+	_ = userRepo.Save(ctx, domain.NewUser("Merchant Owner", "owner@example.com", "+6281234567890"))
+
+	return usecase.NewMerchantService(
+		memory.NewInMemoryMerchantRepository(),
+		userRepo,
+		secretBox,
+		memory.NewInMemoryCache(),
+		usecase.MerchantConfig{
+			Environment:        environment,
+			SignatureTolerance: 5 * time.Minute,
+			RotationOverlap:    time.Hour,
+		},
+	), userRepo
+}
+
+func setupMerchantRoutes(t *testing.T, ctx context.Context, merchantService *usecase.MerchantService, userRepo *memory.InMemoryUserRepository) *echo.Echo {
+	t.Helper()
+
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	for userID := 1; userID <= 2; userID++ {
+		wallet := domain.NewWallet(userID, "USD", "Main wallet")
+		wallet.ID = userID
+		wallet.Balance = 1000
+		_ = walletRepo.Save(ctx, wallet)
+	}
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	transactionService := usecase.NewTransactionService(transactionRepo, walletRepo, nil, nil)
+	authorizer := handlers.NewAuthorizer(walletService, transactionService)
+	walletHandler := handlers.NewWalletHandler(walletService, nil, authorizer)
+
+	e := echo.New()
+	e.Validator = &testValidator{validator: validator.New()}
+
+	v1 := e.Group("/api/v1", handlers.Authenticate(newTestTokenService(t), merchantService))
+	ownsWallet := authorizer.WalletOwner("id")
+	canRead := handlers.RequireScope(domain.APIScopeRead)
+	canTransfer := handlers.RequireScope(domain.APIScopeTransfers)
+
+	wallets := v1.Group("/wallets")
+	wallets.GET("/:id", walletHandler.GetWallet, canRead, ownsWallet)
+	wallets.POST("/:id/withdraw", walletHandler.Withdraw, canTransfer, ownsWallet)
+
+	users := v1.Group("/users", handlers.RejectAPIClients())
+	users.GET("/:user_id", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	return e
+}
+
+func signRequest(method, target, body, apiKey, secret string, timestamp time.Time) *http.Request {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(domain.APISigningString(method, target, timestamp.Unix(), []byte(body))))
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(handlers.HeaderAPIKey, apiKey)
+	req.Header.Set(handlers.HeaderAPITimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(handlers.HeaderAPISignature, hex.EncodeToString(mac.Sum(nil)))
+
+	return req
+}
+
+func serve(e *echo.Echo, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMerchant_SignedRequests(t *testing.T) {
+	ctx := context.Background()
+	merchantService, userRepo := newTestMerchantService(t, ctx, domain.MerchantEnvironmentSandbox)
+	e := setupMerchantRoutes(t, ctx, merchantService, userRepo)
+
+	_, credentials, err := merchantService.CreateMerchant(ctx, primary.CreateMerchantRequest{
+		Name:        "Acme",
+		UserID:      1,
+		Environment: domain.MerchantEnvironmentSandbox,
+		Scopes:      []domain.APIScope{domain.APIScopeRead},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.HasPrefix(credentials.APIKey, "ek_test_") {
+		t.Errorf("expected sandbox key prefix, got %s", credentials.APIKey)
+	}
+	if credentials.Key.KeyHash == credentials.APIKey || strings.Contains(credentials.Key.SigningSecret, credentials.SigningSecret) {
+		t.Error("expected key and secret not to be stored in plain text")
+	}
+
+	now := time.Now()
+
+	t.Run("valid signature", func(t *testing.T) {
+		rec := serve(e, signRequest(http.MethodGet, "/api/v1/wallets/1", "", credentials.APIKey, credentials.SigningSecret, now))
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("replayed request", func(t *testing.T) {
+		req := signRequest(http.MethodGet, "/api/v1/wallets/1?replay=1", "", credentials.APIKey, credentials.SigningSecret, now)
+		if rec := serve(e, req); rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		replay := signRequest(http.MethodGet, "/api/v1/wallets/1?replay=1", "", credentials.APIKey, credentials.SigningSecret, now)
+		replay.Header.Set(handlers.HeaderAPISignature, strings.ToUpper(replay.Header.Get(handlers.HeaderAPISignature)))
+		if rec := serve(e, replay); rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		req := signRequest(http.MethodPost, "/api/v1/wallets/1/withdraw", `{"amount":10}`, credentials.APIKey, credentials.SigningSecret, now)
+		req.Body = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"amount":999}`)).Body
+
+		if rec := serve(e, req); rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("stale timestamp", func(t *testing.T) {
+		req := signRequest(http.MethodGet, "/api/v1/wallets/1", "", credentials.APIKey, credentials.SigningSecret, now.Add(-10*time.Minute))
+		if rec := serve(e, req); rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		req := signRequest(http.MethodGet, "/api/v1/wallets/1", "", "ek_test_unknown", credentials.SigningSecret, now)
+		if rec := serve(e, req); rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("missing scope", func(t *testing.T) {
+		req := signRequest(http.MethodPost, "/api/v1/wallets/1/withdraw", `{"amount":10}`, credentials.APIKey, credentials.SigningSecret, now)
+		if rec := serve(e, req); rec.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("wallet of another user", func(t *testing.T) {
+		req := signRequest(http.MethodGet, "/api/v1/wallets/2", "", credentials.APIKey, credentials.SigningSecret, now)
+		if rec := serve(e, req); rec.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("user routes", func(t *testing.T) {
+		req := signRequest(http.MethodGet, "/api/v1/users/1", "", credentials.APIKey, credentials.SigningSecret, now)
+		if rec := serve(e, req); rec.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", rec.Code)
+		}
+	})
+}
+
+func TestMerchant_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	merchantService, userRepo := newTestMerchantService(t, ctx, domain.MerchantEnvironmentSandbox)
+	e := setupMerchantRoutes(t, ctx, merchantService, userRepo)
+
+	merchant, oldCredentials, err := merchantService.CreateMerchant(ctx, primary.CreateMerchantRequest{
+		Name:        "Acme",
+		UserID:      1,
+		Environment: domain.MerchantEnvironmentSandbox,
+		Scopes:      []domain.APIScope{domain.APIScopeRead, domain.APIScopeTransfers},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	newCredentials, err := merchantService.RotateAPIKey(ctx, merchant.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Both keys work during the overlap
+	for i, credentials := range []*domain.APICredentials{oldCredentials, newCredentials} {
+		req := signRequest(http.MethodGet, "/api/v1/wallets/1", "", credentials.APIKey, credentials.SigningSecret, time.Now())
+		if rec := serve(e, req); rec.Code != http.StatusOK {
+			t.Errorf("key %d: expected status 200, got %d: %s", i, rec.Code, rec.Body.String())
+		}
+	}
+
+	keys, err := merchantService.GetAPIKeys(ctx, merchant.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != newCredentials.Key.ID {
+		t.Fatalf("expected 2 keys newest first, got %+v", keys)
+	}
+	if keys[0].ExpiresAt != nil || keys[1].ExpiresAt == nil {
+		t.Error("expected only the previous key to expire")
+	}
+
+	if err := merchantService.RevokeAPIKey(ctx, merchant.ID, oldCredentials.Key.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	req := signRequest(http.MethodGet, "/api/v1/wallets/1", "", oldCredentials.APIKey, oldCredentials.SigningSecret, time.Now())
+	if rec := serve(e, req); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 for a revoked key, got %d", rec.Code)
+	}
+
+	if err := merchantService.RevokeAPIKey(ctx, merchant.ID, 999); !errors.Is(err, usecase.ErrAPIKeyNotFound) {
+		t.Errorf("expected ErrAPIKeyNotFound, got %v", err)
+	}
+}
+
+func TestMerchant_EnvironmentMismatch(t *testing.T) {
+	ctx := context.Background()
+	merchantService, _ := newTestMerchantService(t, ctx, domain.MerchantEnvironmentLive)
+
+	_, credentials, err := merchantService.CreateMerchant(ctx, primary.CreateMerchantRequest{
+		Name:        "Acme",
+		UserID:      1,
+		Environment: domain.MerchantEnvironmentSandbox,
+		Scopes:      []domain.APIScope{domain.APIScopeRead},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	timestamp := time.Now().Unix()
+	mac := hmac.New(sha256.New, []byte(credentials.SigningSecret))
+	mac.Write([]byte(domain.APISigningString(http.MethodGet, "/api/v1/wallets/1", timestamp, nil)))
+
+	_, err = merchantService.AuthenticateRequest(ctx, primary.SignedRequest{
+		APIKey:    credentials.APIKey,
+		Timestamp: strconv.FormatInt(timestamp, 10),
+		Signature: hex.EncodeToString(mac.Sum(nil)),
+		Method:    http.MethodGet,
+		Path:      "/api/v1/wallets/1",
+	})
+	if !errors.Is(err, domain.ErrAPIKeyEnvironmentMismatch) {
+		t.Errorf("expected ErrAPIKeyEnvironmentMismatch, got %v", err)
+	}
+}
+
+func TestMerchant_CreateValidation(t *testing.T) {
+	ctx := context.Background()
+	merchantService, _ := newTestMerchantService(t, ctx, domain.MerchantEnvironmentSandbox)
+
+	tests := []struct {
+		name string
+		req  primary.CreateMerchantRequest
+		want error
+	}{
+		{
+			name: "unknown scope",
+			req:  primary.CreateMerchantRequest{Name: "Acme", UserID: 1, Environment: domain.MerchantEnvironmentSandbox, Scopes: []domain.APIScope{"admin"}},
+			want: domain.ErrInvalidAPIScope,
+		},
+		{
+			name: "unknown environment",
+			req:  primary.CreateMerchantRequest{Name: "Acme", UserID: 1, Environment: "STAGING", Scopes: []domain.APIScope{domain.APIScopeRead}},
+			want: domain.ErrInvalidMerchantEnvironment,
+		},
+		{
+			name: "unknown user",
+			req:  primary.CreateMerchantRequest{Name: "Acme", UserID: 42, Environment: domain.MerchantEnvironmentSandbox, Scopes: []domain.APIScope{domain.APIScopeRead}},
+			want: usecase.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := merchantService.CreateMerchant(ctx, tt.req)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
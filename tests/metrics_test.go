@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"ports-and-adapters-architecture/internal/adapters/metrics"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/infrastructure"
+	"ports-and-adapters-architecture/internal/usecase"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// scrapeMetrics returns the metrics exposition served by m
+func scrapeMetrics(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	return string(body)
+}
+
+func TestMetrics_WalletOperationsAndEvents(t *testing.T) {
+	ctx := context.Background()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	userRepo := memory.NewInMemoryUserRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	m := metrics.NewMetrics()
+
+	publisher := metrics.NewEventPublisher(&recordingPublisher{}, m)
+	walletService := metrics.NewWalletService(
+		usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil), m)
+
+	wallet := domain.NewWallet(1, "EUR", "Main wallet")
+	_ = walletRepo.Save(ctx, wallet)
+
+	if _, err := walletService.Deposit(ctx, wallet.ID, 500, "top up"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := walletService.Withdraw(ctx, wallet.ID, 1000, "too much"); err == nil {
+		t.Fatal("expected the withdrawal to fail")
+	}
+	if _, err := walletService.Deposit(ctx, 99, 500, "no wallet"); err == nil {
+		t.Fatal("expected the deposit to fail")
+	}
+
+	_ = publisher.Publish(ctx, "wallets", infrastructure.Event{Type: "wallet.deposit"})
+	_ = publisher.PublishBatch(ctx, "transactions", []infrastructure.Event{{Type: "a"}, {Type: "b"}})
+
+	failing := metrics.NewEventPublisher(&recordingPublisher{err: errors.New("broker down")}, m)
+	_ = failing.Publish(ctx, "wallets", infrastructure.Event{Type: "wallet.deposit"})
+
+	exposition := scrapeMetrics(t, m)
+	for _, want := range []string{
+		`ewallet_wallet_operations_total{currency="EUR",operation="deposit",status="completed"} 1`,
+		`ewallet_wallet_operations_total{currency="EUR",operation="withdrawal",status="failed"} 1`,
+		`ewallet_wallet_operations_total{currency="unknown",operation="deposit",status="failed"} 1`,
+		`ewallet_events_published_total{status="success",topic="wallets"} 1`,
+		`ewallet_events_published_total{status="success",topic="transactions"} 2`,
+		`ewallet_events_published_total{status="error",topic="wallets"} 1`,
+	} {
+		if !strings.Contains(exposition, want) {
+			t.Errorf("expected %s in\n%s", want, exposition)
+		}
+	}
+}
+
+func TestMetrics_HTTPMiddleware(t *testing.T) {
+	m := metrics.NewMetrics()
+
+	e := echo.New()
+	e.Use(m.HTTPMiddleware())
+	e.GET("/api/v1/wallets/:id", func(c echo.Context) error {
+		if c.Param("id") == "0" {
+			return echo.NewHTTPError(http.StatusNotFound, "Wallet not found")
+		}
+		return c.JSON(http.StatusOK, map[string]string{"status": "success"})
+	})
+
+	for _, path := range []string{"/api/v1/wallets/1", "/api/v1/wallets/2", "/api/v1/wallets/0", "/unknown"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	exposition := scrapeMetrics(t, m)
+	for _, want := range []string{
+		`ewallet_http_requests_total{code="200",method="GET",route="/api/v1/wallets/:id"} 2`,
+		`ewallet_http_requests_total{code="404",method="GET",route="/api/v1/wallets/:id"} 1`,
+		`ewallet_http_request_duration_seconds_count{method="GET",route="/api/v1/wallets/:id"} 3`,
+	} {
+		if !strings.Contains(exposition, want) {
+			t.Errorf("expected %s in\n%s", want, exposition)
+		}
+	}
+
+	if strings.Contains(exposition, `route="/unknown"`) {
+		t.Error("expected unknown paths not to get a route label of their own")
+	}
+}
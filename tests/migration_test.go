@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"ports-and-adapters-architecture/internal/adapters/persistence"
+	"ports-and-adapters-architecture/migrations"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestLoadMigrations_EmbeddedSchema(t *testing.T) {
+	loaded, err := persistence.LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("expected the embedded migrations to load, got %v", err)
+	}
+	if len(loaded) == 0 {
+		t.Fatal("expected embedded migrations")
+	}
+
+	for i, migration := range loaded {
+		if migration.Version != i+1 {
+			t.Errorf("expected version %d, got %d_%s", i+1, migration.Version, migration.Name)
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			t.Errorf("expected %d_%s to have a down migration", migration.Version, migration.Name)
+		}
+	}
+
+	schema := loaded[0].Up + loaded[1].Up + loaded[2].Up + loaded[3].Up
+	for _, table := range []string{"users", "wallets", "transactions", "payments"} {
+		if !strings.Contains(schema, "CREATE TABLE IF NOT EXISTS "+table+" (") {
+			t.Errorf("expected the initial migrations to create %s", table)
+		}
+	}
+	if !strings.Contains(schema, "CHECK (balance >= 0)") {
+		t.Error("expected wallet balances to be constrained to be non negative")
+	}
+}
+
+func TestLoadMigrations_InvalidFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		err   string
+	}{
+		{
+			name:  "missing version",
+			files: fstest.MapFS{"create_users.up.sql": {Data: []byte("SELECT 1")}},
+			err:   "invalid migration file name",
+		},
+		{
+			name:  "unknown direction",
+			files: fstest.MapFS{"001_create_users.sideways.sql": {Data: []byte("SELECT 1")}},
+			err:   "invalid migration file name",
+		},
+		{
+			name:  "down without up",
+			files: fstest.MapFS{"001_create_users.down.sql": {Data: []byte("DROP TABLE users")}},
+			err:   "has no up statements",
+		},
+		{
+			name: "conflicting names",
+			files: fstest.MapFS{
+				"001_create_users.up.sql":  {Data: []byte("SELECT 1")},
+				"001_create_people.up.sql": {Data: []byte("SELECT 1")},
+			},
+			err: "has two names",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := persistence.LoadMigrations(tt.files)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestLoadMigrations_OrdersByVersion(t *testing.T) {
+	loaded, err := persistence.LoadMigrations(fstest.MapFS{
+		"010_add_index.up.sql":      {Data: []byte("CREATE INDEX")},
+		"002_create_wallets.up.sql": {Data: []byte("CREATE TABLE wallets")},
+		"001_create_users.up.sql":   {Data: []byte("CREATE TABLE users")},
+		"001_create_users.down.sql": {Data: []byte("DROP TABLE users")},
+	})
+	if err != nil {
+		t.Fatalf("expected migrations to load, got %v", err)
+	}
+
+	if len(loaded) != 3 || loaded[0].Version != 1 || loaded[1].Version != 2 || loaded[2].Version != 10 {
+		t.Fatalf("expected versions 1, 2 and 10 in order, got %+v", loaded)
+	}
+	if loaded[0].Down != "DROP TABLE users" || loaded[1].Down != "" {
+		t.Errorf("expected down statements to be paired by version, got %+v", loaded[:2])
+	}
+}
+
+// TestPostgresMigrator_ConcurrentUp needs a disposable Postgres database named
+// by TEST_POSTGRES_HOST and TEST_POSTGRES_DB, it is skipped without them
+func TestPostgresMigrator_ConcurrentUp(t *testing.T) {
+	host, name := os.Getenv("TEST_POSTGRES_HOST"), os.Getenv("TEST_POSTGRES_DB")
+	if host == "" || name == "" {
+		t.Skip("TEST_POSTGRES_HOST and TEST_POSTGRES_DB are not set")
+	}
+
+	ctx := context.Background()
+	db, err := persistence.NewPostgresConnection(persistence.PostgresConfig{
+		Host:         host,
+		Port:         "5432",
+		Name:         name,
+		User:         "postgres",
+		Password:     "postgres",
+		MaxOpenConns: 5,
+		MaxIdleConns: 5,
+		// Shorter than the migration, and than the wait for the lock
+		OperationTimeout: 200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to Postgres: %v", err)
+	}
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"001_slow_table.up.sql":   {Data: []byte("SELECT pg_sleep(1); CREATE TABLE migrator_lock_test (id INTEGER)")},
+		"001_slow_table.down.sql": {Data: []byte("DROP TABLE migrator_lock_test")},
+	}
+
+	var wg sync.WaitGroup
+	applied := make([]int, 2)
+	errs := make([]error, 2)
+	for i := range 2 {
+		migrator, err := persistence.NewPostgresMigrator(db, fsys)
+		if err != nil {
+			t.Fatalf("failed to load migrations: %v", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			migrations, err := migrator.Up(ctx)
+			applied[i], errs[i] = len(migrations), err
+		}()
+	}
+	wg.Wait()
+
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("expected both migrators to wait for the lock, got %v and %v", errs[0], errs[1])
+	}
+	if applied[0]+applied[1] != 1 {
+		t.Errorf("expected the migration to be applied once, got %d and %d", applied[0], applied[1])
+	}
+
+	// The pooled connections keep the operation timeout
+	if _, err := db.ExecContext(ctx, "SELECT pg_sleep(1)"); err == nil {
+		t.Error("expected the operation timeout to be restored after migrating")
+	}
+
+	migrator, _ := persistence.NewPostgresMigrator(db, fsys)
+	if _, err := migrator.Down(ctx, 1); err != nil {
+		t.Errorf("failed to roll back the test migration: %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"ports-and-adapters-architecture/api/rest"
+	"ports-and-adapters-architecture/api/rest/openapi"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/usecase"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+)
+
+var echoPathParam = regexp.MustCompile(`:([a-z_]+)`)
+
+func loadTestSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("failed to load OpenAPI spec: %v", err)
+	}
+
+	return doc
+}
+
+// setupOpenAPIRoutes registers the real routes on top of in-memory services
+// with users 1 and 2 and a USD wallet each
+func setupOpenAPIRoutes(t *testing.T, ctx context.Context) (*echo.Echo, string) {
+	t.Helper()
+
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	userService := usecase.NewUserService(userRepo, nil, nil)
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	transactionService := usecase.NewTransactionService(transactionRepo, walletRepo, nil, nil)
+	feeService := usecase.NewFeeService(&domain.FeeSchedule{
+		Rules: []domain.FeeRule{{TransactionType: domain.TransactionTypeWithdrawal, Type: domain.FeeTypeFlat, FlatAmount: 5}},
+	}, map[string]int{"USD": 2}, walletRepo, transactionRepo, nil)
+	tokens := newTestTokenService(t)
+
+	for userID := 1; userID <= 2; userID++ {
+		user := domain.NewUser("Test User", fmt.Sprintf("user%d@example.com", userID), fmt.Sprintf("+62812345678%d", userID))
+		_ = userRepo.Save(ctx, user)
+
+		wallet := domain.NewWallet(user.ID, "USD", "Main wallet")
+		wallet.Balance = 1000
+		_ = walletRepo.Save(ctx, wallet)
+	}
+
+	e := echo.New()
+	rest.SetupRoutes(e, userService, walletService, transactionService, nil, nil, nil, nil, nil, nil,
+		nil, feeService, nil, nil, usecase.NewHealthService(usecase.HealthConfig{}), tokens, tokens, nil)
+
+	return e, issueTestToken(t, tokens, 99, domain.RoleAdmin)
+}
+
+func TestOpenAPI_SpecCoversRoutes(t *testing.T) {
+	doc := loadTestSpec(t)
+
+	// Every optional route group is switched on
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	tokens := newTestTokenService(t)
+
+	e := echo.New()
+	rest.SetupRoutes(e, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		usecase.NewRiskService(nil, memory.NewInMemoryRiskAssessmentRepository(), nil),
+		usecase.NewFeeService(&domain.FeeSchedule{}, nil, walletRepo, transactionRepo, nil),
+		usecase.NewMerchantService(memory.NewInMemoryMerchantRepository(), memory.NewInMemoryUserRepository(), nil, nil, usecase.MerchantConfig{}),
+		nil, nil, tokens, tokens, nil)
+
+	registered := map[string]bool{}
+	for _, route := range e.Routes() {
+		// Groups add not-found routes so their middleware also runs for unknown paths
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		registered[route.Method+" "+echoPathParam.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+
+	documented := map[string]bool{}
+	for path, item := range doc.Paths {
+		for method := range item.Operations() {
+			documented[method+" "+path] = true
+		}
+	}
+
+	var missing, stale []string
+	for route := range registered {
+		if !documented[route] {
+			missing = append(missing, route)
+		}
+	}
+	for route := range documented {
+		if !registered[route] {
+			stale = append(stale, route)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+
+	if len(missing) > 0 {
+		t.Errorf("routes missing from the OpenAPI spec:\n  %s", strings.Join(missing, "\n  "))
+	}
+	if len(stale) > 0 {
+		t.Errorf("spec operations without a route:\n  %s", strings.Join(stale, "\n  "))
+	}
+}
+
+func TestOpenAPI_ResponsesMatchSpec(t *testing.T) {
+	ctx := context.Background()
+	doc := loadTestSpec(t)
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("failed to build router: %v", err)
+	}
+
+	e, token := setupOpenAPIRoutes(t, ctx)
+
+	// Later requests depend on the state left by earlier ones
+	tests := []struct {
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/health", "", http.StatusOK},
+		{http.MethodGet, "/health/live", "", http.StatusOK},
+		{http.MethodGet, "/health/ready", "", http.StatusOK},
+		{http.MethodGet, "/openapi.json", "", http.StatusOK},
+		{http.MethodGet, "/docs", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/auth/dev-token", `{"user_id":1}`, http.StatusCreated},
+		{http.MethodPost, "/api/v1/users", `{"fullname":"Jane Doe","email":"jane@example.com","phone":"+6281234567890"}`, http.StatusCreated},
+		{http.MethodGet, "/api/v1/users/3", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/users/lookup?email=jane@example.com", "", http.StatusOK},
+		{http.MethodPut, "/api/v1/users/3/handle", `{"handle":"jane"}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/users/3/deactivate", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/wallets", `{"user_id":1,"currency_code":"EUR","description":"Savings"}`, http.StatusCreated},
+		{http.MethodGet, "/api/v1/wallets/1", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/wallets/1/deposit", `{"amount":500,"description":"Top up"}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/wallets/1/withdraw", `{"amount":200}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/wallets/1/transfer", `{"to_wallet_id":2,"amount":300}`, http.StatusOK},
+		{http.MethodGet, "/api/v1/wallets/1/transactions?limit=1", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/wallets/1/transactions?type=DEPOSIT,TRANSFER&include_total=true", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/wallets/1/balance", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/users/1/wallets", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/transactions?status=completed", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/transactions/1", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/admin/wallets/2/freeze", `{"reason":"compliance_hold","actor":"ops"}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/admin/transactions/reconcile", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/fees/schedule", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/fees/quote", `{"wallet_id":1,"transaction_type":"WITHDRAWAL","amount":100}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/wallets/2/withdraw", `{"amount":100}`, http.StatusForbidden},
+		{http.MethodPost, "/api/v1/wallets/1/withdraw", `{"amount":0}`, http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/wallets/99", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+
+			checkAgainstSpec(t, router, tt.method, tt.target, tt.body, token, rec)
+		})
+	}
+}
+
+// checkAgainstSpec validates a request and the response it got against the spec
+func checkAgainstSpec(t *testing.T, router routers.Router, method, target, body, token string, rec *httptest.ResponseRecorder) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+
+	route, pathParams, err := router.FindRoute(req)
+	if err != nil {
+		t.Fatalf("no spec operation for %s %s: %v", method, target, err)
+	}
+
+	options := &openapi3filter.Options{
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		IncludeResponseStatus: true,
+		SkipSettingDefaults:   true,
+	}
+	input := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    options,
+	}
+	if rec.Code < http.StatusBadRequest {
+		if err := openapi3filter.ValidateRequest(context.Background(), input); err != nil {
+			t.Errorf("request does not match the spec: %v", err)
+		}
+	}
+
+	err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(rec.Body.Bytes())),
+		Options:                options,
+	})
+	if err != nil {
+		t.Errorf("response does not match the spec: %v\n%s", err, rec.Body.String())
+	}
+}
+
+func TestOpenAPI_ValidateRequests(t *testing.T) {
+	ctx := context.Background()
+	e, token := setupOpenAPIRoutes(t, ctx)
+
+	validateRequests, err := openapi.ValidateRequests(loadTestSpec(t))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	e.Use(validateRequests)
+
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		body    string
+		status  int
+		message string
+	}{
+		{"ValidRequest", http.MethodPost, "/api/v1/wallets/1/deposit", `{"amount":100}`, http.StatusOK, ""},
+		{"WrongBodyType", http.MethodPost, "/api/v1/wallets/1/deposit", `{"amount":"ten"}`, http.StatusBadRequest, "amount"},
+		{"MissingField", http.MethodPost, "/api/v1/wallets/1/transfer", `{"amount":100}`, http.StatusBadRequest, "to_wallet_id"},
+		// The handler would quietly fall back to the default page size
+		{"InvalidQueryParameter", http.MethodGet, "/api/v1/wallets/1/transactions?limit=ten", "", http.StatusBadRequest, "limit"},
+		{"InvalidPathParameter", http.MethodGet, "/api/v1/wallets/abc", "", http.StatusBadRequest, "id"},
+		{"UnknownPath", http.MethodGet, "/api/v1/unknown", "", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.message != "" && !strings.Contains(rec.Body.String(), tt.message) {
+				t.Errorf("expected the error to name %q, got %s", tt.message, rec.Body.String())
+			}
+		})
+	}
+}
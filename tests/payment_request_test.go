@@ -0,0 +1,265 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/usecase"
+	"testing"
+	"time"
+)
+
+func setupPaymentRequestService(ctx context.Context) (
+	*usecase.PaymentRequestService,
+	*memory.InMemoryWalletRepository,
+	*memory.InMemoryPaymentRequestRepository,
+) {
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	requestRepo := memory.NewInMemoryPaymentRequestRepository()
+
+	requester := domain.NewUser("Requester", "requester@example.com", "+1111111111")
+	requester.ID = 1
+	_ = userRepo.Save(ctx, requester)
+
+	payer := domain.NewUser("Payer", "payer@example.com", "+2222222222")
+	payer.ID = 2
+	_ = userRepo.Save(ctx, payer)
+
+	requesterWallet := domain.NewWallet(1, "USD", "Requester wallet")
+	requesterWallet.ID = 1
+	_ = walletRepo.Save(ctx, requesterWallet)
+
+	payerWallet := domain.NewWallet(2, "USD", "Payer wallet")
+	payerWallet.ID = 2
+	payerWallet.Balance = 500
+	_ = walletRepo.Save(ctx, payerWallet)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	service := usecase.NewPaymentRequestService(requestRepo, walletRepo, userRepo, walletService, nil,
+		usecase.PaymentRequestConfig{DefaultExpiry: time.Hour})
+
+	return service, walletRepo, requestRepo
+}
+
+func TestPaymentRequestService_Accept(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	service, walletRepo, _ := setupPaymentRequestService(ctx)
+
+	request, err := service.CreatePaymentRequest(ctx, primary.CreatePaymentRequest{
+		RequesterWalletID: 1,
+		PayerUserID:       2,
+		Amount:            200,
+		Note:              "Dinner",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inbox, _ := service.GetInbox(ctx, 2, domain.PaymentRequestStatusPending, 10, 0)
+	if len(inbox) != 1 || inbox[0].ID != request.ID {
+		t.Fatalf("expected request %d in payer inbox, got %v", request.ID, inbox)
+	}
+
+	// Only the payer's own wallets can pay the request
+	if _, err := service.AcceptPaymentRequest(ctx, request.ID, 1); !errors.Is(err, domain.ErrPaymentRequestNotAllowed) {
+		t.Errorf("expected ErrPaymentRequestNotAllowed, got %v", err)
+	}
+
+	accepted, err := service.AcceptPaymentRequest(ctx, request.ID, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if accepted.Status != domain.PaymentRequestStatusAccepted || accepted.TransactionID == nil {
+		t.Errorf("expected accepted request with transaction, got %+v", accepted)
+	}
+
+	payerWallet, _ := walletRepo.FindByID(ctx, 2)
+	requesterWallet, _ := walletRepo.FindByID(ctx, 1)
+	if payerWallet.Balance != 300 || requesterWallet.Balance != 200 {
+		t.Errorf("expected balances 300 and 200, got %d and %d", payerWallet.Balance, requesterWallet.Balance)
+	}
+
+	// A request can only be paid once
+	if _, err := service.AcceptPaymentRequest(ctx, request.ID, 2); !errors.Is(err, domain.ErrPaymentRequestNotPending) {
+		t.Errorf("expected ErrPaymentRequestNotPending, got %v", err)
+	}
+
+	outbox, _ := service.GetOutbox(ctx, 1, domain.PaymentRequestStatusAccepted, 10, 0)
+	if len(outbox) != 1 {
+		t.Errorf("expected 1 accepted request in requester outbox, got %d", len(outbox))
+	}
+}
+
+func TestPaymentRequestService_FailedTransferKeepsRequestPending(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	service, _, _ := setupPaymentRequestService(ctx)
+
+	request, _ := service.CreatePaymentRequest(ctx, primary.CreatePaymentRequest{
+		RequesterWalletID: 1,
+		PayerUserID:       2,
+		Amount:            1000,
+	})
+
+	if _, err := service.AcceptPaymentRequest(ctx, request.ID, 2); err == nil {
+		t.Fatal("expected insufficient balance error but got none")
+	}
+
+	stored, _ := service.GetPaymentRequest(ctx, request.ID)
+	if stored.Status != domain.PaymentRequestStatusPending {
+		t.Errorf("expected request to stay pending, got %s", stored.Status)
+	}
+
+	// The payer can still decline it, the requester can no longer cancel it
+	if _, err := service.DeclinePaymentRequest(ctx, request.ID, 1); !errors.Is(err, domain.ErrPaymentRequestNotAllowed) {
+		t.Errorf("expected ErrPaymentRequestNotAllowed, got %v", err)
+	}
+
+	declined, err := service.DeclinePaymentRequest(ctx, request.ID, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if declined.Status != domain.PaymentRequestStatusDeclined {
+		t.Errorf("expected declined request, got %s", declined.Status)
+	}
+
+	if _, err := service.CancelPaymentRequest(ctx, request.ID, 1); !errors.Is(err, domain.ErrPaymentRequestNotPending) {
+		t.Errorf("expected ErrPaymentRequestNotPending, got %v", err)
+	}
+}
+
+// flakyPaymentRequestRepository fails the first update that accepts a request
+type flakyPaymentRequestRepository struct {
+	*memory.InMemoryPaymentRequestRepository
+	failed bool
+}
+
+func (r *flakyPaymentRequestRepository) UpdateIfStatus(
+	ctx context.Context,
+	request *domain.PaymentRequest,
+	expected domain.PaymentRequestStatus,
+) (bool, error) {
+	if request.Status == domain.PaymentRequestStatusAccepted && !r.failed {
+		r.failed = true
+		return false, errors.New("database unavailable")
+	}
+	return r.InMemoryPaymentRequestRepository.UpdateIfStatus(ctx, request, expected)
+}
+
+func TestPaymentRequestService_RecoverInterruptedAccepts(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	requestRepo := &flakyPaymentRequestRepository{InMemoryPaymentRequestRepository: memory.NewInMemoryPaymentRequestRepository()}
+
+	for id := 1; id <= 2; id++ {
+		user := domain.NewUser("User", fmt.Sprintf("user%d@example.com", id), fmt.Sprintf("+%d", id))
+		user.ID = id
+		_ = userRepo.Save(ctx, user)
+
+		wallet := domain.NewWallet(id, "USD", "Wallet")
+		wallet.ID = id
+		_ = walletRepo.Save(ctx, wallet)
+	}
+	_ = walletRepo.UpdateBalance(ctx, 2, 500)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	service := usecase.NewPaymentRequestService(requestRepo, walletRepo, userRepo, walletService, nil,
+		usecase.PaymentRequestConfig{DefaultExpiry: time.Hour, ProcessingTimeout: time.Millisecond})
+
+	paid, _ := service.CreatePaymentRequest(ctx, primary.CreatePaymentRequest{RequesterWalletID: 1, PayerUserID: 2, Amount: 200})
+	unpaid, _ := service.CreatePaymentRequest(ctx, primary.CreatePaymentRequest{RequesterWalletID: 1, PayerUserID: 2, Amount: 100})
+
+	// The transfer goes through but the request cannot be marked accepted,
+	// the payer still gets the request back with its transaction
+	accepted, err := service.AcceptPaymentRequest(ctx, paid.ID, 2)
+	if err != nil {
+		t.Fatalf("expected no error for a payment that went through, got %v", err)
+	}
+	if accepted.TransactionID == nil {
+		t.Fatalf("expected the request to carry its transaction, got %+v", accepted)
+	}
+
+	// An accept interrupted before its transfer leaves the request claimed
+	claimed, _ := requestRepo.FindByID(ctx, unpaid.ID)
+	_ = claimed.Claim()
+	_, _ = requestRepo.UpdateIfStatus(ctx, claimed, domain.PaymentRequestStatusPending)
+
+	time.Sleep(5 * time.Millisecond)
+
+	recovered, err := service.RecoverPaymentRequests(ctx)
+	if err != nil || recovered != 2 {
+		t.Fatalf("expected 2 recovered requests, got %d and %v", recovered, err)
+	}
+
+	stored, _ := service.GetPaymentRequest(ctx, paid.ID)
+	if stored.Status != domain.PaymentRequestStatusAccepted || stored.TransactionID == nil || *stored.TransactionID != *accepted.TransactionID {
+		t.Errorf("expected the paid request to be accepted with its transfer, got %+v", stored)
+	}
+
+	stored, _ = service.GetPaymentRequest(ctx, unpaid.ID)
+	if stored.Status != domain.PaymentRequestStatusPending {
+		t.Errorf("expected the unpaid request to be pending again, got %s", stored.Status)
+	}
+
+	// The payer was charged once
+	payerWallet, _ := walletRepo.FindByID(ctx, 2)
+	if payerWallet.Balance != 300 {
+		t.Errorf("expected payer balance 300, got %d", payerWallet.Balance)
+	}
+}
+
+func TestPaymentRequestService_Expiry(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	service, _, requestRepo := setupPaymentRequestService(ctx)
+
+	request, _ := service.CreatePaymentRequest(ctx, primary.CreatePaymentRequest{
+		RequesterWalletID: 1,
+		PayerUserID:       2,
+		Amount:            100,
+	})
+
+	// Move the expiry into the past
+	stored, _ := requestRepo.FindByID(ctx, request.ID)
+	stored.ExpiresAt = time.Now().Add(-time.Minute)
+	_, _ = requestRepo.UpdateIfStatus(ctx, stored, domain.PaymentRequestStatusPending)
+
+	if _, err := service.AcceptPaymentRequest(ctx, request.ID, 2); !errors.Is(err, domain.ErrPaymentRequestExpired) {
+		t.Fatalf("expected ErrPaymentRequestExpired, got %v", err)
+	}
+
+	expired, _ := service.GetPaymentRequest(ctx, request.ID)
+	if expired.Status != domain.PaymentRequestStatusExpired {
+		t.Errorf("expected expired request, got %s", expired.Status)
+	}
+
+	// The worker closes requests nobody touched
+	another, _ := service.CreatePaymentRequest(ctx, primary.CreatePaymentRequest{
+		RequesterWalletID: 1,
+		PayerUserID:       2,
+		Amount:            100,
+	})
+	stored, _ = requestRepo.FindByID(ctx, another.ID)
+	stored.ExpiresAt = time.Now().Add(-time.Minute)
+	_, _ = requestRepo.UpdateIfStatus(ctx, stored, domain.PaymentRequestStatusPending)
+
+	count, err := service.ExpirePaymentRequests(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 expired request, got %d", count)
+	}
+}
@@ -0,0 +1,196 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/internal/adapters/logging"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/usecase"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRateLimit_Check(t *testing.T) {
+	ctx := context.Background()
+	rateLimitService := usecase.NewRateLimitService(memory.NewInMemoryCache(), map[string]domain.RateLimitPolicy{
+		"api": {
+			IP:     domain.RateLimit{Requests: 5, Window: time.Hour},
+			User:   domain.RateLimit{Requests: 2, Window: time.Hour},
+			APIKey: domain.RateLimit{Requests: 3, Window: time.Hour},
+		},
+	})
+
+	user := domain.RateLimitSubject{IP: "192.0.2.1", UserID: 1}
+	for i := 1; i <= 2; i++ {
+		status, err := rateLimitService.Check(ctx, "api", user)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !status.Allowed || status.Limit != 2 || status.Remaining != 2-i {
+			t.Errorf("request %d: expected allowed with %d remaining, got %+v", i, 2-i, status)
+		}
+	}
+
+	status, err := rateLimitService.Check(ctx, "api", user)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if status.Allowed || status.RetryAfter <= 0 {
+		t.Errorf("expected the user to be limited with a retry delay, got %+v", status)
+	}
+
+	// API keys are counted apart from the user owning them
+	apiClient := domain.RateLimitSubject{IP: "192.0.2.2", UserID: 1, APIKeyID: 7}
+	for i := 1; i <= 3; i++ {
+		status, err := rateLimitService.Check(ctx, "api", apiClient)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !status.Allowed {
+			t.Errorf("request %d: expected the API key to be allowed, got %+v", i, status)
+		}
+	}
+
+	// The IP limit applies to every caller behind it
+	for i := 1; i <= 3; i++ {
+		_, _ = rateLimitService.Check(ctx, "api", domain.RateLimitSubject{IP: "192.0.2.3", UserID: 10 + i})
+	}
+	status, err = rateLimitService.Check(ctx, "api", domain.RateLimitSubject{IP: "192.0.2.3", UserID: 20})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !status.Allowed || status.Limit != 5 || status.Remaining != 1 {
+		t.Errorf("expected the IP limit to be the most constrained, got %+v", status)
+	}
+
+	status, err = rateLimitService.Check(ctx, "public", user)
+	if err != nil || status != nil {
+		t.Errorf("expected no limit for an unconfigured group, got %+v, %v", status, err)
+	}
+}
+
+func TestRateLimit_SlidingWindow(t *testing.T) {
+	ctx := context.Background()
+	cache := memory.NewInMemoryCache()
+	limit := domain.RateLimit{Requests: 10, Window: 24 * time.Hour}
+	rateLimitService := usecase.NewRateLimitService(cache, map[string]domain.RateLimitPolicy{
+		"api": {IP: limit},
+	})
+
+	// A full previous window still weighs on the current one
+	previous := time.Now().UnixNano()/int64(limit.Window) - 1
+	_ = cache.Set(ctx, fmt.Sprintf("rate_limit:api:ip:192.0.2.1:%d", previous), []byte("1000000000"), limit.Window)
+
+	status, err := rateLimitService.Check(ctx, "api", domain.RateLimitSubject{IP: "192.0.2.1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if status.Allowed {
+		t.Errorf("expected the previous window to count, got %+v", status)
+	}
+	if status.RetryAfter <= 0 || status.RetryAfter > 2*limit.Window {
+		t.Errorf("expected a retry delay within two windows, got %v", status.RetryAfter)
+	}
+
+	status, err = rateLimitService.Check(ctx, "api", domain.RateLimitSubject{IP: "192.0.2.2"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !status.Allowed || status.Remaining != 9 {
+		t.Errorf("expected another IP to be allowed, got %+v", status)
+	}
+}
+
+func TestRateLimit_Middleware(t *testing.T) {
+	rateLimitService := usecase.NewRateLimitService(memory.NewInMemoryCache(), map[string]domain.RateLimitPolicy{
+		"public": {IP: domain.RateLimit{Requests: 2, Window: time.Minute}},
+	})
+
+	e := echo.New()
+	public := e.Group("/api/v1", handlers.RateLimit(rateLimitService, nil, "public"))
+	public.GET("/ping", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	for i := 1; i <= 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Header().Get(handlers.HeaderRateLimitLimit) != "2" {
+			t.Errorf("request %d: expected RateLimit-Limit 2, got %q", i, rec.Header().Get(handlers.HeaderRateLimitLimit))
+		}
+		if rec.Header().Get(handlers.HeaderRateLimitPolicy) != "2;w=60" {
+			t.Errorf("request %d: expected RateLimit-Policy 2;w=60, got %q", i, rec.Header().Get(handlers.HeaderRateLimitPolicy))
+		}
+
+		if i <= 2 {
+			if rec.Code != http.StatusOK {
+				t.Errorf("request %d: expected status 200, got %d", i, rec.Code)
+			}
+			if got := rec.Header().Get(handlers.HeaderRateLimitRemaining); got != strconv.Itoa(2-i) {
+				t.Errorf("request %d: expected RateLimit-Remaining %d, got %q", i, 2-i, got)
+			}
+			continue
+		}
+
+		if rec.Code != http.StatusTooManyRequests {
+			t.Errorf("expected status 429, got %d", rec.Code)
+		}
+		// The full current window has to slide out, which ends during the next one
+		retryAfter, err := strconv.Atoi(rec.Header().Get(echo.HeaderRetryAfter))
+		if err != nil || retryAfter < 1 || retryAfter > 120 {
+			t.Errorf("expected Retry-After within two windows, got %q", rec.Header().Get(echo.HeaderRetryAfter))
+		}
+	}
+}
+
+// unavailableRateLimitService fails every check, like an unreachable cache
+type unavailableRateLimitService struct{}
+
+func (unavailableRateLimitService) Check(ctx context.Context, group string, subject domain.RateLimitSubject) (*domain.RateLimitStatus, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestRateLimit_MiddlewareLetsThroughWhenUnavailable(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.NewSlogLogger(logging.Config{Level: "info", Format: "json"}, &buf)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	e := echo.New()
+	public := e.Group("/api/v1", handlers.RateLimit(unavailableRateLimitService{}, logger, "public"))
+	public.GET("/ping", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil)
+	req = req.WithContext(domain.ContextWithRequestID(req.Context(), "req-7"))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+
+	// The failure is logged under the request ID
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a JSON log line, got %q", buf.String())
+	}
+	if entry["msg"] != "rate limit check failed" || entry["level"] != "WARN" || entry["request_id"] != "req-7" ||
+		entry["error"] != "connection refused" {
+		t.Errorf("unexpected log entry %v", entry)
+	}
+}
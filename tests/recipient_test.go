@@ -0,0 +1,208 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/usecase"
+	"testing"
+)
+
+func setupRecipientService(ctx context.Context, config usecase.RecipientConfig) (
+	*usecase.RecipientService,
+	*usecase.UserService,
+	*memory.InMemoryWalletRepository,
+) {
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	cache := memory.NewInMemoryCache()
+
+	sender := domain.NewUser("Sender", "sender@example.com", "+6281111111111")
+	sender.ID = 1
+	_ = userRepo.Save(ctx, sender)
+
+	recipient := domain.NewUser("John Doe", "john@example.com", "+6282222222222")
+	recipient.ID = 2
+	recipient.Handle = "johndoe"
+	_ = userRepo.Save(ctx, recipient)
+
+	senderWallet := domain.NewWallet(1, "USD", "Sender wallet")
+	senderWallet.ID = 1
+	senderWallet.Balance = 1000
+	_ = walletRepo.Save(ctx, senderWallet)
+
+	recipientWallet := domain.NewWallet(2, "USD", "Recipient wallet")
+	recipientWallet.ID = 2
+	_ = walletRepo.Save(ctx, recipientWallet)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	userService := usecase.NewUserService(userRepo, nil, nil)
+	service := usecase.NewRecipientService(userRepo, walletRepo, walletService, cache, config)
+
+	return service, userService, walletRepo
+}
+
+func TestParseRecipient(t *testing.T) {
+	tests := []struct {
+		name       string
+		identifier string
+		wantType   domain.RecipientType
+		wantValue  string
+		wantErr    error
+	}{
+		{"Phone", "+62 822-2222-2222", domain.RecipientTypePhone, "+6282222222222", nil},
+		{"Email", "john@example.com", domain.RecipientTypeEmail, "john@example.com", nil},
+		{"Handle with @", "@JohnDoe", domain.RecipientTypeHandle, "johndoe", nil},
+		{"Plain handle", "johndoe", domain.RecipientTypeHandle, "johndoe", nil},
+		{"Invalid phone", "+12", "", "", domain.ErrInvalidRecipient},
+		{"Invalid handle", "j!", "", "", domain.ErrInvalidRecipient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipientType, value, err := domain.ParseRecipient(tt.identifier)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if recipientType != tt.wantType || value != tt.wantValue {
+				t.Errorf("expected %s %q, got %s %q", tt.wantType, tt.wantValue, recipientType, value)
+			}
+		})
+	}
+
+	if masked := domain.MaskName("John Doe"); masked != "J*** D**" {
+		t.Errorf("expected masked name J*** D**, got %s", masked)
+	}
+}
+
+func TestUserService_SetHandle(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	_, userService, _ := setupRecipientService(ctx, usecase.RecipientConfig{})
+
+	user, err := userService.SetHandle(ctx, 1, "@Sender_01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if user.Handle != "sender_01" {
+		t.Errorf("expected normalized handle sender_01, got %s", user.Handle)
+	}
+
+	// Handles are unique regardless of case
+	if _, err := userService.SetHandle(ctx, 1, "JohnDoe"); !errors.Is(err, usecase.ErrHandleAlreadyTaken) {
+		t.Errorf("expected ErrHandleAlreadyTaken, got %v", err)
+	}
+
+	if _, err := userService.SetHandle(ctx, 1, "support"); !errors.Is(err, domain.ErrHandleReserved) {
+		t.Errorf("expected ErrHandleReserved, got %v", err)
+	}
+
+	if _, err := userService.SetHandle(ctx, 1, "1abc"); !errors.Is(err, domain.ErrInvalidHandle) {
+		t.Errorf("expected ErrInvalidHandle, got %v", err)
+	}
+
+	// Re-claiming your own handle is fine
+	if _, err := userService.SetHandle(ctx, 2, "johndoe"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRecipientService_ConfirmedTransfer(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	service, _, walletRepo := setupRecipientService(ctx, usecase.RecipientConfig{RequireConfirmation: true})
+
+	for _, identifier := range []string{"+6282222222222", "john@example.com", "@johndoe"} {
+		recipient, err := service.ResolveRecipient(ctx, identifier, "usd")
+		if err != nil {
+			t.Fatalf("unexpected error resolving %s: %v", identifier, err)
+		}
+
+		if recipient.MaskedName != "J*** D**" || recipient.ConfirmationToken == "" {
+			t.Errorf("expected masked name and confirmation token, got %+v", recipient)
+		}
+	}
+
+	if _, err := service.ResolveRecipient(ctx, "@johndoe", "EUR"); !errors.Is(err, usecase.ErrRecipientWalletNotFound) {
+		t.Errorf("expected ErrRecipientWalletNotFound, got %v", err)
+	}
+
+	if _, err := service.ResolveRecipient(ctx, "@nobody", "USD"); !errors.Is(err, usecase.ErrRecipientNotFound) {
+		t.Errorf("expected ErrRecipientNotFound, got %v", err)
+	}
+
+	// Confirmation is required before moving funds
+	_, err := service.TransferToRecipient(ctx, primary.TransferToRecipientRequest{
+		FromWalletID: 1,
+		Recipient:    "@johndoe",
+		Amount:       100,
+	})
+	if !errors.Is(err, usecase.ErrConfirmationRequired) {
+		t.Errorf("expected ErrConfirmationRequired, got %v", err)
+	}
+
+	recipient, _ := service.ResolveRecipient(ctx, "@johndoe", "USD")
+
+	transaction, err := service.TransferToRecipient(ctx, primary.TransferToRecipientRequest{
+		FromWalletID:      1,
+		ConfirmationToken: recipient.ConfirmationToken,
+		Amount:            300,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if transaction.ToWalletID == nil || *transaction.ToWalletID != 2 {
+		t.Errorf("expected transfer to wallet 2, got %+v", transaction.ToWalletID)
+	}
+
+	recipientWallet, _ := walletRepo.FindByID(ctx, 2)
+	if recipientWallet.Balance != 300 {
+		t.Errorf("expected recipient balance 300, got %d", recipientWallet.Balance)
+	}
+
+	// Tokens are single use
+	_, err = service.TransferToRecipient(ctx, primary.TransferToRecipientRequest{
+		FromWalletID:      1,
+		ConfirmationToken: recipient.ConfirmationToken,
+		Amount:            300,
+	})
+	if !errors.Is(err, usecase.ErrInvalidConfirmationToken) {
+		t.Errorf("expected ErrInvalidConfirmationToken, got %v", err)
+	}
+}
+
+func TestRecipientService_TransferWithoutConfirmation(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	service, _, walletRepo := setupRecipientService(ctx, usecase.RecipientConfig{})
+
+	if _, err := service.TransferToRecipient(ctx, primary.TransferToRecipientRequest{
+		FromWalletID: 1,
+		Recipient:    "john@example.com",
+		Amount:       250,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	recipientWallet, _ := walletRepo.FindByID(ctx, 2)
+	if recipientWallet.Balance != 250 {
+		t.Errorf("expected recipient balance 250, got %d", recipientWallet.Balance)
+	}
+
+	// Sending to yourself by identifier is rejected
+	_, err := service.TransferToRecipient(ctx, primary.TransferToRecipientRequest{
+		FromWalletID: 1,
+		Recipient:    "sender@example.com",
+		Amount:       100,
+	})
+	if !errors.Is(err, domain.ErrRecipientIsSender) {
+		t.Errorf("expected ErrRecipientIsSender, got %v", err)
+	}
+}
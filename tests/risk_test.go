@@ -0,0 +1,335 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"ports-and-adapters-architecture/internal/adapters/logging"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/adapters/risk"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/secondary/external"
+	"ports-and-adapters-architecture/internal/usecase"
+	"testing"
+	"time"
+)
+
+func TestRulesEngine_Evaluate(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	engine, err := risk.NewRulesEngine(risk.RulesConfig{
+		Velocity: risk.VelocityRuleConfig{
+			Enabled:  true,
+			Window:   10 * time.Minute,
+			MaxCount: 2,
+			Decision: domain.RiskDecisionReview,
+		},
+		NewRecipient: risk.NewRecipientRuleConfig{
+			Enabled:         true,
+			AmountThreshold: 500,
+			Decision:        domain.RiskDecisionReview,
+		},
+		OddHour: risk.OddHourRuleConfig{
+			Enabled:         true,
+			StartHour:       22,
+			EndHour:         5,
+			AmountThreshold: 1000,
+			Decision:        domain.RiskDecisionReview,
+		},
+	}, transactionRepo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Two recent withdrawals from wallet 1
+	for i := 0; i < 2; i++ {
+		tx, _ := domain.NewTransaction(1, domain.TransactionTypeWithdrawal, 10, "Withdrawal")
+		tx.Complete()
+		_ = transactionRepo.Create(ctx, tx)
+	}
+
+	// A completed transfer from wallet 1 to wallet 2
+	known, _ := domain.NewTransferTransaction(1, 2, 10, "Known recipient")
+	known.Complete()
+	_ = transactionRepo.Create(ctx, known)
+
+	noon := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2025, 1, 1, 23, 30, 0, 0, time.UTC)
+	newRecipient := 3
+	knownRecipient := 2
+
+	tests := []struct {
+		name     string
+		request  external.RiskCheckRequest
+		wantRule string
+	}{
+		{
+			name: "Velocity exceeded",
+			request: external.RiskCheckRequest{
+				WalletID: 1, TransactionType: domain.TransactionTypeWithdrawal, Amount: 10,
+				RequestedAt: time.Now(),
+			},
+			wantRule: risk.RuleVelocity,
+		},
+		{
+			name: "Large transfer to new recipient",
+			request: external.RiskCheckRequest{
+				WalletID: 1, TransactionType: domain.TransactionTypeTransfer, Amount: 600,
+				ToWalletID: &newRecipient, RequestedAt: noon,
+			},
+			wantRule: risk.RuleNewRecipient,
+		},
+		{
+			name: "Large transfer to known recipient",
+			request: external.RiskCheckRequest{
+				WalletID: 1, TransactionType: domain.TransactionTypeTransfer, Amount: 600,
+				ToWalletID: &knownRecipient, RequestedAt: noon,
+			},
+			wantRule: "",
+		},
+		{
+			name: "Large amount at odd hour",
+			request: external.RiskCheckRequest{
+				WalletID: 5, TransactionType: domain.TransactionTypeWithdrawal, Amount: 1500,
+				RequestedAt: midnight,
+			},
+			wantRule: risk.RuleOddHour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := engine.Evaluate(ctx, tt.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantRule == "" {
+				if len(hits) != 0 {
+					t.Errorf("expected no rule hits, got %v", hits)
+				}
+				return
+			}
+
+			if len(hits) != 1 || hits[0].Rule != tt.wantRule {
+				t.Errorf("expected %s hit, got %v", tt.wantRule, hits)
+			}
+		})
+	}
+}
+
+func TestWalletService_WithdrawDeniedByRiskCheck(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	assessmentRepo := memory.NewInMemoryRiskAssessmentRepository()
+
+	wallet := domain.NewWallet(1, "USD", "Test wallet")
+	wallet.ID = 1
+	_ = walletRepo.Save(ctx, wallet)
+
+	engine, err := risk.NewRulesEngine(risk.RulesConfig{
+		RapidInOut: risk.RapidInOutRuleConfig{
+			Enabled:  true,
+			Window:   30 * time.Minute,
+			MinRatio: 0.9,
+			Decision: domain.RiskDecisionDeny,
+		},
+	}, transactionRepo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	walletService.SetRiskService(usecase.NewRiskService(engine, assessmentRepo, nil))
+
+	if _, err := walletService.Deposit(ctx, 1, 1000, "Incoming"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Draining the deposit right away is denied
+	_, err = walletService.Withdraw(ctx, 1, 950, "Cash out")
+	if !errors.Is(err, usecase.ErrTransactionDenied) {
+		t.Fatalf("expected ErrTransactionDenied, got %v", err)
+	}
+
+	updatedWallet, _ := walletRepo.FindByID(ctx, 1)
+	if updatedWallet.Balance != 1000 {
+		t.Errorf("expected balance 1000, got %d", updatedWallet.Balance)
+	}
+
+	// A small withdrawal is allowed and linked to its transaction
+	transaction, err := walletService.Withdraw(ctx, 1, 100, "Groceries")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assessments, _ := assessmentRepo.FindByWalletID(ctx, 1, 10, 0)
+	if len(assessments) != 2 {
+		t.Fatalf("expected 2 audited assessments, got %d", len(assessments))
+	}
+
+	if assessments[0].Decision != domain.RiskDecisionAllow || assessments[0].TransactionID == nil ||
+		*assessments[0].TransactionID != transaction.ID {
+		t.Errorf("expected allowed assessment linked to transaction %d, got %+v", transaction.ID, assessments[0])
+	}
+
+	if assessments[1].Decision != domain.RiskDecisionDeny {
+		t.Errorf("expected denied assessment, got %s", assessments[1].Decision)
+	}
+}
+
+// unlinkableRiskAssessmentRepository saves assessments but fails to update them
+type unlinkableRiskAssessmentRepository struct {
+	*memory.InMemoryRiskAssessmentRepository
+}
+
+func (r unlinkableRiskAssessmentRepository) Update(ctx context.Context, assessment *domain.RiskAssessment) error {
+	return errors.New("database unavailable")
+}
+
+func TestWalletService_TransferFlaggedForReviewGoesThrough(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	assessmentRepo := memory.NewInMemoryRiskAssessmentRepository()
+
+	source := domain.NewWallet(1, "USD", "Source wallet")
+	source.ID = 1
+	source.Balance = 1000
+	_ = walletRepo.Save(ctx, source)
+
+	target := domain.NewWallet(2, "USD", "Target wallet")
+	target.ID = 2
+	_ = walletRepo.Save(ctx, target)
+
+	engine, _ := risk.NewRulesEngine(risk.RulesConfig{
+		NewRecipient: risk.NewRecipientRuleConfig{
+			Enabled:         true,
+			AmountThreshold: 100,
+			Decision:        domain.RiskDecisionReview,
+		},
+	}, transactionRepo)
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	walletService.SetRiskService(usecase.NewRiskService(engine, assessmentRepo, nil))
+
+	// A REVIEW decision is advisory, the transfer completes right away
+	transaction, err := walletService.Transfer(ctx, 1, 2, 500, "First payment")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if transaction.Status != domain.TransactionStatusCompleted {
+		t.Errorf("expected the flagged transfer to complete, got %s", transaction.Status)
+	}
+
+	updatedSource, _ := walletRepo.FindByID(ctx, 1)
+	updatedTarget, _ := walletRepo.FindByID(ctx, 2)
+	if updatedSource.Balance != 500 || updatedTarget.Balance != 500 {
+		t.Errorf("expected balances 500 and 500, got %d and %d", updatedSource.Balance, updatedTarget.Balance)
+	}
+
+	// and waits in the review queue linked to its transaction
+	queue, _ := assessmentRepo.FindByReviewStatus(ctx, domain.RiskReviewStatusPending, 10, 0)
+	if len(queue) != 1 || queue[0].TransactionID == nil || *queue[0].TransactionID != transaction.ID {
+		t.Errorf("expected a pending review linked to transaction %d, got %+v", transaction.ID, queue)
+	}
+}
+
+func TestWalletService_WithdrawLogsUnlinkedRiskAssessment(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	assessmentRepo := unlinkableRiskAssessmentRepository{memory.NewInMemoryRiskAssessmentRepository()}
+
+	wallet := domain.NewWallet(1, "USD", "Test wallet")
+	wallet.ID = 1
+	wallet.Balance = 1000
+	_ = walletRepo.Save(ctx, wallet)
+
+	engine, _ := risk.NewRulesEngine(risk.RulesConfig{}, transactionRepo)
+
+	var buf bytes.Buffer
+	logger, err := logging.NewSlogLogger(logging.Config{Level: "error", Format: "json"}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	walletService := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+	walletService.SetRiskService(usecase.NewRiskService(engine, assessmentRepo, nil))
+	walletService.SetLogger(logger)
+
+	// The withdrawal still goes through, the failed link is logged
+	transaction, err := walletService.Withdraw(ctx, 1, 100, "Groceries")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if transaction.Status != domain.TransactionStatusCompleted {
+		t.Errorf("expected the withdrawal to complete, got %s", transaction.Status)
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte("failed to link risk assessment")) {
+		t.Errorf("expected the failed link to be logged, got %q", buf.String())
+	}
+}
+
+func TestRiskService_ReviewQueue(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+	assessmentRepo := memory.NewInMemoryRiskAssessmentRepository()
+
+	engine, _ := risk.NewRulesEngine(risk.RulesConfig{
+		NewRecipient: risk.NewRecipientRuleConfig{
+			Enabled:         true,
+			AmountThreshold: 100,
+			Decision:        domain.RiskDecisionReview,
+		},
+	}, transactionRepo)
+
+	riskService := usecase.NewRiskService(engine, assessmentRepo, nil)
+
+	toWalletID := 2
+	assessment, err := riskService.Assess(ctx, external.RiskCheckRequest{
+		WalletID:        1,
+		TransactionType: domain.TransactionTypeTransfer,
+		Amount:          500,
+		ToWalletID:      &toWalletID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	queue, _ := riskService.GetReviewQueue(ctx, 10, 0)
+	if len(queue) != 1 || queue[0].ID != assessment.ID {
+		t.Fatalf("expected assessment %d in review queue, got %v", assessment.ID, queue)
+	}
+
+	approved, err := riskService.ApproveReview(ctx, assessment.ID, "analyst@example.com", "Known payee")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if approved.ReviewStatus != domain.RiskReviewStatusApproved {
+		t.Errorf("expected approved status, got %s", approved.ReviewStatus)
+	}
+
+	if _, err := riskService.RejectReview(ctx, assessment.ID, "analyst@example.com", ""); !errors.Is(err, domain.ErrRiskReviewNotPending) {
+		t.Errorf("expected ErrRiskReviewNotPending, got %v", err)
+	}
+
+	queue, _ = riskService.GetReviewQueue(ctx, 10, 0)
+	if len(queue) != 0 {
+		t.Errorf("expected empty review queue, got %d", len(queue))
+	}
+}
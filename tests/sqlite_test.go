@@ -0,0 +1,506 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"ports-and-adapters-architecture/internal/adapters/persistence"
+	"ports-and-adapters-architecture/internal/adapters/persistence/sqlite"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/usecase"
+	sqlitemigrations "ports-and-adapters-architecture/migrations/sqlite"
+	"testing"
+	"time"
+)
+
+// openSQLite opens a migrated SQLite database file in a temporary directory
+func openSQLite(t *testing.T, path string) *sql.DB {
+	t.Helper()
+
+	db, err := sqlite.NewSQLiteConnection(sqlite.Config{Path: path})
+	if err != nil {
+		t.Fatalf("failed to open SQLite database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := persistence.NewSQLiteMigrator(db, sqlitemigrations.FS)
+	if err != nil {
+		t.Fatalf("failed to load SQLite migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to migrate SQLite database: %v", err)
+	}
+
+	return db
+}
+
+// seedSQLiteWallet saves a user with a wallet holding balance
+func seedSQLiteWallet(t *testing.T, ctx context.Context, db *sql.DB, email string, balance int) *domain.Wallet {
+	t.Helper()
+
+	user := domain.NewUser("Test User", email, email)
+	if err := sqlite.NewSQLiteUserRepository(db).Save(ctx, user); err != nil {
+		t.Fatalf("failed to save user: %v", err)
+	}
+
+	wallet := domain.NewWallet(user.ID, "USD", "Main wallet")
+	wallet.Balance = balance
+	if err := sqlite.NewSQLiteWalletRepository(db).Save(ctx, wallet); err != nil {
+		t.Fatalf("failed to save wallet: %v", err)
+	}
+
+	return wallet
+}
+
+func TestSQLiteMigrator_UpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.NewSQLiteConnection(sqlite.Config{Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("failed to open SQLite database: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := persistence.NewSQLiteMigrator(db, sqlitemigrations.FS)
+	if err != nil {
+		t.Fatalf("failed to load SQLite migrations: %v", err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil || len(applied) < 3 {
+		t.Fatalf("expected every migration to be applied, got %d and %v", len(applied), err)
+	}
+	total := len(applied)
+
+	applied, err = migrator.Up(ctx)
+	if err != nil || len(applied) != 0 {
+		t.Fatalf("expected nothing left to apply, got %d and %v", len(applied), err)
+	}
+
+	rolledBack, err := migrator.Down(ctx, total-2)
+	if err != nil {
+		t.Fatalf("expected the rollback to succeed, got %v", err)
+	}
+	if len(rolledBack) != total-2 ||
+		rolledBack[total-4].Name != "create_payments" || rolledBack[total-3].Name != "create_transactions" {
+		t.Fatalf("expected payments then transactions to be rolled back, got %+v", rolledBack)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("expected the status to load, got %v", err)
+	}
+	for _, status := range statuses {
+		pending := status.AppliedAt == nil
+		if pending != (status.Version > 2) {
+			t.Errorf("expected only versions above 2 to be pending, got %03d_%s pending %v", status.Version, status.Name, pending)
+		}
+	}
+
+	if _, err := db.ExecContext(ctx, `SELECT 1 FROM transactions`); err == nil {
+		t.Error("expected the transactions table to be dropped")
+	}
+}
+
+func TestSQLiteRepositories_PersistWalletOperations(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "ewallet.db")
+	db := openSQLite(t, path)
+
+	source := seedSQLiteWallet(t, ctx, db, "source@example.com", 0)
+	target := seedSQLiteWallet(t, ctx, db, "target@example.com", 0)
+
+	walletService := usecase.NewWalletService(
+		sqlite.NewSQLiteWalletRepository(db),
+		sqlite.NewSQLiteUserRepository(db),
+		sqlite.NewSQLiteTransactionRepository(db),
+		nil,
+		nil,
+	)
+
+	if _, err := walletService.Deposit(ctx, source.ID, 1000, "Top up"); err != nil {
+		t.Fatalf("expected the deposit to succeed, got %v", err)
+	}
+	if _, err := walletService.Transfer(ctx, source.ID, target.ID, 300, "Rent"); err != nil {
+		t.Fatalf("expected the transfer to succeed, got %v", err)
+	}
+	if _, err := walletService.Withdraw(ctx, source.ID, 5000, "Too much"); err == nil {
+		t.Error("expected a withdrawal above the balance to fail")
+	}
+
+	// Reopening the file sees everything written before
+	db.Close()
+	db = openSQLite(t, path)
+
+	walletRepo := sqlite.NewSQLiteWalletRepository(db)
+	reloaded, err := walletRepo.FindByID(ctx, source.ID)
+	if err != nil || reloaded == nil {
+		t.Fatalf("expected the wallet to be persisted, got %v and %v", reloaded, err)
+	}
+	if reloaded.Balance != 700 {
+		t.Errorf("expected a balance of 700, got %d", reloaded.Balance)
+	}
+
+	count, err := sqlite.NewSQLiteTransactionRepository(db).CountByWalletID(ctx, target.ID)
+	if err != nil || count != 1 {
+		t.Errorf("expected the transfer to be recorded for the target wallet, got %d and %v", count, err)
+	}
+
+	if err := walletRepo.UpdateBalance(ctx, target.ID, -1); err == nil {
+		t.Error("expected a negative balance to be rejected by the schema")
+	}
+	if err := walletRepo.UpdateBalance(ctx, 999, 100); err == nil {
+		t.Error("expected updating a missing wallet to fail")
+	}
+}
+
+func TestSQLiteTransactionRepository_PersistsStatusOverride(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "ewallet.db")
+	db := openSQLite(t, path)
+
+	wallet := seedSQLiteWallet(t, ctx, db, "owner@example.com", 0)
+	transactionRepo := sqlite.NewSQLiteTransactionRepository(db)
+
+	transaction, _ := domain.NewTransaction(wallet.ID, domain.TransactionTypeDeposit, 100, "Top up")
+	transaction.Status = domain.TransactionStatusPending
+	if err := transactionRepo.Create(ctx, transaction); err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	transactionService := usecase.NewTransactionService(transactionRepo, sqlite.NewSQLiteWalletRepository(db), nil, nil)
+	err := transactionService.UpdateTransactionStatus(ctx, transaction.ID, domain.TransactionStatusFailed, "Stuck at the provider", "user:1")
+	if err != nil {
+		t.Fatalf("expected the override to succeed, got %v", err)
+	}
+
+	// Reopening the file reads back who failed the transaction and why
+	db.Close()
+	db = openSQLite(t, path)
+
+	reloaded, err := sqlite.NewSQLiteTransactionRepository(db).FindByID(ctx, transaction.ID)
+	if err != nil || reloaded == nil {
+		t.Fatalf("expected the transaction to be persisted, got %v and %v", reloaded, err)
+	}
+	if reloaded.Status != domain.TransactionStatusFailed {
+		t.Errorf("expected failed status, got %s", reloaded.Status)
+	}
+	if reloaded.StatusReason != "Stuck at the provider" || reloaded.StatusActor != "user:1" {
+		t.Errorf("expected the reason and actor to be persisted, got %q and %q", reloaded.StatusReason, reloaded.StatusActor)
+	}
+}
+
+func TestSQLiteTransactionRepository_FindByQuery(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t, filepath.Join(t.TempDir(), "ewallet.db"))
+	wallet := seedSQLiteWallet(t, ctx, db, "history@example.com", 0)
+	other := seedSQLiteWallet(t, ctx, db, "other@example.com", 0)
+	transactionRepo := sqlite.NewSQLiteTransactionRepository(db)
+
+	// Times in another zone are stored in UTC, so they still sort in time order
+	zone := time.FixedZone("UTC+7", 7*60*60)
+	base := time.Date(2024, 1, 1, 7, 0, 0, 0, zone)
+
+	create := func(minute int, txType domain.TransactionType, amount int, description string, toWalletID *int) {
+		t.Helper()
+		transaction, err := domain.NewTransaction(wallet.ID, txType, amount, description)
+		if err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+		transaction.ToWalletID = toWalletID
+		transaction.Status = domain.TransactionStatusCompleted
+		transaction.CreatedAt = base.Add(time.Duration(minute) * time.Minute)
+		if err := transactionRepo.Create(ctx, transaction); err != nil {
+			t.Fatalf("failed to save transaction: %v", err)
+		}
+	}
+
+	create(0, domain.TransactionTypeDeposit, 1000, "Salary", nil)
+	create(1, domain.TransactionTypeTransfer, 200, "Rent 100%", intPtr(other.ID))
+	create(2, domain.TransactionTypeWithdrawal, 300, "ATM", nil)
+	create(3, domain.TransactionTypeTransfer, 50, "rent deposit", intPtr(other.ID))
+
+	query := domain.TransactionQuery{
+		WalletID: wallet.ID,
+		Filter:   domain.TransactionFilter{Description: "RENT"},
+	}
+	found, err := transactionRepo.FindByQuery(ctx, query)
+	if err != nil || len(found) != 2 || found[0].Description != "rent deposit" {
+		t.Fatalf("expected both rent transfers newest first, got %d and %v", len(found), err)
+	}
+
+	// The % of the search term is matched literally
+	query.Filter.Description = "100%"
+	if count, err := transactionRepo.CountByQuery(ctx, query); err != nil || count != 1 {
+		t.Errorf("expected one match for a literal %%, got %d and %v", count, err)
+	}
+
+	query.Filter = domain.TransactionFilter{
+		Direction: domain.TransactionDirectionOut,
+		Types:     []domain.TransactionType{domain.TransactionTypeTransfer, domain.TransactionTypeWithdrawal},
+	}
+	if count, err := transactionRepo.CountByQuery(ctx, query); err != nil || count != 3 {
+		t.Errorf("expected three outgoing transactions, got %d and %v", count, err)
+	}
+
+	query.Filter = domain.TransactionFilter{}
+	sum, err := transactionRepo.SumBalanceChanges(ctx, query)
+	if err != nil || sum != 1000-200-300-50 {
+		t.Errorf("expected the balance changes to add up to 450, got %d and %v", sum, err)
+	}
+
+	// A cursor continues after the last row of the previous page
+	query.OldestFirst = true
+	query.Limit = 2
+	firstPage, err := transactionRepo.FindByQuery(ctx, query)
+	if err != nil || len(firstPage) != 2 {
+		t.Fatalf("expected a first page of 2, got %d and %v", len(firstPage), err)
+	}
+	last := firstPage[len(firstPage)-1]
+	query.After = &domain.TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	secondPage, err := transactionRepo.FindByQuery(ctx, query)
+	if err != nil || len(secondPage) != 2 || secondPage[0].Description != "ATM" {
+		t.Fatalf("expected the second page to start at the withdrawal, got %d and %v", len(secondPage), err)
+	}
+	if !secondPage[0].CreatedAt.Equal(base.Add(2 * time.Minute)) {
+		t.Errorf("expected the creation time to round trip, got %s", secondPage[0].CreatedAt)
+	}
+}
+
+func TestSQLiteDBTransaction_CommitAndRollback(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t, filepath.Join(t.TempDir(), "ewallet.db"))
+	userRepo := sqlite.NewSQLiteUserRepository(db)
+	dbTx := sqlite.NewSQLiteDBTransaction(db)
+
+	txCtx, err := dbTx.BeginTx(ctx)
+	if err != nil {
+		t.Fatalf("expected the transaction to begin, got %v", err)
+	}
+	if _, err := dbTx.BeginTx(txCtx); err == nil {
+		t.Error("expected a nested transaction to be refused")
+	}
+
+	discarded := domain.NewUser("Discarded", "discarded@example.com", "+100")
+	if err := userRepo.Save(txCtx, discarded); err != nil {
+		t.Fatalf("expected the user to be saved in the transaction, got %v", err)
+	}
+	if found, _ := userRepo.FindByID(txCtx, discarded.ID); found == nil {
+		t.Error("expected the transaction to see its own writes")
+	}
+	if err := dbTx.RollbackTx(txCtx); err != nil {
+		t.Fatalf("expected the rollback to succeed, got %v", err)
+	}
+	if found, err := userRepo.FindByEmail(ctx, discarded.Email); err != nil || found != nil {
+		t.Errorf("expected the rolled back user to be gone, got %v and %v", found, err)
+	}
+
+	txCtx, err = dbTx.BeginTx(ctx)
+	if err != nil {
+		t.Fatalf("expected the transaction to begin, got %v", err)
+	}
+	kept := domain.NewUser("Kept", "kept@example.com", "+200")
+	kept.Handle = "kept"
+	if err := userRepo.Save(txCtx, kept); err != nil {
+		t.Fatalf("expected the user to be saved in the transaction, got %v", err)
+	}
+	if err := dbTx.CommitTx(txCtx); err != nil {
+		t.Fatalf("expected the commit to succeed, got %v", err)
+	}
+	if found, err := userRepo.FindByHandle(ctx, "kept"); err != nil || found == nil || found.ID != kept.ID {
+		t.Errorf("expected the committed user to be found by handle, got %v and %v", found, err)
+	}
+
+	if err := dbTx.CommitTx(ctx); err == nil {
+		t.Error("expected committing without a transaction to fail")
+	}
+}
+
+func TestSQLitePaymentRepository_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t, filepath.Join(t.TempDir(), "ewallet.db"))
+	wallet := seedSQLiteWallet(t, ctx, db, "payer@example.com", 0)
+
+	transaction, err := domain.NewTransaction(wallet.ID, domain.TransactionTypeDeposit, 500, "Card top up")
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if err := sqlite.NewSQLiteTransactionRepository(db).Create(ctx, transaction); err != nil {
+		t.Fatalf("failed to save transaction: %v", err)
+	}
+
+	paymentRepo := sqlite.NewSQLitePaymentRepository(db)
+	payment, err := domain.NewPayment(transaction.ID, 500, domain.PaymentProviderStripe, "Card top up")
+	if err != nil {
+		t.Fatalf("failed to create payment: %v", err)
+	}
+	payment.ExternalID = "pi_123"
+	payment.Details = map[string]interface{}{"card_last4": "4242"}
+	payment.CreatedAt = time.Now().Add(-time.Hour)
+	if err := paymentRepo.Create(ctx, payment); err != nil {
+		t.Fatalf("expected the payment to be saved, got %v", err)
+	}
+
+	found, err := paymentRepo.FindByExternalID(ctx, "pi_123")
+	if err != nil || found == nil || found.ID != payment.ID {
+		t.Fatalf("expected the payment to be found by external ID, got %v and %v", found, err)
+	}
+	if found.Details["card_last4"] != "4242" {
+		t.Errorf("expected the details to round trip, got %v", found.Details)
+	}
+
+	pending, err := paymentRepo.FindPendingPayments(ctx, 30)
+	if err != nil || len(pending) != 1 {
+		t.Errorf("expected the hour old payment to be pending, got %d and %v", len(pending), err)
+	}
+
+	now := time.Now()
+	found.Status = domain.PaymentStatusCompleted
+	found.CompletedAt = &now
+	if err := paymentRepo.Update(ctx, found); err != nil {
+		t.Fatalf("expected the payment to be updated, got %v", err)
+	}
+	if pending, _ := paymentRepo.FindPendingPayments(ctx, 0); len(pending) != 0 {
+		t.Errorf("expected no pending payments after completion, got %d", len(pending))
+	}
+}
+
+func TestSQLiteBatchTransferRepository_RoundTripAndClaim(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t, filepath.Join(t.TempDir(), "ewallet.db"))
+	source := seedSQLiteWallet(t, ctx, db, "source@example.com", 1000)
+	target := seedSQLiteWallet(t, ctx, db, "target@example.com", 0)
+
+	batchRepo := sqlite.NewSQLiteBatchTransferRepository(db)
+	batch, err := domain.NewBatchTransfer(source.ID, domain.BatchTransferModeBestEffort, "Payroll", []domain.BatchTransferItem{
+		{ToWalletID: target.ID, Amount: 300, Description: "Salary"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create batch transfer: %v", err)
+	}
+	batch.UpdatedAt = time.Now().Add(-time.Hour)
+	if err := batchRepo.Create(ctx, batch); err != nil {
+		t.Fatalf("expected the batch transfer to be saved, got %v", err)
+	}
+
+	found, err := batchRepo.FindByID(ctx, batch.ID)
+	if err != nil || found == nil || len(found.Items) != 1 || found.Items[0].Description != "Salary" {
+		t.Fatalf("expected the batch transfer to round trip with its items, got %+v and %v", found, err)
+	}
+
+	staleBefore := time.Now().Add(-time.Minute)
+	unfinished, err := batchRepo.FindUnfinished(ctx, staleBefore, 10)
+	if err != nil || len(unfinished) != 1 {
+		t.Fatalf("expected the stale batch transfer to be unfinished, got %d and %v", len(unfinished), err)
+	}
+
+	claimed, err := batchRepo.Claim(ctx, batch.ID, staleBefore)
+	if err != nil || !claimed {
+		t.Fatalf("expected the first claim to succeed, got %v and %v", claimed, err)
+	}
+	claimed, err = batchRepo.Claim(ctx, batch.ID, staleBefore)
+	if err != nil || claimed {
+		t.Fatalf("expected the second claim to lose, got %v and %v", claimed, err)
+	}
+}
+
+func TestSQLitePaymentRequestRepository_UpdateIfStatus(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t, filepath.Join(t.TempDir(), "ewallet.db"))
+	requester := seedSQLiteWallet(t, ctx, db, "requester@example.com", 0)
+	payer := seedSQLiteWallet(t, ctx, db, "payer@example.com", 500)
+
+	requestRepo := sqlite.NewSQLitePaymentRequestRepository(db)
+	request, err := domain.NewPaymentRequest(requester, payer.UserID, 200, "Dinner", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("failed to create payment request: %v", err)
+	}
+	if err := requestRepo.Create(ctx, request); err != nil {
+		t.Fatalf("expected the payment request to be saved, got %v", err)
+	}
+
+	pending, err := requestRepo.FindByPayerUserID(ctx, payer.UserID, domain.PaymentRequestStatusPending, 10, 0)
+	if err != nil || len(pending) != 1 || pending[0].Note != "Dinner" {
+		t.Fatalf("expected the request to be listed for the payer, got %d and %v", len(pending), err)
+	}
+
+	now := time.Now()
+	request.Status = domain.PaymentRequestStatusDeclined
+	request.RespondedAt = &now
+	updated, err := requestRepo.UpdateIfStatus(ctx, request, domain.PaymentRequestStatusPending)
+	if err != nil || !updated {
+		t.Fatalf("expected the pending request to be declined, got %v and %v", updated, err)
+	}
+
+	request.Status = domain.PaymentRequestStatusCancelled
+	updated, err = requestRepo.UpdateIfStatus(ctx, request, domain.PaymentRequestStatusPending)
+	if err != nil || updated {
+		t.Fatalf("expected the declined request not to change again, got %v and %v", updated, err)
+	}
+
+	found, err := requestRepo.FindByID(ctx, request.ID)
+	if err != nil || found.Status != domain.PaymentRequestStatusDeclined || found.RespondedAt == nil {
+		t.Fatalf("expected the request to stay declined, got %+v and %v", found, err)
+	}
+}
+
+func TestSQLiteRepositories_PersistAcrossReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "ewallet.db")
+	db := openSQLite(t, path)
+	wallet := seedSQLiteWallet(t, ctx, db, "merchant@example.com", 700)
+
+	takenAt := domain.EndOfDay(time.Now().Add(-48 * time.Hour))
+	snapshot := &domain.BalanceSnapshot{WalletID: wallet.ID, Balance: 700, CurrencyCode: "USD", TakenAt: takenAt, CreatedAt: time.Now()}
+	if err := sqlite.NewSQLiteBalanceSnapshotRepository(db).Save(ctx, snapshot); err != nil {
+		t.Fatalf("expected the snapshot to be saved, got %v", err)
+	}
+
+	assessment := domain.NewRiskAssessment(wallet.ID, domain.TransactionTypeWithdrawal, 700, nil, []domain.RiskRuleHit{
+		{Rule: "velocity", Decision: domain.RiskDecisionReview, Reason: "too many withdrawals"},
+	})
+	if err := sqlite.NewSQLiteRiskAssessmentRepository(db).Create(ctx, assessment); err != nil {
+		t.Fatalf("expected the risk assessment to be saved, got %v", err)
+	}
+
+	merchant, err := domain.NewMerchant("Shop", wallet.UserID, domain.MerchantEnvironmentSandbox, []domain.APIScope{domain.APIScopeRead})
+	if err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	if err := sqlite.NewSQLiteMerchantRepository(db).Create(ctx, merchant); err != nil {
+		t.Fatalf("expected the merchant to be saved, got %v", err)
+	}
+
+	deadEvent := &domain.DeadEvent{
+		Topic:     "transactions",
+		EventType: "transaction.completed",
+		Payload:   map[string]interface{}{"transaction_id": float64(7)},
+		Error:     "handler failed",
+		FailedAt:  time.Now(),
+	}
+	if err := sqlite.NewSQLiteDeadEventRepository(db).Create(ctx, deadEvent); err != nil {
+		t.Fatalf("expected the dead event to be saved, got %v", err)
+	}
+	db.Close()
+
+	db = openSQLite(t, path)
+
+	foundSnapshot, err := sqlite.NewSQLiteBalanceSnapshotRepository(db).FindLatest(ctx, wallet.ID, time.Now())
+	if err != nil || foundSnapshot == nil || foundSnapshot.Balance != 700 || !foundSnapshot.TakenAt.Equal(takenAt) {
+		t.Errorf("expected the snapshot to survive a reopen, got %+v and %v", foundSnapshot, err)
+	}
+
+	reviews, err := sqlite.NewSQLiteRiskAssessmentRepository(db).FindByReviewStatus(ctx, domain.RiskReviewStatusPending, 10, 0)
+	if err != nil || len(reviews) != 1 || len(reviews[0].RuleHits) != 1 || reviews[0].RuleHits[0].Rule != "velocity" {
+		t.Errorf("expected the pending review to survive a reopen, got %d and %v", len(reviews), err)
+	}
+
+	foundMerchant, err := sqlite.NewSQLiteMerchantRepository(db).FindByID(ctx, merchant.ID)
+	if err != nil || foundMerchant == nil || !foundMerchant.HasScope(domain.APIScopeRead) {
+		t.Errorf("expected the merchant to survive a reopen, got %+v and %v", foundMerchant, err)
+	}
+
+	deadEvents, err := sqlite.NewSQLiteDeadEventRepository(db).FindPending(ctx, 10)
+	if err != nil || len(deadEvents) != 1 || deadEvents[0].Payload["transaction_id"] != float64(7) {
+		t.Errorf("expected the dead event to survive a reopen, got %d and %v", len(deadEvents), err)
+	}
+}
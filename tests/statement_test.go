@@ -0,0 +1,310 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/adapters/statement"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/usecase"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// setupStatement creates wallet 1 with a deposit before January 5th, a month of
+// activity and a deposit after February 1st, so January 5th to February 1st
+// opens at 1000 and closes at 1590
+func setupStatement(t *testing.T, ctx context.Context) (*usecase.StatementService, *memory.InMemoryTransactionRepository) {
+	t.Helper()
+
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	wallet := domain.NewWallet(1, "USD", "Main wallet")
+	wallet.ID = 1
+	wallet.Balance = 1690
+	_ = walletRepo.Save(ctx, wallet)
+
+	other, revenue := 2, 99
+	add := func(walletID int, txType domain.TransactionType, amount, fee int, toWalletID *int, status domain.TransactionStatus, createdAt time.Time) {
+		transaction, err := domain.NewTransaction(walletID, txType, amount, string(txType))
+		if err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+		transaction.Fee = fee
+		transaction.ToWalletID = toWalletID
+		transaction.Status = status
+		transaction.CreatedAt = createdAt
+		_ = transactionRepo.Create(ctx, transaction)
+	}
+
+	completed := domain.TransactionStatusCompleted
+	add(1, domain.TransactionTypeDeposit, 1000, 0, nil, completed, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	add(1, domain.TransactionTypeDeposit, 500, 0, nil, completed, time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC))
+	add(1, domain.TransactionTypeWithdrawal, 200, 10, nil, completed, time.Date(2024, 1, 12, 9, 0, 0, 0, time.UTC))
+	add(1, domain.TransactionTypeFee, 10, 0, &revenue, completed, time.Date(2024, 1, 12, 9, 0, 0, 0, time.UTC))
+	add(other, domain.TransactionTypeTransfer, 300, 5, intPtr(1), completed, time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC))
+	add(1, domain.TransactionTypeDeposit, 50, 0, nil, domain.TransactionStatusPending, time.Date(2024, 1, 25, 9, 0, 0, 0, time.UTC))
+	add(1, domain.TransactionTypeDeposit, 100, 0, nil, completed, time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC))
+
+	statementService := usecase.NewStatementService(walletRepo, transactionRepo, statement.NewCSVRenderer(), statement.NewPDFRenderer())
+	return statementService, transactionRepo
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func exportStatement(t *testing.T, statementService *usecase.StatementService, req primary.StatementRequest) (string, string, [][]string) {
+	t.Helper()
+
+	var out bytes.Buffer
+	var contentType, filename string
+	err := statementService.ExportStatement(context.Background(), req, func(ct, name string) io.Writer {
+		contentType, filename = ct, name
+		return &out
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if req.Format != domain.StatementFormatCSV {
+		return contentType, filename, nil
+	}
+
+	reader := csv.NewReader(&out)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("expected valid CSV, got %v", err)
+	}
+	return contentType, filename, rows
+}
+
+func TestStatement_CSV(t *testing.T) {
+	statementService, _ := setupStatement(t, context.Background())
+
+	contentType, filename, rows := exportStatement(t, statementService, primary.StatementRequest{
+		WalletID: 1,
+		From:     time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Format:   domain.StatementFormatCSV,
+	})
+
+	if !strings.HasPrefix(contentType, "text/csv") {
+		t.Errorf("expected a CSV content type, got %q", contentType)
+	}
+	if filename != "statement-1-2024-01-05-2024-02-01.csv" {
+		t.Errorf("unexpected filename %q", filename)
+	}
+
+	values := map[string]string{}
+	var lines [][]string
+	totals := map[string][]string{}
+	section := ""
+	for _, row := range rows {
+		switch {
+		case row[0] == "Date" || row[0] == "Type":
+			section = row[0]
+		case len(row) == 2:
+			values[row[0]] = row[1]
+		case section == "Date":
+			lines = append(lines, row)
+		case section == "Type":
+			totals[row[0]] = row[1:]
+		}
+	}
+
+	if values["Opening Balance"] != "1000" || values["Closing Balance"] != "1590" {
+		t.Errorf("expected balances 1000 to 1590, got %v", values)
+	}
+
+	// The fee transaction and the pending deposit are left out
+	expected := [][2]string{{"500", "1500"}, {"-210", "1290"}, {"300", "1590"}}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %v", len(expected), lines)
+	}
+	for i, line := range lines {
+		if line[8] != expected[i][0] || line[9] != expected[i][1] {
+			t.Errorf("line %d: expected change %s and balance %s, got %v", i, expected[i][0], expected[i][1], line)
+		}
+	}
+	if lines[2][5] != "2" {
+		t.Errorf("expected the transfer to show the sender, got %v", lines[2])
+	}
+
+	if got := strings.Join(totals["WITHDRAWAL"], ","); got != "1,0,210" {
+		t.Errorf("expected withdrawal totals 1,0,210, got %s", got)
+	}
+	if got := strings.Join(totals["TRANSFER"], ","); got != "1,300,0" {
+		t.Errorf("expected transfer totals 1,300,0, got %s", got)
+	}
+}
+
+func TestStatement_StreamsLargeRanges(t *testing.T) {
+	ctx := context.Background()
+	statementService, transactionRepo := setupStatement(t, ctx)
+
+	// More than one repository page of transactions created at the same time
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 1201; i++ {
+		transaction, _ := domain.NewTransaction(1, domain.TransactionTypeDeposit, 1, "Deposit")
+		transaction.Status = domain.TransactionStatusCompleted
+		transaction.CreatedAt = at
+		_ = transactionRepo.Create(ctx, transaction)
+	}
+
+	_, _, rows := exportStatement(t, statementService, primary.StatementRequest{
+		WalletID: 1,
+		From:     at,
+		To:       at.Add(time.Second),
+		Format:   domain.StatementFormatCSV,
+	})
+
+	count, lastID, lastBalance := 0, 0, ""
+	for _, row := range rows {
+		if len(row) != 10 || row[0] == "Date" {
+			continue
+		}
+		id, _ := strconv.Atoi(row[1])
+		if id <= lastID {
+			t.Fatalf("expected transactions oldest first, got %d after %d", id, lastID)
+		}
+		count, lastID, lastBalance = count+1, id, row[9]
+	}
+
+	if count != 1201 {
+		t.Errorf("expected 1201 lines, got %d", count)
+	}
+	// Nothing follows the range, so it ends at the current balance
+	if lastBalance != "1690" {
+		t.Errorf("expected a final balance of 1690, got %s", lastBalance)
+	}
+}
+
+func TestStatement_InvalidRequests(t *testing.T) {
+	statementService, _ := setupStatement(t, context.Background())
+	from := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		req      primary.StatementRequest
+		expected error
+	}{
+		{"unknown format", primary.StatementRequest{WalletID: 1, From: from, To: from.AddDate(0, 1, 0), Format: "xml"}, domain.ErrInvalidStatementFormat},
+		{"empty range", primary.StatementRequest{WalletID: 1, From: from, To: from, Format: domain.StatementFormatCSV}, domain.ErrInvalidDateRange},
+		{"unknown wallet", primary.StatementRequest{WalletID: 7, From: from, To: from.AddDate(0, 1, 0), Format: domain.StatementFormatCSV}, usecase.ErrWalletNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opened := false
+			err := statementService.ExportStatement(context.Background(), tt.req, func(string, string) io.Writer {
+				opened = true
+				return io.Discard
+			})
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+			if opened {
+				t.Error("expected nothing to be written")
+			}
+		})
+	}
+}
+
+func TestStatement_PDFDownload(t *testing.T) {
+	ctx := context.Background()
+	statementService, transactionRepo := setupStatement(t, ctx)
+
+	// Enough lines to spill onto more pages
+	for i := 0; i < 150; i++ {
+		transaction, _ := domain.NewTransaction(1, domain.TransactionTypeDeposit, 1, "Deposit (daily) \\ café")
+		transaction.Status = domain.TransactionStatusCompleted
+		transaction.CreatedAt = time.Date(2024, 1, 15, 0, i, 0, 0, time.UTC)
+		_ = transactionRepo.Create(ctx, transaction)
+	}
+
+	e := echo.New()
+	e.GET("/wallets/:id/statements", handlers.NewStatementHandler(statementService).ExportStatement)
+
+	req := httptest.NewRequest(http.MethodGet, "/wallets/1/statements?from=2024-01-05&to=2024-01-31&format=pdf", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get(echo.HeaderContentType) != "application/pdf" {
+		t.Errorf("expected a PDF content type, got %q", rec.Header().Get(echo.HeaderContentType))
+	}
+	if got := rec.Header().Get(echo.HeaderContentDisposition); got != "attachment; filename=statement-1-2024-01-05-2024-02-01.pdf" {
+		t.Errorf("unexpected content disposition %q", got)
+	}
+
+	body := rec.Body.Bytes()
+	if !bytes.HasPrefix(body, []byte("%PDF-1.4")) || !bytes.HasSuffix(body, []byte("%%EOF\n")) {
+		t.Fatal("expected a complete PDF document")
+	}
+
+	// The cross-reference table has to point at every object
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(body)
+	if match == nil {
+		t.Fatal("expected a startxref entry")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(body[xref:], []byte("xref\n")) {
+		t.Fatalf("expected startxref to point at the xref table")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(body[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if !bytes.HasPrefix(body[offset:], []byte(strconv.Itoa(i+1)+" 0 obj")) {
+			t.Errorf("expected object %d at offset %d", i+1, offset)
+		}
+	}
+
+	if !bytes.Contains(body, []byte("/Count 3")) {
+		t.Error("expected the statement to span three pages")
+	}
+	if !bytes.Contains(body, []byte(`(Deposit \(daily\) \\ caf?)`)) {
+		t.Error("expected descriptions to be escaped")
+	}
+}
+
+func TestStatement_RejectedDownload(t *testing.T) {
+	statementService, _ := setupStatement(t, context.Background())
+
+	e := echo.New()
+	e.GET("/wallets/:id/statements", handlers.NewStatementHandler(statementService).ExportStatement)
+
+	for _, query := range []string{
+		"from=2024-01-05",
+		"from=2024-01-05&to=2024-01-31&format=xml",
+		"from=2024-02-01&to=2024-01-05",
+		"from=yesterday&to=2024-01-05",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/wallets/1/statements?"+query, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, rec.Code)
+		}
+		if strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), "text/csv") {
+			t.Errorf("%s: expected an error response, not a statement", query)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/adapters/tracing"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/usecase"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a tracer provider recording every span until the test ends
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return recorder
+}
+
+// findSpan returns the ended span with a name, or fails the test
+func findSpan(t *testing.T, recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	t.Fatalf("expected a %s span", name)
+	return nil
+}
+
+func TestTracing_HTTPSpanParentsUseCaseSpans(t *testing.T) {
+	recorder := recordSpans(t)
+
+	walletRepo := memory.NewInMemoryWalletRepository()
+	walletService := tracing.NewWalletService(usecase.NewWalletService(
+		walletRepo,
+		memory.NewInMemoryUserRepository(),
+		memory.NewInMemoryTransactionRepository(),
+		nil,
+		nil,
+	))
+
+	wallet := domain.NewWallet(1, "USD", "Main wallet")
+	_ = walletRepo.Save(context.Background(), wallet)
+
+	e := echo.New()
+	e.Use(tracing.HTTPMiddleware())
+	e.POST("/api/v1/wallets/:id/withdraw", func(c echo.Context) error {
+		if _, err := walletService.Withdraw(c.Request().Context(), wallet.ID, 1000, "too much"); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallets/1/withdraw", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	serverSpan := findSpan(t, recorder, "POST /api/v1/wallets/:id/withdraw")
+	if got := serverSpan.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the request span to continue the caller trace, got %s", got)
+	}
+	if got := serverSpan.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("expected the request span to be a child of the caller span, got %s", got)
+	}
+
+	useCaseSpan := findSpan(t, recorder, "WalletService.Withdraw")
+	if useCaseSpan.Parent().SpanID() != serverSpan.SpanContext().SpanID() {
+		t.Error("expected the use case span to be a child of the request span")
+	}
+	if useCaseSpan.Status().Code != codes.Error {
+		t.Errorf("expected the failed withdrawal to mark the span as an error, got %v", useCaseSpan.Status().Code)
+	}
+	if serverSpan.Status().Code == codes.Error {
+		t.Error("expected a 4xx response not to mark the request span as an error")
+	}
+}
+
+func TestTracing_FileExporter(t *testing.T) {
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	file := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{
+		Enabled:     true,
+		ServiceName: "mini-ewallet-test",
+		Exporter:    tracing.ExporterFile,
+		File:        file,
+		SampleRatio: 1,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	userService := tracing.NewUserService(usecase.NewUserService(memory.NewInMemoryUserRepository(), nil, nil))
+	_, _ = userService.GetUser(context.Background(), 42)
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("expected the trace file to exist, got %v", err)
+	}
+	for _, want := range []string{`"Name":"UserService.GetUser"`, `"Value":"mini-ewallet-test"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in\n%s", want, data)
+		}
+	}
+}
+
+func TestTracing_SetupRejectsUnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), tracing.Config{Enabled: true, Exporter: "jaeger"})
+	if err == nil {
+		t.Fatal("expected an unknown exporter to be rejected")
+	}
+}
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+func setupTransactionHandler(t *testing.T, ctx context.Context) (*echo.Echo, *memory.InMemoryTransactionRepository, string) {
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	wallet := domain.NewWallet(1, "USD", "Test wallet")
+	wallet.ID = 1
+	_ = walletRepo.Save(ctx, wallet)
+
+	walletService := usecase.NewWalletService(walletRepo, nil, transactionRepo, nil, nil)
+	transactionService := usecase.NewTransactionService(transactionRepo, walletRepo, nil, nil)
+	transactionHandler := handlers.NewTransactionHandler(transactionService, handlers.NewAuthorizer(walletService, transactionService))
+	tokens := newTestTokenService(t)
+
+	e := echo.New()
+	e.Validator = &testValidator{validator: validator.New()}
+
+	v1 := e.Group("/api/v1", handlers.Authenticate(tokens, nil))
+
+	transactions := v1.Group("/transactions")
+	transactions.GET("", transactionHandler.GetTransactions)
+	transactions.GET("/:id", transactionHandler.GetTransaction)
+
+	admin := v1.Group("/admin", handlers.RequireRole(domain.RoleAdmin))
+	admin.PUT("/transactions/:id/status", transactionHandler.UpdateTransactionStatus)
+	admin.POST("/transactions/reconcile", transactionHandler.ReconcileTransactions)
+
+	return e, transactionRepo, issueTestToken(t, tokens, 1, domain.RoleAdmin)
+}
+
+func doTransactionRequest(e *echo.Echo, token, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestTransactionHandler_GetAndList(t *testing.T) {
+	ctx := context.Background()
+	e, transactionRepo, token := setupTransactionHandler(t, ctx)
+
+	for i := 0; i < 3; i++ {
+		transaction, _ := domain.NewTransaction(1, domain.TransactionTypeDeposit, 100*(i+1), "Deposit")
+		transaction.Status = domain.TransactionStatusPending
+		if i == 2 {
+			transaction.Complete()
+		}
+		_ = transactionRepo.Create(ctx, transaction)
+	}
+
+	rec := doTransactionRequest(e, token, http.MethodGet, "/api/v1/transactions/1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := doTransactionRequest(e, token, http.MethodGet, "/api/v1/transactions/99", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown transaction, got %d", rec.Code)
+	}
+
+	rec = doTransactionRequest(e, token, http.MethodGet, "/api/v1/transactions?status=pending&limit=1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp struct {
+		Data struct {
+			Transactions []*domain.Transaction `json:"transactions"`
+		} `json:"data"`
+	}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+
+	if len(resp.Data.Transactions) != 1 || resp.Data.Transactions[0].Status != domain.TransactionStatusPending {
+		t.Errorf("expected one pending transaction, got %+v", resp.Data.Transactions)
+	}
+
+	if rec := doTransactionRequest(e, token, http.MethodGet, "/api/v1/transactions?status=UNKNOWN", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown status, got %d", rec.Code)
+	}
+
+	if rec := doTransactionRequest(e, token, http.MethodGet, "/api/v1/transactions", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without status, got %d", rec.Code)
+	}
+}
+
+func TestTransactionHandler_AdminOperations(t *testing.T) {
+	ctx := context.Background()
+	e, transactionRepo, token := setupTransactionHandler(t, ctx)
+
+	stale, _ := domain.NewTransaction(1, domain.TransactionTypeDeposit, 100, "Stale deposit")
+	stale.Status = domain.TransactionStatusPending
+	stale.CreatedAt = time.Now().Add(-time.Hour)
+	_ = transactionRepo.Create(ctx, stale)
+
+	fresh, _ := domain.NewTransaction(1, domain.TransactionTypeDeposit, 100, "Fresh deposit")
+	fresh.Status = domain.TransactionStatusPending
+	_ = transactionRepo.Create(ctx, fresh)
+
+	if rec := doTransactionRequest(e, token, http.MethodPut, "/api/v1/admin/transactions/2/status", `{"status":"failed"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a reason, got %d", rec.Code)
+	}
+
+	if rec := doTransactionRequest(e, token, http.MethodPut, "/api/v1/admin/transactions/2/status", `{"status":"completed","reason":"Settled offline"}`); rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 for completing by hand, got %d", rec.Code)
+	}
+
+	rec := doTransactionRequest(e, token, http.MethodPut, "/api/v1/admin/transactions/2/status", `{"status":"failed","reason":"Stuck at the provider"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	updated, _ := transactionRepo.FindByID(ctx, fresh.ID)
+	if updated.Status != domain.TransactionStatusFailed {
+		t.Errorf("expected failed transaction, got %s", updated.Status)
+	}
+
+	if rec := doTransactionRequest(e, token, http.MethodPut, "/api/v1/admin/transactions/2/status", `{"status":"DONE","reason":"Stuck at the provider"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown status, got %d", rec.Code)
+	}
+
+	rec = doTransactionRequest(e, token, http.MethodPost, "/api/v1/admin/transactions/reconcile", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	reconciled, _ := transactionRepo.FindByID(ctx, stale.ID)
+	if reconciled.Status != domain.TransactionStatusFailed {
+		t.Errorf("expected stale pending transaction to fail, got %s", reconciled.Status)
+	}
+}
@@ -0,0 +1,275 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func setupTransactionHistory(t *testing.T, ctx context.Context, count int) (*usecase.WalletService, *memory.InMemoryTransactionRepository, time.Time) {
+	t.Helper()
+
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	wallet := domain.NewWallet(1, "USD", "Main wallet")
+	wallet.ID = 1
+	_ = walletRepo.Save(ctx, wallet)
+
+	// Pairs of transactions share a timestamp, so the ID has to break ties
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < count; i++ {
+		transaction, err := domain.NewTransaction(1, domain.TransactionTypeDeposit, 100+i, "Deposit")
+		if err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+		transaction.CreatedAt = base.Add(time.Duration(i/2) * time.Minute)
+		_ = transactionRepo.Create(ctx, transaction)
+	}
+
+	walletService := usecase.NewWalletService(walletRepo, memory.NewInMemoryUserRepository(), transactionRepo, nil, nil)
+
+	return walletService, transactionRepo, base
+}
+
+func TestTransactionHistory_CursorPages(t *testing.T) {
+	ctx := context.Background()
+	walletService, transactionRepo, base := setupTransactionHistory(t, ctx, 7)
+
+	var seen []int
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("expected the listing to end")
+		}
+
+		page, err := walletService.GetTransactionHistoryPage(ctx, 1, primary.TransactionHistoryQuery{Limit: 3, Cursor: cursor})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if page.Total != nil {
+			t.Error("expected no total unless asked for")
+		}
+
+		for _, transaction := range page.Transactions {
+			seen = append(seen, transaction.ID)
+		}
+
+		// A transaction arriving while paging does not shift the following pages
+		if pages == 0 {
+			transaction, _ := domain.NewTransaction(1, domain.TransactionTypeDeposit, 999, "Late deposit")
+			transaction.CreatedAt = base.Add(time.Hour)
+			_ = transactionRepo.Create(ctx, transaction)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	expected := []int{7, 6, 5, 4, 3, 2, 1}
+	if len(seen) != len(expected) {
+		t.Fatalf("expected transactions %v, got %v", expected, seen)
+	}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("expected transactions %v, got %v", expected, seen)
+		}
+	}
+}
+
+func TestTransactionHistory_OffsetPages(t *testing.T) {
+	ctx := context.Background()
+	walletService, _, _ := setupTransactionHistory(t, ctx, 5)
+
+	page, err := walletService.GetTransactionHistoryPage(ctx, 1, primary.TransactionHistoryQuery{Limit: 2, Offset: 2, IncludeTotal: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(page.Transactions) != 2 || page.Transactions[0].ID != 3 || page.Transactions[1].ID != 2 {
+		t.Errorf("expected transactions 3 and 2, got %+v", page.Transactions)
+	}
+	if page.Total == nil || *page.Total != 5 {
+		t.Errorf("expected a total of 5, got %v", page.Total)
+	}
+
+	// An offset page hands out a cursor to switch over
+	next, err := walletService.GetTransactionHistoryPage(ctx, 1, primary.TransactionHistoryQuery{Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(next.Transactions) != 1 || next.Transactions[0].ID != 1 || next.NextCursor != "" {
+		t.Errorf("expected the last transaction only, got %+v", next)
+	}
+}
+
+func TestTransactionHistory_InvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	walletService, _, _ := setupTransactionHistory(t, ctx, 1)
+
+	for _, cursor := range []string{"not a cursor", "bm90LWEtY3Vyc29y"} {
+		_, err := walletService.GetTransactionHistoryPage(ctx, 1, primary.TransactionHistoryQuery{Limit: 2, Cursor: cursor})
+		if !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Errorf("cursor %q: expected ErrInvalidCursor, got %v", cursor, err)
+		}
+	}
+
+	_, err := walletService.GetTransactionHistoryPage(ctx, 99, primary.TransactionHistoryQuery{Limit: 2})
+	if !errors.Is(err, usecase.ErrWalletNotFound) {
+		t.Errorf("expected ErrWalletNotFound, got %v", err)
+	}
+}
+
+func setupFilteredHistory(t *testing.T, ctx context.Context) (*usecase.WalletService, *echo.Echo) {
+	t.Helper()
+
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	for walletID := 1; walletID <= 3; walletID++ {
+		wallet := domain.NewWallet(walletID, "USD", "Main wallet")
+		wallet.ID = walletID
+		_ = walletRepo.Save(ctx, wallet)
+	}
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	add := func(day int, transaction *domain.Transaction, status domain.TransactionStatus) {
+		transaction.CreatedAt = base.AddDate(0, 0, day)
+		transaction.Status = status
+		_ = transactionRepo.Create(ctx, transaction)
+	}
+
+	deposit, _ := domain.NewTransaction(1, domain.TransactionTypeDeposit, 1000, "Salary March")
+	add(0, deposit, domain.TransactionStatusCompleted)
+	withdrawal, _ := domain.NewTransaction(1, domain.TransactionTypeWithdrawal, 200, "ATM withdrawal")
+	add(1, withdrawal, domain.TransactionStatusCompleted)
+	sent, _ := domain.NewTransferTransaction(1, 2, 300, "Rent share")
+	add(2, sent, domain.TransactionStatusCompleted)
+	received, _ := domain.NewTransferTransaction(3, 1, 50, "Lunch 100% paid back")
+	add(3, received, domain.TransactionStatusPending)
+	failed, _ := domain.NewTransferTransaction(1, 3, 5000, "Rent deposit")
+	add(4, failed, domain.TransactionStatusFailed)
+	other, _ := domain.NewTransferTransaction(2, 3, 10, "Not ours")
+	add(4, other, domain.TransactionStatusCompleted)
+
+	walletService := usecase.NewWalletService(walletRepo, memory.NewInMemoryUserRepository(), transactionRepo, nil, nil)
+
+	e := echo.New()
+	e.GET("/api/v1/wallets/:id/transactions", handlers.NewWalletHandler(walletService, nil, nil).GetTransactionHistory)
+
+	return walletService, e
+}
+
+func TestTransactionHistory_Filters(t *testing.T) {
+	ctx := context.Background()
+	walletService, _ := setupFilteredHistory(t, ctx)
+
+	from := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	minAmount, maxAmount := 100, 1000
+	counterparty := 3
+
+	tests := []struct {
+		name     string
+		filter   domain.TransactionFilter
+		expected []int
+	}{
+		{name: "no filter", filter: domain.TransactionFilter{}, expected: []int{5, 4, 3, 2, 1}},
+		{name: "date range", filter: domain.TransactionFilter{From: &from, To: &to}, expected: []int{3, 2}},
+		{name: "type", filter: domain.TransactionFilter{Types: []domain.TransactionType{domain.TransactionTypeDeposit, domain.TransactionTypeWithdrawal}}, expected: []int{2, 1}},
+		{name: "status", filter: domain.TransactionFilter{Statuses: []domain.TransactionStatus{domain.TransactionStatusPending, domain.TransactionStatusFailed}}, expected: []int{5, 4}},
+		{name: "amount range", filter: domain.TransactionFilter{MinAmount: &minAmount, MaxAmount: &maxAmount}, expected: []int{3, 2, 1}},
+		{name: "counterparty", filter: domain.TransactionFilter{CounterpartyWalletID: &counterparty}, expected: []int{5, 4}},
+		{name: "incoming", filter: domain.TransactionFilter{Direction: domain.TransactionDirectionIn}, expected: []int{4, 1}},
+		{name: "outgoing", filter: domain.TransactionFilter{Direction: domain.TransactionDirectionOut}, expected: []int{5, 3, 2}},
+		{name: "description", filter: domain.TransactionFilter{Description: "RENT"}, expected: []int{5, 3}},
+		{name: "combined", filter: domain.TransactionFilter{Description: "rent", Direction: domain.TransactionDirectionOut, MaxAmount: &maxAmount}, expected: []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := walletService.GetTransactionHistoryPage(ctx, 1, primary.TransactionHistoryQuery{Filter: tt.filter, Limit: 10, IncludeTotal: true})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			var ids []int
+			for _, transaction := range page.Transactions {
+				ids = append(ids, transaction.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.expected) {
+				t.Errorf("expected transactions %v, got %v", tt.expected, ids)
+			}
+			if page.Total == nil || *page.Total != len(tt.expected) {
+				t.Errorf("expected a total of %d, got %v", len(tt.expected), page.Total)
+			}
+		})
+	}
+
+	invalid := []domain.TransactionFilter{
+		{From: &to, To: &from},
+		{Types: []domain.TransactionType{"REFUND"}},
+		{MinAmount: &maxAmount, MaxAmount: &minAmount},
+		{Direction: "SIDEWAYS"},
+	}
+	for _, filter := range invalid {
+		if _, err := walletService.GetTransactionHistoryPage(ctx, 1, primary.TransactionHistoryQuery{Filter: filter, Limit: 10}); err == nil {
+			t.Errorf("expected filter %+v to be rejected", filter)
+		}
+	}
+}
+
+func TestTransactionHistory_FilterQueryParams(t *testing.T) {
+	ctx := context.Background()
+	_, e := setupFilteredHistory(t, ctx)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := get("/api/v1/wallets/1/transactions?type=transfer&direction=out&from=2024-03-03&to=2024-03-05&q=rent")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Data struct {
+			Transactions []domain.Transaction `json:"transactions"`
+			Total        int                  `json:"total"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Data.Total != 2 || len(response.Data.Transactions) != 2 {
+		t.Errorf("expected the two rent transfers, got %+v", response.Data)
+	}
+
+	// The description search takes wildcards literally
+	rec = get("/api/v1/wallets/1/transactions?q=100%25")
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil || response.Data.Total != 1 {
+		t.Errorf("expected one match for a literal percent sign, got %s", rec.Body.String())
+	}
+
+	for _, query := range []string{"from=yesterday", "min_amount=ten", "status=LOST", "direction=up", "from=2024-03-05&to=2024-03-01"} {
+		if rec := get("/api/v1/wallets/1/transactions?" + query); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, rec.Code)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"errors"
 	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
 	"ports-and-adapters-architecture/internal/domain"
 	"ports-and-adapters-architecture/internal/usecase"
@@ -73,15 +74,38 @@ func TestTransactionService_UpdateTransactionStatus(t *testing.T) {
 		nil,
 	)
 
+	// Completing a transaction by hand would record funds that never moved
+	err := transactionService.UpdateTransactionStatus(ctx, 1, domain.TransactionStatusCompleted, "Settled offline", "user:1")
+	if !errors.Is(err, domain.ErrInvalidTransactionStatusTransition) {
+		t.Fatalf("expected ErrInvalidTransactionStatusTransition, got %v", err)
+	}
+
+	// The reason and the actor are required
+	err = transactionService.UpdateTransactionStatus(ctx, 1, domain.TransactionStatusFailed, " ", "user:1")
+	if !errors.Is(err, domain.ErrTransactionStatusReasonRequired) {
+		t.Fatalf("expected ErrTransactionStatusReasonRequired, got %v", err)
+	}
+
+	err = transactionService.UpdateTransactionStatus(ctx, 1, domain.TransactionStatusFailed, "Stuck at the provider", "")
+	if !errors.Is(err, domain.ErrTransactionStatusActorRequired) {
+		t.Fatalf("expected ErrTransactionStatusActorRequired, got %v", err)
+	}
+
 	// Test status update
-	err := transactionService.UpdateTransactionStatus(ctx, 1, domain.TransactionStatusCompleted)
+	err = transactionService.UpdateTransactionStatus(ctx, 1, domain.TransactionStatusFailed, "Stuck at the provider", "user:1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
 	// Verify status
 	updatedTx, _ := transactionRepo.FindByID(ctx, 1)
-	if updatedTx.Status != domain.TransactionStatusCompleted {
-		t.Errorf("expected completed status, got %s", updatedTx.Status)
+	if updatedTx.Status != domain.TransactionStatusFailed {
+		t.Errorf("expected failed status, got %s", updatedTx.Status)
+	}
+
+	// A failed transaction cannot be changed again
+	err = transactionService.UpdateTransactionStatus(ctx, 1, domain.TransactionStatusFailed, "Stuck at the provider", "user:1")
+	if !errors.Is(err, domain.ErrInvalidTransactionStatusTransition) {
+		t.Errorf("expected ErrInvalidTransactionStatusTransition, got %v", err)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"ports-and-adapters-architecture/api/rest/handlers"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/usecase"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type testValidator struct {
+	validator *validator.Validate
+}
+
+func (v *testValidator) Validate(i interface{}) error {
+	return v.validator.Struct(i)
+}
+
+func setupUserHandler() *echo.Echo {
+	userRepo := memory.NewInMemoryUserRepository()
+	userService := usecase.NewUserService(userRepo, nil, nil)
+	userHandler := handlers.NewUserHandler(userService)
+
+	e := echo.New()
+	e.Validator = &testValidator{validator: validator.New()}
+
+	users := e.Group("/api/v1/users")
+	users.POST("", userHandler.CreateUser)
+	users.GET("/lookup", userHandler.LookupUser)
+	users.GET("/:user_id", userHandler.GetUser)
+	users.PUT("/:user_id", userHandler.UpdateUser)
+	users.POST("/:user_id/activate", userHandler.ActivateUser)
+	users.POST("/:user_id/deactivate", userHandler.DeactivateUser)
+
+	return e
+}
+
+func doUserRequest(e *echo.Echo, method, target, body string) (*httptest.ResponseRecorder, *domain.User) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	var resp struct {
+		Data *domain.User `json:"data"`
+	}
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+
+	return rec, resp.Data
+}
+
+func TestUserHandler_CreateAndLookup(t *testing.T) {
+	e := setupUserHandler()
+
+	rec, user := doUserRequest(e, http.MethodPost, "/api/v1/users",
+		`{"fullname":"Jane Doe","email":"jane@example.com","phone":"+6281234567890"}`)
+	if rec.Code != http.StatusCreated || user == nil || user.ID == 0 {
+		t.Fatalf("expected 201 with created user, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{"Invalid email", `{"fullname":"John","email":"not-an-email","phone":"+6281111111111"}`, http.StatusBadRequest},
+		{"Phone not in E.164", `{"fullname":"John","email":"john@example.com","phone":"081111111111"}`, http.StatusBadRequest},
+		{"Missing fullname", `{"email":"john@example.com","phone":"+6281111111111"}`, http.StatusBadRequest},
+		{"Duplicate email", `{"fullname":"John","email":"jane@example.com","phone":"+6281111111111"}`, http.StatusConflict},
+		{"Duplicate phone", `{"fullname":"John","email":"john@example.com","phone":"+6281234567890"}`, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, _ := doUserRequest(e, http.MethodPost, "/api/v1/users", tt.body)
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	rec, found := doUserRequest(e, http.MethodGet, "/api/v1/users/lookup?phone="+url.QueryEscape("+6281234567890"), "")
+	if rec.Code != http.StatusOK || found == nil || found.ID != user.ID {
+		t.Errorf("expected lookup by phone to find user %d, got %d: %s", user.ID, rec.Code, rec.Body.String())
+	}
+
+	rec, found = doUserRequest(e, http.MethodGet, "/api/v1/users/lookup?email=jane@example.com", "")
+	if rec.Code != http.StatusOK || found == nil || found.ID != user.ID {
+		t.Errorf("expected lookup by email to find user %d, got %d: %s", user.ID, rec.Code, rec.Body.String())
+	}
+
+	if rec, _ := doUserRequest(e, http.MethodGet, "/api/v1/users/lookup", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for lookup without email or phone, got %d", rec.Code)
+	}
+
+	if rec, _ := doUserRequest(e, http.MethodGet, "/api/v1/users/lookup?email=nobody@example.com", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown email, got %d", rec.Code)
+	}
+}
+
+func TestUserHandler_UpdateAndStatus(t *testing.T) {
+	e := setupUserHandler()
+
+	_, jane := doUserRequest(e, http.MethodPost, "/api/v1/users",
+		`{"fullname":"Jane Doe","email":"jane@example.com","phone":"+6281234567890"}`)
+	_, _ = doUserRequest(e, http.MethodPost, "/api/v1/users",
+		`{"fullname":"John Doe","email":"john@example.com","phone":"+6289876543210"}`)
+
+	target := "/api/v1/users/" + strconv.Itoa(jane.ID)
+
+	rec, updated := doUserRequest(e, http.MethodPut, target,
+		`{"fullname":"Jane Smith","email":"jane.smith@example.com","phone":"+6281234567890"}`)
+	if rec.Code != http.StatusOK || updated.Fullname != "Jane Smith" || updated.Email != "jane.smith@example.com" {
+		t.Errorf("expected updated user, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec, _ := doUserRequest(e, http.MethodPut, target,
+		`{"fullname":"Jane Smith","email":"john@example.com","phone":"+6281234567890"}`); rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 when taking another user's email, got %d", rec.Code)
+	}
+
+	rec, user := doUserRequest(e, http.MethodPost, target+"/deactivate", "")
+	if rec.Code != http.StatusOK || user.Status != domain.UserStatusInactive {
+		t.Errorf("expected inactive user, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec, user = doUserRequest(e, http.MethodPost, target+"/activate", "")
+	if rec.Code != http.StatusOK || user.Status != domain.UserStatusActive {
+		t.Errorf("expected active user, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec, _ := doUserRequest(e, http.MethodGet, "/api/v1/users/999", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown user, got %d", rec.Code)
+	}
+}
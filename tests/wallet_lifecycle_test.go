@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"ports-and-adapters-architecture/internal/adapters/persistence/memory"
+	"ports-and-adapters-architecture/internal/domain"
+	"ports-and-adapters-architecture/internal/ports/primary"
+	"ports-and-adapters-architecture/internal/usecase"
+	"testing"
+)
+
+func setupWalletLifecycle(ctx context.Context) (
+	*usecase.WalletService,
+	*memory.InMemoryWalletRepository,
+	*memory.InMemoryTransactionRepository,
+) {
+	userRepo := memory.NewInMemoryUserRepository()
+	walletRepo := memory.NewInMemoryWalletRepository()
+	transactionRepo := memory.NewInMemoryTransactionRepository()
+
+	user := domain.NewUser("Owner", "owner@example.com", "+1111111111")
+	user.ID = 1
+	_ = userRepo.Save(ctx, user)
+
+	wallet := domain.NewWallet(1, "USD", "Main wallet")
+	wallet.ID = 1
+	wallet.Balance = 1000
+	_ = walletRepo.Save(ctx, wallet)
+
+	other := domain.NewWallet(2, "USD", "Other wallet")
+	other.ID = 2
+	other.Balance = 500
+	_ = walletRepo.Save(ctx, other)
+
+	euro := domain.NewWallet(1, "EUR", "Euro wallet")
+	euro.ID = 3
+	_ = walletRepo.Save(ctx, euro)
+
+	service := usecase.NewWalletService(walletRepo, userRepo, transactionRepo, nil, nil)
+
+	return service, walletRepo, transactionRepo
+}
+
+func TestWallet_DirectionRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    domain.WalletStatus
+		creditErr error
+		debitErr  error
+	}{
+		{"Active", domain.WalletStatusActive, nil, nil},
+		{"Frozen", domain.WalletStatusFrozen, nil, domain.ErrWalletFrozen},
+		{"Closed", domain.WalletStatusClosed, domain.ErrWalletClosed, domain.ErrWalletClosed},
+		{"Inactive", domain.WalletStatusInactive, domain.ErrWalletNotActive, domain.ErrWalletNotActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet := domain.NewWallet(1, "USD", "")
+			wallet.Balance = 100
+			wallet.Status = tt.status
+
+			if err := wallet.Credit(10); !errors.Is(err, tt.creditErr) {
+				t.Errorf("expected credit error %v, got %v", tt.creditErr, err)
+			}
+
+			if err := wallet.Debit(10); !errors.Is(err, tt.debitErr) {
+				t.Errorf("expected debit error %v, got %v", tt.debitErr, err)
+			}
+		})
+	}
+}
+
+func TestWalletService_FreezeAndUnfreeze(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	service, _, _ := setupWalletLifecycle(ctx)
+
+	if _, err := service.FreezeWallet(ctx, 1, "NOT_A_REASON", "admin@example.com"); !errors.Is(err, domain.ErrInvalidWalletStatusReason) {
+		t.Errorf("expected ErrInvalidWalletStatusReason, got %v", err)
+	}
+
+	if _, err := service.FreezeWallet(ctx, 1, domain.WalletStatusReasonSuspectedFraud, ""); !errors.Is(err, domain.ErrWalletStatusActorRequired) {
+		t.Errorf("expected ErrWalletStatusActorRequired, got %v", err)
+	}
+
+	wallet, err := service.FreezeWallet(ctx, 1, domain.WalletStatusReasonSuspectedFraud, "admin@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if wallet.Status != domain.WalletStatusFrozen || wallet.StatusActor != "admin@example.com" || wallet.StatusChangedAt == nil {
+		t.Errorf("expected frozen wallet with actor, got %+v", wallet)
+	}
+
+	// Outbound funds are blocked, inbound funds are accepted
+	if _, err := service.Withdraw(ctx, 1, 100, ""); !errors.Is(err, domain.ErrWalletFrozen) {
+		t.Errorf("expected ErrWalletFrozen on withdraw, got %v", err)
+	}
+
+	if _, err := service.Transfer(ctx, 1, 2, 100, ""); !errors.Is(err, domain.ErrWalletFrozen) {
+		t.Errorf("expected ErrWalletFrozen on transfer out, got %v", err)
+	}
+
+	if _, err := service.Transfer(ctx, 2, 1, 100, ""); err != nil {
+		t.Errorf("expected transfer into frozen wallet to succeed, got %v", err)
+	}
+
+	if _, err := service.Deposit(ctx, 1, 100, ""); err != nil {
+		t.Errorf("expected deposit into frozen wallet to succeed, got %v", err)
+	}
+
+	if _, err := service.FreezeWallet(ctx, 1, domain.WalletStatusReasonSuspectedFraud, "admin@example.com"); !errors.Is(err, domain.ErrInvalidWalletStatusTransition) {
+		t.Errorf("expected ErrInvalidWalletStatusTransition, got %v", err)
+	}
+
+	wallet, err = service.UnfreezeWallet(ctx, 1, domain.WalletStatusReasonResolved, "admin@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if wallet.Status != domain.WalletStatusActive || wallet.StatusReason != domain.WalletStatusReasonResolved {
+		t.Errorf("expected active wallet with resolved reason, got %+v", wallet)
+	}
+
+	if _, err := service.Withdraw(ctx, 1, 100, ""); err != nil {
+		t.Errorf("expected withdraw after unfreeze to succeed, got %v", err)
+	}
+}
+
+func TestWalletService_CloseWallet(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	service, walletRepo, transactionRepo := setupWalletLifecycle(ctx)
+
+	// Frozen wallets can be closed too
+	_, _ = service.FreezeWallet(ctx, 1, domain.WalletStatusReasonCompliance, "admin@example.com")
+
+	req := primary.CloseWalletRequest{
+		WalletID: 1,
+		Reason:   domain.WalletStatusReasonCustomerRequest,
+		Actor:    "admin@example.com",
+	}
+
+	if _, err := service.CloseWallet(ctx, req); !errors.Is(err, usecase.ErrSweepWalletRequired) {
+		t.Errorf("expected ErrSweepWalletRequired, got %v", err)
+	}
+
+	req.SweepToWalletID = 3
+	if _, err := service.CloseWallet(ctx, req); !errors.Is(err, usecase.ErrInvalidSweepWallet) {
+		t.Errorf("expected ErrInvalidSweepWallet, got %v", err)
+	}
+
+	req.SweepToWalletID = 2
+	closed, err := service.CloseWallet(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if closed.Status != domain.WalletStatusClosed || closed.Balance != 0 {
+		t.Errorf("expected closed empty wallet, got %+v", closed)
+	}
+
+	target, _ := walletRepo.FindByID(ctx, 2)
+	if target.Balance != 1500 {
+		t.Errorf("expected sweep wallet balance 1500, got %d", target.Balance)
+	}
+
+	transactions, _ := transactionRepo.FindByWalletID(ctx, 1, 10, 0)
+	if len(transactions) != 1 || transactions[0].Reference != "SWEEP-1" || transactions[0].Status != domain.TransactionStatusCompleted {
+		t.Errorf("expected one completed sweep transaction, got %+v", transactions)
+	}
+
+	// Closed wallets reject every operation
+	if _, err := service.Deposit(ctx, 1, 100, ""); !errors.Is(err, domain.ErrWalletClosed) {
+		t.Errorf("expected ErrWalletClosed on deposit, got %v", err)
+	}
+
+	if _, err := service.Transfer(ctx, 2, 1, 100, ""); !errors.Is(err, domain.ErrWalletClosed) {
+		t.Errorf("expected ErrWalletClosed on transfer in, got %v", err)
+	}
+
+	if _, err := service.CloseWallet(ctx, req); !errors.Is(err, domain.ErrWalletClosed) {
+		t.Errorf("expected ErrWalletClosed on second close, got %v", err)
+	}
+
+	// Empty wallets close without a sweep wallet
+	closed, err = service.CloseWallet(ctx, primary.CloseWalletRequest{
+		WalletID: 3,
+		Reason:   domain.WalletStatusReasonDormant,
+		Actor:    "admin@example.com",
+	})
+	if err != nil || closed.Status != domain.WalletStatusClosed {
+		t.Errorf("expected empty wallet to close, got %+v, %v", closed, err)
+	}
+}